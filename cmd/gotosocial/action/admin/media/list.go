@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type list struct {
@@ -189,11 +190,23 @@ var ListAttachments action.GTSAction = func(ctx context.Context) error {
 				return ""
 			}
 
+			if util.PtrValueOr(m.Proxied, false) {
+				// Original not
+				// stored, skip.
+				return ""
+			}
+
 			return path.Join(mediaPath, m.File.Path)
 		}
 
 	default:
 		filter = func(m *gtsmodel.MediaAttachment) string {
+			if util.PtrValueOr(m.Proxied, false) {
+				// Original not
+				// stored, skip.
+				return ""
+			}
+
 			return path.Join(mediaPath, m.File.Path)
 		}
 	}
@@ -0,0 +1,306 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"sync/atomic"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+type migrate struct {
+	from    string
+	to      string
+	src     *storage.Driver
+	dst     *storage.Driver
+	workers int
+
+	// Migration counters.
+	copied  atomic.Int64
+	skipped atomic.Int64
+	failed  atomic.Int64
+	bytes   atomic.Int64
+}
+
+func setupMigrate() (*migrate, error) {
+	var (
+		from    = config.GetStorageBackend()
+		to      = config.GetAdminMediaMigrateTo()
+		workers = config.GetAdminMediaMigrateWorkers()
+	)
+
+	// Validate flags.
+	switch to {
+	case "local", "s3":
+	default:
+		return nil, fmt.Errorf("invalid storage backend to migrate to: %s", to)
+	}
+
+	if to == from {
+		return nil, fmt.Errorf("storage backend is already %s; nothing to migrate", to)
+	}
+
+	if workers < 1 {
+		return nil, fmt.Errorf("parallelism must be at least 1, got %d", workers)
+	}
+
+	// Open the currently configured storage.
+	src, err := storage.AutoConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s storage: %w", from, err)
+	}
+
+	// Open the storage to migrate to,
+	// using the same storage config.
+	var dst *storage.Driver
+	if to == "s3" {
+		dst, err = storage.NewS3Storage()
+	} else {
+		dst, err = storage.NewFileStorage()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s storage: %w", to, err)
+	}
+
+	// Bypass any on-disk cache, so we
+	// only see what's really in storage.
+	src.Cache = nil
+	dst.Cache = nil
+
+	return &migrate{
+		from:    from,
+		to:      to,
+		src:     src,
+		dst:     dst,
+		workers: workers,
+	}, nil
+}
+
+// run copies every key from source to destination
+// storage, using the configured number of workers.
+func (m *migrate) run(ctx context.Context) error {
+	var (
+		keys = make(chan string)
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < m.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				if err := m.copyKey(ctx, key); err != nil {
+					log.Errorf(ctx, "error migrating %s: %v", key, err)
+					m.failed.Add(1)
+				}
+			}
+		}()
+	}
+
+	// Feed every key in source storage to the workers.
+	err := m.src.WalkKeys(ctx, func(key string) error {
+		select {
+		case keys <- key:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	close(keys)
+	wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("error walking %s storage: %w", m.from, err)
+	}
+
+	return nil
+}
+
+// copyKey copies the file at key from source to destination storage,
+// verifying the size and checksum of the copy. Files already present
+// at the destination (eg., from an interrupted previous run) are only
+// copied again if they don't match the source.
+func (m *migrate) copyKey(ctx context.Context, key string) error {
+	srcStat, err := m.src.Storage.Stat(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error checking %s storage: %w", m.from, err)
+	} else if srcStat == nil {
+		// Removed since walk.
+		return nil
+	}
+
+	dstStat, err := m.dst.Storage.Stat(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error checking %s storage: %w", m.to, err)
+	}
+
+	if dstStat != nil {
+		if dstStat.Size == srcStat.Size {
+			// Already copied, check it's intact.
+			srcSum, err := m.checksum(ctx, m.src, key)
+			if err != nil {
+				return err
+			}
+
+			dstSum, err := m.checksum(ctx, m.dst, key)
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(srcSum, dstSum) {
+				log.Debugf(ctx, "skipping already migrated %s", key)
+				m.skipped.Add(1)
+				return nil
+			}
+		}
+
+		// Remove partial / mismatched copy.
+		log.Warnf(ctx, "replacing mismatched %s in %s storage", key, m.to)
+		if err := m.dst.Delete(ctx, key); err != nil && !storage.IsNotFound(err) {
+			return gtserror.Newf("error removing from %s storage: %w", m.to, err)
+		}
+	}
+
+	rc, err := m.src.GetStream(ctx, key)
+	if err != nil {
+		return gtserror.Newf("error reading from %s storage: %w", m.from, err)
+	}
+	defer rc.Close()
+
+	// Hash the source as we copy it.
+	hash := sha256.New()
+	sz, err := m.dst.PutStream(ctx, key, io.TeeReader(rc, hash))
+	if err != nil {
+		return gtserror.Newf("error writing to %s storage: %w", m.to, err)
+	}
+	srcSum := hash.Sum(nil)
+
+	// Verify the copy matches.
+	dstSum, err := m.checksum(ctx, m.dst, key)
+	if err != nil {
+		return err
+	}
+
+	if sz != srcStat.Size || !bytes.Equal(srcSum, dstSum) {
+		// Don't leave a bad copy lying around.
+		if err := m.dst.Delete(ctx, key); err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error removing bad copy of %s: %v", key, err)
+		}
+		return gtserror.Newf("copy does not match: size %d/%d", sz, srcStat.Size)
+	}
+
+	m.copied.Add(1)
+	m.bytes.Add(sz)
+	return nil
+}
+
+// checksum returns the SHA-256 sum of the file at key in given storage.
+func (m *migrate) checksum(ctx context.Context, d *storage.Driver, key string) ([]byte, error) {
+	rc, err := d.GetStream(ctx, key)
+	if err != nil {
+		return nil, gtserror.Newf("error reading %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return nil, gtserror.Newf("error reading %s: %w", key, err)
+	}
+
+	return hash.Sum(nil), nil
+}
+
+// storageBackendRegex matches the storage-backend setting in a YAML config file.
+var storageBackendRegex = regexp.MustCompile(`(?m)^(storage-backend:\s*)\S.*$`)
+
+// switchOver points the config file at the migrated-to storage backend.
+func (m *migrate) switchOver(ctx context.Context) error {
+	path := config.GetConfigPath()
+	if path == "" {
+		log.Warnf(ctx, "no config file in use; set storage-backend to %s yourself before restarting", m.to)
+		return nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if !storageBackendRegex.Match(b) {
+		log.Warnf(ctx, "storage-backend not set in %s; set it to %s yourself before restarting", path, m.to)
+		return nil
+	}
+
+	b = storageBackendRegex.ReplaceAll(b, []byte(`${1}"`+m.to+`"`))
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if err := os.WriteFile(path, b, stat.Mode()); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+
+	log.Infof(ctx, "set storage-backend to %s in %s", m.to, path)
+	return nil
+}
+
+// Migrate copies all media from the currently configured
+// storage backend to another, switching the configured
+// backend over only once everything is copied and verified.
+var Migrate action.GTSAction = func(ctx context.Context) error {
+	migrate, err := setupMigrate()
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "migrating media from %s to %s storage with %d workers", migrate.from, migrate.to, migrate.workers)
+
+	if err := migrate.run(ctx); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "copied: %d (%s), already migrated: %d, failed: %d",
+		migrate.copied.Load(),
+		bytesize.Size(migrate.bytes.Load()),
+		migrate.skipped.Load(),
+		migrate.failed.Load(),
+	)
+
+	if n := migrate.failed.Load(); n > 0 {
+		return errors.New("migration incomplete, so storage backend was not switched; re-run to resume")
+	}
+
+	return migrate.switchOver(ctx)
+}
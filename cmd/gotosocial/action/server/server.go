@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -31,7 +32,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -121,6 +124,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Set the state storage driver
 	state.Storage = storage
 
+	// Use system DNS resolver for
+	// email domain MX lookups.
+	state.MXResolver = net.DefaultResolver
+
 	// Build HTTP client
 	client := httpclient.New(httpclient.Config{
 		AllowRanges:           config.MustParseIPPrefixes(config.GetHTTPClientAllowIPs()),
@@ -135,7 +142,8 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	typeConverter := typeutils.NewConverter(&state)
 	visFilter := visibility.NewFilter(&state)
 	spamFilter := spam.NewFilter(&state)
-	federatingDB := federatingdb.New(&state, typeConverter, visFilter, spamFilter)
+	policyFilter := policy.NewFilter(&state)
+	federatingDB := federatingdb.New(&state, typeConverter, visFilter, spamFilter, policyFilter)
 	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
 	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, visFilter, mediaManager)
 
@@ -206,6 +214,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		&state,
 		emailSender,
+		policyFilter,
 	)
 
 	// Initialize the specialized workers.
@@ -225,7 +234,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	}
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, state.Storage); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -258,6 +267,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		// note: hooks adding ctx fields must be ABOVE
 		// the logger, otherwise won't be accessible.
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(&state),
 		middleware.HeaderFilter(&state),
 		middleware.UserAgent(),
 		middleware.CORS(),
@@ -314,16 +324,22 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
+	// Set up the sign-up form challenge, if any.
+	signupChallenge, err := challenge.FromConfig()
+	if err != nil {
+		return fmt.Errorf("error setting up sign-up challenge: %w", err)
+	}
+
 	var (
 		authModule        = api.NewAuth(dbService, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(dbService, processor)                                // api client endpoints
+		clientModule      = api.NewClient(dbService, processor, signupChallenge)               // api client endpoints
 		metricsModule     = api.NewMetrics()                                                   // Metrics endpoints
 		healthModule      = api.NewHealth(dbService.Ready)                                     // Health check endpoints
 		fileserverModule  = api.NewFileserver(processor)                                       // fileserver endpoints
 		wellKnownModule   = api.NewWellKnown(processor)                                        // .well-known endpoints
 		nodeInfoModule    = api.NewNodeInfo(processor)                                         // nodeinfo endpoint
 		activityPubModule = api.NewActivityPub(dbService, processor)                           // ActivityPub endpoints
-		webModule         = web.New(dbService, processor)                                      // web pages + user profiles + settings panels etc
+		webModule         = web.New(dbService, processor, signupChallenge)                     // web pages + user profiles + settings panels etc
 	)
 
 	// create required middleware
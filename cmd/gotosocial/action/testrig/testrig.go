@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
@@ -143,7 +144,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(&state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, state.Storage); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -165,6 +166,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	middlewares = append(middlewares, []gin.HandlerFunc{
 		middleware.Logger(config.GetLogClientIP()),
+		middleware.IPBlock(&state),
 		middleware.HeaderFilter(&state),
 		middleware.UserAgent(),
 		middleware.CORS(),
@@ -221,16 +223,22 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error generating session name for session middleware: %w", err)
 	}
 
+	// Set up the sign-up form challenge, if any.
+	signupChallenge, err := challenge.FromConfig()
+	if err != nil {
+		return fmt.Errorf("error setting up sign-up challenge: %w", err)
+	}
+
 	var (
 		authModule        = api.NewAuth(state.DB, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state.DB, processor)                                // api client endpoints
+		clientModule      = api.NewClient(state.DB, processor, signupChallenge)               // api client endpoints
 		metricsModule     = api.NewMetrics()                                                  // Metrics endpoints
 		healthModule      = api.NewHealth(state.DB.Ready)                                     // Health check endpoints
 		fileserverModule  = api.NewFileserver(processor)                                      // fileserver endpoints
 		wellKnownModule   = api.NewWellKnown(processor)                                       // .well-known endpoints
 		nodeInfoModule    = api.NewNodeInfo(processor)                                        // nodeinfo endpoint
 		activityPubModule = api.NewActivityPub(state.DB, processor)                           // ActivityPub endpoints
-		webModule         = web.New(state.DB, processor)                                      // web pages + user profiles + settings panels etc
+		webModule         = web.New(state.DB, processor, signupChallenge)                     // web pages + user profiles + settings panels etc
 	)
 
 	// these should be routed in order
@@ -217,6 +217,23 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaList(adminMediaListEmojisLocalCmd)
 	adminMediaCmd.AddCommand(adminMediaListEmojisLocalCmd)
 
+	/*
+		ADMIN MEDIA MIGRATE COMMANDS
+	*/
+
+	adminMediaMigrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "copy all media to another storage backend, then switch over to it",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.Migrate)
+		},
+	}
+	config.AddAdminMediaMigrate(adminMediaMigrateCmd)
+	adminMediaCmd.AddCommand(adminMediaMigrateCmd)
+
 	/*
 		ADMIN MEDIA PRUNE COMMANDS
 	*/
@@ -264,6 +281,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaPrune(adminMediaPruneAllCmd)
 	adminMediaPruneCmd.AddCommand(adminMediaPruneAllCmd)
 
+	adminMediaPruneDedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "deduplicate identical media files in storage, reporting the space saved",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Dedupe)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaPruneDedupeCmd)
+	adminMediaPruneCmd.AddCommand(adminMediaPruneDedupeCmd)
+
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
 	adminCmd.AddCommand(adminMediaCmd)
@@ -800,10 +800,11 @@ func extractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
 	}
 
 	return &gtsmodel.Tag{
-		Name:     tagName,
-		Useable:  util.Ptr(true), // Assume true by default.
-		Listable: util.Ptr(true), // Assume true by default.
-		Href:     href,
+		Name:      tagName,
+		Useable:   util.Ptr(true), // Assume true by default.
+		Listable:  util.Ptr(true), // Assume true by default.
+		Trendable: util.Ptr(true), // Assume true by default.
+		Href:      href,
 	}, nil
 }
 
@@ -18,11 +18,15 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"net/http"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
@@ -56,6 +60,8 @@ const (
 	OauthFinalizePath = "/finalize"
 	// OauthOobTokenPath is the path for serving an html representation of an oob token page.
 	OauthOobTokenPath = "/oob" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthRevokePath is the API path for revoking access tokens (RFC 7009).
+	OauthRevokePath = "/revoke"
 
 	/*
 		params / session keys
@@ -64,6 +70,7 @@ const (
 	callbackStateParam   = "state"
 	callbackCodeParam    = "code"
 	sessionUserID        = "userid"
+	sessionPasswordHash  = "password_hash"
 	sessionClientID      = "client_id"
 	sessionRedirectURI   = "redirect_uri"
 	sessionForceLogin    = "force_login"
@@ -73,6 +80,9 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+
+	sessionCodeChallenge       = "code_challenge"
+	sessionCodeChallengeMethod = "code_challenge_method"
 )
 
 type Module struct {
@@ -106,6 +116,7 @@ func (m *Module) RouteOauth(attachHandler func(method string, path string, f ...
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
 	attachHandler(http.MethodGet, OauthOobTokenPath, m.OobHandler)
+	attachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 }
 
 func (m *Module) clearSession(s sessions.Session) {
@@ -114,3 +125,38 @@ func (m *Module) clearSession(s sessions.Session) {
 		panic(err)
 	}
 }
+
+// setSessionUser marks the session as signed in as the
+// given user, tying it to the user's current password.
+func setSessionUser(s sessions.Session, user *gtsmodel.User) {
+	s.Set(sessionUserID, user.ID)
+	s.Set(sessionPasswordHash, passwordHash(user))
+}
+
+// sessionUserValid returns whether the session was signed in with
+// the user's current password. Sessions signed in before a password
+// change or reset are no longer valid, and must sign in again.
+func sessionUserValid(s sessions.Session, user *gtsmodel.User) bool {
+	hash, _ := s.Get(sessionPasswordHash).(string)
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(passwordHash(user))) == 1
+}
+
+// passwordHash returns a hash of the user's
+// encrypted password to store on their session,
+// which changes whenever their password does.
+func passwordHash(user *gtsmodel.User) string {
+	sum := sha256.Sum256([]byte(user.EncryptedPassword))
+	return hex.EncodeToString(sum[:])
+}
+
+// signOutSession signs the session out of the
+// user, keeping any pending authorize request
+// so they can sign in again and carry on.
+func (m *Module) signOutSession(c *gin.Context, s sessions.Session) {
+	s.Delete(sessionUserID)
+	s.Delete(sessionPasswordHash)
+	if err := s.Save(); err != nil {
+		panic(err)
+	}
+	c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
+}
@@ -19,6 +19,8 @@ package auth_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http/httptest"
 
@@ -64,10 +66,18 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID       = "userid"
+	sessionPasswordHash = "password_hash"
+	sessionClientID     = "client_id"
 )
 
+// passwordHash returns the hash of the user's
+// password that's stored on a signed in session.
+func passwordHash(user *gtsmodel.User) string {
+	sum := sha256.Sum256([]byte(user.EncryptedPassword))
+	return hex.EncodeToString(sum[:])
+}
+
 func (suite *AuthStandardTestSuite) SetupSuite() {
 	suite.testTokens = testrig.NewTestTokens()
 	suite.testClients = testrig.NewTestClients()
@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
 )
 
 // AuthorizeGETHandler should be served as GET at https://example.org/oauth/authorize
@@ -103,6 +104,13 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !sessionUserValid(s, user) {
+		// Password has changed since
+		// this session signed in.
+		m.signOutSession(c, s)
+		return
+	}
+
 	acct, err := m.db.GetAccountByID(c.Request.Context(), user.AccountID)
 	if err != nil {
 		m.clearSession(s)
@@ -205,6 +213,14 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		clientState = s
 	}
 
+	var codeChallenge, codeChallengeMethod string
+	if s, ok := s.Get(sessionCodeChallenge).(string); ok {
+		codeChallenge = s
+	}
+	if s, ok := s.Get(sessionCodeChallengeMethod).(string); ok {
+		codeChallengeMethod = s
+	}
+
 	userID, ok := s.Get(sessionUserID).(string)
 	if !ok {
 		errs = append(errs, fmt.Sprintf("key %s was not found in session", sessionUserID))
@@ -230,6 +246,13 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if !sessionUserValid(s, user) {
+		// Password has changed since
+		// this session signed in.
+		m.signOutSession(c, s)
+		return
+	}
+
 	acct, err := m.db.GetAccountByID(c.Request.Context(), user.AccountID)
 	if err != nil {
 		m.clearSession(s)
@@ -268,6 +291,11 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("state", clientState)
 	}
 
+	if codeChallenge != "" {
+		c.Request.Form.Set(sessionCodeChallenge, codeChallenge)
+		c.Request.Form.Set(sessionCodeChallengeMethod, codeChallengeMethod)
+	}
+
 	if errWithCode := m.processor.OAuthHandleAuthorizeRequest(c.Writer, c.Request); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 	}
@@ -301,6 +329,20 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 		form.Scope = "read"
 	}
 
+	// PKCE is optional, but if the client
+	// uses it we only accept S256 challenges,
+	// since plain challenges offer no protection
+	// against an intercepted authorization code.
+	if form.CodeChallenge != "" && form.CodeChallengeMethod != string(oauth2.CodeChallengeS256) {
+		err := fmt.Errorf("code_challenge_method must be %s", oauth2.CodeChallengeS256)
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
+	if form.CodeChallenge == "" && form.CodeChallengeMethod != "" {
+		err := errors.New("code_challenge_method was set on OAuthAuthorize form, but code_challenge was not")
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
 	// save these values from the form so we can use them elsewhere in the session
 	s.Set(sessionForceLogin, form.ForceLogin)
 	s.Set(sessionResponseType, form.ResponseType)
@@ -309,6 +351,8 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
+	s.Set(sessionCodeChallenge, form.CodeChallenge)
+	s.Set(sessionCodeChallengeMethod, form.CodeChallengeMethod)
 
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving form values onto session: %s", err)
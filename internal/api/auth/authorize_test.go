@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -84,6 +86,7 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 
 		testSession := sessions.Default(ctx)
 		testSession.Set(sessionUserID, user.ID)
+		testSession.Set(sessionPasswordHash, passwordHash(user))
 		testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
 		if err := testSession.Save(); err != nil {
 			panic(fmt.Errorf("failed on case %s: %w", testCase.description, err))
@@ -113,6 +116,68 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeAfterPasswordChange() {
+	ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath, nil, "")
+
+	user := suite.testUsers["local_account_1"]
+
+	// Session signed in with
+	// a since-changed password.
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, user.ID)
+	testSession.Set(sessionPasswordHash, "some-old-password-hash")
+	testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+	if err := testSession.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.AuthorizeGETHandler(ctx)
+
+	// Should be sent to sign in again, keeping
+	// the rest of the session for afterwards.
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal("/auth"+auth.AuthSignInPath, recorder.Header().Get("Location"))
+	suite.Nil(testSession.Get(sessionUserID))
+	suite.Equal(suite.testApplications["application_1"].ClientID, testSession.Get(sessionClientID))
+}
+
+func (suite *AuthAuthorizeTestSuite) authorizeWithChallenge(challenge string, method string) *httptest.ResponseRecorder {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {suite.testApplications["application_1"].ClientID},
+		"redirect_uri":          {suite.testApplications["application_1"].RedirectURI},
+		"scope":                 {"read"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {method},
+	}
+
+	ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath+"?"+query.Encode(), nil, "")
+
+	// No user in the session yet, so the
+	// form is checked and saved for sign-in.
+	suite.authModule.AuthorizeGETHandler(ctx)
+
+	return recorder
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeCodeChallengeS256() {
+	recorder := suite.authorizeWithChallenge("E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", "S256")
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal("/auth"+auth.AuthSignInPath, recorder.Header().Get("Location"))
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeCodeChallengePlain() {
+	recorder := suite.authorizeWithChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk", "plain")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Contains(recorder.Body.String(), "code_challenge_method must be S256")
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeCodeChallengeNoMethod() {
+	recorder := suite.authorizeWithChallenge("E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", "")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Contains(recorder.Body.String(), "code_challenge_method must be S256")
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
@@ -165,7 +165,7 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	s.Set(sessionUserID, user.ID)
+	setSessionUser(s, user)
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
@@ -248,7 +248,7 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	}
 	s.Delete(sessionClaims)
 	s.Delete(sessionAppID)
-	s.Set(sessionUserID, user.ID)
+	setSessionUser(s, user)
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// revokeRequestForm is the form for an RFC 7009 token
+// revocation request. The optional `token_type_hint`
+// is not parsed, since we only issue access tokens.
+type revokeRequestForm struct {
+	Token        *string `form:"token" json:"token" xml:"token"`
+	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+}
+
+// RevokePOSTHandler should be served as POST at https://example.org/oauth/revoke.
+// It allows a client to revoke an access token that was issued to it, as per RFC 7009.
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	help := []string{}
+
+	form := &revokeRequestForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, err.Error()))
+		return
+	}
+
+	if form.Token == nil || *form.Token == "" {
+		help = append(help, "token was not set in the revoke request form")
+	}
+
+	// Clients may authenticate with either
+	// form fields or HTTP basic authentication.
+	clientID, clientSecret, ok := c.Request.BasicAuth()
+	if !ok {
+		if form.ClientID != nil {
+			clientID = *form.ClientID
+		}
+		if form.ClientSecret != nil {
+			clientSecret = *form.ClientSecret
+		}
+	}
+
+	if clientID == "" {
+		help = append(help, "client_id was not set in the revoke request form")
+	}
+
+	if clientSecret == "" {
+		help = append(help, "client_secret was not set in the revoke request form")
+	}
+
+	if len(help) != 0 {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.ErrInvalidRequest, help...))
+		return
+	}
+
+	if errWithCode := m.processor.OAuthRevokeAccessToken(
+		c.Request.Context(),
+		clientID,
+		clientSecret,
+		*form.Token,
+	); errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, struct{}{})
+}
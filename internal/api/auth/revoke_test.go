@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RevokeTestSuite) revoke(clientID string, clientSecret string, token string) int {
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"token":         {token},
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RevokePOSTHandler(ctx)
+
+	return recorder.Code
+}
+
+func (suite *RevokeTestSuite) TestRevokeOK() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.Equal(http.StatusOK, suite.revoke(testClient.ID, testClient.Secret, testToken.Access))
+
+	_, err := suite.db.GetTokenByAccess(context.Background(), testToken.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnknownToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	suite.Equal(http.StatusOK, suite.revoke(testClient.ID, testClient.Secret, "not-a-real-token"))
+}
+
+func (suite *RevokeTestSuite) TestRevokeWrongSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	suite.Equal(http.StatusUnauthorized, suite.revoke(testClient.ID, "nope", testToken.Access))
+
+	_, err := suite.db.GetTokenByAccess(context.Background(), testToken.Access)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeOtherClientsToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_2"]
+
+	suite.Equal(http.StatusForbidden, suite.revoke(testClient.ID, testClient.Secret, testToken.Access))
+
+	_, err := suite.db.GetTokenByAccess(context.Background(), testToken.Access)
+	suite.NoError(err)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, &RevokeTestSuite{})
+}
@@ -35,6 +35,7 @@ type tokenRequestForm struct {
 	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
 	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
+	CodeVerifier *string `form:"code_verifier" json:"code_verifier" xml:"code_verifier"`
 }
 
 // TokenPOSTHandler should be served as a POST at https://example.org/oauth/token
@@ -93,6 +94,17 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		help = append(help, "code was not set in the token request form, but must be set since grant_type is authorization_code")
 	}
 
+	if form.CodeVerifier != nil {
+		if grantType != "authorization_code" {
+			help = append(help, "a code_verifier was provided in the token request form, but grant_type was not set to authorization_code")
+		} else {
+			// The verifier is checked against the code
+			// challenge stored with the authorization code
+			// by the oauth server when exchanging the code.
+			c.Request.Form.Set("code_verifier", *form.CodeVerifier)
+		}
+	}
+
 	if form.Scope != nil {
 		c.Request.Form.Set("scope", *form.Scope)
 	}
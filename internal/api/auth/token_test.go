@@ -19,6 +19,8 @@ package auth_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -209,6 +211,116 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeWrongGrantType() {
 	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: a code was provided in the token request form, but grant_type was not set to authorization_code"}`, string(b))
 }
 
+func (suite *TokenTestSuite) putPKCEToken(verifier string) *gtsmodel.Token {
+	token := new(gtsmodel.Token)
+	*token = *suite.testTokens["local_account_1_user_authorization_token"]
+	token.ID = "01J2M7R8PYA3C8B0W3Y1W0S5EQ"
+	token.Code = "NTEYZJA4ZTETNWI0ZS0ZNMVILWE0ZMYTNJM3NJQ4YJI0YZM5"
+
+	sum := sha256.Sum256([]byte(verifier))
+	token.CodeChallenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	token.CodeChallengeMethod = "S256"
+
+	if err := suite.db.PutToken(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCE() {
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.putPKCEToken(verifier)
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {testToken.Code},
+			"code_verifier": {verifier},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	t := &apimodel.Token{}
+	err = json.Unmarshal(b, t)
+	suite.NoError(err)
+	suite.NotEmpty(t.AccessToken)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCEWrongVerifier() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.putPKCEToken("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {testToken.Code},
+			"code_verifier": {"this-is-not-the-verifier-you-are-looking-for-123"},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCENoVerifier() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.putPKCEToken("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {testToken.Code},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, &TokenTestSuite{})
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accountwarnings"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
@@ -33,6 +34,7 @@ import (
 	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
@@ -46,6 +48,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -56,31 +59,33 @@ type Client struct {
 	processor *processing.Processor
 	db        db.DB
 
-	accounts       *accounts.Module       // api/v1/accounts
-	admin          *admin.Module          // api/v1/admin
-	apps           *apps.Module           // api/v1/apps
-	blocks         *blocks.Module         // api/v1/blocks
-	bookmarks      *bookmarks.Module      // api/v1/bookmarks
-	conversations  *conversations.Module  // api/v1/conversations
-	customEmojis   *customemojis.Module   // api/v1/custom_emojis
-	favourites     *favourites.Module     // api/v1/favourites
-	featuredTags   *featuredtags.Module   // api/v1/featured_tags
-	filtersV1      *filtersV1.Module      // api/v1/filters
-	followRequests *followrequests.Module // api/v1/follow_requests
-	instance       *instance.Module       // api/v1/instance
-	lists          *lists.Module          // api/v1/lists
-	markers        *markers.Module        // api/v1/markers
-	media          *media.Module          // api/v1/media, api/v2/media
-	mutes          *mutes.Module          // api/v1/mutes
-	notifications  *notifications.Module  // api/v1/notifications
-	polls          *polls.Module          // api/v1/polls
-	preferences    *preferences.Module    // api/v1/preferences
-	reports        *reports.Module        // api/v1/reports
-	search         *search.Module         // api/v1/search, api/v2/search
-	statuses       *statuses.Module       // api/v1/statuses
-	streaming      *streaming.Module      // api/v1/streaming
-	timelines      *timelines.Module      // api/v1/timelines
-	user           *user.Module           // api/v1/user
+	accounts        *accounts.Module        // api/v1/accounts
+	accountWarnings *accountwarnings.Module // api/v1/account_warnings
+	admin           *admin.Module           // api/v1/admin
+	apps            *apps.Module            // api/v1/apps
+	blocks          *blocks.Module          // api/v1/blocks
+	bookmarks       *bookmarks.Module       // api/v1/bookmarks
+	conversations   *conversations.Module   // api/v1/conversations
+	customEmojis    *customemojis.Module    // api/v1/custom_emojis
+	favourites      *favourites.Module      // api/v1/favourites
+	featuredTags    *featuredtags.Module    // api/v1/featured_tags
+	filtersV1       *filtersV1.Module       // api/v1/filters
+	followRequests  *followrequests.Module  // api/v1/follow_requests
+	instance        *instance.Module        // api/v1/instance
+	invites         *invites.Module         // api/v1/invites
+	lists           *lists.Module           // api/v1/lists
+	markers         *markers.Module         // api/v1/markers
+	media           *media.Module           // api/v1/media, api/v2/media
+	mutes           *mutes.Module           // api/v1/mutes
+	notifications   *notifications.Module   // api/v1/notifications
+	polls           *polls.Module           // api/v1/polls
+	preferences     *preferences.Module     // api/v1/preferences
+	reports         *reports.Module         // api/v1/reports
+	search          *search.Module          // api/v1/search, api/v2/search
+	statuses        *statuses.Module        // api/v1/statuses
+	streaming       *streaming.Module       // api/v1/streaming
+	timelines       *timelines.Module       // api/v1/timelines
+	user            *user.Module            // api/v1/user
 }
 
 func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -101,6 +106,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	// so that the module can attach its routes to this group
 	h := apiGroup.Handle
 	c.accounts.Route(h)
+	c.accountWarnings.Route(h)
 	c.admin.Route(h)
 	c.apps.Route(h)
 	c.blocks.Route(h)
@@ -112,6 +118,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.filtersV1.Route(h)
 	c.followRequests.Route(h)
 	c.instance.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
@@ -127,35 +134,39 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.user.Route(h)
 }
 
-func NewClient(db db.DB, p *processing.Processor) *Client {
+// NewClient returns the client API router. If signupChallenge
+// is not nil, it must be completed when creating an account.
+func NewClient(db db.DB, p *processing.Processor, signupChallenge challenge.Challenger) *Client {
 	return &Client{
 		processor: p,
 		db:        db,
 
-		accounts:       accounts.New(p),
-		admin:          admin.New(p),
-		apps:           apps.New(p),
-		blocks:         blocks.New(p),
-		bookmarks:      bookmarks.New(p),
-		conversations:  conversations.New(p),
-		customEmojis:   customemojis.New(p),
-		favourites:     favourites.New(p),
-		featuredTags:   featuredtags.New(p),
-		filtersV1:      filtersV1.New(p),
-		followRequests: followrequests.New(p),
-		instance:       instance.New(p),
-		lists:          lists.New(p),
-		markers:        markers.New(p),
-		media:          media.New(p),
-		mutes:          mutes.New(p),
-		notifications:  notifications.New(p),
-		polls:          polls.New(p),
-		preferences:    preferences.New(p),
-		reports:        reports.New(p),
-		search:         search.New(p),
-		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
-		timelines:      timelines.New(p),
-		user:           user.New(p),
+		accounts:        accounts.New(p, signupChallenge),
+		accountWarnings: accountwarnings.New(p),
+		admin:           admin.New(p),
+		apps:            apps.New(p),
+		blocks:          blocks.New(p),
+		bookmarks:       bookmarks.New(p),
+		conversations:   conversations.New(p),
+		customEmojis:    customemojis.New(p),
+		favourites:      favourites.New(p),
+		featuredTags:    featuredtags.New(p),
+		filtersV1:       filtersV1.New(p),
+		followRequests:  followrequests.New(p),
+		instance:        instance.New(p),
+		invites:         invites.New(p),
+		lists:           lists.New(p),
+		markers:         markers.New(p),
+		media:           media.New(p),
+		mutes:           mutes.New(p),
+		notifications:   notifications.New(p),
+		polls:           polls.New(p),
+		preferences:     preferences.New(p),
+		reports:         reports.New(p),
+		search:          search.New(p),
+		statuses:        statuses.New(p),
+		streaming:       streaming.New(p, time.Second*30, 4096),
+		timelines:       timelines.New(p),
+		user:            user.New(p),
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -59,7 +60,10 @@ import (
 //			schema:
 //				"$ref": "#/definitions/oauthToken"
 //		'400':
-//			description: bad request
+//			description: >-
+//				Bad request. If a sign-up challenge is configured on this
+//				instance, this is also returned when the challenge response
+//				fields submitted with the form are missing or don't pass.
 //		'401':
 //			description: unauthorized
 //		'404':
@@ -105,6 +109,15 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 	}
 	form.IP = signUpIP
 
+	// Make sure the sign-up challenge was completed,
+	// same as when signing up via the web form.
+	if m.signupChallenge != nil {
+		if errWithCode := m.verifySignupChallenge(c, signUpIP); errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	// Create the new account + user.
 	ctx := c.Request.Context()
 	user, errWithCode := m.processor.Account().Create(
@@ -131,3 +144,24 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 
 	apiutil.JSON(c, http.StatusOK, ti)
 }
+
+// verifySignupChallenge checks the sign-up challenge
+// response included in the submitted form.
+func (m *Module) verifySignupChallenge(c *gin.Context, ip net.IP) gtserror.WithCode {
+	ctx := c.Request.Context()
+
+	// Form (if any) was already parsed by ShouldBind;
+	// JSON and XML bodies have no challenge fields.
+	err := challenge.Check(ctx, m.signupChallenge, c.Request.PostForm, ip)
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := challenge.IsFailure(err); !ok {
+		err := gtserror.Newf("error verifying sign-up challenge: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	const help = "sign-up check failed; this instance requires a sign-up challenge to be completed, please sign up via the web form instead"
+	return gtserror.NewErrorBadRequest(err, help)
+}
@@ -17,3 +17,66 @@
 // */
 
 package accounts_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
+)
+
+type AccountCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountCreateTestSuite) createAccount(module *accounts.Module, form url.Values) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), accounts.BasePath, "application/x-www-form-urlencoded")
+	ctx.Request.Method = http.MethodPost
+
+	module.AccountCreatePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateAccountChallengeMissing() {
+	pow, err := challenge.NewProofOfWork(1)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	module := accounts.New(suite.processor, pow)
+
+	code, body := suite.createAccount(module, url.Values{
+		"username":  {"someone_new"},
+		"email":     {"someone_new@example.org"},
+		"password":  {"this is a very long and secure password"},
+		"agreement": {"true"},
+		"locale":    {"en"},
+		"reason":    {"i'd like to try this instance out please"},
+	})
+
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, "requires a sign-up challenge")
+
+	// Nothing should have been created.
+	_, err = suite.db.GetAccountByUsernameDomain(context.Background(), "someone_new", "")
+	suite.Error(err)
+}
+
+func TestAccountCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountCreateTestSuite))
+}
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -59,12 +60,16 @@ const (
 )
 
 type Module struct {
-	processor *processing.Processor
+	processor       *processing.Processor
+	signupChallenge challenge.Challenger
 }
 
-func New(processor *processing.Processor) *Module {
+// New returns a new accounts module. If signupChallenge
+// is not nil, it must be completed when creating an account.
+func New(processor *processing.Processor, signupChallenge challenge.Challenger) *Module {
 	return &Module{
-		processor: processor,
+		processor:       processor,
+		signupChallenge: signupChallenge,
 	}
 }
 
@@ -53,14 +53,50 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken, one of `suspend`, `silence`, `unsilence` or `warn`.
+//			Silencing an account hides its statuses from public and tag timelines and
+//			from non-followers, forces its media to be shown as sensitive, and makes
+//			follows from the account always require approval.
+//			Warning or silencing a local account issues a moderation warning to it,
+//			delivered by email and notification, which the account may appeal once.
 //		type: string
 //		required: true
 //	-
 //		name: text
 //		in: formData
-//		description: Optional text describing why this action was taken.
+//		description: >-
+//			Optional text describing why this action was taken.
+//			For warnings, this text is shown to the warned account.
 //		type: string
+//	-
+//		name: content_warning
+//		in: formData
+//		description: >-
+//			Optional content warning to prepend to the account's statuses while it
+//			is silenced. Only used when type is `silence`.
+//		type: string
+//	-
+//		name: report_id
+//		in: formData
+//		description: >-
+//			Optional ID of a report against the account to link to the warning.
+//			If status_ids or rule_ids are not given, those of the report are used.
+//		type: string
+//	-
+//		name: status_ids[]
+//		in: formData
+//		description: Optional IDs of statuses of the account to link to the warning.
+//		type: array
+//		items:
+//			type: string
+//	-
+//		name: rule_ids[]
+//		in: formData
+//		description: Optional IDs of instance rules broken, to link to the warning.
+//		type: array
+//		items:
+//			type: string
 //
 //	security:
 //	- OAuth2 Bearer:
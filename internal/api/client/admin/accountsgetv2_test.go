@@ -114,7 +114,12 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
         "name": "user"
       }
     },
-    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "media_storage": {
+      "used": 0,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -160,7 +165,12 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
         "name": "admin"
       }
     },
-    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+    "media_storage": {
+      "used": 69401,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   {
     "id": "01AY6P665V14JJR0AFVRT7311Y",
@@ -247,7 +257,12 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
         "name": "user"
       }
     },
-    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "media_storage": {
+      "used": 4463269,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   {
     "id": "01F8MH0BBE4FHXPH513MBVFHB0",
@@ -292,7 +307,12 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
         "name": "user"
       }
     },
-    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "media_storage": {
+      "used": 0,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   {
     "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
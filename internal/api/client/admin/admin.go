@@ -26,38 +26,73 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
+	BasePath                    = "/v1/admin"
+	EmojiPath                   = BasePath + "/custom_emojis"
+	EmojiPathWithID             = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath         = EmojiPath + "/categories"
+	DomainBlocksPath            = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID      = DomainBlocksPath + "/:" + IDKey
+	DomainAllowsPath            = BasePath + "/domain_allows"
+	DomainAllowsPathWithID      = DomainAllowsPath + "/:" + IDKey
+	DomainKeysExpirePath        = BasePath + "/domain_keys_expire"
+	EmailDomainBlocksPath       = BasePath + "/email_domain_blocks"
+	EmailDomainBlocksPathWithID = EmailDomainBlocksPath + "/:" + IDKey
+	DomainLimitsPath            = BasePath + "/domain_limits"
+	DomainLimitsPathWithID      = DomainLimitsPath + "/:" + IDKey
+	IPBlocksPath                = BasePath + "/ip_blocks"
+	IPBlocksPathWithID          = IPBlocksPath + "/:" + IDKey
+	InboundPoliciesPath         = BasePath + "/inbound_policies"
+	InboundPoliciesPathWithID   = InboundPoliciesPath + "/:" + IDKey
+	InboundPoliciesReloadPath   = InboundPoliciesPath + "/reload"
+	QuarantinePath              = BasePath + "/quarantine"
+	QuarantinePathWithID        = QuarantinePath + "/:" + IDKey
+	QuarantineReleasePath       = QuarantinePathWithID + "/release"
+	QuarantineSuspendPath       = QuarantinePathWithID + "/suspend"
+	TagsPath                    = BasePath + "/tags"
+	TagsPathWithID              = TagsPath + "/:" + IDKey
+	HeaderAllowsPath            = BasePath + "/header_allows"
+	HeaderAllowsPathWithID      = HeaderAllowsPath + "/:" + IDKey
+	HeaderBlocksPath            = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID      = HeaderBlocksPath + "/:" + IDKey
+	AccountsV1Path              = BasePath + "/accounts"
+	AccountsV2Path              = "/v2/admin/accounts"
+	AccountsPathWithID          = AccountsV1Path + "/:" + IDKey
+	AccountsActionPath          = AccountsPathWithID + "/action"
+	AccountsApprovePath         = AccountsPathWithID + "/approve"
+	AccountsRejectPath          = AccountsPathWithID + "/reject"
+	AccountsMediaQuotaPath      = AccountsPathWithID + "/media_quota"
+	MediaCleanupPath            = BasePath + "/media_cleanup"
+	MediaRefetchPath            = BasePath + "/media_refetch"
+	MediaHashBlocksPath         = BasePath + "/media_hash_blocks"
+	MediaHashBlocksPathWithID   = MediaHashBlocksPath + "/:" + IDKey
+	MediaHashMatchesPath        = BasePath + "/media_hash_matches"
+	MediaHashMatchesPathWithID  = MediaHashMatchesPath + "/:" + IDKey
+	AuditLogPath                = BasePath + "/audit_log"
+	MeasuresPath                = BasePath + "/measures"
+	DimensionsPath              = BasePath + "/dimensions"
+	RetentionPath               = BasePath + "/retention"
+	ReportsPath                 = BasePath + "/reports"
+	ReportsPathWithID           = ReportsPath + "/:" + IDKey
+	ReportsResolvePath          = ReportsPathWithID + "/resolve"
+	ReportsAssignPath           = ReportsPathWithID + "/assign_to_self"
+	ReportsUnassignPath         = ReportsPathWithID + "/unassign"
+	ReportsNotesPath            = ReportsPathWithID + "/notes"
+	ReportsNotesPathWithID      = ReportsNotesPath + "/:" + NoteIDKey
+	AccountWarningsPath         = BasePath + "/account_warnings"
+	AccountWarningsPathWithID   = AccountWarningsPath + "/:" + IDKey
+	AccountWarningsApprovePath  = AccountWarningsPathWithID + "/appeal/approve"
+	AccountWarningsRejectPath   = AccountWarningsPathWithID + "/appeal/reject"
+	EmailPath                   = BasePath + "/email"
+	EmailTestPath               = EmailPath + "/test"
+	InvitesPath                 = BasePath + "/invites"
+	InvitesPathWithID           = InvitesPath + "/:" + IDKey
+	InstanceRulesPath           = BasePath + "/instance/rules"
+	InstanceRulesPathWithID     = InstanceRulesPath + "/:" + IDKey
+	DebugPath                   = BasePath + "/debug"
+	DebugAPUrlPath              = DebugPath + "/apurl"
 
 	IDKey                 = "id"
+	NoteIDKey             = "note_id"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
@@ -65,6 +100,7 @@ const (
 	DomainQueryKey        = "domain"
 	ResolvedKey           = "resolved"
 	AccountIDKey          = "account_id"
+	MediaHashBlockIDKey   = "media_hash_block_id"
 	TargetAccountIDKey    = "target_account_id"
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
@@ -102,6 +138,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// email domain block stuff
+	attachHandler(http.MethodPost, EmailDomainBlocksPath, m.EmailDomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPath, m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPathWithID, m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodDelete, EmailDomainBlocksPathWithID, m.EmailDomainBlockDELETEHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -112,6 +154,47 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, m.HeaderFilterAllowDELETE)
 	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, m.HeaderFilterBlockDELETE)
 
+	// domain limit stuff
+	attachHandler(http.MethodPost, DomainLimitsPath, m.DomainLimitsPOSTHandler)
+	attachHandler(http.MethodGet, DomainLimitsPath, m.DomainLimitsGETHandler)
+	attachHandler(http.MethodGet, DomainLimitsPathWithID, m.DomainLimitGETHandler)
+	attachHandler(http.MethodPut, DomainLimitsPathWithID, m.DomainLimitPUTHandler)
+	attachHandler(http.MethodDelete, DomainLimitsPathWithID, m.DomainLimitDELETEHandler)
+
+	// ip block stuff
+	attachHandler(http.MethodPost, IPBlocksPath, m.IPBlocksPOSTHandler)
+	attachHandler(http.MethodGet, IPBlocksPath, m.IPBlocksGETHandler)
+	attachHandler(http.MethodGet, IPBlocksPathWithID, m.IPBlockGETHandler)
+	attachHandler(http.MethodPut, IPBlocksPathWithID, m.IPBlockPUTHandler)
+	attachHandler(http.MethodDelete, IPBlocksPathWithID, m.IPBlockDELETEHandler)
+
+	// inbound policy stuff
+	attachHandler(http.MethodPost, InboundPoliciesPath, m.InboundPoliciesPOSTHandler)
+	attachHandler(http.MethodGet, InboundPoliciesPath, m.InboundPoliciesGETHandler)
+	attachHandler(http.MethodPost, InboundPoliciesReloadPath, m.InboundPoliciesReloadPOSTHandler)
+	attachHandler(http.MethodGet, InboundPoliciesPathWithID, m.InboundPolicyGETHandler)
+	attachHandler(http.MethodPut, InboundPoliciesPathWithID, m.InboundPolicyPUTHandler)
+	attachHandler(http.MethodDelete, InboundPoliciesPathWithID, m.InboundPolicyDELETEHandler)
+
+	// media hash block stuff
+	attachHandler(http.MethodPost, MediaHashBlocksPath, m.MediaHashBlocksPOSTHandler)
+	attachHandler(http.MethodGet, MediaHashBlocksPath, m.MediaHashBlocksGETHandler)
+	attachHandler(http.MethodGet, MediaHashBlocksPathWithID, m.MediaHashBlockGETHandler)
+	attachHandler(http.MethodDelete, MediaHashBlocksPathWithID, m.MediaHashBlockDELETEHandler)
+	attachHandler(http.MethodGet, MediaHashMatchesPath, m.MediaHashMatchesGETHandler)
+	attachHandler(http.MethodDelete, MediaHashMatchesPathWithID, m.MediaHashMatchDELETEHandler)
+
+	// quarantine stuff
+	attachHandler(http.MethodGet, QuarantinePath, m.QuarantinedStatusesGETHandler)
+	attachHandler(http.MethodGet, QuarantinePathWithID, m.QuarantinedStatusGETHandler)
+	attachHandler(http.MethodPost, QuarantineReleasePath, m.QuarantinedStatusReleasePOSTHandler)
+	attachHandler(http.MethodPost, QuarantineSuspendPath, m.QuarantinedStatusSuspendPOSTHandler)
+
+	// tag stuff
+	attachHandler(http.MethodGet, TagsPath, m.TagsGETHandler)
+	attachHandler(http.MethodGet, TagsPathWithID, m.TagGETHandler)
+	attachHandler(http.MethodPut, TagsPathWithID, m.TagPUTHandler)
+
 	// domain maintenance stuff
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
 
@@ -122,19 +205,44 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, AccountsMediaQuotaPath, m.AccountMediaQuotaPOSTHandler)
+	attachHandler(http.MethodDelete, AccountsMediaQuotaPath, m.AccountMediaQuotaDELETEHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
 
+	// audit log stuff
+	attachHandler(http.MethodGet, AuditLogPath, m.AuditLogGETHandler)
+
+	// dashboard stuff
+	attachHandler(http.MethodPost, MeasuresPath, m.MeasuresPOSTHandler)
+	attachHandler(http.MethodPost, DimensionsPath, m.DimensionsPOSTHandler)
+	attachHandler(http.MethodPost, RetentionPath, m.RetentionPOSTHandler)
+
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
 	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
 	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodPost, ReportsAssignPath, m.ReportAssignPOSTHandler)
+	attachHandler(http.MethodPost, ReportsUnassignPath, m.ReportUnassignPOSTHandler)
+	attachHandler(http.MethodGet, ReportsNotesPath, m.ReportNotesGETHandler)
+	attachHandler(http.MethodPost, ReportsNotesPath, m.ReportNotePOSTHandler)
+	attachHandler(http.MethodDelete, ReportsNotesPathWithID, m.ReportNoteDELETEHandler)
+
+	// account warnings stuff
+	attachHandler(http.MethodGet, AccountWarningsPath, m.AccountWarningsGETHandler)
+	attachHandler(http.MethodGet, AccountWarningsPathWithID, m.AccountWarningGETHandler)
+	attachHandler(http.MethodPost, AccountWarningsApprovePath, m.AccountWarningApprovePOSTHandler)
+	attachHandler(http.MethodPost, AccountWarningsRejectPath, m.AccountWarningRejectPOSTHandler)
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
 
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodDelete, InvitesPathWithID, m.InviteDELETEHandler)
+
 	// instance rules stuff
 	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
 	attachHandler(http.MethodGet, InstanceRulesPathWithID, m.RuleGETHandler)
@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// AuditLogGETHandler swagger:operation GET /api/v1/admin/audit_log adminAuditLogGet
+//
+// View the admin audit log of this instance, newest first.
+//
+// Each entry records a mutation performed by an admin, such as
+// creating a domain block or silencing an account, along with
+// summaries of the target before and after the mutation.
+//
+// The next and previous queries can be parsed from the returned Link header.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: account_id
+//		type: string
+//		description: Return only entries for mutations performed by the given admin account.
+//		in: query
+//	-
+//		name: target_type
+//		type: string
+//		description: >-
+//			Return only entries targeting the given type of entity. One of:
+//			account, domain_block, domain_allow, domain_limit, email_domain_block,
+//			ip_block, emoji, rule, header_allow, header_block, instance, report,
+//			account_warning, invite, inbound_policy, quarantined_status, tag,
+//			media_hash_block.
+//		in: query
+//	-
+//		name: start
+//		type: string
+//		description: >-
+//			Return only entries created at or after the given time.
+//			Accepts an RFC3339 timestamp, or a date like 2024-05-16.
+//		in: query
+//	-
+//		name: end
+//		type: string
+//		description: >-
+//			Return only entries created before the given time.
+//			Accepts an RFC3339 timestamp, or a date like 2024-05-16,
+//			in which case entries from that whole day are included.
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only entries *OLDER* than the given max ID.
+//			The entry with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only entries *NEWER* than the given since ID.
+//			The entry with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only entries immediately *NEWER* than the given min ID.
+//			The entry with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of entries to return.
+//		default: 100
+//		minimum: 1
+//		maximum: 200
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Array of audit log entries.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminAuditLogEntry"
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AuditLogGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	start, errWithCode := apiutil.ParseAdminStart(c.Query(apiutil.AdminStartKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	end, errWithCode := apiutil.ParseAdminEnd(c.Query(apiutil.AdminEndKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page, errWithCode := paging.ParseIDPage(c, 1, 200, 100)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Admin().AuditLogGet(
+		c.Request.Context(),
+		c.Query(AccountIDKey),
+		c.Query(apiutil.AdminTargetTypeKey),
+		start,
+		end,
+		page,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp.Items)
+}
@@ -147,7 +147,7 @@ func (m *Module) createHeaderFilter(c *gin.Context, create func(context.Context,
 }
 
 // deleteHeaderFilter is a gin handler function that deletes an HTTP header filter with provided ID, using given delete function.
-func (m *Module) deleteHeaderFilter(c *gin.Context, delete func(context.Context, string) gtserror.WithCode) {
+func (m *Module) deleteHeaderFilter(c *gin.Context, delete func(context.Context, *gtsmodel.Account, string) gtserror.WithCode) {
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
 		errWithCode := gtserror.NewErrorUnauthorized(err, err.Error())
@@ -173,7 +173,7 @@ func (m *Module) deleteHeaderFilter(c *gin.Context, delete func(context.Context,
 		return
 	}
 
-	errWithCode = delete(c.Request.Context(), filterID)
+	errWithCode = delete(c.Request.Context(), authed.Account, filterID)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
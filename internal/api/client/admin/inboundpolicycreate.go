@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// InboundPoliciesPOSTHandler swagger:operation POST /api/v1/admin/inbound_policies inboundPolicyCreate
+//
+// Create a new inbound policy.
+//
+// Every activity received from a remote instance runs through enabled inbound
+// policies, in order of priority, before it is processed. A policy matches an
+// activity when all of its set matchers match. At least one matcher (domains,
+// keywords, regex, max_actor_age or min_mentions) must be set.
+//
+// Changes take effect immediately, without a restart.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: title
+//		in: formData
+//		description: Short title of the policy, for admins.
+//		type: string
+//		required: true
+//	-
+//		name: priority
+//		in: formData
+//		description: Policies run in ascending order of priority.
+//		type: integer
+//	-
+//		name: enabled
+//		in: formData
+//		description: Whether the policy runs at all.
+//		type: boolean
+//		default: true
+//	-
+//		name: dry_run
+//		in: formData
+//		description: Only log and count matches, without applying the action.
+//		type: boolean
+//		default: false
+//	-
+//		name: action
+//		in: formData
+//		description: What the policy does to matching activities.
+//		type: string
+//		enum:
+//			- reject
+//			- strip_media
+//			- force_sensitive
+//			- unlist
+//			- rewrite_hashtags
+//			- quarantine
+//		required: true
+//	-
+//		name: domains[]
+//		in: formData
+//		description: Match activities from these domains, or their subdomains.
+//		type: array
+//		items:
+//			type: string
+//	-
+//		name: keywords[]
+//		in: formData
+//		description: Match statuses containing any of these keywords (case-insensitive).
+//		type: array
+//		items:
+//			type: string
+//	-
+//		name: regex
+//		in: formData
+//		description: Match statuses whose text matches this regular expression (case-insensitive).
+//		type: string
+//	-
+//		name: max_actor_age
+//		in: formData
+//		description: Match activities from actors first seen less than this many seconds ago. 0 to not match on actor age.
+//		type: integer
+//	-
+//		name: min_mentions
+//		in: formData
+//		description: Match statuses mentioning at least this many accounts. 0 to not match on mentions.
+//		type: integer
+//	-
+//		name: hashtag_rewrites
+//		in: formData
+//		description: >-
+//			For action rewrite_hashtags: hashtag names mapped to their replacement,
+//			or to an empty string to remove the hashtag. Form-encoded as
+//			`hashtag_rewrites[name]=replacement`.
+//		type: object
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The newly-created inbound policy.
+//			schema:
+//				"$ref": "#/definitions/adminInboundPolicy"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) InboundPoliciesPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminInboundPolicyCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Hashtag rewrites in form
+	// encoding look like key[name].
+	if form.HashtagRewrites == nil {
+		form.HashtagRewrites = c.PostFormMap("hashtag_rewrites")
+	}
+
+	policy, errWithCode := m.processor.Admin().InboundPolicyCreate(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, policy)
+}
@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MeasuresPOSTHandler swagger:operation POST /api/v1/admin/measures adminMeasures
+//
+// Get quantitative measures of instance activity, with daily values over a period.
+//
+// Available keys are: new_users, active_users (local accounts that posted,
+// boosted or faved), interactions (replies, boosts and faves of local
+// statuses), opened_reports, resolved_reports, instance_statuses,
+// instance_followers and instance_media_attachments. The instance_*
+// measures require a domain parameter, eg., `instance_statuses[domain]`.
+//
+// Values are computed in whole UTC days, and cached for a few minutes.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: keys[]
+//		in: formData
+//		description: Keys of the measures to get.
+//		type: array
+//		items:
+//			type: string
+//		collectionFormat: multi
+//		required: true
+//	-
+//		name: start_at
+//		in: formData
+//		description: >-
+//			Start of the period, as a date or RFC3339 timestamp.
+//			Defaults to 30 days before end_at.
+//		type: string
+//	-
+//		name: end_at
+//		in: formData
+//		description: >-
+//			End of the period (inclusive), as a date or RFC3339
+//			timestamp. Defaults to today. Periods may be at most 366 days.
+//		type: string
+//	-
+//		name: instance_statuses[domain]
+//		in: formData
+//		description: Domain for the instance_statuses measure.
+//		type: string
+//	-
+//		name: instance_followers[domain]
+//		in: formData
+//		description: Domain for the instance_followers measure.
+//		type: string
+//	-
+//		name: instance_media_attachments[domain]
+//		in: formData
+//		description: Domain for the instance_media_attachments measure.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The requested measures.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminMeasure"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MeasuresPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminMeasuresRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Per-measure params in form
+	// encoding look like key[param].
+	if form.InstanceStatuses == nil {
+		form.InstanceStatuses = c.PostFormMap("instance_statuses")
+	}
+	if form.InstanceFollowers == nil {
+		form.InstanceFollowers = c.PostFormMap("instance_followers")
+	}
+	if form.InstanceMediaAttachments == nil {
+		form.InstanceMediaAttachments = c.PostFormMap("instance_media_attachments")
+	}
+
+	measures, errWithCode := m.processor.Admin().MeasuresGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, measures)
+}
@@ -63,6 +63,22 @@ import (
 //
 //			Sample: The reported account was suspended.
 //		type: string
+//	-
+//		name: warning_text
+//		in: formData
+//		description: >-
+//			Optional text of a warning to issue to the reported account, if it's
+//			local to this instance. The warning is linked to the statuses and rules
+//			of the report, and is delivered to the account by email and notification.
+//		type: string
+//	-
+//		name: forward
+//		in: formData
+//		description: >-
+//			Forward the report to the instance of the reported account, if it's
+//			remote and the report was not already forwarded by the reporter.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -82,6 +98,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable entity
 //		'500':
 //			description: internal server error
 func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
@@ -120,7 +138,7 @@ func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form.ActionTakenComment)
+	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
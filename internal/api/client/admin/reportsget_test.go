@@ -241,7 +241,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "name": "user"
         }
       },
-      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+      "media_storage": {
+        "used": 0,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "assigned_account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -287,7 +292,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "name": "admin"
         }
       },
-      "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+      "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+      "media_storage": {
+        "used": 69401,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "action_taken_by_account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -333,7 +343,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "name": "admin"
         }
       },
-      "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+      "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+      "media_storage": {
+        "used": 69401,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "statuses": [],
     "rules": [],
@@ -403,7 +418,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "name": "user"
         }
       },
-      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+      "media_storage": {
+        "used": 0,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "target_account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -625,7 +645,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
           "name": "user"
         }
       },
-      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+      "media_storage": {
+        "used": 0,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "target_account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -847,7 +872,12 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
           "name": "user"
         }
       },
-      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+      "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+      "media_storage": {
+        "used": 0,
+        "quota": 0,
+        "quota_override": false
+      }
     },
     "target_account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
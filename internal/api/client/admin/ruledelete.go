@@ -102,7 +102,7 @@ func (m *Module) RuleDELETEHandler(c *gin.Context) {
 		return
 	}
 
-	apiRule, errWithCode := m.processor.Admin().RuleDelete(c.Request.Context(), ruleID)
+	apiRule, errWithCode := m.processor.Admin().RuleDelete(c.Request.Context(), authed.Account, ruleID)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -106,7 +106,7 @@ func (m *Module) RulePATCHHandler(c *gin.Context) {
 		return
 	}
 
-	apiRule, errWithCode := m.processor.Admin().RuleUpdate(c.Request.Context(), ruleID, form)
+	apiRule, errWithCode := m.processor.Admin().RuleUpdate(c.Request.Context(), authed.Account, ruleID, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -29,6 +29,8 @@ const (
 	IDKey            = "id"                                    // IDKey is the key for media attachment IDs
 	BasePath         = "/:" + apiutil.APIVersionKey + "/media" // BasePath is the base API path for making media requests through v1 or v2 of the api (for mastodon API compatibility)
 	AttachmentWithID = BasePath + "/:" + IDKey                 // BasePathWithID corresponds to a media attachment with the given ID
+	UploadsPath      = BasePath + "/uploads"                   // UploadsPath is the base path for resumable uploads
+	UploadWithID     = UploadsPath + "/:" + IDKey              // UploadWithID corresponds to a resumable upload with the given ID
 )
 
 type Module struct {
@@ -45,4 +47,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
 	attachHandler(http.MethodGet, AttachmentWithID, m.MediaGETHandler)
 	attachHandler(http.MethodPut, AttachmentWithID, m.MediaPUTHandler)
+	attachHandler(http.MethodOptions, UploadsPath, m.MediaUploadOPTIONSHandler)
+	attachHandler(http.MethodPost, UploadsPath, m.MediaUploadPOSTHandler)
+	attachHandler(http.MethodHead, UploadWithID, m.MediaUploadHEADHandler)
+	attachHandler(http.MethodPatch, UploadWithID, m.MediaUploadPATCHHandler)
+	attachHandler(http.MethodDelete, UploadWithID, m.MediaUploadDELETEHandler)
 }
@@ -71,9 +71,17 @@ import (
 //	-
 //		name: file
 //		in: formData
-//		description: The media attachment to upload.
+//		description: >-
+//			The media attachment to upload.
+//			Either this or `upload_id` must be set.
 //		type: file
-//		required: true
+//	-
+//		name: upload_id
+//		in: formData
+//		description: >-
+//			ID of a complete resumable upload (see `/api/v1/media/uploads`)
+//			to use as the media attachment, instead of uploading a file.
+//		type: string
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -148,13 +156,18 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 }
 
 func validateCreateMedia(form *apimodel.AttachmentRequest) error {
-	// check there actually is a file attached and it's not size 0
-	if form.File == nil {
+	// check there actually is a file attached or uploaded
+	if form.File == nil && form.UploadID == "" {
 		return errors.New("no attachment given")
 	}
 
+	if form.File != nil && form.UploadID != "" {
+		return errors.New("only one of file or upload_id should be given")
+	}
+
 	maxVideoSize := config.GetMediaVideoMaxSize()
 	maxImageSize := config.GetMediaImageMaxSize()
+	maxAudioSize := config.GetMediaAudioMaxSize()
 	minDescriptionChars := config.GetMediaDescriptionMinChars()
 	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
 
@@ -164,8 +177,11 @@ func validateCreateMedia(form *apimodel.AttachmentRequest) error {
 	if maxImageSize > maxSize {
 		maxSize = maxImageSize
 	}
+	if maxAudioSize > maxSize {
+		maxSize = maxAudioSize
+	}
 
-	if form.File.Size > int64(maxSize) {
+	if form.File != nil && form.File.Size > int64(maxSize) {
 		return fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, form.File.Size)
 	}
 
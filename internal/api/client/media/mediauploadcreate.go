@@ -0,0 +1,144 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadPOSTHandler swagger:operation POST /api/{api_version}/media/uploads mediaUploadCreate
+//
+// Start a resumable upload of a media file.
+//
+// Resumable uploads follow the tus protocol (https://tus.io/protocols/resumable-upload),
+// version 1.0.0, with the creation, expiration and termination extensions.
+//
+// The file is sent in chunks with PATCH requests to the URL in the `Location` header.
+// Once the whole file has been sent, create a media attachment from it by passing the
+// upload ID as `upload_id` to `POST /api/{api_version}/media`.
+//
+// Unfinished uploads are removed once they expire, which is some time after the last chunk was received.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: Upload-Length
+//		type: integer
+//		in: header
+//		description: Total size of the file to upload, in bytes.
+//		required: true
+//	-
+//		name: Tus-Resumable
+//		type: string
+//		in: header
+//		description: Version of the tus protocol in use, if any. Must be `1.0.0`.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'201':
+//			description: The newly-started upload.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//			headers:
+//				Location:
+//					type: string
+//					description: URL of the upload, to send chunks to.
+//				Upload-Expires:
+//					type: string
+//					description: Time at which the upload will be removed unless another chunk is received.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'412':
+//			description: unsupported tus version
+//		'413':
+//			description: upload length exceeds the max size of media
+//		'422':
+//			description: unprocessable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPOSTHandler(c *gin.Context) {
+	apiVersion, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if errWithCode := checkTusVersion(c); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if c.GetHeader(uploadDeferHeader) != "" {
+		const text = "deferred upload length is not supported"
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	length, errWithCode := parseUploadHeader(c, uploadLengthHeader)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadCreate(c.Request.Context(), authed.Account, length)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Header("Location", uploadLocation(apiVersion, upload.ID))
+	apiutil.JSON(c, http.StatusCreated, upload)
+}
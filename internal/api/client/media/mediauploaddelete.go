@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadDELETEHandler swagger:operation DELETE /api/{api_version}/media/uploads/{id} mediaUploadDelete
+//
+// Cancel a resumable upload, removing anything received so far.
+//
+//	---
+//	tags:
+//	- media
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		type: string
+//		in: path
+//		description: ID of the upload.
+//		required: true
+//	-
+//		name: Tus-Resumable
+//		type: string
+//		in: header
+//		description: Version of the tus protocol in use, if any. Must be `1.0.0`.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'204':
+//			description: The upload was cancelled.
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'410':
+//			description: upload expired
+//		'412':
+//			description: unsupported tus version
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadDELETEHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := checkTusVersion(c); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.Media().UploadDelete(c.Request.Context(), authed.Account, c.Param(IDKey)); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
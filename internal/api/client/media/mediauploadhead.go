@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadHEADHandler swagger:operation HEAD /api/{api_version}/media/uploads/{id} mediaUploadGet
+//
+// Get how much of a resumable upload has been received, to know where to resume it from.
+//
+//	---
+//	tags:
+//	- media
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		type: string
+//		in: path
+//		description: ID of the upload.
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The state of the upload.
+//			headers:
+//				Upload-Offset:
+//					type: integer
+//					description: Number of bytes of the file received so far.
+//				Upload-Length:
+//					type: integer
+//					description: Total size of the file being uploaded, in bytes.
+//				Upload-Expires:
+//					type: string
+//					description: Time at which the upload will be removed unless another chunk is received.
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'410':
+//			description: upload expired
+//		'412':
+//			description: unsupported tus version
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadHEADHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := checkTusVersion(c); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadGet(c.Request.Context(), authed.Account, c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+// MediaUploadOPTIONSHandler swagger:operation OPTIONS /api/{api_version}/media/uploads mediaUploadOptions
+//
+// Get the tus protocol version, extensions and max upload size supported for resumable uploads.
+//
+//	---
+//	tags:
+//	- media
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//
+//	responses:
+//		'204':
+//			description: Supported tus protocol features.
+//			headers:
+//				Tus-Version:
+//					type: string
+//					description: Supported tus protocol versions.
+//				Tus-Extension:
+//					type: string
+//					description: Supported tus protocol extensions.
+//				Tus-Max-Size:
+//					type: integer
+//					description: Max size of an upload, in bytes.
+func (m *Module) MediaUploadOPTIONSHandler(c *gin.Context) {
+	c.Header(tusResumableHeader, tusVersion)
+	c.Header(tusVersionHeader, tusVersion)
+	c.Header(tusExtensionHeader, tusExtensions)
+	c.Header(tusMaxSizeHeader, strconv.FormatInt(media.UploadMaxSize(), 10))
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadPATCHHandler swagger:operation PATCH /api/{api_version}/media/uploads/{id} mediaUploadAppend
+//
+// Send the next chunk of a resumable upload.
+//
+// The request body is the chunk of the file, starting at the offset given in `Upload-Offset`,
+// which must match the number of bytes received so far. Each chunk is all or nothing: if a
+// request fails part-way, get the upload with HEAD to find where to resume from.
+//
+// Chunks which would take the upload past its length, or past the max size of videos, are rejected.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/offset+octet-stream
+//
+//	parameters:
+//	-
+//		name: api_version
+//		type: string
+//		in: path
+//		description: Version of the API to use. Must be either `v1` or `v2`.
+//		required: true
+//	-
+//		name: id
+//		type: string
+//		in: path
+//		description: ID of the upload.
+//		required: true
+//	-
+//		name: Upload-Offset
+//		type: integer
+//		in: header
+//		description: Offset in the file at which this chunk starts, in bytes.
+//		required: true
+//	-
+//		name: Tus-Resumable
+//		type: string
+//		in: header
+//		description: Version of the tus protocol in use, if any. Must be `1.0.0`.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'204':
+//			description: The chunk was received.
+//			headers:
+//				Upload-Offset:
+//					type: integer
+//					description: Number of bytes of the file received so far.
+//				Upload-Expires:
+//					type: string
+//					description: Time at which the upload will be removed unless another chunk is received.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'409':
+//			description: offset doesn't match the number of bytes received so far
+//		'410':
+//			description: upload expired
+//		'412':
+//			description: unsupported tus version
+//		'413':
+//			description: chunk would take the upload past its length or the max size of media
+//		'415':
+//			description: content type must be application/offset+octet-stream
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPATCHHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		[]string{apiutil.APIv1, apiutil.APIv2}...,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if errWithCode := checkTusVersion(c); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if ct := c.ContentType(); ct != tusContentType {
+		const text = "content type must be " + tusContentType
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnsupportedMediaType(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	offset, errWithCode := parseUploadHeader(c, uploadOffsetHeader)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadAppend(
+		c.Request.Context(),
+		authed.Account,
+		c.Param(IDKey),
+		offset,
+		c.Request.Body,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Resumable uploads follow the core tus protocol, version 1.0.0,
+// with the creation, expiration and termination extensions, so
+// that existing tus client libraries can be used.
+//
+// See https://tus.io/protocols/resumable-upload
+const (
+	tusVersion     = "1.0.0"
+	tusExtensions  = "creation,expiration,termination"
+	tusContentType = "application/offset+octet-stream"
+
+	tusResumableHeader  = "Tus-Resumable"
+	tusVersionHeader    = "Tus-Version"
+	tusExtensionHeader  = "Tus-Extension"
+	tusMaxSizeHeader    = "Tus-Max-Size"
+	uploadLengthHeader  = "Upload-Length"
+	uploadOffsetHeader  = "Upload-Offset"
+	uploadExpiresHeader = "Upload-Expires"
+	uploadDeferHeader   = "Upload-Defer-Length"
+)
+
+// checkTusVersion returns an error if the request is made with
+// a version of the tus protocol other than the one we support.
+// Requests without a version are fine, for clients that don't
+// use a tus library.
+func checkTusVersion(c *gin.Context) gtserror.WithCode {
+	// Every response should
+	// include our version.
+	c.Header(tusResumableHeader, tusVersion)
+
+	version := c.GetHeader(tusResumableHeader)
+	if version == "" || version == tusVersion {
+		return nil
+	}
+
+	c.Header(tusVersionHeader, tusVersion)
+	const text = "unsupported tus version, only " + tusVersion + " is supported"
+	return gtserror.NewErrorPreconditionFailed(errors.New(text), text)
+}
+
+// parseUploadHeader parses the given
+// non-negative integer upload header.
+func parseUploadHeader(c *gin.Context, key string) (int64, gtserror.WithCode) {
+	value := c.GetHeader(key)
+	if value == "" {
+		text := key + " header not set"
+		return 0, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i < 0 {
+		text := key + " header must be a non-negative integer"
+		return 0, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return i, nil
+}
+
+// setUploadHeaders sets headers describing the
+// state of the given upload on the response.
+func setUploadHeaders(c *gin.Context, upload *apimodel.MediaUpload) {
+	c.Header(uploadOffsetHeader, strconv.FormatInt(upload.Offset, 10))
+	c.Header(uploadLengthHeader, strconv.FormatInt(upload.Length, 10))
+
+	if expires, err := util.ParseISO8601(upload.ExpiresAt); err == nil {
+		c.Header(uploadExpiresHeader, expires.Format(http.TimeFormat))
+	}
+}
+
+// uploadLocation returns the absolute
+// URL of the upload with the given ID.
+func uploadLocation(apiVersion string, uploadID string) string {
+	return config.GetProtocol() + "://" + config.GetHost() +
+		"/api/" + apiVersion + "/media/uploads/" + uploadID
+}
@@ -84,7 +84,7 @@ import (
 //		type: array
 //		items:
 //			type: string
-//			description: Array of types of notifications to exclude (follow, favourite, reblog, mention, poll, follow_request)
+//			description: Array of types of notifications to exclude (follow, favourite, reblog, mention, poll, follow_request, moderation_warning)
 //		in: query
 //		required: false
 //
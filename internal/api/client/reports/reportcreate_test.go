@@ -190,6 +190,55 @@ func (suite *ReportCreateTestSuite) TestCreateReport7() {
 	suite.Nil(report)
 }
 
+func (suite *ReportCreateTestSuite) TestCreateReportCategory() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+	ruleID := testrig.NewTestRules()["rule1"].ID
+
+	// No category + rules -> violation.
+	report, err := suite.createReport(http.StatusOK, "", &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		RuleIDs:   []string{ruleID},
+	})
+	suite.NoError(err)
+	suite.Equal("violation", report.Category)
+
+	// No category, no rules -> other.
+	report, err = suite.createReport(http.StatusOK, "", &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+	})
+	suite.NoError(err)
+	suite.Equal("other", report.Category)
+
+	report, err = suite.createReport(http.StatusOK, "", &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Category:  "spam",
+	})
+	suite.NoError(err)
+	suite.Equal("spam", report.Category)
+
+	report, err = suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: category violation requires at least one rule_id"}`, &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Category:  "violation",
+	})
+	suite.NoError(err)
+	suite.Nil(report)
+
+	report, err = suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: rule_ids can only be set for category violation"}`, &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Category:  "legal",
+		RuleIDs:   []string{ruleID},
+	})
+	suite.NoError(err)
+	suite.Nil(report)
+
+	report, err = suite.createReport(http.StatusBadRequest, `{"error":"Bad Request: category turtles not recognized, valid categories are: spam, legal, violation, other"}`, &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		Category:  "turtles",
+	})
+	suite.NoError(err)
+	suite.Nil(report)
+}
+
 func TestReportCreateTestSuite(t *testing.T) {
 	suite.Run(t, &ReportCreateTestSuite{})
 }
@@ -33,6 +33,9 @@ const (
 	MediaSizeKey = "media_size"
 	// FileNameKey is the actual filename being sought. Will usually be a UUID then something like .jpeg
 	FileNameKey = "file_name"
+	// WidthKey is the url query key for the width at which an image thumbnail
+	// will be displayed, used to pick the best-sized derivative to serve instead.
+	WidthKey = "width"
 	// FileServePath is the fileserve path minus the 'fileserver/:account_id/:media_type' prefix.
 	FileServePath = "/:" + MediaSizeKey + "/:" + FileNameKey
 )
@@ -76,6 +76,16 @@ func (m *Module) ServeFile(c *gin.Context) {
 		return
 	}
 
+	var width int
+	if widthStr := c.Query(WidthKey); widthStr != "" {
+		width, err = strconv.Atoi(widthStr)
+		if err != nil || width <= 0 {
+			err := fmt.Errorf("invalid %s %s in request", WidthKey, widthStr)
+			apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	// Acquire context from gin request.
 	ctx := c.Request.Context()
 
@@ -84,6 +94,7 @@ func (m *Module) ServeFile(c *gin.Context) {
 		MediaType: mediaType,
 		MediaSize: mediaSize,
 		FileName:  fileName,
+		Width:     width,
 	})
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -118,15 +129,28 @@ func (m *Module) ServeFile(c *gin.Context) {
 	// if this is a head request, just return info + throw the reader away
 	if c.Request.Method == http.MethodHead {
 		c.Header("Content-Type", contentType)
-		c.Header("Content-Length", strconv.FormatInt(content.ContentLength, 10))
+		if content.ContentLength >= 0 {
+			c.Header("Content-Length", strconv.FormatInt(content.ContentLength, 10))
+		}
 		c.Status(http.StatusOK)
 		return
 	}
 
+	if rs, ok := content.Content.(io.ReadSeeker); ok {
+		// Source supports seeking (eg., a file from local
+		// disk or the storage cache), so let the standard
+		// library handle range and conditional requests.
+		c.Header("Content-Type", contentType)
+		http.ServeContent(c.Writer, c.Request, fileName, time.Time{}, rs)
+		return
+	}
+
 	// Look for a provided range header.
 	rng := c.GetHeader("Range")
-	if rng == "" {
-		// This is a simple query for the whole file, so do a read from whole reader.
+	if rng == "" || content.ContentLength < 0 {
+		// This is a simple query for the whole file, or we can't serve
+		// a range of a file of unknown length, so do a read from whole reader.
+		// (A negative content length leaves out the Content-Length header).
 		c.DataFromReader(http.StatusOK, content.ContentLength, contentType, content.Content, nil)
 		return
 	}
@@ -217,27 +241,18 @@ func serveFileRange(rw http.ResponseWriter, r *http.Request, src io.Reader, rng
 		endRng = strconv.FormatInt(end, 10)
 	}
 
-	if start >= end {
+	if start > end {
 		// This range starts _after_ their range end, unsatisfiable and nonsense!
 		rw.Header().Set("Content-Range", "bytes *"+strconv.FormatInt(size, 10))
 		http.Error(rw, "Unsatisfiable Range", http.StatusRequestedRangeNotSatisfiable)
 		return
 	}
 
-	if rs, ok := src.(io.ReadSeeker); ok {
-		// Source supports seeking (usually *os.File),
-		// seek to the 'start' byte position in file.
-		if _, err := rs.Seek(start, 0); err != nil {
-			log.Errorf(r.Context(), "error seeking in source: %v", err)
-			return
-		}
-	} else {
-		// Compat for when no seek call is implemented,
-		// dump the first 'start' many bytes into void.
-		if _, err := fastcopy.CopyN(io.Discard, src, start); err != nil {
-			log.Errorf(r.Context(), "error reading from source: %v", err)
-			return
-		}
+	// No seek call is implemented, so
+	// dump the first 'start' many bytes into void.
+	if _, err := fastcopy.CopyN(io.Discard, src, start); err != nil {
+		log.Errorf(r.Context(), "error reading from source: %v", err)
+		return
 	}
 
 	// Determine new content length
@@ -105,6 +105,14 @@ type Account struct {
 	// If set, indicates that this account is currently inactive, and has migrated to the given account.
 	// Key/value omitted for accounts that haven't moved, and for suspended accounts.
 	Moved *Account `json:"moved,omitempty"`
+
+	// Additional fields not exposed via JSON
+	// (used only internally for templating etc).
+
+	// Srcset of the avatar at each of its derivative widths.
+	AvatarSrcSet string `json:"-"`
+	// Srcset of the header at each of its derivative widths.
+	HeaderSrcSet string `json:"-"`
 }
 
 // AccountCreateRequest models account creation parameters.
@@ -138,6 +146,10 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code to use for this sign-up, if any.
+	// Required when registration is not open.
+	// swagger:parameters
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountWarning models a moderation warning (aka. strike)
+// issued to an account by an admin of this instance.
+//
+// swagger:model accountWarning
+type AccountWarning struct {
+	// ID of the warning.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The date when this warning was issued (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Action taken against the account along with this warning.
+	// One of: none, silence, disable.
+	// example: silence
+	Action string `json:"action"`
+	// Explanation of the warning, written by the admin who issued it.
+	// example: Please stop posting spam.
+	Text string `json:"text"`
+	// Array of IDs of statuses that this warning pertains to.
+	// Will be empty if no statuses were attached.
+	// example: ["01GPBN5YDY6JKBWE44H7YQBDCQ","01GPBN65PDWSBPWVDD0SQCFFY3"]
+	StatusIDs []string `json:"status_ids"`
+	// Array of instance rules that were broken.
+	// Will be empty if no rules were attached.
+	Rules []InstanceRule `json:"rules"`
+	// ID of the report that led to this warning, if any.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ReportID *string `json:"report_id"`
+	// Account that received the warning.
+	TargetAccount *Account `json:"target_account"`
+	// Appeal submitted against this warning.
+	// Will be null if the warning has not been appealed.
+	Appeal *AccountWarningAppeal `json:"appeal"`
+}
+
+// AccountWarningAppeal models an appeal
+// submitted against an account warning.
+//
+// swagger:model accountWarningAppeal
+type AccountWarningAppeal struct {
+	// Text of the appeal, explaining why the warning should be overturned.
+	// example: I wasn't posting spam, I was sharing my art!
+	Text string `json:"text"`
+	// State of the appeal. One of: pending, approved, rejected.
+	// example: pending
+	State string `json:"state"`
+	// The date when the appeal was submitted (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The date when the appeal was approved or rejected (ISO 8601 Datetime).
+	// Will be null if the appeal is still pending.
+	// example: 2021-07-30T09:20:25+00:00
+	ResolvedAt *string `json:"resolved_at"`
+}
+
+// AccountWarningAppealRequest models a request
+// to appeal a warning issued to the requester.
+//
+// swagger:ignore
+type AccountWarningAppealRequest struct {
+	// Text explaining why the warning should be overturned.
+	Text string `form:"text" json:"text" xml:"text"`
+}
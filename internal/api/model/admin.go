@@ -17,6 +17,8 @@
 
 package model
 
+import "encoding/json"
+
 // AdminAccountInfo models the admin view of an account's details.
 //
 // swagger:model adminAccountInfo
@@ -72,6 +74,19 @@ type AdminAccountInfo struct {
 	CreatedByApplicationID string `json:"created_by_application_id,omitempty"`
 	// The ID of the account that invited this user
 	InvitedByAccountID string `json:"invited_by_account_id,omitempty"`
+	// Media storage used by the account, and its quota.
+	// Omitted for remote accounts.
+	MediaStorage *MediaStorage `json:"media_storage,omitempty"`
+}
+
+// AdminAccountMediaQuotaRequest is the form submitted as a
+// POST to override the media storage quota of an account.
+//
+// swagger:ignore
+type AdminAccountMediaQuotaRequest struct {
+	// Max total bytes of media that the
+	// account may store. 0 means unlimited.
+	Quota *int64 `form:"quota" json:"quota" xml:"quota"`
 }
 
 // AdminReport models the admin view of a report.
@@ -132,6 +147,79 @@ type AdminReport struct {
 type AdminReportResolveRequest struct {
 	// Comment to show to the creator of the report when an admin marks it as resolved.
 	ActionTakenComment *string `form:"action_taken_comment" json:"action_taken_comment" xml:"action_taken_comment"`
+	// Text of a warning to issue to the reported account
+	// (if local) along with resolving the report. The warning
+	// will reference the statuses and rules of the report.
+	WarningText *string `form:"warning_text" json:"warning_text" xml:"warning_text"`
+	// Forward the report to the instance of the reported
+	// account (if remote), if it wasn't forwarded already.
+	Forward bool `form:"forward" json:"forward" xml:"forward"`
+}
+
+// AdminReportNote models an internal moderator note on a report.
+//
+// swagger:model adminReportNote
+type AdminReportNote struct {
+	// ID of the note.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The date when this note was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Plaintext content of the note.
+	// example: Reached out to the admin of their instance.
+	Content string `json:"content"`
+	// The moderator account that created the note.
+	Account *AdminAccountInfo `json:"account"`
+}
+
+// AdminReportNoteCreateRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/notes
+//
+// swagger:ignore
+type AdminReportNoteCreateRequest struct {
+	// Plaintext content of the note.
+	Content string `form:"content" json:"content" xml:"content"`
+}
+
+// AdminAuditLogEntry models one mutation performed by an admin of this instance.
+//
+// swagger:model adminAuditLogEntry
+type AdminAuditLogEntry struct {
+	// ID of the entry.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// When the mutation was performed (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The admin account that performed the mutation.
+	Account *AdminAccountInfo `json:"account"`
+	// What was done to the target, eg., create, update, delete, silence, resolve.
+	// example: create
+	Action string `json:"action"`
+	// Type of the target.
+	// enum:
+	//   - account
+	//   - domain_block
+	//   - domain_allow
+	//   - emoji
+	//   - rule
+	//   - header_allow
+	//   - header_block
+	//   - instance
+	//   - report
+	// example: domain_block
+	TargetType string `json:"target_type"`
+	// ID of the target.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	TargetID string `json:"target_id"`
+	// Summary of the target before the mutation.
+	// Null if the target didn't exist yet.
+	// swagger:type object
+	Before json.RawMessage `json:"before"`
+	// Summary of the target after the mutation.
+	// Null if the target no longer exists.
+	// swagger:type object
+	After json.RawMessage `json:"after"`
 }
 
 // AdminEmoji models the admin view of a custom emoji.
@@ -170,10 +258,22 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One of disable, silence, unsilence, suspend, warn.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Content warning to prepend to statuses
+	// of a silenced account. Only used for silence.
+	ContentWarning string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+	// ID of a report to link to the
+	// warning issued with this action.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
+	// IDs of statuses to link to the
+	// warning issued with this action.
+	StatusIDs []string `form:"status_ids[]" json:"status_ids" xml:"status_ids"`
+	// IDs of instance rules to link to
+	// the warning issued with this action.
+	RuleIDs []string `form:"rule_ids[]" json:"rule_ids" xml:"rule_ids"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
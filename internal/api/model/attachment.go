@@ -23,8 +23,11 @@ import "mime/multipart"
 //
 // swagger: ignore
 type AttachmentRequest struct {
-	// Media file.
-	File *multipart.FileHeader `form:"file" binding:"required"`
+	// Media file. Either this or UploadID must be set.
+	File *multipart.FileHeader `form:"file"`
+	// ID of a complete resumable upload
+	// to use instead of a media file.
+	UploadID string `form:"upload_id"`
 	// Description of the media file. Optional.
 	// This will be used as alt-text for users of screenreaders etc.
 	// example: This is an image of some kittens, they are very cute and fluffy.
@@ -96,6 +99,9 @@ type Attachment struct {
 
 	// Parent status of this media is sensitive.
 	Sensitive bool `json:"-"`
+
+	// Srcset of the preview at each of its derivative widths.
+	SrcSet string `json:"-"`
 }
 
 // MediaMeta models media metadata.
@@ -109,6 +115,10 @@ type MediaMeta struct {
 	Small MediaDimensions `json:"small,omitempty"`
 	// Focus data for the media.
 	Focus *MediaFocus `json:"focus,omitempty"`
+	// Audio channels of the media.
+	// Only set for audio.
+	// example: stereo
+	AudioChannels string `json:"audio_channels,omitempty"`
 }
 
 // MediaFocus models the focal point of a piece of media.
@@ -48,4 +48,8 @@ type GetContentRequestForm struct {
 	MediaSize string
 	// Filename of the content
 	FileName string
+	// Width at which the content will be displayed,
+	// used to pick the best-sized image derivative.
+	// 0 means the requested size as-is.
+	Width int
 }
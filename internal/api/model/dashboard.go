@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminMeasure models one quantitative
+// measure of instance activity over time.
+//
+// swagger:model adminMeasure
+type AdminMeasure struct {
+	// Key of this measure, eg., `new_users`.
+	// example: new_users
+	Key string `json:"key"`
+	// Unit of this measure, if any, eg., `bytes`.
+	// example: bytes
+	Unit string `json:"unit,omitempty"`
+	// Total value of this measure
+	// over the requested period.
+	// example: 48
+	Total string `json:"total"`
+	// Human readable version of total, if applicable.
+	// example: 1.2 MB
+	HumanValue string `json:"human_value,omitempty"`
+	// Total value of this measure over
+	// the preceding period of equal length.
+	// example: 41
+	PreviousTotal string `json:"previous_total,omitempty"`
+	// Value of this measure for each day in the requested period.
+	Data []AdminMeasureData `json:"data"`
+}
+
+// AdminMeasureData models the value of
+// a measure over one day.
+//
+// swagger:model adminMeasureData
+type AdminMeasureData struct {
+	// Midnight UTC at the start of this day (ISO 8601 Datetime).
+	// example: 2021-07-30T00:00:00.000Z
+	Date string `json:"date"`
+	// Value of the measure for this day.
+	// example: 3
+	Value string `json:"value"`
+}
+
+// AdminDimension models qualitative data
+// about the instance, keyed by some category.
+//
+// swagger:model adminDimension
+type AdminDimension struct {
+	// Key of this dimension, eg., `languages`.
+	// example: languages
+	Key string `json:"key"`
+	// Data for this dimension.
+	Data []AdminDimensionData `json:"data"`
+}
+
+// AdminDimensionData models one category
+// of a dimension and its value.
+//
+// swagger:model adminDimensionData
+type AdminDimensionData struct {
+	// Key of this category.
+	// example: en
+	Key string `json:"key"`
+	// Human readable version of key.
+	// example: en
+	HumanKey string `json:"human_key"`
+	// Value of this category.
+	// example: 10
+	Value string `json:"value"`
+	// Unit of value, if any, eg., `bytes`.
+	// example: bytes
+	Unit string `json:"unit,omitempty"`
+	// Human readable version of value, if applicable.
+	// example: 1.2 MB
+	HumanValue string `json:"human_value,omitempty"`
+}
+
+// AdminCohort models how many users who
+// signed up in one period remained active
+// in that period and subsequent periods.
+//
+// swagger:model adminCohort
+type AdminCohort struct {
+	// Start of the sign-up period of this
+	// cohort (ISO 8601 Datetime).
+	// example: 2021-07-01T00:00:00.000Z
+	Period string `json:"period"`
+	// Length of each period, either `day` or `month`.
+	// example: month
+	Frequency string `json:"frequency"`
+	// Retention of this cohort in each period.
+	Data []AdminCohortData `json:"data"`
+}
+
+// AdminCohortData models the retention
+// of a cohort over one period.
+//
+// swagger:model adminCohortData
+type AdminCohortData struct {
+	// Start of this period (ISO 8601 Datetime).
+	// example: 2021-07-01T00:00:00.000Z
+	Date string `json:"date"`
+	// Fraction of the cohort that was active in this period.
+	// example: 0.5
+	Rate float64 `json:"rate"`
+	// Number of users in the cohort that were active in this period.
+	// example: 4
+	Value string `json:"value"`
+}
+
+// AdminMeasuresRequest models a request
+// to get one or more measures.
+//
+// swagger:ignore
+type AdminMeasuresRequest struct {
+	// Keys of the measures to get.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period (date or RFC3339 timestamp).
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period (date or RFC3339 timestamp).
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Parameters for the instance_statuses measure.
+	// Form-encoded as instance_statuses[domain].
+	InstanceStatuses map[string]string `form:"-" json:"instance_statuses" xml:"-"`
+	// Parameters for the instance_followers measure.
+	// Form-encoded as instance_followers[domain].
+	InstanceFollowers map[string]string `form:"-" json:"instance_followers" xml:"-"`
+	// Parameters for the instance_media_attachments measure.
+	// Form-encoded as instance_media_attachments[domain].
+	InstanceMediaAttachments map[string]string `form:"-" json:"instance_media_attachments" xml:"-"`
+}
+
+// AdminDimensionsRequest models a request
+// to get one or more dimensions.
+//
+// swagger:ignore
+type AdminDimensionsRequest struct {
+	// Keys of the dimensions to get.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period (date or RFC3339 timestamp).
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period (date or RFC3339 timestamp).
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Maximum number of categories
+	// to return for each dimension.
+	Limit int `form:"limit" json:"limit" xml:"limit"`
+}
+
+// AdminRetentionRequest models a request
+// to get retention data for user cohorts.
+//
+// swagger:ignore
+type AdminRetentionRequest struct {
+	// Start of the period (date or RFC3339 timestamp).
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period (date or RFC3339 timestamp).
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Length of each cohort period, `day` or `month`.
+	Frequency string `form:"frequency" json:"frequency" xml:"frequency"`
+}
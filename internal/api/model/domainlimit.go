@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainLimit represents a moderation limit (aka. silence) on a domain
+// and all of its subdomains. Statuses from accounts on a limited domain
+// are hidden from public and tag timelines and from non-followers, their
+// media is always shown as sensitive, and their follows always require
+// approval.
+//
+// swagger:model domainLimit
+type DomainLimit struct {
+	// The ID of the domain limit.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The limited domain.
+	// example: example.org
+	Domain string `json:"domain"`
+
+	// Content warning prepended to statuses
+	// from accounts on this domain, if any.
+	// example: from a limited instance
+	ContentWarning string `json:"content_warning"`
+
+	// Private comment for this limit, visible to admins only.
+	// example: lots of unmoderated spam
+	PrivateComment string `json:"private_comment"`
+
+	// Public comment on why this limit was created.
+	// example: lots of unmoderated spam
+	PublicComment string `json:"public_comment"`
+
+	// The ID of the admin account that created this domain limit.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the domain limit was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainLimitCreateRequest is the form submitted as a POST to create a new domain limit.
+//
+// swagger:ignore
+type DomainLimitCreateRequest struct {
+	// The domain to limit.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+
+	// Content warning to prepend to statuses
+	// from accounts on this domain.
+	ContentWarning string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+
+	// Private comment for this limit.
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+
+	// Public comment on why this limit was created.
+	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
+
+// DomainLimitUpdateRequest is the form submitted as a PUT to update an existing domain limit.
+//
+// swagger:ignore
+type DomainLimitUpdateRequest struct {
+	// Content warning to prepend to statuses
+	// from accounts on this domain.
+	ContentWarning *string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+
+	// Private comment for this limit.
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+
+	// Public comment on why this limit was created.
+	PublicComment *string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// EmailDomainBlock represents a block on sign-ups
+// using email addresses from the given domain.
+//
+// swagger:model emailDomainBlock
+type EmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The blocked email domain. Addresses at this domain or any
+	// of its subdomains, or whose domain has a mail exchange (MX)
+	// host at this domain or any of its subdomains, are blocked.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Time at which the block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// EmailDomainBlockCreateRequest is the form submitted
+// as a POST to create a new email domain block.
+//
+// swagger:ignore
+type EmailDomainBlockCreateRequest struct {
+	// The email domain to block.
+	// example: example.org
+	Domain string `form:"domain" json:"domain"`
+}
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminInboundPolicy represents a policy in the pipeline that
+// activities received from remote instances pass through.
+//
+// swagger:model adminInboundPolicy
+type AdminInboundPolicy struct {
+	// The ID of the policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Short title of the policy.
+	// example: no crypto spam
+	Title string `json:"title"`
+
+	// Policies run in ascending order of priority.
+	// example: 0
+	Priority int `json:"priority"`
+
+	// Whether the policy runs at all.
+	Enabled bool `json:"enabled"`
+
+	// Whether the policy only logs and counts
+	// matches, without applying its action.
+	DryRun bool `json:"dry_run"`
+
+	// What the policy does to matching activities.
+	//
+	//	- reject: drop the activity entirely.
+	//	- strip_media: remove media attachments from statuses.
+	//	- force_sensitive: mark statuses as sensitive.
+	//	- unlist: make public statuses unlisted.
+	//	- rewrite_hashtags: rename or remove hashtags on statuses.
+	//	- quarantine: store statuses, but don't timeline or notify them.
+	//
+	// enum:
+	//	- reject
+	//	- strip_media
+	//	- force_sensitive
+	//	- unlist
+	//	- rewrite_hashtags
+	//	- quarantine
+	// example: reject
+	Action string `json:"action"`
+
+	// Match activities from these domains, or their subdomains.
+	Domains []string `json:"domains"`
+
+	// Match statuses containing any of these keywords (case-insensitive).
+	Keywords []string `json:"keywords"`
+
+	// Match statuses whose text matches this
+	// regular expression (case-insensitive).
+	// example: buy (now|today)
+	Regex string `json:"regex"`
+
+	// Match activities from actors first seen less
+	// than this many seconds ago. 0 if not set.
+	// example: 86400
+	MaxActorAge int64 `json:"max_actor_age"`
+
+	// Match statuses mentioning at least
+	// this many accounts. 0 if not set.
+	// example: 5
+	MinMentions int `json:"min_mentions"`
+
+	// For rewrite_hashtags: hashtag names mapped to
+	// their replacement, or "" to remove the hashtag.
+	HashtagRewrites map[string]string `json:"hashtag_rewrites"`
+
+	// Match counts of the policy since the instance last started.
+	Stats AdminInboundPolicyStats `json:"stats"`
+
+	// The ID of the admin account that created this policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the policy was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AdminInboundPolicyStats represents the
+// number of times an inbound policy matched.
+//
+// swagger:model adminInboundPolicyStats
+type AdminInboundPolicyStats struct {
+	// Number of matches while not in dry-run mode.
+	// example: 12
+	Matches int64 `json:"matches"`
+
+	// Number of matches while in dry-run mode.
+	// example: 3
+	DryRunMatches int64 `json:"dry_run_matches"`
+
+	// Time of the latest match (ISO 8601 Datetime), if any.
+	// example: 2021-07-30T09:20:25+00:00
+	LastMatchedAt *string `json:"last_matched_at"`
+}
+
+// AdminInboundPolicyCreateRequest is the form submitted
+// as a POST to create a new inbound policy.
+//
+// swagger:ignore
+type AdminInboundPolicyCreateRequest struct {
+	Title           string            `form:"title" json:"title" xml:"title"`
+	Priority        int               `form:"priority" json:"priority" xml:"priority"`
+	Enabled         *bool             `form:"enabled" json:"enabled" xml:"enabled"`
+	DryRun          bool              `form:"dry_run" json:"dry_run" xml:"dry_run"`
+	Action          string            `form:"action" json:"action" xml:"action"`
+	Domains         []string          `form:"domains[]" json:"domains" xml:"domains"`
+	Keywords        []string          `form:"keywords[]" json:"keywords" xml:"keywords"`
+	Regex           string            `form:"regex" json:"regex" xml:"regex"`
+	MaxActorAge     int64             `form:"max_actor_age" json:"max_actor_age" xml:"max_actor_age"`
+	MinMentions     int               `form:"min_mentions" json:"min_mentions" xml:"min_mentions"`
+	HashtagRewrites map[string]string `form:"-" json:"hashtag_rewrites" xml:"-"` // Form-encoded as hashtag_rewrites[name]=replacement.
+}
+
+// AdminInboundPolicyUpdateRequest is the form submitted as a
+// PUT to update an existing inbound policy. Only set fields
+// are updated.
+//
+// swagger:ignore
+type AdminInboundPolicyUpdateRequest struct {
+	Title           *string           `form:"title" json:"title" xml:"title"`
+	Priority        *int              `form:"priority" json:"priority" xml:"priority"`
+	Enabled         *bool             `form:"enabled" json:"enabled" xml:"enabled"`
+	DryRun          *bool             `form:"dry_run" json:"dry_run" xml:"dry_run"`
+	Action          *string           `form:"action" json:"action" xml:"action"`
+	Domains         *[]string         `form:"domains[]" json:"domains" xml:"domains"`
+	Keywords        *[]string         `form:"keywords[]" json:"keywords" xml:"keywords"`
+	Regex           *string           `form:"regex" json:"regex" xml:"regex"`
+	MaxActorAge     *int64            `form:"max_actor_age" json:"max_actor_age" xml:"max_actor_age"`
+	MinMentions     *int              `form:"min_mentions" json:"min_mentions" xml:"min_mentions"`
+	HashtagRewrites map[string]string `form:"-" json:"hashtag_rewrites" xml:"-"` // Form-encoded as hashtag_rewrites[name]=replacement.
+}
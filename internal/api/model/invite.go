@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package model
+
+// Invite represents an invite link that can be
+// used to sign up to this instance, even when
+// registration is otherwise closed.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The invite code.
+	// example: Z7mhrNAv6w
+	Code string `json:"code"`
+	// Link that can be shared with someone to let them sign up using this invite.
+	// example: https://example.org/signup?invite_code=Z7mhrNAv6w
+	URL string `json:"url"`
+	// When the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the invite expires (ISO 8601 Datetime).
+	// Null if the invite does not expire.
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Maximum number of sign-ups that can use this invite.
+	// Null if there is no limit.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+	// Number of sign-ups that have used this invite so far.
+	// example: 1
+	Uses int `json:"uses"`
+	// Sign-ups using this invite are approved
+	// without needing to be reviewed by a moderator.
+	AutoApprove bool `json:"auto_approve"`
+	// Whether this invite can still be used to sign up
+	// (ie., it has not expired or reached its max uses).
+	Usable bool `json:"usable"`
+	// The account that created this invite.
+	Account *Account `json:"account,omitempty"`
+}
+
+// InviteCreateRequest models a request to create an invite.
+//
+// swagger:parameters inviteCreate
+type InviteCreateRequest struct {
+	// Maximum number of sign-ups that can use this invite.
+	// 0 or unset means no limit.
+	// in: formData
+	MaxUses int `form:"max_uses" json:"max_uses"`
+	// Number of seconds from now after which this invite expires.
+	// 0 or unset means the invite does not expire.
+	// in: formData
+	ExpiresIn int `form:"expires_in" json:"expires_in"`
+	// Approve sign-ups using this invite automatically,
+	// without them having to be reviewed by a moderator.
+	// Only admins and moderators may set this.
+	// in: formData
+	AutoApprove bool `form:"auto_approve" json:"auto_approve"`
+}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// IPBlock represents a block on an IP address or range of IP addresses.
+//
+// swagger:model ipBlock
+type IPBlock struct {
+	// The ID of the IP block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The blocked IP address range, in CIDR notation.
+	// example: 192.0.2.0/24
+	IP string `json:"ip"`
+
+	// What the block prevents clients in the range from doing.
+	//
+	//	- sign_up_requires_approval: sign-ups are never automatically approved, even via an auto-approve invite.
+	//	- sign_up_block: sign-ups are not permitted.
+	//	- no_access: no requests are permitted at all.
+	//
+	// enum:
+	//	- sign_up_requires_approval
+	//	- sign_up_block
+	//	- no_access
+	// example: sign_up_block
+	Severity string `json:"severity"`
+
+	// Private comment for this block, visible to admins only.
+	// example: spam sign-ups
+	Comment string `json:"comment"`
+
+	// The ID of the admin account that created this IP block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the IP block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Time at which the IP block expires (ISO 8601 Datetime), if it expires.
+	// example: 2021-08-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// IPBlockCreateRequest is the form submitted as a POST to create a new IP block.
+//
+// swagger:ignore
+type IPBlockCreateRequest struct {
+	// The IP address or range (CIDR) to block.
+	IP string `form:"ip" json:"ip" xml:"ip"`
+
+	// What the block prevents clients in the range from doing.
+	Severity string `form:"severity" json:"severity" xml:"severity"`
+
+	// Private comment for this block.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+
+	// Number of seconds after which the block
+	// expires. If not set, the block never expires.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
+
+// IPBlockUpdateRequest is the form submitted as a PUT to update an existing IP block.
+//
+// swagger:ignore
+type IPBlockUpdateRequest struct {
+	// The IP address or range (CIDR) to block.
+	IP *string `form:"ip" json:"ip" xml:"ip"`
+
+	// What the block prevents clients in the range from doing.
+	Severity *string `form:"severity" json:"severity" xml:"severity"`
+
+	// Private comment for this block.
+	Comment *string `form:"comment" json:"comment" xml:"comment"`
+
+	// Number of seconds from now after which the block
+	// expires. Set to 0 to make the block never expire.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package model
+
+import "mime/multipart"
+
+// MediaHashBlock represents a block on media whose
+// perceptual hash is close to the blocked hash.
+//
+// swagger:model mediaHashBlock
+type MediaHashBlock struct {
+	// The ID of the media hash block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The blocked perceptual hash, as 16 hex characters.
+	// example: f0e4c2d7c9b1a385
+	Hash string `json:"hash"`
+
+	// Private comment for this block, visible to admins only.
+	// example: known abusive image
+	Comment string `json:"comment"`
+
+	// The ID of the admin account that created this media hash block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the media hash block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// MediaHashBlockCreateRequest is the form submitted as a POST to create a new media hash block.
+//
+// swagger:ignore
+type MediaHashBlockCreateRequest struct {
+	// The perceptual hash to block, as 16 hex characters.
+	Hash string `form:"hash" json:"hash" xml:"hash"`
+
+	// Sample image to calculate the hash to block from.
+	File *multipart.FileHeader `form:"file" json:"file" xml:"file"`
+
+	// Private comment for this block.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+}
+
+// AdminMediaHashMatch represents remote media that was
+// dropped for matching a media hash block, held in a
+// queue for admin review.
+//
+// swagger:model adminMediaHashMatch
+type AdminMediaHashMatch struct {
+	// The ID of the media hash match.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Time at which the media was dropped (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Perceptual hash of the dropped media, as 16 hex characters.
+	// example: f0e4c2d7c9b1a384
+	Hash string `json:"hash"`
+
+	// Number of bits by which the hash of the
+	// dropped media differs from the blocked hash.
+	// example: 1
+	Distance int `json:"distance"`
+
+	// Remote URL of the dropped media.
+	// example: https://example.org/media/some_image.jpg
+	RemoteURL string `json:"remote_url"`
+
+	// ID of the status the dropped media is attached to, if any.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	StatusID *string `json:"status_id"`
+
+	// The media hash block that the media matched.
+	MediaHashBlock *MediaHashBlock `json:"media_hash_block"`
+
+	// The account that owns the dropped media.
+	Account *AdminAccountInfo `json:"account"`
+}
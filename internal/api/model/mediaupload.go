@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// MediaUpload represents a resumable media upload,
+// which is sent in chunks over any number of requests.
+//
+// swagger:model mediaUpload
+type MediaUpload struct {
+	// The ID of the upload. Pass this as upload_id
+	// when creating a media attachment, once the
+	// whole file has been sent.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+
+	// Total size of the file being uploaded, in bytes.
+	// example: 104857600
+	Length int64 `json:"length"`
+
+	// Number of bytes of the file received so far.
+	// example: 5242880
+	Offset int64 `json:"offset"`
+
+	// Time at which the upload will be removed,
+	// unless another chunk is received (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt string `json:"expires_at"`
+}
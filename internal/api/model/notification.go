@@ -34,6 +34,7 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	moderation_warning = An admin has issued a warning to you. `moderation_warning` will be set. `account` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -44,6 +45,9 @@ type Notification struct {
 
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
+
+	// Warning that was the object of the notification, for moderation warnings.
+	ModerationWarning *AccountWarning `json:"moderation_warning,omitempty"`
 }
 
 /*
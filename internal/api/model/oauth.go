@@ -36,4 +36,47 @@ type OAuthAuthorize struct {
 	// The authorization server must return the unmodified state value back to the application.
 	// See https://www.oauth.com/oauth2-servers/authorization/the-authorization-request/
 	State string `form:"state" json:"state"`
+	// PKCE code challenge (RFC 7636), derived from a code verifier
+	// held by the client and checked again at the token endpoint.
+	CodeChallenge string `form:"code_challenge" json:"code_challenge"`
+	// Method used to derive the code challenge. Only `S256` is supported.
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// OAuthServerMetadata represents OAuth 2.0 authorization server metadata,
+// served at https://example.org/.well-known/oauth-authorization-server.
+//
+// See: https://www.rfc-editor.org/rfc/rfc8414.html#section-2
+//
+// swagger:model oauthServerMetadata
+type OAuthServerMetadata struct {
+	// The authorization server's issuer identifier.
+	// example: https://example.org/
+	Issuer string `json:"issuer"`
+	// URL of the authorization endpoint.
+	// example: https://example.org/oauth/authorize
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// URL of the token endpoint.
+	// example: https://example.org/oauth/token
+	TokenEndpoint string `json:"token_endpoint"`
+	// URL of the token revocation endpoint.
+	// example: https://example.org/oauth/revoke
+	RevocationEndpoint string `json:"revocation_endpoint"`
+	// URL of the (Mastodon API) application registration endpoint.
+	// example: https://example.org/api/v1/apps
+	AppRegistrationEndpoint string `json:"app_registration_endpoint"`
+	// OAuth scopes supported by this server.
+	ScopesSupported []string `json:"scopes_supported"`
+	// OAuth response types supported by this server.
+	ResponseTypesSupported []string `json:"response_types_supported"`
+	// OAuth grant types supported by this server.
+	GrantTypesSupported []string `json:"grant_types_supported"`
+	// Client authentication methods supported by the token endpoint.
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	// Client authentication methods supported by the revocation endpoint.
+	RevocationEndpointAuthMethodsSupported []string `json:"revocation_endpoint_auth_methods_supported"`
+	// PKCE code challenge methods supported by this server.
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+	// URL of a page containing human-readable information about the server.
+	ServiceDocumentation string `json:"service_documentation,omitempty"`
 }
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminQuarantinedStatus represents a remote status held
+// back from timelines and notifications for admin review.
+//
+// swagger:model adminQuarantinedStatus
+type AdminQuarantinedStatus struct {
+	// The ID of the quarantine entry.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// Time at which the status was quarantined (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+
+	// Spam score of the status. 0 if
+	// it was quarantined by a policy.
+	// example: 13
+	Score int `json:"score"`
+
+	// Spam signals that contributed to the score.
+	// example: ["unknown_mentions","errant_links"]
+	Signals []string `json:"signals"`
+
+	// ID of the inbound policy that
+	// quarantined the status, if any.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	PolicyID *string `json:"policy_id"`
+
+	// The account that sent the status.
+	Account *AdminAccountInfo `json:"account"`
+
+	// The quarantined status.
+	Status *Status `json:"status"`
+}
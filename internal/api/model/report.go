@@ -82,10 +82,10 @@ type ReportCreateRequest struct {
 	// default: false
 	// in: formData
 	Forward bool `form:"forward" json:"forward" xml:"forward"`
-	// Specify if the report is due to spam, violation of enumerated instance rules, or some other reason.
-	// Currently only 'other' is supported.
+	// Specify if the report is due to spam, illegal content, violation of enumerated instance rules, or some other reason.
+	// One of 'spam', 'legal', 'violation', or 'other'. If not set, defaults to 'violation'
+	// when rule_ids are provided, else 'other'. Category 'violation' requires at least one rule ID.
 	// Sample: other
-	// default: other
 	// in: formData
 	Category string `form:"category" json:"category" xml:"category"`
 	// IDs of rules on this instance which have been broken according to the reporter.
@@ -43,4 +43,25 @@ type Source struct {
 	//
 	// Omitted from json if empty / not set.
 	AlsoKnownAsURIs []string `json:"also_known_as_uris,omitempty"`
+	// Media storage used by this account, and its quota.
+	MediaStorage *MediaStorage `json:"media_storage,omitempty"`
+}
+
+// MediaStorage models the media storage
+// usage and quota of a local account.
+//
+// swagger:model mediaStorage
+type MediaStorage struct {
+	// Total bytes of media (files + thumbnails) stored by the account.
+	// example: 1048576
+	Used int64 `json:"used"`
+	// Max total bytes of media that the account may store.
+	// 0 means storage is unlimited.
+	// example: 104857600
+	Quota int64 `json:"quota"`
+	// Whether the quota was set specifically for this account
+	// by an admin, instead of coming from the account's role.
+	//
+	// Only shown to admins.
+	QuotaOverride *bool `json:"quota_override,omitempty"`
 }
@@ -32,3 +32,59 @@ type Tag struct {
 	// example: []
 	History *[]any `json:"history,omitempty"`
 }
+
+// TagHistory represents usage of a hashtag on one day.
+//
+// swagger:model tagHistory
+type TagHistory struct {
+	// UNIX timestamp of midnight UTC on this day.
+	// example: 1574553600
+	Day string `json:"day"`
+	// Number of statuses using the hashtag on this day.
+	// example: 8
+	Uses string `json:"uses"`
+	// Number of accounts using the hashtag on this day.
+	// example: 3
+	Accounts string `json:"accounts"`
+}
+
+// AdminTag represents a hashtag along with its usage
+// on this instance, and the moderation flags set on it.
+//
+// swagger:model adminTag
+type AdminTag struct {
+	// The ID of the hashtag.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The value of the hashtag after the # sign.
+	// example: helloworld
+	Name string `json:"name"`
+	// Web link to the hashtag.
+	// example: https://example.org/tags/helloworld
+	URL string `json:"url"`
+	// Usage of this hashtag over the last week, newest day first.
+	History []TagHistory `json:"history"`
+	// Hashtag is linkified in statuses
+	// posted by accounts on this instance.
+	// example: true
+	Usable bool `json:"usable"`
+	// Hashtag appears in search results, and
+	// statuses can be listed on its timeline.
+	// example: true
+	Listable bool `json:"listable"`
+	// Hashtag can be shown in trends.
+	// example: true
+	Trendable bool `json:"trendable"`
+}
+
+// AdminTagUpdateRequest is the form submitted as a PUT
+// to update the flags of a hashtag. Only set fields
+// are updated.
+//
+// swagger:ignore
+type AdminTagUpdateRequest struct {
+	Usable    *bool `form:"usable" json:"usable" xml:"usable"`
+	Listable  *bool `form:"listable" json:"listable" xml:"listable"`
+	Trendable *bool `form:"trendable" json:"trendable" xml:"trendable"`
+}
@@ -34,3 +34,48 @@ type PasswordChangeRequest struct {
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
 }
+
+// EmailChangeRequest models user email change parameters.
+//
+// swagger:parameters userEmailChange
+type EmailChangeRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// Desired new email address.
+	//
+	// in: formData
+	// required: true
+	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
+}
+
+// User models fields relevant to one user, as
+// opposed to the account that the user owns.
+//
+// swagger:model user
+type User struct {
+	// Database ID of this user.
+	ID string `json:"id"`
+	// Time this user was created. ISO 8601 Datetime
+	CreatedAt string `json:"created_at"`
+	// Confirmed email address of this user, if set.
+	Email string `json:"email,omitempty"`
+	// Unconfirmed email address of this user, if set.
+	UnconfirmedEmail string `json:"unconfirmed_email,omitempty"`
+	// Time the user's email address was last confirmed. ISO 8601 Datetime
+	ConfirmedAt string `json:"confirmed_at,omitempty"`
+	// Locale of this user.
+	Locale string `json:"locale,omitempty"`
+	// Database ID of this user's account.
+	AccountID string `json:"account_id"`
+	// User is a moderator.
+	Moderator bool `json:"moderator"`
+	// User is an admin.
+	Admin bool `json:"admin"`
+	// User's sign-up has been approved.
+	Approved bool `json:"approved"`
+	// User is disabled from signing in.
+	Disabled bool `json:"disabled"`
+}
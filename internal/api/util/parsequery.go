@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
@@ -71,23 +72,29 @@ const (
 
 	/* Admin query keys */
 
-	AdminRemoteKey      = "remote"
-	AdminActiveKey      = "active"
-	AdminPendingKey     = "pending"
-	AdminDisabledKey    = "disabled"
-	AdminSilencedKey    = "silenced"
-	AdminSuspendedKey   = "suspended"
-	AdminSensitizedKey  = "sensitized"
-	AdminDisplayNameKey = "display_name"
-	AdminByDomainKey    = "by_domain"
-	AdminEmailKey       = "email"
-	AdminIPKey          = "ip"
-	AdminStaffKey       = "staff"
-	AdminOriginKey      = "origin"
-	AdminStatusKey      = "status"
-	AdminPermissionsKey = "permissions"
-	AdminRoleIDsKey     = "role_ids[]"
-	AdminInvitedByKey   = "invited_by"
+	AdminRemoteKey        = "remote"
+	AdminActiveKey        = "active"
+	AdminPendingKey       = "pending"
+	AdminDisabledKey      = "disabled"
+	AdminSilencedKey      = "silenced"
+	AdminSuspendedKey     = "suspended"
+	AdminSensitizedKey    = "sensitized"
+	AdminDisplayNameKey   = "display_name"
+	AdminByDomainKey      = "by_domain"
+	AdminEmailKey         = "email"
+	AdminIPKey            = "ip"
+	AdminStaffKey         = "staff"
+	AdminOriginKey        = "origin"
+	AdminStatusKey        = "status"
+	AdminPermissionsKey   = "permissions"
+	AdminRoleIDsKey       = "role_ids[]"
+	AdminInvitedByKey     = "invited_by"
+	AdminPendingAppealKey = "pending_appeal"
+	AdminTargetTypeKey    = "target_type"
+	AdminStartKey         = "start"
+	AdminEndKey           = "end"
+	AdminStartAtKey       = "start_at"
+	AdminEndAtKey         = "end_at"
 )
 
 /*
@@ -187,6 +194,47 @@ func ParseAdminStaff(value string, defaultValue bool) (bool, gtserror.WithCode)
 	return parseBool(value, defaultValue, AdminStaffKey)
 }
 
+func ParseAdminPendingAppeal(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AdminPendingAppealKey)
+}
+
+// ParseAdminStart parses the given value as the
+// start of a time range, either as an RFC3339
+// timestamp or as a date (at start of that day).
+func ParseAdminStart(value string) (time.Time, gtserror.WithCode) {
+	t, _, errWithCode := parseTime(value, AdminStartKey)
+	return t, errWithCode
+}
+
+// ParseAdminEnd parses the given value as the
+// (exclusive) end of a time range, either as an
+// RFC3339 timestamp or as a date, in which case
+// the range includes the whole of that day.
+func ParseAdminEnd(value string) (time.Time, gtserror.WithCode) {
+	t, dateOnly, errWithCode := parseTime(value, AdminEndKey)
+	if dateOnly {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, errWithCode
+}
+
+// ParseAdminStartAt is like ParseAdminStart,
+// for the start_at key of admin dashboard requests.
+func ParseAdminStartAt(value string) (time.Time, gtserror.WithCode) {
+	t, _, errWithCode := parseTime(value, AdminStartAtKey)
+	return t, errWithCode
+}
+
+// ParseAdminEndAt is like ParseAdminEnd,
+// for the end_at key of admin dashboard requests.
+func ParseAdminEndAt(value string) (time.Time, gtserror.WithCode) {
+	t, dateOnly, errWithCode := parseTime(value, AdminEndAtKey)
+	if dateOnly {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, errWithCode
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -307,6 +355,23 @@ func parseInt(value string, defaultValue int, max int, min int, key string) (int
 	return i, nil
 }
 
+func parseTime(value string, key string) (t time.Time, dateOnly bool, errWithCode gtserror.WithCode) {
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, parseError(key, value, time.Time{}, err)
+	}
+
+	return t, false, nil
+}
+
 // parseError returns gtserror.WithCode set to 400 Bad Request, to indicate
 // to the caller that a key was set to a value that could not be parsed.
 func parseError(key string, value, defaultValue any, err error) gtserror.WithCode {
@@ -21,6 +21,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/hostmeta"
 	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/nodeinfo"
+	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/oauthserver"
 	"github.com/superseriousbusiness/gotosocial/internal/api/wellknown/webfinger"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -28,9 +29,10 @@ import (
 )
 
 type WellKnown struct {
-	nodeInfo  *nodeinfo.Module
-	webfinger *webfinger.Module
-	hostMeta  *hostmeta.Module
+	nodeInfo    *nodeinfo.Module
+	webfinger   *webfinger.Module
+	hostMeta    *hostmeta.Module
+	oauthServer *oauthserver.Module
 }
 
 func (w *WellKnown) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -50,12 +52,14 @@ func (w *WellKnown) Route(r *router.Router, m ...gin.HandlerFunc) {
 	w.nodeInfo.Route(wellKnownGroup.Handle)
 	w.webfinger.Route(wellKnownGroup.Handle)
 	w.hostMeta.Route(wellKnownGroup.Handle)
+	w.oauthServer.Route(wellKnownGroup.Handle)
 }
 
 func NewWellKnown(p *processing.Processor) *WellKnown {
 	return &WellKnown{
-		nodeInfo:  nodeinfo.New(p),
-		webfinger: webfinger.New(p),
-		hostMeta:  hostmeta.New(p),
+		nodeInfo:    nodeinfo.New(p),
+		webfinger:   webfinger.New(p),
+		hostMeta:    hostmeta.New(p),
+		oauthServer: oauthserver.New(p),
 	}
 }
@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauthserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type OAuthServerGetTestSuite struct {
+	OAuthServerStandardTestSuite
+}
+
+func (suite *OAuthServerGetTestSuite) getMetadata(accept string) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/.well-known/oauth-authorization-server", nil)
+	ctx.Request.Header.Set("accept", accept)
+
+	suite.oauthServerModule.OAuthServerMetadataGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if result.StatusCode != http.StatusOK {
+		return result.StatusCode, string(b)
+	}
+
+	var dst bytes.Buffer
+	if err := json.Indent(&dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result.StatusCode, dst.String()
+}
+
+func (suite *OAuthServerGetTestSuite) TestGetMetadata() {
+	code, body := suite.getMetadata("application/json")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{
+  "issuer": "http://localhost:8080/",
+  "authorization_endpoint": "http://localhost:8080/oauth/authorize",
+  "token_endpoint": "http://localhost:8080/oauth/token",
+  "revocation_endpoint": "http://localhost:8080/oauth/revoke",
+  "app_registration_endpoint": "http://localhost:8080/api/v1/apps",
+  "scopes_supported": [
+    "read",
+    "write",
+    "follow",
+    "push",
+    "admin"
+  ],
+  "response_types_supported": [
+    "code"
+  ],
+  "grant_types_supported": [
+    "authorization_code",
+    "client_credentials"
+  ],
+  "token_endpoint_auth_methods_supported": [
+    "client_secret_post"
+  ],
+  "revocation_endpoint_auth_methods_supported": [
+    "client_secret_post",
+    "client_secret_basic"
+  ],
+  "code_challenge_methods_supported": [
+    "S256"
+  ],
+  "service_documentation": "https://docs.gotosocial.org/en/latest/api/authentication/"
+}`, body)
+}
+
+func (suite *OAuthServerGetTestSuite) TestGetMetadataNotAcceptable() {
+	code, _ := suite.getMetadata("text/html")
+	suite.Equal(http.StatusNotAcceptable, code)
+}
+
+func TestOAuthServerGetTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthServerGetTestSuite))
+}
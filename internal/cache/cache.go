@@ -20,7 +20,11 @@ package cache
 import (
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/cache/domainlimit"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/inboundpolicy"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/ipblock"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/mediahash"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -38,6 +42,22 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// IPBlocks provides access to
+	// the admin IP block cache.
+	IPBlocks ipblock.Cache
+
+	// MediaHashBlocks provides access to
+	// the admin media hash block cache.
+	MediaHashBlocks mediahash.Cache
+
+	// DomainLimits provides access
+	// to the domain limit cache.
+	DomainLimits domainlimit.Cache
+
+	// InboundPolicies provides access
+	// to the inbound policy cache.
+	InboundPolicies inboundpolicy.Cache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
@@ -94,6 +114,14 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initWebfinger()
 	c.initVisibility()
+
+	// Drop any loaded IP blocks, media hash blocks,
+	// domain limits and inbound policies, they'll
+	// be reloaded on demand.
+	c.IPBlocks.Clear()
+	c.MediaHashBlocks.Clear()
+	c.DomainLimits.Clear()
+	c.InboundPolicies.Clear()
 }
 
 // Start will start any caches that require a background
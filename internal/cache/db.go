@@ -918,6 +918,7 @@ func (c *Caches) initNotification() {
 		// will be populated separately.
 		// See internal/db/bundb/notification.go.
 		n2.Status = nil
+		n2.AccountWarning = nil
 		n2.OriginAccount = nil
 		n2.TargetAccount = nil
 
@@ -1040,6 +1041,7 @@ func (c *Caches) initReport() {
 		r2.Statuses = nil
 		r2.Rules = nil
 		r2.ActionTakenByAccount = nil
+		r2.AssignedAccount = nil
 
 		return r2
 	}
@@ -1283,6 +1285,7 @@ func (c *Caches) initUser() {
 			{Fields: "AccountID"},
 			{Fields: "Email"},
 			{Fields: "ConfirmationToken"},
+			{Fields: "ResetPasswordToken"},
 			{Fields: "ExternalID"},
 		},
 		MaxSize:    cap,
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package domainlimit
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching domain limits
+// in memory to reduce load on an underlying storage
+// mechanism, and to allow fast matching of domains
+// (including subdomains) against them.
+type Cache struct {
+	// current cached domain limits, by domain.
+	ptr atomic.Pointer[map[string]*gtsmodel.DomainLimit]
+}
+
+// Match returns the domain limit that applies to the given
+// domain, or nil if there is none, loading using callback
+// if necessary. Where limits exist on both a domain and its
+// parent domain, the limit on the more specific one is returned.
+func (c *Cache) Match(domain string, load func() ([]*gtsmodel.DomainLimit, error)) (*gtsmodel.DomainLimit, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load limits from callback.
+		limits, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("error reloading cache: %w", err)
+		}
+
+		// Index the limits by domain.
+		m := make(map[string]*gtsmodel.DomainLimit, len(limits))
+		for _, limit := range limits {
+			m[limit.Domain] = limit
+		}
+
+		// Store the new
+		// domain limit map.
+		ptr = &m
+		c.ptr.Store(ptr)
+	}
+
+	// Walk up from the given domain through
+	// each of its parent domains, eg:
+	// "sub.example.org" -> "example.org" -> "org".
+	for domain != "" {
+		if limit, ok := (*ptr)[domain]; ok {
+			return limit, nil
+		}
+
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+
+		domain = domain[i+1:]
+	}
+
+	return nil, nil
+}
+
+// Clear will drop the currently loaded limits,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
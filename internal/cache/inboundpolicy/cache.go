@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package inboundpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching enabled inbound
+// policies in memory, with their regular expressions
+// pre-compiled, to avoid hitting the database (and
+// recompiling) for every inbound activity.
+type Cache struct {
+	// current cached policies slice.
+	ptr atomic.Pointer[[]Policy]
+}
+
+// Policy is a cached inbound policy
+// with its regular expression compiled.
+type Policy struct {
+	*gtsmodel.InboundPolicy
+
+	// Compiled Regex, nil if unset.
+	Regexp *regexp.Regexp
+}
+
+// Load returns the cached policies, loading
+// them using callback if necessary. Only
+// enabled policies are kept from load.
+func (c *Cache) Load(load func() ([]*gtsmodel.InboundPolicy, error)) ([]Policy, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load policies from callback.
+		policies, err := loadPolicies(load)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the new
+		// policies slice.
+		ptr = &policies
+		c.ptr.Store(ptr)
+	}
+
+	return *ptr, nil
+}
+
+// Clear will drop the currently loaded policies,
+// triggering a reload on next call to .Load().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
+
+// loadPolicies will load policies from given load callback, compiling their regexes.
+func loadPolicies(load func() ([]*gtsmodel.InboundPolicy, error)) ([]Policy, error) {
+	// Load policies from callback.
+	policies, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error reloading cache: %w", err)
+	}
+
+	// Allocate new slice to store compiled policies.
+	compiled := make([]Policy, 0, len(policies))
+
+	for _, policy := range policies {
+		if !*policy.Enabled {
+			continue
+		}
+
+		regexp, err := policy.Regexp()
+		if err != nil {
+			return nil, fmt.Errorf("error compiling inbound policy %s regex: %w", policy.ID, err)
+		}
+
+		compiled = append(compiled, Policy{
+			InboundPolicy: policy,
+			Regexp:        regexp,
+		})
+	}
+
+	return compiled, nil
+}
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package mediahash
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching parsed media hash
+// blocks in memory to reduce load on an underlying storage
+// mechanism, and to allow fast matching of perceptual hashes.
+type Cache struct {
+	// current cached media hash blocks slice.
+	ptr atomic.Pointer[[]entry]
+}
+
+// entry is a cached media hash
+// block with its hash pre-parsed.
+type entry struct {
+	hash  uint64
+	block *gtsmodel.MediaHashBlock
+}
+
+// Match returns the media hash block whose hash is closest to the given
+// hash, within maxDistance bits, along with the Hamming distance between
+// them. If no blocks are close enough, returns nil. Blocks are loaded
+// using callback if necessary.
+func (c *Cache) Match(hash uint64, maxDistance int, load func() ([]*gtsmodel.MediaHashBlock, error)) (*gtsmodel.MediaHashBlock, int, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load blocks from callback.
+		entries, err := loadEntries(load)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Store the new
+		// media hash block entries.
+		ptr = &entries
+		c.ptr.Store(ptr)
+	}
+
+	var (
+		match    *gtsmodel.MediaHashBlock
+		distance int
+	)
+
+	for _, e := range *ptr {
+		d := bits.OnesCount64(hash ^ e.hash)
+		if d > maxDistance {
+			continue
+		}
+
+		if match == nil || d < distance {
+			match = e.block
+			distance = d
+		}
+	}
+
+	return match, distance, nil
+}
+
+// Clear will drop the currently loaded blocks,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
+
+// loadEntries will load blocks from given load callback, parsing their hashes.
+func loadEntries(load func() ([]*gtsmodel.MediaHashBlock, error)) ([]entry, error) {
+	// Load blocks from callback.
+	blocks, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error reloading cache: %w", err)
+	}
+
+	// Allocate new entry slice to store parsed blocks.
+	entries := make([]entry, 0, len(blocks))
+
+	for _, block := range blocks {
+		hash, err := block.HashValue()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing media hash block %s: %w", block.ID, err)
+		}
+
+		entries = append(entries, entry{
+			hash:  hash,
+			block: block,
+		})
+	}
+
+	return entries, nil
+}
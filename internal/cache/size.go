@@ -278,6 +278,8 @@ func sizeofAccountStats() uintptr {
 		StatusesCount:       util.Ptr(100),
 		StatusesPinnedCount: util.Ptr(100),
 		LastStatusAt:        exampleTime,
+		MediaStorageUsed:    util.Ptr(int64(100)),
+		MediaStorageQuota:   util.Ptr(int64(100)),
 	}))
 }
 
@@ -480,9 +482,20 @@ func sizeofMedia() uintptr {
 			URL:         exampleURI,
 			RemoteURL:   exampleURI,
 		},
-		Avatar: func() *bool { ok := false; return &ok }(),
-		Header: func() *bool { ok := false; return &ok }(),
-		Cached: func() *bool { ok := true; return &ok }(),
+		Avatar:         func() *bool { ok := false; return &ok }(),
+		Header:         func() *bool { ok := false; return &ok }(),
+		Cached:         func() *bool { ok := true; return &ok }(),
+		Proxied:        func() *bool { ok := false; return &ok }(),
+		PerceptualHash: "f0e4c2d7c9b1a385",
+		Blocked:        func() *bool { ok := false; return &ok }(),
+		Derivatives: []*gtsmodel.Derivative{
+			{
+				Width:       640,
+				Height:      360,
+				Path:        exampleURI,
+				ContentType: "image/jpeg",
+			},
+		},
 	}))
 }
 
@@ -555,12 +568,14 @@ func sizeofReport() uintptr {
 		URI:                    exampleURI,
 		AccountID:              exampleID,
 		TargetAccountID:        exampleID,
+		Category:               gtsmodel.ReportCategoryViolation,
 		Comment:                exampleText,
 		StatusIDs:              []string{exampleID, exampleID, exampleID},
 		Forwarded:              func() *bool { ok := true; return &ok }(),
 		ActionTaken:            exampleText,
 		ActionTakenAt:          exampleTime,
 		ActionTakenByAccountID: exampleID,
+		AssignedAccountID:      exampleID,
 	}))
 }
 
@@ -618,6 +633,7 @@ func sizeofTag() uintptr {
 		UpdatedAt: exampleTime,
 		Useable:   func() *bool { ok := true; return &ok }(),
 		Listable:  func() *bool { ok := true; return &ok }(),
+		Trendable: func() *bool { ok := true; return &ok }(),
 	}))
 }
 
@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package challenge provides challenges that must
+// be completed in order to submit the sign-up form,
+// to deter scripted sign-ups.
+package challenge
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+// Challenger is a provider of sign-up challenges.
+//
+// GoToSocial provides a built-in proof-of-work
+// challenger; external CAPTCHA providers can be
+// supported by implementing this interface.
+type Challenger interface {
+	// Name returns a short, fixed name for
+	// this type of challenge, eg., "pow".
+	Name() string
+
+	// Issue returns a new challenge to
+	// be rendered in the sign-up form.
+	Issue(ctx context.Context) (*Challenge, error)
+
+	// Verify checks the challenge response submitted
+	// with the sign-up form from the given IP.
+	//
+	// If the response doesn't pass the challenge,
+	// the returned error will be a *Failure. Any
+	// other error indicates that the response
+	// could not be checked, eg., because an
+	// external provider could not be reached.
+	Verify(ctx context.Context, form url.Values, ip net.IP) error
+}
+
+// Challenge contains what's needed
+// to render a sign-up challenge.
+type Challenge struct {
+	// Kind of challenge, used by the sign-up
+	// template and script to decide how to
+	// present and/or solve the challenge.
+	Kind string
+
+	// Script is the URL of a script to load
+	// on the sign-up page, if any. For example,
+	// an external CAPTCHA provider's widget.
+	Script string
+
+	// Fields are included as hidden
+	// inputs in the sign-up form.
+	Fields map[string]string
+}
+
+// Failure is returned by Challenger.Verify when
+// a response doesn't pass the sign-up challenge.
+type Failure struct {
+	// Reason is a short, fixed description of
+	// why the response failed, suitable for
+	// use in logs and metrics, eg., "expired".
+	Reason string
+}
+
+func (f *Failure) Error() string {
+	return "sign-up challenge failed: " + f.Reason
+}
+
+// IsFailure returns the *Failure
+// wrapped in err, if any.
+func IsFailure(err error) (*Failure, bool) {
+	var failure *Failure
+	ok := errors.As(err, &failure)
+	return failure, ok
+}
+
+// Check verifies the given sign-up challenge response
+// with the given Challenger, as with Challenger.Verify,
+// recording any *Failure in metrics. It should be used
+// by every sign-up path, so that all failures are counted.
+func Check(ctx context.Context, c Challenger, form url.Values, ip net.IP) error {
+	err := c.Verify(ctx, form, ip)
+	if failure, ok := IsFailure(err); ok {
+		metrics.SignupChallengeFailed(ctx, c.Name(), failure.Reason)
+	}
+	return err
+}
+
+// FromConfig returns the sign-up Challenger
+// set by accounts-signup-challenge, or nil if
+// no sign-up challenge is configured.
+func FromConfig() (Challenger, error) {
+	switch challenge := config.GetAccountsSignupChallenge(); challenge {
+	case config.AccountsSignupChallengeNone:
+		return nil, nil
+
+	case config.AccountsSignupChallengePoW:
+		return NewProofOfWork(config.GetAccountsSignupPowDifficulty())
+
+	default:
+		return nil, errors.New("unrecognized sign-up challenge " + challenge)
+	}
+}
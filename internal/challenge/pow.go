@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// Names of the form fields used
+	// by the proof-of-work challenge.
+	PoWTokenField      = "challenge_token"
+	PoWDifficultyField = "challenge_difficulty"
+	PoWNonceField      = "challenge_nonce"
+
+	// powTokenTTL is how long an issued token remains
+	// valid, ie., how long a visitor has to fill in
+	// and submit the sign-up form.
+	powTokenTTL = time.Hour
+
+	// powNonceMaxLen is the longest nonce we'll accept.
+	powNonceMaxLen = 20
+
+	// token payload: issued at (8 bytes), difficulty (1 byte), random (16 bytes).
+	powPayloadLen = 8 + 1 + 16
+)
+
+// ProofOfWork is a hashcash-style Challenger. Each
+// issued challenge is a token signed by the server;
+// to solve it, the visitor's browser must find a
+// nonce such that the SHA-256 hash of "token:nonce"
+// has at least the required number of leading zero
+// bits. Solving takes a little while, but checking
+// the solution is cheap.
+//
+// Tokens are stateless, and are tied to the key of
+// the ProofOfWork that issued them. Tokens that have
+// been successfully used are remembered until they
+// expire, so each one can only be used once.
+type ProofOfWork struct {
+	difficulty int
+	key        []byte
+	used       cache.TTLCache[string, struct{}]
+	now        func() time.Time
+}
+
+// NewProofOfWork returns a new ProofOfWork challenger
+// requiring the given number of leading zero bits.
+func NewProofOfWork(difficulty int) (*ProofOfWork, error) {
+	if difficulty < 1 || difficulty > 32 {
+		return nil, fmt.Errorf("proof-of-work difficulty %d not between 1 and 32", difficulty)
+	}
+
+	// Generate a fresh signing key; tokens
+	// issued before a restart become invalid.
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("error generating proof-of-work key: %w", err)
+	}
+
+	used := cache.NewTTL[string, struct{}](0, 10000, powTokenTTL)
+	if !used.Start(time.Minute) {
+		log.Panic(nil, "could not start proof-of-work token cache")
+	}
+
+	return &ProofOfWork{
+		difficulty: difficulty,
+		key:        key,
+		used:       used,
+		now:        time.Now,
+	}, nil
+}
+
+func (p *ProofOfWork) Name() string {
+	return "pow"
+}
+
+func (p *ProofOfWork) Issue(ctx context.Context) (*Challenge, error) {
+	payload := make([]byte, powPayloadLen)
+	binary.BigEndian.PutUint64(payload, uint64(p.now().Unix()))
+	payload[8] = byte(p.difficulty)
+	if _, err := rand.Read(payload[9:]); err != nil {
+		return nil, fmt.Errorf("error generating proof-of-work token: %w", err)
+	}
+
+	return &Challenge{
+		Kind: p.Name(),
+		Fields: map[string]string{
+			PoWTokenField:      p.sign(payload),
+			PoWDifficultyField: strconv.Itoa(p.difficulty),
+			PoWNonceField:      "",
+		},
+	}, nil
+}
+
+func (p *ProofOfWork) Verify(ctx context.Context, form url.Values, ip net.IP) error {
+	token := form.Get(PoWTokenField)
+	nonce := form.Get(PoWNonceField)
+	if token == "" || nonce == "" {
+		return &Failure{Reason: "missing"}
+	}
+
+	if len(nonce) > powNonceMaxLen ||
+		strings.Trim(nonce, "0123456789") != "" {
+		return &Failure{Reason: "invalid"}
+	}
+
+	payload, ok := p.open(token)
+	if !ok {
+		return &Failure{Reason: "invalid"}
+	}
+
+	issuedAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	if p.now().Sub(issuedAt) > powTokenTTL {
+		return &Failure{Reason: "expired"}
+	}
+
+	// Check the work against the difficulty the token
+	// was issued with, as long as that's not lower than
+	// what's currently required.
+	difficulty := int(payload[8])
+	if difficulty < p.difficulty {
+		return &Failure{Reason: "expired"}
+	}
+
+	if leadingZeroBits(sha256.Sum256([]byte(token+":"+nonce))) < difficulty {
+		return &Failure{Reason: "insufficient_work"}
+	}
+
+	// Only now mark the token as used, so that
+	// bad guesses don't burn a valid token.
+	if !p.used.Add(token, struct{}{}) {
+		return &Failure{Reason: "reused"}
+	}
+
+	return nil
+}
+
+// sign returns the given payload and its
+// signature, encoded together as a token.
+func (p *ProofOfWork) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) +
+		"." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// open checks the signature of the given
+// token, returning the payload if it's valid.
+func (p *ProofOfWork) open(token string) ([]byte, bool) {
+	enc, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil || len(payload) != powPayloadLen {
+		return nil, false
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil {
+		return nil, false
+	}
+
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, false
+	}
+
+	return payload, true
+}
+
+// leadingZeroBits returns the number of
+// leading zero bits in the given hash.
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	var n int
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
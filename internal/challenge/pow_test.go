@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package challenge
+
+import (
+	"context"
+	"crypto/sha256"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProofOfWorkTestSuite struct {
+	suite.Suite
+}
+
+// solve brute-forces a nonce for the given challenge,
+// returning the sign-up form values to submit.
+func (suite *ProofOfWorkTestSuite) solve(challenge *Challenge) url.Values {
+	token := challenge.Fields[PoWTokenField]
+	difficulty, err := strconv.Atoi(challenge.Fields[PoWDifficultyField])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		if leadingZeroBits(sha256.Sum256([]byte(token+":"+nonce))) >= difficulty {
+			return url.Values{
+				PoWTokenField: {token},
+				PoWNonceField: {nonce},
+			}
+		}
+	}
+}
+
+func (suite *ProofOfWorkTestSuite) newPoW(difficulty int) *ProofOfWork {
+	pow, err := NewProofOfWork(difficulty)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return pow
+}
+
+func (suite *ProofOfWorkTestSuite) issue(pow *ProofOfWork) *Challenge {
+	challenge, err := pow.Issue(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return challenge
+}
+
+func (suite *ProofOfWorkTestSuite) failureReason(err error) string {
+	failure, ok := IsFailure(err)
+	if !ok {
+		suite.FailNow("expected *Failure", "got %v", err)
+	}
+	return failure.Reason
+}
+
+func (suite *ProofOfWorkTestSuite) TestVerify() {
+	var (
+		ctx = context.Background()
+		ip  = net.ParseIP("192.0.2.1")
+		pow = suite.newPoW(8)
+	)
+
+	form := suite.solve(suite.issue(pow))
+	suite.NoError(pow.Verify(ctx, form, ip))
+
+	// The same solution can't be used twice.
+	suite.Equal("reused", suite.failureReason(pow.Verify(ctx, form, ip)))
+}
+
+func (suite *ProofOfWorkTestSuite) TestVerifyMissing() {
+	pow := suite.newPoW(8)
+	err := pow.Verify(context.Background(), url.Values{}, net.ParseIP("192.0.2.1"))
+	suite.Equal("missing", suite.failureReason(err))
+}
+
+func (suite *ProofOfWorkTestSuite) TestVerifyInsufficientWork() {
+	var (
+		ctx   = context.Background()
+		ip    = net.ParseIP("192.0.2.1")
+		pow   = suite.newPoW(16)
+		token = suite.issue(pow).Fields[PoWTokenField]
+	)
+
+	// Find a nonce that *doesn't* have enough
+	// zero bits, which won't take many tries.
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		if leadingZeroBits(sha256.Sum256([]byte(token+":"+nonce))) < 16 {
+			form := url.Values{PoWTokenField: {token}, PoWNonceField: {nonce}}
+			err := pow.Verify(ctx, form, ip)
+			suite.Equal("insufficient_work", suite.failureReason(err))
+			return
+		}
+	}
+}
+
+func (suite *ProofOfWorkTestSuite) TestVerifyTampered() {
+	var (
+		ctx  = context.Background()
+		ip   = net.ParseIP("192.0.2.1")
+		pow  = suite.newPoW(8)
+		form = suite.solve(suite.issue(pow))
+	)
+
+	// A token signed by a different
+	// key (eg., before a restart) fails.
+	other := suite.newPoW(8)
+	err := other.Verify(ctx, form, ip)
+	suite.Equal("invalid", suite.failureReason(err))
+
+	// So does a nonce with junk in it.
+	form.Set(PoWNonceField, form.Get(PoWNonceField)+"x")
+	err = pow.Verify(ctx, form, ip)
+	suite.Equal("invalid", suite.failureReason(err))
+}
+
+func (suite *ProofOfWorkTestSuite) TestVerifyExpired() {
+	var (
+		ctx  = context.Background()
+		ip   = net.ParseIP("192.0.2.1")
+		pow  = suite.newPoW(8)
+		form = suite.solve(suite.issue(pow))
+	)
+
+	pow.now = func() time.Time { return time.Now().Add(powTokenTTL + time.Minute) }
+	err := pow.Verify(ctx, form, ip)
+	suite.Equal("expired", suite.failureReason(err))
+}
+
+func (suite *ProofOfWorkTestSuite) TestLeadingZeroBits() {
+	var sum [sha256.Size]byte
+	suite.Equal(256, leadingZeroBits(sum))
+
+	sum[1] = 0b00010000
+	suite.Equal(11, leadingZeroBits(sum))
+
+	sum[0] = 0b10000000
+	suite.Equal(0, leadingZeroBits(sum))
+}
+
+func TestProofOfWorkTestSuite(t *testing.T) {
+	suite.Run(t, new(ProofOfWorkTestSuite))
+}
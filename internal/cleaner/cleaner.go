@@ -19,12 +19,14 @@ package cleaner
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
@@ -72,18 +74,32 @@ func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error)
 	return true, nil
 }
 
-// removeFiles removes the provided files, returning the number of them returned.
+// removeFiles removes the provided files, returning the number of them removed.
+// Files still shared with other media in storage are left alone and not counted.
 func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error) {
-	if gtscontext.DryRun(ctx) {
-		// Dry run, do nothing.
-		return len(files), nil
-	}
-
 	var (
 		errs     gtserror.MultiError
 		errCount int
 	)
 
+	// Drop any shared files.
+	files = slices.DeleteFunc(files, func(path string) bool {
+		shared, err := media.IsSharedFile(ctx, c.state, path)
+		if err != nil {
+			errs.Append(err)
+			return true
+		}
+		if shared {
+			log.Debugf(ctx, "skipping shared file: %s", path)
+		}
+		return shared
+	})
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(files), errs.Combine()
+	}
+
 	for _, path := range files {
 		// Remove each provided storage path.
 		log.Debugf(ctx, "removing file: %s", path)
@@ -105,6 +121,38 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	return diff, nil
 }
 
+// releaseFiles releases this media's reference to each of the provided files,
+// returning the number of them removed from storage as they're no longer shared.
+func (c *Cleaner) releaseFiles(ctx context.Context, files ...string) (int, error) {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(files), nil
+	}
+
+	var (
+		errs    gtserror.MultiError
+		removed int
+	)
+
+	for _, path := range files {
+		// Release each provided storage path.
+		log.Debugf(ctx, "releasing file: %s", path)
+		ok, err := media.ReleaseFile(ctx, c.state, path)
+		if err != nil {
+			errs.Appendf("error releasing %s: %w", path, err)
+		} else if ok {
+			removed++
+		}
+	}
+
+	// Wrap the combined error slice.
+	if err := errs.Combine(); err != nil {
+		return removed, gtserror.Newf("error(s) releasing files: %w", err)
+	}
+
+	return removed, nil
+}
+
 // ScheduleJobs schedules cleaning
 // jobs using configured parameters.
 //
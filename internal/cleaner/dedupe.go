@@ -0,0 +1,307 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// LogDedupe performs Cleaner.Dedupe(...), logging the start and outcome.
+func (c *Cleaner) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, saved, err := c.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduplicated: %d, saved: %s", n, bytesize.Size(saved))
+	}
+}
+
+// Dedupe will register all cached media attachment and emoji files in storage
+// for content-addressed deduplication, pointing any found to be identical to an
+// already registered file at that file instead and removing the redundant copy.
+// Returns the number of files deduplicated, and the total bytes saved by doing so.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (c *Cleaner) Dedupe(ctx context.Context) (int, int64, error) {
+	var (
+		total int
+		saved int64
+		page  paging.Page
+
+		// seen tracks hashes of files which would
+		// have been registered, in the case of a dry
+		// run where nothing is actually registered.
+		seen = make(map[string]string)
+	)
+
+	// dedupe is a small wrapper around
+	// dedupeFiles that updates totals.
+	dedupe := func(files []dedupeTarget, update func(columns ...string) error) error {
+		n, size, err := c.dedupeFiles(ctx, files, seen, update)
+		total += n
+		saved += size
+		return err
+	}
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments to next maxID.
+		attachments, err := c.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, saved, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			if !*media.Cached {
+				// Nothing to dedupe.
+				continue
+			}
+
+			var files []dedupeTarget
+
+			// Original file of proxied
+			// remote media isn't stored.
+			if !util.PtrValueOr(media.Proxied, false) {
+				files = append(files, dedupeTarget{&media.File.Path, "file_path"})
+			}
+
+			files = append(files, dedupeTarget{&media.Thumbnail.Path, "thumbnail_path"})
+
+			// Point media at its deduplicated files.
+			if err := dedupe(files, func(columns ...string) error {
+				return c.state.DB.UpdateAttachment(ctx, media, columns...)
+			}); err != nil {
+				return total, saved, gtserror.Newf("error deduplicating media %s: %w", media.ID, err)
+			}
+		}
+	}
+
+	// Reset paging for emojis.
+	page = paging.Page{Limit: selectLimit}
+
+	for {
+		// Fetch the next batch of emoji to next max ID.
+		emojis, err := c.state.DB.GetEmojis(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, saved, gtserror.Newf("error getting emojis: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no emoji or the same group is returned, we reached end.
+		if len(emojis) == 0 || maxID == emojis[len(emojis)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID'.
+		maxID = emojis[len(emojis)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, emoji := range emojis {
+			if !*emoji.Cached {
+				// Nothing to dedupe.
+				continue
+			}
+
+			files := []dedupeTarget{
+				{&emoji.ImagePath, "image_path"},
+				{&emoji.ImageStaticPath, "image_static_path"},
+			}
+
+			// Point emoji at its deduplicated files.
+			if err := dedupe(files, func(columns ...string) error {
+				return c.state.DB.UpdateEmoji(ctx, emoji, columns...)
+			}); err != nil {
+				return total, saved, gtserror.Newf("error deduplicating emoji %s: %w", emoji.ID, err)
+			}
+		}
+	}
+
+	return total, saved, nil
+}
+
+// dedupeTarget is a storage path field of a
+// media attachment or emoji to deduplicate,
+// with the name of its database column.
+type dedupeTarget struct {
+	path   *string
+	column string
+}
+
+// dedupeFiles deduplicates the given files of a single media attachment or
+// emoji, calling update with the columns of any paths that changed. Redundant
+// copies are only removed from storage once update succeeds, so nothing is left
+// pointing at a removed file. If anything fails, references added to shared files
+// are dropped again and paths are reset. Returns the number of files deduplicated
+// and the total bytes saved by doing so.
+func (c *Cleaner) dedupeFiles(
+	ctx context.Context,
+	files []dedupeTarget,
+	seen map[string]string,
+	update func(columns ...string) error,
+) (int, int64, error) {
+	var (
+		changed  []dedupeTarget
+		oldPaths []string
+		columns  []string
+		saved    int64
+	)
+
+	// undo drops references added to shared
+	// files, and puts back the original paths.
+	undo := func() {
+		for i, file := range changed {
+			if !gtscontext.DryRun(ctx) {
+				if _, err := media.ReleaseFile(ctx, c.state, *file.path); err != nil {
+					log.Errorf(ctx, "error releasing %s: %v", *file.path, err)
+				}
+			}
+			*file.path = oldPaths[i]
+		}
+	}
+
+	for _, file := range files {
+		newPath, size, err := c.dedupeFile(ctx, *file.path, seen)
+		if err != nil {
+			undo()
+			return 0, 0, err
+		}
+
+		if newPath == *file.path {
+			// Nothing to do.
+			continue
+		}
+
+		changed = append(changed, file)
+		oldPaths = append(oldPaths, *file.path)
+		columns = append(columns, file.column)
+		saved += size
+		*file.path = newPath
+	}
+
+	if len(changed) == 0 || gtscontext.DryRun(ctx) {
+		return len(changed), saved, nil
+	}
+
+	if err := update(columns...); err != nil {
+		undo()
+		return 0, 0, err
+	}
+
+	// Now nothing points at them,
+	// remove the redundant copies.
+	for _, path := range oldPaths {
+		if err := c.state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error removing duplicate file %s: %v", path, err)
+		}
+	}
+
+	return len(changed), saved, nil
+}
+
+// dedupeFile registers the file at given storage path for deduplication, if not
+// already, returning the path of the (possibly different) registered file to use
+// and the size of the file. The seen map is used to track registrations on dry run.
+func (c *Cleaner) dedupeFile(ctx context.Context, path string, seen map[string]string) (string, int64, error) {
+	blob, err := c.state.DB.GetMediaBlobByPath(ctx, path)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", 0, gtserror.Newf("error getting media blob: %w", err)
+	}
+
+	if blob != nil {
+		// Already registered.
+		return path, blob.Size, nil
+	}
+
+	// Open the file for hashing.
+	rc, err := c.state.Storage.GetStream(ctx, path)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			// Missing files are for FixCacheStates() to fix.
+			log.Warnf(ctx, "missing file: %s", path)
+			return path, 0, nil
+		}
+		return "", 0, gtserror.Newf("error opening %s: %w", path, err)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, rc)
+	_ = rc.Close()
+	if err != nil {
+		return "", 0, gtserror.Newf("error reading %s: %w", path, err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+
+	if !gtscontext.DryRun(ctx) {
+		// Register the file, sharing with any identical
+		// one. Our copy is removed by the caller, once
+		// nothing points at it any more.
+		newPath, err := media.RegisterFile(ctx, c.state, path, sum, size)
+		if err != nil {
+			return "", 0, err
+		}
+		return newPath, size, nil
+	}
+
+	// Dry run, check for what this would be
+	// shared with without registering anything.
+	blob, err = c.state.DB.GetMediaBlobByHash(ctx, sum)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", 0, gtserror.Newf("error getting media blob: %w", err)
+	}
+
+	if blob != nil {
+		return blob.Path, size, nil
+	}
+
+	if seenPath, ok := seen[sum]; ok {
+		return seenPath, size, nil
+	}
+
+	seen[sum] = path
+	return path, size, nil
+}
@@ -466,11 +466,7 @@ func (e *Emoji) uncache(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	}
 
 	// Remove emoji and static.
-	_, err := e.removeFiles(ctx,
-		emoji.ImagePath,
-		emoji.ImageStaticPath,
-	)
-	if err != nil {
+	if err := e.removeEmojiFiles(ctx, emoji); err != nil {
 		return gtserror.Newf("error removing emoji files: %w", err)
 	}
 
@@ -484,6 +480,24 @@ func (e *Emoji) uncache(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	return nil
 }
 
+// removeEmojiFiles removes the image and static image files for
+// the given emoji, only releasing its references if it's cached.
+func (e *Emoji) removeEmojiFiles(ctx context.Context, emoji *gtsmodel.Emoji) error {
+	var err error
+	if *emoji.Cached {
+		_, err = e.releaseFiles(ctx,
+			emoji.ImageStaticPath,
+			emoji.ImagePath,
+		)
+	} else {
+		_, err = e.removeFiles(ctx,
+			emoji.ImageStaticPath,
+			emoji.ImagePath,
+		)
+	}
+	return err
+}
+
 func (e *Emoji) delete(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
@@ -491,11 +505,7 @@ func (e *Emoji) delete(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	}
 
 	// Remove emoji and static files.
-	_, err := e.removeFiles(ctx,
-		emoji.ImageStaticPath,
-		emoji.ImagePath,
-	)
-	if err != nil {
+	if err := e.removeEmojiFiles(ctx, emoji); err != nil {
 		return gtserror.Newf("error removing emoji files: %w", err)
 	}
 
@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Media encompasses a set of
@@ -47,6 +48,7 @@ func (m *Media) All(ctx context.Context, maxRemoteDays int) {
 	m.LogPruneOrphaned(ctx)
 	m.LogPruneUnused(ctx)
 	m.LogFixCacheStates(ctx)
+	m.LogPruneExpiredUploads(ctx)
 	_ = m.state.Storage.Storage.Clean(ctx)
 }
 
@@ -90,6 +92,16 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
+// LogPruneExpiredUploads performs Media.PruneExpiredUploads(...), logging the start and outcome.
+func (m *Media) LogPruneExpiredUploads(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.PruneExpiredUploads(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
@@ -218,6 +230,50 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 	return total, nil
 }
 
+// PruneExpiredUploads will delete all resumable media uploads that expired before being
+// finished and used, along with any of their chunks staged in storage.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) PruneExpiredUploads(ctx context.Context) (int, error) {
+	var total int
+
+	// Start from now.
+	before := time.Now()
+
+	for {
+		// Fetch the next batch of media uploads that expired before last-set time.
+		uploads, err := m.state.DB.GetExpiredMediaUploads(ctx, before, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting expired media uploads: %w", err)
+		}
+
+		// If no uploads / same group is returned, we reached the end.
+		if len(uploads) == 0 ||
+			before.Equal(uploads[len(uploads)-1].ExpiresAt) {
+			break
+		}
+
+		// Use last expires-at as the next 'before' value.
+		before = uploads[len(uploads)-1].ExpiresAt
+
+		for _, upload := range uploads {
+			if gtscontext.DryRun(ctx) {
+				// Dry run, only count.
+				total++
+				continue
+			}
+
+			log.Debugf(ctx, "deleting expired media upload: %s", upload.ID)
+			if err := media.DeleteUpload(ctx, m.state, upload); err != nil {
+				return total, err
+			}
+
+			total++
+		}
+	}
+
+	return total, nil
+}
+
 // FixCacheStatus will check all media for up-to-date cache status (i.e. in storage driver).
 // Media marked as cached, with any required files missing, will be automatically uncached.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
@@ -274,6 +330,17 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 		return false, nil
 	}
 
+	// Shared files may outlive the media they were
+	// first stored for, so check references first.
+	shared, err := media.IsSharedFile(ctx, m.state, path)
+	if err != nil {
+		return false, err
+	}
+
+	if shared {
+		return false, nil
+	}
+
 	var (
 		// 0th -> whole match
 		// 1st -> account ID
@@ -303,6 +370,11 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			return true, nil
 		}
 
+	case media.TypeUpload:
+		// Staged chunks of resumable uploads are
+		// removed along with their expired uploads.
+		return false, nil
+
 	case media.TypeEmoji:
 		// Generate static URL for this emoji to lookup.
 		staticURL := uris.URIForAttachment(
@@ -405,10 +477,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	}
 
 	// Check whether files exist.
-	exist, err := m.haveFiles(ctx,
-		media.Thumbnail.Path,
-		media.File.Path,
-	)
+	exist, err := m.haveFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return false, err
 	}
@@ -422,10 +491,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		_, err := m.removeFiles(ctx, allMediaFiles(media)...)
 		return true, err
 
 	default:
@@ -539,44 +605,81 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
-	if err != nil {
+	if err := m.removeMediaFiles(ctx, media); err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
 
 	// Update attachment to reflect that we no longer have it cached.
 	log.Debugf(ctx, "marking media attachment as uncached: %s", media.ID)
 	media.Cached = func() *bool { i := false; return &i }()
-	if err := m.state.DB.UpdateAttachment(ctx, media, "cached"); err != nil {
+	media.Proxied = func() *bool { i := false; return &i }()
+	if err := m.state.DB.UpdateAttachment(ctx, media, "cached", "proxied"); err != nil {
 		return gtserror.Newf("error updating media: %w", err)
 	}
 
 	return nil
 }
 
-func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+// removeMediaFiles removes the media and thumbnail files for the
+// given attachment, only releasing its references if it's cached.
+func (m *Media) removeMediaFiles(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+	var err error
+	if *media.Cached {
+		_, err = m.releaseFiles(ctx, mediaFiles(media)...)
+	} else {
+		_, err = m.removeFiles(ctx, allMediaFiles(media)...)
+	}
+	return err
+}
+
+// mediaFiles returns the storage paths of the files we
+// expect to have stored for the given cached attachment.
+// For proxied remote media, that's only the thumbnail
+// and any derivatives.
+func mediaFiles(media *gtsmodel.MediaAttachment) []string {
+	files := []string{media.Thumbnail.Path}
+	if !util.PtrValueOr(media.Proxied, false) {
+		files = append(files, media.File.Path)
+	}
+	for _, derivative := range media.Derivatives {
+		files = append(files, derivative.Path)
+	}
+	return files
+}
+
+// allMediaFiles returns the storage paths of all
+// files that may be stored for the given attachment,
+// whether or not we expect them to exist.
+func allMediaFiles(media *gtsmodel.MediaAttachment) []string {
+	files := []string{media.Thumbnail.Path, media.File.Path}
+	for _, derivative := range media.Derivatives {
+		files = append(files, derivative.Path)
+	}
+	return files
+}
+
+func (m *Media) delete(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
 		return nil
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
-	if err != nil {
+	if err := m.removeMediaFiles(ctx, attachment); err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
 
 	// Delete media attachment entirely from the database.
-	log.Debugf(ctx, "deleting media attachment: %s", media.ID)
-	if err := m.state.DB.DeleteAttachment(ctx, media.ID); err != nil {
+	log.Debugf(ctx, "deleting media attachment: %s", attachment.ID)
+	if err := m.state.DB.DeleteAttachment(ctx, attachment.ID); err != nil {
 		return gtserror.Newf("error deleting media: %w", err)
 	}
 
+	// Give the space back to the owning
+	// account's quota, if it was local.
+	if err := media.ReleaseStorageUsed(ctx, m.state, attachment); err != nil {
+		return gtserror.Newf("error releasing media storage used: %w", err)
+	}
+
 	return nil
 }
@@ -382,6 +382,10 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
 	}
 
+	// first recached attachment, whose
+	// files the identical second will share
+	var first *gtsmodel.MediaAttachment
+
 	for _, original := range []*gtsmodel.MediaAttachment{
 		testStatusAttachment,
 		testHeader,
@@ -397,9 +401,16 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		// recachedAttachment should be basically the same as the old attachment
 		suite.True(*recachedAttachment.Cached)
 		suite.Equal(original.ID, recachedAttachment.ID)
-		suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
-		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
-		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta)       // and the filemeta should be the same
+		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta) // the filemeta should be the same
+
+		if first == nil {
+			first = recachedAttachment
+			suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
+			suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
+		} else {
+			suite.Equal(first.File.Path, recachedAttachment.File.Path)           // identical file should be shared
+			suite.Equal(first.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
+		}
 
 		// recached files should be back in storage
 		_, err = suite.storage.Get(ctx, recachedAttachment.File.Path)
@@ -426,3 +437,56 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(3, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	testDuplicate := suite.testAttachments["admin_account_status_1_attachment_1"] // older, so checked after
+
+	// Overwrite one thumbnail with an identical copy of another.
+	b, err := suite.storage.Get(ctx, testAttachment.Thumbnail.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Put(ctx, testDuplicate.Thumbnail.Path, b)
+	suite.NoError(err)
+
+	// Dry run should find the duplicate but change nothing.
+	total, saved, err := suite.cleaner.Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.EqualValues(len(b), saved)
+
+	_, err = suite.storage.Get(ctx, testDuplicate.Thumbnail.Path)
+	suite.NoError(err)
+
+	// Now do it for real.
+	total, saved, err = suite.cleaner.Dedupe(ctx)
+	suite.NoError(err)
+	suite.Equal(1, total)
+	suite.EqualValues(len(b), saved)
+
+	// Duplicate should now share the original thumbnail.
+	dbDuplicate, err := suite.db.GetAttachmentByID(ctx, testDuplicate.ID)
+	suite.NoError(err)
+	suite.Equal(testAttachment.Thumbnail.Path, dbDuplicate.Thumbnail.Path)
+	suite.Equal(testDuplicate.File.Path, dbDuplicate.File.Path)
+
+	// And the redundant copy should be gone.
+	_, err = suite.storage.Get(ctx, testDuplicate.Thumbnail.Path)
+	suite.True(storage.IsNotFound(err))
+
+	// Deleting the original shouldn't remove the shared file.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	orphaned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Zero(orphaned)
+	suite.NoError(suite.db.DeleteAttachment(ctx, dbAttachment.ID))
+	orphaned, err = suite.cleaner.Media().PruneOrphaned(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Equal(1, orphaned) // only the original's own full-size file
+
+	// Nothing left to dedupe.
+	total, _, err = suite.cleaner.Dedupe(ctx)
+	suite.NoError(err)
+	suite.Zero(total)
+}
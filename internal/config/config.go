@@ -77,23 +77,35 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	InstanceFederationMode                     string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter               bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and quarantine messages identified as spam"`
+	InstanceFederationSpamThreshold            int                `name:"instance-federation-spam-threshold" usage:"Spam score at or above which messages are quarantined for admin review, when instance-federation-spam-filter is enabled"`
+	InstanceFederationSpamUnknownMentionWeight int                `name:"instance-federation-spam-unknown-mention-weight" usage:"Spam score added for each account mentioned in a message that the receiver doesn't follow"`
+	InstanceFederationSpamMediaWeight          int                `name:"instance-federation-spam-media-weight" usage:"Spam score added for messages with media attachments"`
+	InstanceFederationSpamErrantLinkWeight     int                `name:"instance-federation-spam-errant-link-weight" usage:"Spam score added for messages containing links that aren't mentions or hashtags"`
+	InstanceFederationSpamNewAccountWeight     int                `name:"instance-federation-spam-new-account-weight" usage:"Spam score added for messages from accounts first seen less than instance-federation-spam-new-account-age ago"`
+	InstanceFederationSpamNewAccountAge        time.Duration      `name:"instance-federation-spam-new-account-age" usage:"How long after first seeing an account it's considered new by the spam filter"`
+	InstanceFederationSpamDuplicateWeight      int                `name:"instance-federation-spam-duplicate-weight" usage:"Spam score added for messages with the same content as messages recently sent by several other accounts"`
+	InstanceFederationSpamBurstWeight          int                `name:"instance-federation-spam-burst-weight" usage:"Spam score added for messages from accounts that recently contacted many local accounts they have no relationship with"`
+	InstanceExposePeers                        bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended                    bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb                 bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline               bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes             bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion              bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                          language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+
+	AccountsRegistrationOpen    bool   `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsReasonRequired      bool   `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsAllowUserInvites    bool   `name:"accounts-allow-user-invites" usage:"Allow non-staff users to generate invite links for signing up to this instance."`
+	AccountsSignupChallenge     string `name:"accounts-signup-challenge" usage:"Challenge that must be completed to submit the sign-up form, to deter automated sign-ups. Options: ['', 'pow']. Empty string disables the challenge."`
+	AccountsSignupPowDifficulty int    `name:"accounts-signup-pow-difficulty" usage:"Number of leading zero bits required in the proof-of-work sign-up challenge. Each extra bit doubles the average work a browser must do."`
+	AccountsAllowCustomCSS      bool   `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength     int    `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
+	MediaAudioMaxSize        bytesize.Size `name:"media-audio-max-size" usage:"Max size of accepted audio files in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
@@ -101,6 +113,20 @@ type Configuration struct {
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
 	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
 	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaQuotaUser           bytesize.Size `name:"media-quota-user" usage:"Max total size in bytes of media that a user account may store. If set to 0, storage is unlimited."`
+	MediaQuotaModerator      bytesize.Size `name:"media-quota-moderator" usage:"Max total size in bytes of media that a moderator account may store. If set to 0, storage is unlimited."`
+	MediaQuotaAdmin          bytesize.Size `name:"media-quota-admin" usage:"Max total size in bytes of media that an admin account may store. If set to 0, storage is unlimited."`
+
+	MediaRemoteProxy             bool          `name:"media-remote-proxy" usage:"Don't store the original files of remote media attachments; stream them from the origin on demand instead. Thumbnails and blurhashes are still stored."`
+	MediaRemoteProxyDomains      []string      `name:"media-remote-proxy-domains" usage:"Domains (and their subdomains) whose remote media attachments should be proxied as with media-remote-proxy. Only used if media-remote-proxy is false."`
+	MediaRemoteProxyCacheTTL     time.Duration `name:"media-remote-proxy-cache-ttl" usage:"How long to keep proxied remote media files in memory after fetching them from the origin."`
+	MediaRemoteProxyCacheMaxSize bytesize.Size `name:"media-remote-proxy-cache-max-size" usage:"Max total size in bytes of proxied remote media files to keep in memory."`
+
+	MediaHashBlockDistance int `name:"media-hash-block-distance" usage:"Max Hamming distance (0-64) between the perceptual hash of a media file and a media hash block for the file to be considered a match. Lower is stricter."`
+
+	MediaUploadExpiry time.Duration `name:"media-upload-expiry" usage:"How long an unfinished resumable media upload is kept after the last chunk was received, before it's removed."`
+
+	MediaDerivativeWidths []int `name:"media-derivative-widths" usage:"Widths in pixels of scaled-down copies to make of image attachments, avatars and headers, to serve to clients that don't need the full size. Set to an empty list to not make any."`
 
 	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
 	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
@@ -111,6 +137,9 @@ type Configuration struct {
 	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
 	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
 
+	StorageS3CacheBasePath string        `name:"storage-s3-cache-base-path" usage:"Full path to a directory where gts should cache media files read from S3 storage. Leave empty to disable caching."`
+	StorageS3CacheMaxSize  bytesize.Size `name:"storage-s3-cache-max-size" usage:"Max total size in bytes of media files to cache on disk from S3 storage."`
+
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
 	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
@@ -179,6 +208,8 @@ type Configuration struct {
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminMediaMigrateTo      string `name:"to" usage:"storage backend to migrate media to from the currently configured one; options: [local, s3]"`
+	AdminMediaMigrateWorkers int    `name:"parallelism" usage:"number of files to copy in parallel when migrating media"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -29,4 +29,9 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Sign-up challenge determines what, if anything,
+	// must be completed to submit the sign-up form.
+	AccountsSignupChallengeNone = ""
+	AccountsSignupChallengePoW  = "pow"
 )
@@ -58,21 +58,33 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
-
-	AccountsRegistrationOpen: false,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
+	InstanceFederationMode:                     InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:               false,
+	InstanceFederationSpamThreshold:            10,
+	InstanceFederationSpamUnknownMentionWeight: 3,
+	InstanceFederationSpamMediaWeight:          10,
+	InstanceFederationSpamErrantLinkWeight:     10,
+	InstanceFederationSpamNewAccountWeight:     4,
+	InstanceFederationSpamNewAccountAge:        24 * time.Hour,
+	InstanceFederationSpamDuplicateWeight:      5,
+	InstanceFederationSpamBurstWeight:          5,
+	InstanceExposePeers:                        false,
+	InstanceExposeSuspended:                    false,
+	InstanceExposeSuspendedWeb:                 false,
+	InstanceDeliverToSharedInboxes:             true,
+	InstanceLanguages:                          make(language.Languages, 0),
+
+	AccountsRegistrationOpen:    false,
+	AccountsReasonRequired:      true,
+	AccountsAllowUserInvites:    false,
+	AccountsSignupChallenge:     AccountsSignupChallengeNone,
+	AccountsSignupPowDifficulty: 16,
+	AccountsAllowCustomCSS:      false,
+	AccountsCustomCSSLength:     10000,
 
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
+	MediaAudioMaxSize:        40 * bytesize.MiB,
 	MediaDescriptionMinChars: 0,
 	MediaDescriptionMaxChars: 1500,
 	MediaRemoteCacheDays:     7,
@@ -80,12 +92,29 @@ var Defaults = Configuration{
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
 	MediaCleanupFrom:         "00:00",        // Midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaQuotaUser:           0,              // Unlimited.
+	MediaQuotaModerator:      0,              // Unlimited.
+	MediaQuotaAdmin:          0,              // Unlimited.
+
+	MediaRemoteProxy:             false,
+	MediaRemoteProxyDomains:      []string{},
+	MediaRemoteProxyCacheTTL:     5 * time.Minute,
+	MediaRemoteProxyCacheMaxSize: 100 * bytesize.MiB,
+
+	MediaHashBlockDistance: 6,
+
+	MediaUploadExpiry: 24 * time.Hour,
+
+	MediaDerivativeWidths: []int{320, 640, 1280},
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
 	StorageS3UseSSL:      true,
 	StorageS3Proxy:       false,
 
+	StorageS3CacheBasePath: "",
+	StorageS3CacheMaxSize:  1 * bytesize.GiB,
+
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
@@ -206,7 +235,8 @@ var Defaults = Configuration{
 		TLSInsecureSkipVerify: false,
 	},
 
-	AdminMediaPruneDryRun: true,
+	AdminMediaPruneDryRun:    true,
+	AdminMediaMigrateWorkers: 8,
 
 	RequestIDHeader: "X-Request-Id",
 
@@ -85,6 +85,14 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamThresholdFlag(), cfg.InstanceFederationSpamThreshold, fieldtag("InstanceFederationSpamThreshold", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamUnknownMentionWeightFlag(), cfg.InstanceFederationSpamUnknownMentionWeight, fieldtag("InstanceFederationSpamUnknownMentionWeight", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamMediaWeightFlag(), cfg.InstanceFederationSpamMediaWeight, fieldtag("InstanceFederationSpamMediaWeight", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamErrantLinkWeightFlag(), cfg.InstanceFederationSpamErrantLinkWeight, fieldtag("InstanceFederationSpamErrantLinkWeight", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamNewAccountWeightFlag(), cfg.InstanceFederationSpamNewAccountWeight, fieldtag("InstanceFederationSpamNewAccountWeight", "usage"))
+		cmd.Flags().Duration(InstanceFederationSpamNewAccountAgeFlag(), cfg.InstanceFederationSpamNewAccountAge, fieldtag("InstanceFederationSpamNewAccountAge", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamDuplicateWeightFlag(), cfg.InstanceFederationSpamDuplicateWeight, fieldtag("InstanceFederationSpamDuplicateWeight", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamBurstWeightFlag(), cfg.InstanceFederationSpamBurstWeight, fieldtag("InstanceFederationSpamBurstWeight", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
@@ -94,11 +102,15 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
+		cmd.Flags().Bool(AccountsAllowUserInvitesFlag(), cfg.AccountsAllowUserInvites, fieldtag("AccountsAllowUserInvites", "usage"))
+		cmd.Flags().String(AccountsSignupChallengeFlag(), cfg.AccountsSignupChallenge, fieldtag("AccountsSignupChallenge", "usage"))
+		cmd.Flags().Int(AccountsSignupPowDifficultyFlag(), cfg.AccountsSignupPowDifficulty, fieldtag("AccountsSignupPowDifficulty", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaVideoMaxSizeFlag(), uint64(cfg.MediaVideoMaxSize), fieldtag("MediaVideoMaxSize", "usage"))
+		cmd.Flags().Uint64(MediaAudioMaxSizeFlag(), uint64(cfg.MediaAudioMaxSize), fieldtag("MediaAudioMaxSize", "usage"))
 		cmd.Flags().Int(MediaDescriptionMinCharsFlag(), cfg.MediaDescriptionMinChars, fieldtag("MediaDescriptionMinChars", "usage"))
 		cmd.Flags().Int(MediaDescriptionMaxCharsFlag(), cfg.MediaDescriptionMaxChars, fieldtag("MediaDescriptionMaxChars", "usage"))
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
@@ -106,6 +118,16 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().Uint64(MediaQuotaUserFlag(), uint64(cfg.MediaQuotaUser), fieldtag("MediaQuotaUser", "usage"))
+		cmd.Flags().Uint64(MediaQuotaModeratorFlag(), uint64(cfg.MediaQuotaModerator), fieldtag("MediaQuotaModerator", "usage"))
+		cmd.Flags().Uint64(MediaQuotaAdminFlag(), uint64(cfg.MediaQuotaAdmin), fieldtag("MediaQuotaAdmin", "usage"))
+		cmd.Flags().Bool(MediaRemoteProxyFlag(), cfg.MediaRemoteProxy, fieldtag("MediaRemoteProxy", "usage"))
+		cmd.Flags().StringSlice(MediaRemoteProxyDomainsFlag(), cfg.MediaRemoteProxyDomains, fieldtag("MediaRemoteProxyDomains", "usage"))
+		cmd.Flags().Duration(MediaRemoteProxyCacheTTLFlag(), cfg.MediaRemoteProxyCacheTTL, fieldtag("MediaRemoteProxyCacheTTL", "usage"))
+		cmd.Flags().Uint64(MediaRemoteProxyCacheMaxSizeFlag(), uint64(cfg.MediaRemoteProxyCacheMaxSize), fieldtag("MediaRemoteProxyCacheMaxSize", "usage"))
+		cmd.Flags().Int(MediaHashBlockDistanceFlag(), cfg.MediaHashBlockDistance, fieldtag("MediaHashBlockDistance", "usage"))
+		cmd.Flags().Duration(MediaUploadExpiryFlag(), cfg.MediaUploadExpiry, fieldtag("MediaUploadExpiry", "usage"))
+		cmd.Flags().IntSlice(MediaDerivativeWidthsFlag(), cfg.MediaDerivativeWidths, fieldtag("MediaDerivativeWidths", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
@@ -218,6 +240,20 @@ func AddAdminMediaList(cmd *cobra.Command) {
 	cmd.Flags().Bool(remoteOnly, false, remoteOnlyUsage)
 }
 
+// AddAdminMediaMigrate attaches flags pertaining to media storage migrate commands.
+func AddAdminMediaMigrate(cmd *cobra.Command) {
+	to := AdminMediaMigrateToFlag()
+	toUsage := fieldtag("AdminMediaMigrateTo", "usage")
+	cmd.Flags().String(to, "", toUsage) // REQUIRED
+	if err := cmd.MarkFlagRequired(to); err != nil {
+		panic(err)
+	}
+
+	workers := AdminMediaMigrateWorkersFlag()
+	workersUsage := fieldtag("AdminMediaMigrateWorkers", "usage")
+	cmd.Flags().Int(workers, Defaults.AdminMediaMigrateWorkers, workersUsage)
+}
+
 // AddAdminMediaPrune attaches flags pertaining to media storage prune commands.
 func AddAdminMediaPrune(cmd *cobra.Command) {
 	name := AdminMediaPruneDryRunFlag()
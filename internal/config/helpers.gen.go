@@ -800,6 +800,236 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationSpamThreshold safely fetches the Configuration value for state's 'InstanceFederationSpamThreshold' field
+func (st *ConfigState) GetInstanceFederationSpamThreshold() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamThreshold
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamThreshold safely sets the Configuration value for state's 'InstanceFederationSpamThreshold' field
+func (st *ConfigState) SetInstanceFederationSpamThreshold(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamThreshold = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamThresholdFlag returns the flag name for the 'InstanceFederationSpamThreshold' field
+func InstanceFederationSpamThresholdFlag() string { return "instance-federation-spam-threshold" }
+
+// GetInstanceFederationSpamThreshold safely fetches the value for global configuration 'InstanceFederationSpamThreshold' field
+func GetInstanceFederationSpamThreshold() int { return global.GetInstanceFederationSpamThreshold() }
+
+// SetInstanceFederationSpamThreshold safely sets the value for global configuration 'InstanceFederationSpamThreshold' field
+func SetInstanceFederationSpamThreshold(v int) { global.SetInstanceFederationSpamThreshold(v) }
+
+// GetInstanceFederationSpamUnknownMentionWeight safely fetches the Configuration value for state's 'InstanceFederationSpamUnknownMentionWeight' field
+func (st *ConfigState) GetInstanceFederationSpamUnknownMentionWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamUnknownMentionWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamUnknownMentionWeight safely sets the Configuration value for state's 'InstanceFederationSpamUnknownMentionWeight' field
+func (st *ConfigState) SetInstanceFederationSpamUnknownMentionWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamUnknownMentionWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamUnknownMentionWeightFlag returns the flag name for the 'InstanceFederationSpamUnknownMentionWeight' field
+func InstanceFederationSpamUnknownMentionWeightFlag() string {
+	return "instance-federation-spam-unknown-mention-weight"
+}
+
+// GetInstanceFederationSpamUnknownMentionWeight safely fetches the value for global configuration 'InstanceFederationSpamUnknownMentionWeight' field
+func GetInstanceFederationSpamUnknownMentionWeight() int {
+	return global.GetInstanceFederationSpamUnknownMentionWeight()
+}
+
+// SetInstanceFederationSpamUnknownMentionWeight safely sets the value for global configuration 'InstanceFederationSpamUnknownMentionWeight' field
+func SetInstanceFederationSpamUnknownMentionWeight(v int) {
+	global.SetInstanceFederationSpamUnknownMentionWeight(v)
+}
+
+// GetInstanceFederationSpamMediaWeight safely fetches the Configuration value for state's 'InstanceFederationSpamMediaWeight' field
+func (st *ConfigState) GetInstanceFederationSpamMediaWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamMediaWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamMediaWeight safely sets the Configuration value for state's 'InstanceFederationSpamMediaWeight' field
+func (st *ConfigState) SetInstanceFederationSpamMediaWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamMediaWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamMediaWeightFlag returns the flag name for the 'InstanceFederationSpamMediaWeight' field
+func InstanceFederationSpamMediaWeightFlag() string { return "instance-federation-spam-media-weight" }
+
+// GetInstanceFederationSpamMediaWeight safely fetches the value for global configuration 'InstanceFederationSpamMediaWeight' field
+func GetInstanceFederationSpamMediaWeight() int { return global.GetInstanceFederationSpamMediaWeight() }
+
+// SetInstanceFederationSpamMediaWeight safely sets the value for global configuration 'InstanceFederationSpamMediaWeight' field
+func SetInstanceFederationSpamMediaWeight(v int) { global.SetInstanceFederationSpamMediaWeight(v) }
+
+// GetInstanceFederationSpamErrantLinkWeight safely fetches the Configuration value for state's 'InstanceFederationSpamErrantLinkWeight' field
+func (st *ConfigState) GetInstanceFederationSpamErrantLinkWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamErrantLinkWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamErrantLinkWeight safely sets the Configuration value for state's 'InstanceFederationSpamErrantLinkWeight' field
+func (st *ConfigState) SetInstanceFederationSpamErrantLinkWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamErrantLinkWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamErrantLinkWeightFlag returns the flag name for the 'InstanceFederationSpamErrantLinkWeight' field
+func InstanceFederationSpamErrantLinkWeightFlag() string {
+	return "instance-federation-spam-errant-link-weight"
+}
+
+// GetInstanceFederationSpamErrantLinkWeight safely fetches the value for global configuration 'InstanceFederationSpamErrantLinkWeight' field
+func GetInstanceFederationSpamErrantLinkWeight() int {
+	return global.GetInstanceFederationSpamErrantLinkWeight()
+}
+
+// SetInstanceFederationSpamErrantLinkWeight safely sets the value for global configuration 'InstanceFederationSpamErrantLinkWeight' field
+func SetInstanceFederationSpamErrantLinkWeight(v int) {
+	global.SetInstanceFederationSpamErrantLinkWeight(v)
+}
+
+// GetInstanceFederationSpamNewAccountWeight safely fetches the Configuration value for state's 'InstanceFederationSpamNewAccountWeight' field
+func (st *ConfigState) GetInstanceFederationSpamNewAccountWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamNewAccountWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamNewAccountWeight safely sets the Configuration value for state's 'InstanceFederationSpamNewAccountWeight' field
+func (st *ConfigState) SetInstanceFederationSpamNewAccountWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamNewAccountWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamNewAccountWeightFlag returns the flag name for the 'InstanceFederationSpamNewAccountWeight' field
+func InstanceFederationSpamNewAccountWeightFlag() string {
+	return "instance-federation-spam-new-account-weight"
+}
+
+// GetInstanceFederationSpamNewAccountWeight safely fetches the value for global configuration 'InstanceFederationSpamNewAccountWeight' field
+func GetInstanceFederationSpamNewAccountWeight() int {
+	return global.GetInstanceFederationSpamNewAccountWeight()
+}
+
+// SetInstanceFederationSpamNewAccountWeight safely sets the value for global configuration 'InstanceFederationSpamNewAccountWeight' field
+func SetInstanceFederationSpamNewAccountWeight(v int) {
+	global.SetInstanceFederationSpamNewAccountWeight(v)
+}
+
+// GetInstanceFederationSpamNewAccountAge safely fetches the Configuration value for state's 'InstanceFederationSpamNewAccountAge' field
+func (st *ConfigState) GetInstanceFederationSpamNewAccountAge() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamNewAccountAge
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamNewAccountAge safely sets the Configuration value for state's 'InstanceFederationSpamNewAccountAge' field
+func (st *ConfigState) SetInstanceFederationSpamNewAccountAge(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamNewAccountAge = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamNewAccountAgeFlag returns the flag name for the 'InstanceFederationSpamNewAccountAge' field
+func InstanceFederationSpamNewAccountAgeFlag() string {
+	return "instance-federation-spam-new-account-age"
+}
+
+// GetInstanceFederationSpamNewAccountAge safely fetches the value for global configuration 'InstanceFederationSpamNewAccountAge' field
+func GetInstanceFederationSpamNewAccountAge() time.Duration {
+	return global.GetInstanceFederationSpamNewAccountAge()
+}
+
+// SetInstanceFederationSpamNewAccountAge safely sets the value for global configuration 'InstanceFederationSpamNewAccountAge' field
+func SetInstanceFederationSpamNewAccountAge(v time.Duration) {
+	global.SetInstanceFederationSpamNewAccountAge(v)
+}
+
+// GetInstanceFederationSpamDuplicateWeight safely fetches the Configuration value for state's 'InstanceFederationSpamDuplicateWeight' field
+func (st *ConfigState) GetInstanceFederationSpamDuplicateWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamDuplicateWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamDuplicateWeight safely sets the Configuration value for state's 'InstanceFederationSpamDuplicateWeight' field
+func (st *ConfigState) SetInstanceFederationSpamDuplicateWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamDuplicateWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamDuplicateWeightFlag returns the flag name for the 'InstanceFederationSpamDuplicateWeight' field
+func InstanceFederationSpamDuplicateWeightFlag() string {
+	return "instance-federation-spam-duplicate-weight"
+}
+
+// GetInstanceFederationSpamDuplicateWeight safely fetches the value for global configuration 'InstanceFederationSpamDuplicateWeight' field
+func GetInstanceFederationSpamDuplicateWeight() int {
+	return global.GetInstanceFederationSpamDuplicateWeight()
+}
+
+// SetInstanceFederationSpamDuplicateWeight safely sets the value for global configuration 'InstanceFederationSpamDuplicateWeight' field
+func SetInstanceFederationSpamDuplicateWeight(v int) {
+	global.SetInstanceFederationSpamDuplicateWeight(v)
+}
+
+// GetInstanceFederationSpamBurstWeight safely fetches the Configuration value for state's 'InstanceFederationSpamBurstWeight' field
+func (st *ConfigState) GetInstanceFederationSpamBurstWeight() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamBurstWeight
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamBurstWeight safely sets the Configuration value for state's 'InstanceFederationSpamBurstWeight' field
+func (st *ConfigState) SetInstanceFederationSpamBurstWeight(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamBurstWeight = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamBurstWeightFlag returns the flag name for the 'InstanceFederationSpamBurstWeight' field
+func InstanceFederationSpamBurstWeightFlag() string { return "instance-federation-spam-burst-weight" }
+
+// GetInstanceFederationSpamBurstWeight safely fetches the value for global configuration 'InstanceFederationSpamBurstWeight' field
+func GetInstanceFederationSpamBurstWeight() int { return global.GetInstanceFederationSpamBurstWeight() }
+
+// SetInstanceFederationSpamBurstWeight safely sets the value for global configuration 'InstanceFederationSpamBurstWeight' field
+func SetInstanceFederationSpamBurstWeight(v int) { global.SetInstanceFederationSpamBurstWeight(v) }
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -1025,6 +1255,81 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsAllowUserInvites safely fetches the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) GetAccountsAllowUserInvites() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsAllowUserInvites
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsAllowUserInvites safely sets the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) SetAccountsAllowUserInvites(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAllowUserInvites = v
+	st.reloadToViper()
+}
+
+// AccountsAllowUserInvitesFlag returns the flag name for the 'AccountsAllowUserInvites' field
+func AccountsAllowUserInvitesFlag() string { return "accounts-allow-user-invites" }
+
+// GetAccountsAllowUserInvites safely fetches the value for global configuration 'AccountsAllowUserInvites' field
+func GetAccountsAllowUserInvites() bool { return global.GetAccountsAllowUserInvites() }
+
+// SetAccountsAllowUserInvites safely sets the value for global configuration 'AccountsAllowUserInvites' field
+func SetAccountsAllowUserInvites(v bool) { global.SetAccountsAllowUserInvites(v) }
+
+// GetAccountsSignupChallenge safely fetches the Configuration value for state's 'AccountsSignupChallenge' field
+func (st *ConfigState) GetAccountsSignupChallenge() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsSignupChallenge
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsSignupChallenge safely sets the Configuration value for state's 'AccountsSignupChallenge' field
+func (st *ConfigState) SetAccountsSignupChallenge(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsSignupChallenge = v
+	st.reloadToViper()
+}
+
+// AccountsSignupChallengeFlag returns the flag name for the 'AccountsSignupChallenge' field
+func AccountsSignupChallengeFlag() string { return "accounts-signup-challenge" }
+
+// GetAccountsSignupChallenge safely fetches the value for global configuration 'AccountsSignupChallenge' field
+func GetAccountsSignupChallenge() string { return global.GetAccountsSignupChallenge() }
+
+// SetAccountsSignupChallenge safely sets the value for global configuration 'AccountsSignupChallenge' field
+func SetAccountsSignupChallenge(v string) { global.SetAccountsSignupChallenge(v) }
+
+// GetAccountsSignupPowDifficulty safely fetches the Configuration value for state's 'AccountsSignupPowDifficulty' field
+func (st *ConfigState) GetAccountsSignupPowDifficulty() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsSignupPowDifficulty
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsSignupPowDifficulty safely sets the Configuration value for state's 'AccountsSignupPowDifficulty' field
+func (st *ConfigState) SetAccountsSignupPowDifficulty(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsSignupPowDifficulty = v
+	st.reloadToViper()
+}
+
+// AccountsSignupPowDifficultyFlag returns the flag name for the 'AccountsSignupPowDifficulty' field
+func AccountsSignupPowDifficultyFlag() string { return "accounts-signup-pow-difficulty" }
+
+// GetAccountsSignupPowDifficulty safely fetches the value for global configuration 'AccountsSignupPowDifficulty' field
+func GetAccountsSignupPowDifficulty() int { return global.GetAccountsSignupPowDifficulty() }
+
+// SetAccountsSignupPowDifficulty safely sets the value for global configuration 'AccountsSignupPowDifficulty' field
+func SetAccountsSignupPowDifficulty(v int) { global.SetAccountsSignupPowDifficulty(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1125,6 +1430,31 @@ func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize()
 // SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
 func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
 
+// GetMediaAudioMaxSize safely fetches the Configuration value for state's 'MediaAudioMaxSize' field
+func (st *ConfigState) GetMediaAudioMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaAudioMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaAudioMaxSize safely sets the Configuration value for state's 'MediaAudioMaxSize' field
+func (st *ConfigState) SetMediaAudioMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaAudioMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaAudioMaxSizeFlag returns the flag name for the 'MediaAudioMaxSize' field
+func MediaAudioMaxSizeFlag() string { return "media-audio-max-size" }
+
+// GetMediaAudioMaxSize safely fetches the value for global configuration 'MediaAudioMaxSize' field
+func GetMediaAudioMaxSize() bytesize.Size { return global.GetMediaAudioMaxSize() }
+
+// SetMediaAudioMaxSize safely sets the value for global configuration 'MediaAudioMaxSize' field
+func SetMediaAudioMaxSize(v bytesize.Size) { global.SetMediaAudioMaxSize(v) }
+
 // GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
 	st.mutex.RLock()
@@ -1300,6 +1630,256 @@ func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery()
 // SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
 func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
 
+// GetMediaQuotaUser safely fetches the Configuration value for state's 'MediaQuotaUser' field
+func (st *ConfigState) GetMediaQuotaUser() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaQuotaUser
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaQuotaUser safely sets the Configuration value for state's 'MediaQuotaUser' field
+func (st *ConfigState) SetMediaQuotaUser(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaQuotaUser = v
+	st.reloadToViper()
+}
+
+// MediaQuotaUserFlag returns the flag name for the 'MediaQuotaUser' field
+func MediaQuotaUserFlag() string { return "media-quota-user" }
+
+// GetMediaQuotaUser safely fetches the value for global configuration 'MediaQuotaUser' field
+func GetMediaQuotaUser() bytesize.Size { return global.GetMediaQuotaUser() }
+
+// SetMediaQuotaUser safely sets the value for global configuration 'MediaQuotaUser' field
+func SetMediaQuotaUser(v bytesize.Size) { global.SetMediaQuotaUser(v) }
+
+// GetMediaQuotaModerator safely fetches the Configuration value for state's 'MediaQuotaModerator' field
+func (st *ConfigState) GetMediaQuotaModerator() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaQuotaModerator
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaQuotaModerator safely sets the Configuration value for state's 'MediaQuotaModerator' field
+func (st *ConfigState) SetMediaQuotaModerator(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaQuotaModerator = v
+	st.reloadToViper()
+}
+
+// MediaQuotaModeratorFlag returns the flag name for the 'MediaQuotaModerator' field
+func MediaQuotaModeratorFlag() string { return "media-quota-moderator" }
+
+// GetMediaQuotaModerator safely fetches the value for global configuration 'MediaQuotaModerator' field
+func GetMediaQuotaModerator() bytesize.Size { return global.GetMediaQuotaModerator() }
+
+// SetMediaQuotaModerator safely sets the value for global configuration 'MediaQuotaModerator' field
+func SetMediaQuotaModerator(v bytesize.Size) { global.SetMediaQuotaModerator(v) }
+
+// GetMediaQuotaAdmin safely fetches the Configuration value for state's 'MediaQuotaAdmin' field
+func (st *ConfigState) GetMediaQuotaAdmin() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaQuotaAdmin
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaQuotaAdmin safely sets the Configuration value for state's 'MediaQuotaAdmin' field
+func (st *ConfigState) SetMediaQuotaAdmin(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaQuotaAdmin = v
+	st.reloadToViper()
+}
+
+// MediaQuotaAdminFlag returns the flag name for the 'MediaQuotaAdmin' field
+func MediaQuotaAdminFlag() string { return "media-quota-admin" }
+
+// GetMediaQuotaAdmin safely fetches the value for global configuration 'MediaQuotaAdmin' field
+func GetMediaQuotaAdmin() bytesize.Size { return global.GetMediaQuotaAdmin() }
+
+// SetMediaQuotaAdmin safely sets the value for global configuration 'MediaQuotaAdmin' field
+func SetMediaQuotaAdmin(v bytesize.Size) { global.SetMediaQuotaAdmin(v) }
+
+// GetMediaRemoteProxy safely fetches the Configuration value for state's 'MediaRemoteProxy' field
+func (st *ConfigState) GetMediaRemoteProxy() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteProxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteProxy safely sets the Configuration value for state's 'MediaRemoteProxy' field
+func (st *ConfigState) SetMediaRemoteProxy(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteProxy = v
+	st.reloadToViper()
+}
+
+// MediaRemoteProxyFlag returns the flag name for the 'MediaRemoteProxy' field
+func MediaRemoteProxyFlag() string { return "media-remote-proxy" }
+
+// GetMediaRemoteProxy safely fetches the value for global configuration 'MediaRemoteProxy' field
+func GetMediaRemoteProxy() bool { return global.GetMediaRemoteProxy() }
+
+// SetMediaRemoteProxy safely sets the value for global configuration 'MediaRemoteProxy' field
+func SetMediaRemoteProxy(v bool) { global.SetMediaRemoteProxy(v) }
+
+// GetMediaRemoteProxyDomains safely fetches the Configuration value for state's 'MediaRemoteProxyDomains' field
+func (st *ConfigState) GetMediaRemoteProxyDomains() (v []string) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteProxyDomains
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteProxyDomains safely sets the Configuration value for state's 'MediaRemoteProxyDomains' field
+func (st *ConfigState) SetMediaRemoteProxyDomains(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteProxyDomains = v
+	st.reloadToViper()
+}
+
+// MediaRemoteProxyDomainsFlag returns the flag name for the 'MediaRemoteProxyDomains' field
+func MediaRemoteProxyDomainsFlag() string { return "media-remote-proxy-domains" }
+
+// GetMediaRemoteProxyDomains safely fetches the value for global configuration 'MediaRemoteProxyDomains' field
+func GetMediaRemoteProxyDomains() []string { return global.GetMediaRemoteProxyDomains() }
+
+// SetMediaRemoteProxyDomains safely sets the value for global configuration 'MediaRemoteProxyDomains' field
+func SetMediaRemoteProxyDomains(v []string) { global.SetMediaRemoteProxyDomains(v) }
+
+// GetMediaRemoteProxyCacheTTL safely fetches the Configuration value for state's 'MediaRemoteProxyCacheTTL' field
+func (st *ConfigState) GetMediaRemoteProxyCacheTTL() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteProxyCacheTTL
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteProxyCacheTTL safely sets the Configuration value for state's 'MediaRemoteProxyCacheTTL' field
+func (st *ConfigState) SetMediaRemoteProxyCacheTTL(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteProxyCacheTTL = v
+	st.reloadToViper()
+}
+
+// MediaRemoteProxyCacheTTLFlag returns the flag name for the 'MediaRemoteProxyCacheTTL' field
+func MediaRemoteProxyCacheTTLFlag() string { return "media-remote-proxy-cache-ttl" }
+
+// GetMediaRemoteProxyCacheTTL safely fetches the value for global configuration 'MediaRemoteProxyCacheTTL' field
+func GetMediaRemoteProxyCacheTTL() time.Duration { return global.GetMediaRemoteProxyCacheTTL() }
+
+// SetMediaRemoteProxyCacheTTL safely sets the value for global configuration 'MediaRemoteProxyCacheTTL' field
+func SetMediaRemoteProxyCacheTTL(v time.Duration) { global.SetMediaRemoteProxyCacheTTL(v) }
+
+// GetMediaRemoteProxyCacheMaxSize safely fetches the Configuration value for state's 'MediaRemoteProxyCacheMaxSize' field
+func (st *ConfigState) GetMediaRemoteProxyCacheMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteProxyCacheMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteProxyCacheMaxSize safely sets the Configuration value for state's 'MediaRemoteProxyCacheMaxSize' field
+func (st *ConfigState) SetMediaRemoteProxyCacheMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteProxyCacheMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaRemoteProxyCacheMaxSizeFlag returns the flag name for the 'MediaRemoteProxyCacheMaxSize' field
+func MediaRemoteProxyCacheMaxSizeFlag() string { return "media-remote-proxy-cache-max-size" }
+
+// GetMediaRemoteProxyCacheMaxSize safely fetches the value for global configuration 'MediaRemoteProxyCacheMaxSize' field
+func GetMediaRemoteProxyCacheMaxSize() bytesize.Size { return global.GetMediaRemoteProxyCacheMaxSize() }
+
+// SetMediaRemoteProxyCacheMaxSize safely sets the value for global configuration 'MediaRemoteProxyCacheMaxSize' field
+func SetMediaRemoteProxyCacheMaxSize(v bytesize.Size) { global.SetMediaRemoteProxyCacheMaxSize(v) }
+
+// GetMediaHashBlockDistance safely fetches the Configuration value for state's 'MediaHashBlockDistance' field
+func (st *ConfigState) GetMediaHashBlockDistance() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaHashBlockDistance
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaHashBlockDistance safely sets the Configuration value for state's 'MediaHashBlockDistance' field
+func (st *ConfigState) SetMediaHashBlockDistance(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaHashBlockDistance = v
+	st.reloadToViper()
+}
+
+// MediaHashBlockDistanceFlag returns the flag name for the 'MediaHashBlockDistance' field
+func MediaHashBlockDistanceFlag() string { return "media-hash-block-distance" }
+
+// GetMediaHashBlockDistance safely fetches the value for global configuration 'MediaHashBlockDistance' field
+func GetMediaHashBlockDistance() int { return global.GetMediaHashBlockDistance() }
+
+// SetMediaHashBlockDistance safely sets the value for global configuration 'MediaHashBlockDistance' field
+func SetMediaHashBlockDistance(v int) { global.SetMediaHashBlockDistance(v) }
+
+// GetMediaUploadExpiry safely fetches the Configuration value for state's 'MediaUploadExpiry' field
+func (st *ConfigState) GetMediaUploadExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaUploadExpiry
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaUploadExpiry safely sets the Configuration value for state's 'MediaUploadExpiry' field
+func (st *ConfigState) SetMediaUploadExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaUploadExpiry = v
+	st.reloadToViper()
+}
+
+// MediaUploadExpiryFlag returns the flag name for the 'MediaUploadExpiry' field
+func MediaUploadExpiryFlag() string { return "media-upload-expiry" }
+
+// GetMediaUploadExpiry safely fetches the value for global configuration 'MediaUploadExpiry' field
+func GetMediaUploadExpiry() time.Duration { return global.GetMediaUploadExpiry() }
+
+// SetMediaUploadExpiry safely sets the value for global configuration 'MediaUploadExpiry' field
+func SetMediaUploadExpiry(v time.Duration) { global.SetMediaUploadExpiry(v) }
+
+// GetMediaDerivativeWidths safely fetches the Configuration value for state's 'MediaDerivativeWidths' field
+func (st *ConfigState) GetMediaDerivativeWidths() (v []int) {
+	st.mutex.RLock()
+	v = st.config.MediaDerivativeWidths
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDerivativeWidths safely sets the Configuration value for state's 'MediaDerivativeWidths' field
+func (st *ConfigState) SetMediaDerivativeWidths(v []int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDerivativeWidths = v
+	st.reloadToViper()
+}
+
+// MediaDerivativeWidthsFlag returns the flag name for the 'MediaDerivativeWidths' field
+func MediaDerivativeWidthsFlag() string { return "media-derivative-widths" }
+
+// GetMediaDerivativeWidths safely fetches the value for global configuration 'MediaDerivativeWidths' field
+func GetMediaDerivativeWidths() []int { return global.GetMediaDerivativeWidths() }
+
+// SetMediaDerivativeWidths safely sets the value for global configuration 'MediaDerivativeWidths' field
+func SetMediaDerivativeWidths(v []int) { global.SetMediaDerivativeWidths(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -1500,6 +2080,56 @@ func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
 // SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
 func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
 
+// GetStorageS3CacheBasePath safely fetches the Configuration value for state's 'StorageS3CacheBasePath' field
+func (st *ConfigState) GetStorageS3CacheBasePath() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3CacheBasePath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3CacheBasePath safely sets the Configuration value for state's 'StorageS3CacheBasePath' field
+func (st *ConfigState) SetStorageS3CacheBasePath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3CacheBasePath = v
+	st.reloadToViper()
+}
+
+// StorageS3CacheBasePathFlag returns the flag name for the 'StorageS3CacheBasePath' field
+func StorageS3CacheBasePathFlag() string { return "storage-s3-cache-base-path" }
+
+// GetStorageS3CacheBasePath safely fetches the value for global configuration 'StorageS3CacheBasePath' field
+func GetStorageS3CacheBasePath() string { return global.GetStorageS3CacheBasePath() }
+
+// SetStorageS3CacheBasePath safely sets the value for global configuration 'StorageS3CacheBasePath' field
+func SetStorageS3CacheBasePath(v string) { global.SetStorageS3CacheBasePath(v) }
+
+// GetStorageS3CacheMaxSize safely fetches the Configuration value for state's 'StorageS3CacheMaxSize' field
+func (st *ConfigState) GetStorageS3CacheMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.StorageS3CacheMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3CacheMaxSize safely sets the Configuration value for state's 'StorageS3CacheMaxSize' field
+func (st *ConfigState) SetStorageS3CacheMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3CacheMaxSize = v
+	st.reloadToViper()
+}
+
+// StorageS3CacheMaxSizeFlag returns the flag name for the 'StorageS3CacheMaxSize' field
+func StorageS3CacheMaxSizeFlag() string { return "storage-s3-cache-max-size" }
+
+// GetStorageS3CacheMaxSize safely fetches the value for global configuration 'StorageS3CacheMaxSize' field
+func GetStorageS3CacheMaxSize() bytesize.Size { return global.GetStorageS3CacheMaxSize() }
+
+// SetStorageS3CacheMaxSize safely sets the value for global configuration 'StorageS3CacheMaxSize' field
+func SetStorageS3CacheMaxSize(v bytesize.Size) { global.SetStorageS3CacheMaxSize(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.RLock()
@@ -3950,6 +4580,56 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminMediaMigrateTo safely fetches the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) GetAdminMediaMigrateTo() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateTo
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateTo safely sets the Configuration value for state's 'AdminMediaMigrateTo' field
+func (st *ConfigState) SetAdminMediaMigrateTo(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateTo = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateToFlag returns the flag name for the 'AdminMediaMigrateTo' field
+func AdminMediaMigrateToFlag() string { return "to" }
+
+// GetAdminMediaMigrateTo safely fetches the value for global configuration 'AdminMediaMigrateTo' field
+func GetAdminMediaMigrateTo() string { return global.GetAdminMediaMigrateTo() }
+
+// SetAdminMediaMigrateTo safely sets the value for global configuration 'AdminMediaMigrateTo' field
+func SetAdminMediaMigrateTo(v string) { global.SetAdminMediaMigrateTo(v) }
+
+// GetAdminMediaMigrateWorkers safely fetches the Configuration value for state's 'AdminMediaMigrateWorkers' field
+func (st *ConfigState) GetAdminMediaMigrateWorkers() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdminMediaMigrateWorkers
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminMediaMigrateWorkers safely sets the Configuration value for state's 'AdminMediaMigrateWorkers' field
+func (st *ConfigState) SetAdminMediaMigrateWorkers(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminMediaMigrateWorkers = v
+	st.reloadToViper()
+}
+
+// AdminMediaMigrateWorkersFlag returns the flag name for the 'AdminMediaMigrateWorkers' field
+func AdminMediaMigrateWorkersFlag() string { return "parallelism" }
+
+// GetAdminMediaMigrateWorkers safely fetches the value for global configuration 'AdminMediaMigrateWorkers' field
+func GetAdminMediaMigrateWorkers() int { return global.GetAdminMediaMigrateWorkers() }
+
+// SetAdminMediaMigrateWorkers safely sets the value for global configuration 'AdminMediaMigrateWorkers' field
+func SetAdminMediaMigrateWorkers(v int) { global.SetAdminMediaMigrateWorkers(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
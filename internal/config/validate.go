@@ -82,6 +82,27 @@ func Validate() error {
 		)
 	}
 
+	// `accounts-signup-challenge` should be
+	// empty or a recognized challenge type.
+	switch challenge := GetAccountsSignupChallenge(); challenge {
+	case AccountsSignupChallengeNone:
+		// No problem.
+
+	case AccountsSignupChallengePoW:
+		if d := GetAccountsSignupPowDifficulty(); d < 1 || d > 32 {
+			errf(
+				"%s must be between 1 and 32, provided value was %d",
+				AccountsSignupPowDifficultyFlag(), d,
+			)
+		}
+
+	default:
+		errf(
+			"%s must be empty or set to pow, provided value was %s",
+			AccountsSignupChallengeFlag(), challenge,
+		)
+	}
+
 	// `federation-mode` should be
 	// "blocklist" or "allowlist".
 	switch fediMode := GetInstanceFederationMode(); fediMode {
@@ -98,6 +119,17 @@ func Validate() error {
 		)
 	}
 
+	// `instance-federation-spam-threshold` should
+	// be positive, or every message would be spam.
+	if GetInstanceFederationSpamFilter() {
+		if t := GetInstanceFederationSpamThreshold(); t < 1 {
+			errf(
+				"%s must be 1 or more, provided value was %d",
+				InstanceFederationSpamThresholdFlag(), t,
+			)
+		}
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type AccountWarning interface {
+	// GetAccountWarningByID fetches the account warning with the given ID from the database.
+	GetAccountWarningByID(ctx context.Context, id string) (*gtsmodel.AccountWarning, error)
+
+	// GetAccountWarnings fetches a page of account warnings from the database, newest
+	// first. If accountID is set, only warnings issued to that account are returned.
+	// If appealState is set, only warnings with an appeal in that state are returned.
+	GetAccountWarnings(ctx context.Context, accountID string, appealState *gtsmodel.AppealState, page *paging.Page) ([]*gtsmodel.AccountWarning, error)
+
+	// PopulateAccountWarning populates the struct pointers on the given account warning.
+	PopulateAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error
+
+	// PutAccountWarning inserts the given account warning into the database.
+	PutAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error
+
+	// UpdateAccountWarning updates the given account warning in the database, only updating given columns if provided.
+	UpdateAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning, columns ...string) error
+
+	// DeleteAccountWarningsByAccountID deletes all warnings issued to the given account ID.
+	DeleteAccountWarningsByAccountID(ctx context.Context, accountID string) error
+}
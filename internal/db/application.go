@@ -71,4 +71,7 @@ type Application interface {
 
 	// DeleteTokenByRefresh ...
 	DeleteTokenByRefresh(ctx context.Context, refresh string) error
+
+	// DeleteTokensByUserID deletes all tokens issued to the given user.
+	DeleteTokensByUserID(ctx context.Context, userID string) error
 }
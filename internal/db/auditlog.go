@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type AuditLog interface {
+	// GetAuditLogEntries fetches a page of audit log entries from the database, newest first.
+	// If accountID is set, only entries created by that account are returned. If targetType
+	// is set, only entries with that target type are returned. If start or end are set, only
+	// entries created at or after start, and/or before end, are returned.
+	GetAuditLogEntries(
+		ctx context.Context,
+		accountID string,
+		targetType gtsmodel.AuditLogTargetType,
+		start time.Time,
+		end time.Time,
+		page *paging.Page,
+	) ([]*gtsmodel.AuditLogEntry, error)
+
+	// PutAuditLogEntry inserts the given audit log entry into the database.
+	PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error
+}
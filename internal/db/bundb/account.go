@@ -442,7 +442,24 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts that signed
+		// up using an invite created by
+		// the given account.
+		inviteIDs := a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+			Column("invite.id").
+			Where("? = ?", bun.Ident("invite.account_id"), invitedBy)
+
+		invitedIDs := a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+			Column("user.account_id").
+			Where("? IN (?)", bun.Ident("user.invite_id"), inviteIDs)
+
+		q = q.Where("? IN (?)", bun.Ident("account.id"), invitedIDs)
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -1268,6 +1285,52 @@ func (a *accountDB) RegenerateAccountStats(ctx context.Context, account *gtsmode
 		}
 		stats.LastStatusAt = lastStatusAt
 
+		// Scan database for total size of
+		// media stored for local accounts.
+		// Remote media is only cached, so
+		// it doesn't count toward anything.
+		//
+		// Derivatives are stored as JSON, so
+		// sizes are summed here rather than
+		// in the database.
+		var mediaStorageUsed int64
+		if account.IsLocal() {
+			var attachments []*gtsmodel.MediaAttachment
+			err = tx.
+				NewSelect().
+				Model(&attachments).
+				Column("file_file_size", "thumbnail_file_size", "derivatives").
+				Where("? = ?", bun.Ident("account_id"), account.ID).
+				Scan(ctx)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return err
+			}
+
+			for _, attachment := range attachments {
+				mediaStorageUsed += int64(attachment.File.FileSize)
+				mediaStorageUsed += int64(attachment.Thumbnail.FileSize)
+				for _, derivative := range attachment.Derivatives {
+					mediaStorageUsed += int64(derivative.FileSize)
+				}
+			}
+		}
+		stats.MediaStorageUsed = &mediaStorageUsed
+
+		// Carry over any media storage quota
+		// override set by an admin, since this
+		// isn't something that can be counted.
+		var mediaStorageQuota *int64
+		err = tx.
+			NewSelect().
+			Table("account_stats").
+			Column("media_storage_quota").
+			Where("? = ?", bun.Ident("account_id"), account.ID).
+			Scan(ctx, &mediaStorageQuota)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+		stats.MediaStorageQuota = mediaStorageQuota
+
 		return nil
 	}); err != nil {
 		return err
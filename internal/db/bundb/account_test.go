@@ -502,6 +502,59 @@ func (suite *AccountTestSuite) TestGetAccountsAll() {
 	suite.Len(accounts, 9)
 }
 
+func (suite *AccountTestSuite) TestGetAccountsInvitedBy() {
+	var (
+		ctx         = context.Background()
+		origin      = ""
+		status      = ""
+		mods        = false
+		invitedBy   = suite.testAccounts["admin_account"].ID
+		username    = ""
+		displayName = ""
+		domain      = ""
+		email       = ""
+		ip          netip.Addr
+		page        *paging.Page = nil
+	)
+
+	// Admin invites local_account_2.
+	invite := &gtsmodel.Invite{
+		ID:        "01J3A2B7F8Q5E3ZC0VXW8J4M6K",
+		Code:      "an-invite-code",
+		AccountID: invitedBy,
+	}
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user := new(gtsmodel.User)
+	*user = *suite.testUsers["local_account_2"]
+	user.InviteID = invite.ID
+	if err := suite.db.UpdateUser(ctx, user, "invite_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetAccounts(
+		ctx,
+		origin,
+		status,
+		mods,
+		invitedBy,
+		username,
+		displayName,
+		domain,
+		email,
+		ip,
+		page,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(accounts, 1)
+	suite.Equal(user.AccountID, accounts[0].ID)
+}
+
 func (suite *AccountTestSuite) TestGetAccountsMaxID() {
 	var (
 		ctx         = context.Background()
@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountWarningDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountWarningDB) GetAccountWarningByID(ctx context.Context, id string) (*gtsmodel.AccountWarning, error) {
+	warning := new(gtsmodel.AccountWarning)
+
+	if err := a.db.
+		NewSelect().
+		Model(warning).
+		Where("? = ?", bun.Ident("account_warning.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return warning, nil
+	}
+
+	if err := a.PopulateAccountWarning(ctx, warning); err != nil {
+		return nil, err
+	}
+
+	return warning, nil
+}
+
+func (a *accountWarningDB) GetAccountWarnings(
+	ctx context.Context,
+	accountID string,
+	appealState *gtsmodel.AppealState,
+	page *paging.Page,
+) ([]*gtsmodel.AccountWarning, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		warnings = make([]*gtsmodel.AccountWarning, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		Model(&warnings)
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("account_warning.account_id"), accountID)
+	}
+
+	if appealState != nil {
+		q = q.Where("? = ?", bun.Ident("account_warning.appeal_state"), *appealState)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account_warning.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("account_warning.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("account_warning.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("account_warning.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want warnings
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(warnings)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return warnings, nil
+	}
+
+	for _, warning := range warnings {
+		if err := a.PopulateAccountWarning(ctx, warning); err != nil {
+			return nil, err
+		}
+	}
+
+	return warnings, nil
+}
+
+func (a *accountWarningDB) PopulateAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(5)
+	)
+
+	if warning.Account == nil {
+		// Warned account is not set, fetch from the database.
+		warning.Account, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			warning.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating warning account: %w", err)
+		}
+	}
+
+	if warning.CreatedByAccount == nil {
+		// Issuing account is not set, fetch from the database.
+		warning.CreatedByAccount, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			warning.CreatedByAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating warning created by account: %w", err)
+		}
+	}
+
+	if warning.ReportID != "" && warning.Report == nil {
+		// Linked report is not set, fetch from the database.
+		warning.Report, err = a.state.DB.GetReportByID(
+			gtscontext.SetBarebones(ctx),
+			warning.ReportID,
+		)
+		if err != nil {
+			errs.Appendf("error populating warning report: %w", err)
+		}
+	}
+
+	if l := len(warning.StatusIDs); l > 0 && l != len(warning.Statuses) {
+		// Linked statuses not set, fetch from the database.
+		warning.Statuses, err = a.state.DB.GetStatusesByIDs(
+			gtscontext.SetBarebones(ctx),
+			warning.StatusIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating warning statuses: %w", err)
+		}
+	}
+
+	if l := len(warning.RuleIDs); l > 0 && l != len(warning.Rules) {
+		// Linked rules not set, fetch from the database.
+		warning.Rules = make([]*gtsmodel.Rule, 0, l)
+		for _, v := range warning.RuleIDs {
+			rule, err := a.state.DB.GetRuleByID(ctx, v)
+			if err != nil {
+				errs.Appendf("error populating warning rules: %w", err)
+			} else {
+				warning.Rules = append(warning.Rules, rule)
+			}
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (a *accountWarningDB) PutAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error {
+	_, err := a.db.
+		NewInsert().
+		Model(warning).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountWarningDB) UpdateAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning, columns ...string) error {
+	warning.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(warning).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_warning.id"), warning.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountWarningDB) DeleteAccountWarningsByAccountID(ctx context.Context, accountID string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_warnings"), bun.Ident("account_warning")).
+		Where("? = ?", bun.Ident("account_warning.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type AccountWarningTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AccountWarningTestSuite) TestGetAccountWarnings() {
+	var (
+		ctx       = context.Background()
+		adminID   = suite.testAccounts["admin_account"].ID
+		accountID = suite.testAccounts["local_account_1"].ID
+		rule      = suite.testRules["rule1"]
+	)
+
+	for _, warning := range []*gtsmodel.AccountWarning{
+		{
+			ID:                 "01HXFQ0Y3J8M2T5W9B4C7D1E2A",
+			AccountID:          accountID,
+			CreatedByAccountID: adminID,
+			Action:             gtsmodel.AdminActionWarn,
+			Text:               "first warning",
+			RuleIDs:            []string{rule.ID},
+		},
+		{
+			ID:                 "01HXFQ0Y3J8M2T5W9B4C7D1E2B",
+			AccountID:          accountID,
+			CreatedByAccountID: adminID,
+			Action:             gtsmodel.AdminActionSilence,
+			Text:               "second warning",
+			AppealState:        gtsmodel.AppealStatePending,
+			AppealText:         "not fair",
+		},
+		{
+			ID:                 "01HXFQ0Y3J8M2T5W9B4C7D1E2C",
+			AccountID:          suite.testAccounts["local_account_2"].ID,
+			CreatedByAccountID: adminID,
+			Action:             gtsmodel.AdminActionWarn,
+		},
+	} {
+		if err := suite.db.PutAccountWarning(ctx, warning); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Warnings for the account, newest first.
+	warnings, err := suite.db.GetAccountWarnings(ctx, accountID, nil, &paging.Page{Limit: 10})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(warnings, 2) {
+		suite.Equal("second warning", warnings[0].Text)
+		suite.Equal("first warning", warnings[1].Text)
+
+		// Rules should be populated.
+		if suite.Len(warnings[1].Rules, 1) {
+			suite.Equal(rule.Text, warnings[1].Rules[0].Text)
+		}
+	}
+
+	// Only warnings with a pending appeal.
+	pending := gtsmodel.AppealStatePending
+	warnings, err = suite.db.GetAccountWarnings(ctx, "", &pending, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(warnings, 1) {
+		suite.Equal("not fair", warnings[0].AppealText)
+		suite.True(warnings[0].IsAppealed())
+	}
+
+	// Deleting the account's warnings
+	// should leave other accounts' alone.
+	if err := suite.db.DeleteAccountWarningsByAccountID(ctx, accountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	warnings, err = suite.db.GetAccountWarnings(ctx, "", nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(warnings, 1)
+}
+
+func TestAccountWarningTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountWarningTestSuite))
+}
@@ -201,6 +201,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		Locale:                 newSignup.Locale,
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
+		InviteID:               newSignup.InviteID,
 		ExternalID:             newSignup.ExternalID,
 	}
 
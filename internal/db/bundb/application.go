@@ -274,3 +274,18 @@ func (a *applicationDB) DeleteTokenByRefresh(ctx context.Context, refresh string
 	a.state.Caches.GTS.Token.Invalidate("Refresh", refresh)
 	return nil
 }
+
+func (a *applicationDB) DeleteTokensByUserID(ctx context.Context, userID string) error {
+	var tokenIDs []string
+
+	if _, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx, &tokenIDs); err != nil {
+		return err
+	}
+
+	a.state.Caches.GTS.Token.InvalidateIDs("ID", tokenIDs)
+	return nil
+}
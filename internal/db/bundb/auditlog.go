@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type auditLogDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *auditLogDB) GetAuditLogEntries(
+	ctx context.Context,
+	accountID string,
+	targetType gtsmodel.AuditLogTargetType,
+	start time.Time,
+	end time.Time,
+	page *paging.Page,
+) ([]*gtsmodel.AuditLogEntry, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		entries = make([]*gtsmodel.AuditLogEntry, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		Model(&entries)
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.account_id"), accountID)
+	}
+
+	if targetType != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.target_type"), targetType)
+	}
+
+	if !start.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("audit_log_entry.created_at"), start)
+	}
+
+	if !end.IsZero() {
+		q = q.Where("? < ?", bun.Ident("audit_log_entry.created_at"), end)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("audit_log_entry.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("audit_log_entry.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("audit_log_entry.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("audit_log_entry.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want entries
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(entries)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return entries, nil
+	}
+
+	for _, entry := range entries {
+		var err error
+
+		// Populate the account that performed the mutation.
+		entry.Account, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			entry.AccountID,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error populating audit log entry account: %w", err)
+		}
+	}
+
+	return entries, nil
+}
+
+func (a *auditLogDB) PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error {
+	_, err := a.db.
+		NewInsert().
+		Model(entry).
+		Exec(ctx)
+	return err
+}
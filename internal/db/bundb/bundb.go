@@ -53,21 +53,30 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountWarning
 	db.Admin
 	db.Application
+	db.AuditLog
 	db.Basic
 	db.Domain
+	db.DomainLimit
 	db.Emoji
 	db.HeaderFilter
+	db.InboundPolicy
 	db.Instance
+	db.Invite
+	db.IPBlock
 	db.Filter
 	db.List
 	db.Marker
+	db.Measure
 	db.Media
+	db.MediaHashBlock
 	db.Mention
 	db.Move
 	db.Notification
 	db.Poll
+	db.QuarantinedStatus
 	db.Relationship
 	db.Report
 	db.Rule
@@ -176,6 +185,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AccountWarning: &accountWarningDB{
+			db:    db,
+			state: state,
+		},
 		Admin: &adminDB{
 			db:    db,
 			state: state,
@@ -184,6 +197,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AuditLog: &auditLogDB{
+			db:    db,
+			state: state,
+		},
 		Basic: &basicDB{
 			db: db,
 		},
@@ -199,6 +216,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		InboundPolicy: &inboundPolicyDB{
+			db:    db,
+			state: state,
+		},
 		Instance: &instanceDB{
 			db:    db,
 			state: state,
@@ -215,6 +236,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Measure: &measureDB{
+			db:    db,
+			state: state,
+		},
 		Media: &mediaDB{
 			db:    db,
 			state: state,
@@ -235,6 +260,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		QuarantinedStatus: &quarantinedStatusDB{
+			db:    db,
+			state: state,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
@@ -243,6 +272,22 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
+		DomainLimit: &domainLimitDB{
+			db:    db,
+			state: state,
+		},
+		IPBlock: &ipBlockDB{
+			db:    db,
+			state: state,
+		},
+		MediaHashBlock: &mediaHashBlockDB{
+			db:    db,
+			state: state,
+		},
 		Rule: &ruleDB{
 			db:    db,
 			state: state,
@@ -274,6 +274,49 @@ func (suite *DomainTestSuite) TestIsOtherDomainBlockedWildcardAndExplicit() {
 	}
 }
 
+func (suite *DomainTestSuite) TestIsEmailDomainBlocked() {
+	ctx := context.Background()
+
+	block := &gtsmodel.EmailDomainBlock{
+		ID:                 "01HXA3B2Y8Q7TJ5W0KZ3N6EVRM",
+		Domain:             "Blocked.Example",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	if err := suite.db.CreateEmailDomainBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Domain should be stored lowercase.
+	suite.Equal("blocked.example", block.Domain)
+
+	for _, test := range []struct {
+		hosts   []string
+		blocked bool
+	}{
+		{[]string{"blocked.example"}, true},
+		{[]string{"BLOCKED.example"}, true},
+		{[]string{"mail.blocked.example"}, true},
+		{[]string{"notblocked.example"}, false},
+		{[]string{"blocked.example.org"}, false},
+		{[]string{"fine.example", "mx1.blocked.example."}, true},
+		{[]string{"fine.example", "mx1.fine.example"}, false},
+		{[]string{}, false},
+	} {
+		blocked, err := suite.db.IsEmailDomainBlocked(ctx, test.hosts...)
+		suite.NoError(err)
+		suite.Equal(test.blocked, blocked, "hosts: %v", test.hosts)
+	}
+
+	if err := suite.db.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	blocked, err := suite.db.IsEmailDomainBlocked(ctx, "blocked.example")
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
 func TestDomainTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainTestSuite))
 }
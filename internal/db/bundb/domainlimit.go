@@ -0,0 +1,241 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type domainLimitDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *domainLimitDB) GetDomainLimitByID(ctx context.Context, id string) (*gtsmodel.DomainLimit, error) {
+	return d.getDomainLimit(ctx, "domain_limit.id", id)
+}
+
+func (d *domainLimitDB) GetDomainLimitByDomain(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.getDomainLimit(ctx, "domain_limit.domain", domain)
+}
+
+func (d *domainLimitDB) getDomainLimit(ctx context.Context, column string, value any) (*gtsmodel.DomainLimit, error) {
+	limit := new(gtsmodel.DomainLimit)
+
+	if err := d.db.
+		NewSelect().
+		Model(limit).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return limit, nil
+	}
+
+	if err := d.PopulateDomainLimit(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+func (d *domainLimitDB) GetDomainLimits(ctx context.Context, page *paging.Page) ([]*gtsmodel.DomainLimit, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		limits = make([]*gtsmodel.DomainLimit, 0, limit)
+	)
+
+	q := d.db.
+		NewSelect().
+		Model(&limits)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("domain_limit.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("domain_limit.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("domain_limit.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("domain_limit.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want limits
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(limits)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return limits, nil
+	}
+
+	for _, limit := range limits {
+		if err := d.PopulateDomainLimit(ctx, limit); err != nil {
+			return nil, err
+		}
+	}
+
+	return limits, nil
+}
+
+func (d *domainLimitDB) PopulateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) error {
+	var err error
+
+	if limit.CreatedByAccount == nil {
+		// Fetch the account that created this limit.
+		limit.CreatedByAccount, err = d.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			limit.CreatedByAccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error populating domain limit account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *domainLimitDB) PutDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) error {
+	// Normalize the domain as punycode
+	var err error
+	limit.Domain, err = util.Punify(limit.Domain)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.db.
+		NewInsert().
+		Model(limit).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	d.clearCaches()
+	return nil
+}
+
+func (d *domainLimitDB) UpdateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit, columns ...string) error {
+	limit.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := d.db.
+		NewUpdate().
+		Model(limit).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_limit.id"), limit.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	d.clearCaches()
+	return nil
+}
+
+func (d *domainLimitDB) DeleteDomainLimitByID(ctx context.Context, id string) error {
+	if _, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("domain_limits"), bun.Ident("domain_limit")).
+		Where("? = ?", bun.Ident("domain_limit.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	d.clearCaches()
+	return nil
+}
+
+func (d *domainLimitDB) MatchDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Domain referencing *us* cannot be limited.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, nil
+	}
+
+	return d.state.Caches.DomainLimits.Match(domain, func() ([]*gtsmodel.DomainLimit, error) {
+		var limits []*gtsmodel.DomainLimit
+
+		// Load all limits into the cache.
+		if err := d.db.
+			NewSelect().
+			Model(&limits).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return limits, nil
+	})
+}
+
+// clearCaches drops the loaded domain limits, and any
+// cached visibility results, as these may both now be
+// out of date following a change to domain limits.
+func (d *domainLimitDB) clearCaches() {
+	d.state.Caches.DomainLimits.Clear()
+	d.state.Caches.Visibility.Clear()
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DomainLimitTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DomainLimitTestSuite) TestMatchDomainLimit() {
+	ctx := context.Background()
+
+	for _, limit := range []*gtsmodel.DomainLimit{
+		{
+			ID:                 "01HXD5A1B2C3D4E5F6G7H8J9K1",
+			Domain:             "example.org",
+			ContentWarning:     "from example.org",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01HXD5A1B2C3D4E5F6G7H8J9K2",
+			Domain:             "Noisy.Example.org",
+			ContentWarning:     "from noisy.example.org",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		if err := suite.db.PutDomainLimit(ctx, limit); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	for _, test := range []struct {
+		domain string
+		cw     string
+	}{
+		{"example.org", "from example.org"},
+		{"sub.example.org", "from example.org"},
+		{"noisy.example.org", "from noisy.example.org"}, // most specific wins
+		{"a.noisy.example.org", "from noisy.example.org"},
+		{"notexample.org", ""},
+		{"example.org.uk", ""},
+		{"", ""},
+	} {
+		limit, err := suite.db.MatchDomainLimit(ctx, test.domain)
+		suite.NoError(err)
+
+		if test.cw == "" {
+			suite.Nil(limit, "domain: %s", test.domain)
+			continue
+		}
+
+		if suite.NotNil(limit, "domain: %s", test.domain) {
+			suite.Equal(test.cw, limit.ContentWarning, "domain: %s", test.domain)
+		}
+	}
+
+	// Deleting the more specific limit should
+	// be reflected immediately in matches.
+	if err := suite.db.DeleteDomainLimitByID(ctx, "01HXD5A1B2C3D4E5F6G7H8J9K2"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	limit, err := suite.db.MatchDomainLimit(ctx, "noisy.example.org")
+	suite.NoError(err)
+	if suite.NotNil(limit) {
+		suite.Equal("example.org", limit.Domain)
+	}
+}
+
+func TestDomainLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainLimitTestSuite))
+}
@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) CreateEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	// Normalize the domain as punycode
+	var err error
+	block.Domain, err = util.Punify(block.Domain)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.NewInsert().
+		Model(block).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.getEmailDomainBlock(ctx, "email_domain_block.domain", domain)
+}
+
+func (d *domainDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error) {
+	return d.getEmailDomainBlock(ctx, "email_domain_block.id", id)
+}
+
+func (d *domainDB) getEmailDomainBlock(ctx context.Context, column string, value any) (*gtsmodel.EmailDomainBlock, error) {
+	var block gtsmodel.EmailDomainBlock
+
+	if err := d.db.
+		NewSelect().
+		Model(&block).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (d *domainDB) GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error) {
+	blocks := []*gtsmodel.EmailDomainBlock{}
+
+	if err := d.db.
+		NewSelect().
+		Model(&blocks).
+		Order("email_domain_block.domain ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (d *domainDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) error {
+	_, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
+		Where("? = ?", bun.Ident("email_domain_block.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) IsEmailDomainBlocked(ctx context.Context, hosts ...string) (bool, error) {
+	// Gather each given host, and all of
+	// its parent domains, so that a block
+	// on 'example.org' also catches hosts
+	// like 'mx1.mail.example.org'.
+	candidates := make([]string, 0, len(hosts)*2)
+	for _, host := range hosts {
+		host, err := util.Punify(strings.TrimSuffix(host, "."))
+		if err != nil || host == "" {
+			continue
+		}
+
+		for {
+			candidates = append(candidates, host)
+
+			i := strings.IndexByte(host, '.')
+			if i < 0 {
+				break
+			}
+			host = host[i+1:]
+		}
+	}
+
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	q := d.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
+		Column("email_domain_block.id").
+		Where("? IN (?)", bun.Ident("email_domain_block.domain"), bun.In(candidates))
+
+	return exists(ctx, q)
+}
@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache/inboundpolicy"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inboundPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inboundPolicyDB) GetInboundPolicyByID(ctx context.Context, id string) (*gtsmodel.InboundPolicy, error) {
+	policy := new(gtsmodel.InboundPolicy)
+
+	if err := i.db.
+		NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("inbound_policy.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return policy, nil
+	}
+
+	if err := i.PopulateInboundPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (i *inboundPolicyDB) GetInboundPolicies(ctx context.Context) ([]*gtsmodel.InboundPolicy, error) {
+	var policies []*gtsmodel.InboundPolicy
+
+	if err := i.db.
+		NewSelect().
+		Model(&policies).
+		Order("inbound_policy.priority ASC", "inbound_policy.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return policies, nil
+	}
+
+	for _, policy := range policies {
+		if err := i.PopulateInboundPolicy(ctx, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return policies, nil
+}
+
+func (i *inboundPolicyDB) PopulateInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy) error {
+	var err error
+
+	if policy.CreatedByAccount == nil {
+		// Fetch the account that created this policy.
+		policy.CreatedByAccount, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			policy.CreatedByAccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error populating inbound policy account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inboundPolicyDB) PutInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy) error {
+	if _, err := i.db.
+		NewInsert().
+		Model(policy).
+		Exec(ctx); err != nil {
+		return err
+	}
+	i.state.Caches.InboundPolicies.Clear()
+	return nil
+}
+
+func (i *inboundPolicyDB) UpdateInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy, columns ...string) error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := i.db.
+		NewUpdate().
+		Model(policy).
+		Column(columns...).
+		Where("? = ?", bun.Ident("inbound_policy.id"), policy.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+	i.state.Caches.InboundPolicies.Clear()
+	return nil
+}
+
+func (i *inboundPolicyDB) DeleteInboundPolicyByID(ctx context.Context, id string) error {
+	if _, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("inbound_policies"), bun.Ident("inbound_policy")).
+		Where("? = ?", bun.Ident("inbound_policy.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+	i.state.Caches.InboundPolicies.Clear()
+	return nil
+}
+
+func (i *inboundPolicyDB) GetEnabledInboundPolicies(ctx context.Context) ([]inboundpolicy.Policy, error) {
+	return i.state.Caches.InboundPolicies.Load(func() ([]*gtsmodel.InboundPolicy, error) {
+		return i.GetInboundPolicies(gtscontext.SetBarebones(ctx))
+	})
+}
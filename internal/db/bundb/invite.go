@@ -0,0 +1,240 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "invite.id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "invite.code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value any) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	if err := i.db.
+		NewSelect().
+		Model(&invite).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &invite, nil
+	}
+
+	if err := i.PopulateInvite(ctx, &invite); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvites(
+	ctx context.Context,
+	accountID string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) ([]*gtsmodel.Invite, error) {
+	inviteIDs := []string{}
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		Column("invite.id").
+		Order("invite.id DESC")
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("invite.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("invite.id"), maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("invite.id"), sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("invite.id"), minID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &inviteIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no invites early.
+	if len(inviteIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	invites := make([]*gtsmodel.Invite, 0, len(inviteIDs))
+	for _, id := range inviteIDs {
+		invite, err := i.GetInviteByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting invite %q: %v", id, err)
+			continue
+		}
+
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	var err error
+
+	if invite.Account == nil {
+		// Fetch the account that created this invite.
+		invite.Account, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			invite.AccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error populating invite account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) (bool, error) {
+	now := time.Now()
+
+	q := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		// Only increment if not yet expired...
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("expires_at")).
+				WhereOr("? > ?", bun.Ident("expires_at"), now)
+		}).
+		// ... and not yet used up.
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("max_uses")).
+				WhereOr("? < ?", bun.Ident("uses"), bun.Ident("max_uses"))
+		})
+
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		// Invite was used up
+		// or expired meanwhile.
+		return false, nil
+	}
+
+	invite.Uses++
+	invite.UpdatedAt = now
+	return true, nil
+}
+
+func (i *inviteDB) DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	now := time.Now()
+
+	if _, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		// Never go below zero.
+		Where("? > 0", bun.Ident("uses")).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	invite.Uses--
+	invite.UpdatedAt = now
+	return nil
+}
+
+func (i *inviteDB) DeleteInviteByID(ctx context.Context, id string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		Where("? = ?", bun.Ident("invite.id"), id).
+		Exec(ctx)
+	return err
+}
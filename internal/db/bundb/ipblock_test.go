@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type IPBlockTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *IPBlockTestSuite) TestMatchIPBlock() {
+	ctx := context.Background()
+
+	for _, block := range []*gtsmodel.IPBlock{
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA1",
+			IP:                 "192.0.2.0/24",
+			Severity:           gtsmodel.IPBlockSeveritySignUpRequiresApproval,
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA2",
+			IP:                 "192.0.2.128/25",
+			Severity:           gtsmodel.IPBlockSeverityNoAccess,
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA3",
+			IP:                 "198.51.100.0/24",
+			Severity:           gtsmodel.IPBlockSeveritySignUpBlock,
+			ExpiresAt:          time.Now().Add(-time.Hour),
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA4",
+			IP:                 "2001:db8::/32",
+			Severity:           gtsmodel.IPBlockSeveritySignUpBlock,
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		if err := suite.db.PutIPBlock(ctx, block); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	for _, test := range []struct {
+		addr     string
+		severity gtsmodel.IPBlockSeverity
+	}{
+		{"192.0.2.1", gtsmodel.IPBlockSeveritySignUpRequiresApproval},
+		{"::ffff:192.0.2.1", gtsmodel.IPBlockSeveritySignUpRequiresApproval},
+		{"192.0.2.200", gtsmodel.IPBlockSeverityNoAccess}, // most severe wins
+		{"198.51.100.1", gtsmodel.IPBlockSeverityUnknown}, // expired
+		{"2001:db8::1", gtsmodel.IPBlockSeveritySignUpBlock},
+		{"203.0.113.1", gtsmodel.IPBlockSeverityUnknown},
+	} {
+		block, err := suite.db.MatchIPBlock(ctx, netip.MustParseAddr(test.addr))
+		suite.NoError(err)
+
+		if test.severity == gtsmodel.IPBlockSeverityUnknown {
+			suite.Nil(block, "addr: %s", test.addr)
+			continue
+		}
+
+		if suite.NotNil(block, "addr: %s", test.addr) {
+			suite.Equal(test.severity, block.Severity, "addr: %s", test.addr)
+		}
+	}
+
+	// Deleting the no access block should
+	// be reflected immediately in matches.
+	if err := suite.db.DeleteIPBlockByID(ctx, "01HXAAAAAAAAAAAAAAAAAAAAA2"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	block, err := suite.db.MatchIPBlock(ctx, netip.MustParseAddr("192.0.2.200"))
+	suite.NoError(err)
+	suite.NotNil(block)
+	suite.Equal(gtsmodel.IPBlockSeveritySignUpRequiresApproval, block.Severity)
+
+	// Likewise for updates.
+	block.Severity = gtsmodel.IPBlockSeveritySignUpBlock
+	if err := suite.db.UpdateIPBlock(ctx, block, "severity"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	block, err = suite.db.MatchIPBlock(ctx, netip.MustParseAddr("192.0.2.200"))
+	suite.NoError(err)
+	suite.NotNil(block)
+	suite.Equal(gtsmodel.IPBlockSeveritySignUpBlock, block.Severity)
+}
+
+func TestIPBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(IPBlockTestSuite))
+}
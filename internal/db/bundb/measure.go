@@ -0,0 +1,443 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
+)
+
+type measureDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (m *measureDB) GetNewUserPoints(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user"))
+	return m.points(ctx, events(q, "user.account_id", "user.created_at", start, end), false)
+}
+
+func (m *measureDB) GetActiveUserPoints(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	return m.points(ctx, m.activity(start, end), false)
+}
+
+func (m *measureDB) CountActiveUsers(ctx context.Context, start time.Time, end time.Time) (int64, error) {
+	var count int64
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("(?) AS ?", m.activity(start, end), bun.Ident("event")).
+		ColumnExpr("COUNT(DISTINCT ?)", bun.Ident("event.account_id")).
+		Scan(ctx, &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (m *measureDB) GetCohortPoints(ctx context.Context, start time.Time, end time.Time, unit db.MeasureUnit) ([]db.CohortPoint, error) {
+	var rows []struct {
+		Cohort   string
+		Period   string
+		Accounts int64
+	}
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("(?) AS ?", m.activity(start, end), bun.Ident("event")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("users"), bun.Ident("user"),
+			bun.Ident("user.account_id"), bun.Ident("event.account_id"),
+		).
+		ColumnExpr("? AS ?", m.truncTime("user.created_at", unit), bun.Ident("cohort")).
+		ColumnExpr("? AS ?", m.truncTime("event.time", unit), bun.Ident("period")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("event.account_id"), bun.Ident("accounts")).
+		Where("? >= ?", bun.Ident("user.created_at"), start).
+		Where("? < ?", bun.Ident("user.created_at"), end).
+		GroupExpr("?, ?", bun.Ident("cohort"), bun.Ident("period")).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	points := make([]db.CohortPoint, 0, len(rows))
+	for _, row := range rows {
+		cohort, err := parseTruncTime(row.Cohort)
+		if err != nil {
+			return nil, err
+		}
+
+		period, err := parseTruncTime(row.Period)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, db.CohortPoint{
+			Cohort:   cohort,
+			Period:   period,
+			Accounts: row.Accounts,
+		})
+	}
+
+	return points, nil
+}
+
+func (m *measureDB) GetInteractionPoints(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	// Replies + boosts targeting local accounts' statuses.
+	statusQ := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IN (?)", bun.Ident("status.in_reply_to_account_id"), m.localAccountIDs()).
+				WhereOr("? IN (?)", bun.Ident("status.boost_of_account_id"), m.localAccountIDs())
+		})
+
+	// Faves targeting local accounts' statuses.
+	faveQ := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Where("? IN (?)", bun.Ident("status_fave.target_account_id"), m.localAccountIDs())
+
+	return m.points(ctx, unionAll(
+		events(statusQ, "status.account_id", "status.created_at", start, end),
+		events(faveQ, "status_fave.account_id", "status_fave.created_at", start, end),
+	), false)
+}
+
+func (m *measureDB) GetOpenedReportPoints(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report"))
+	return m.points(ctx, events(q, "report.account_id", "report.created_at", start, end), false)
+}
+
+func (m *measureDB) GetResolvedReportPoints(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report"))
+	return m.points(ctx, events(q, "report.action_taken_by_account_id", "report.action_taken_at", start, end), false)
+}
+
+func (m *measureDB) GetInstanceStatusPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? IN (?)", bun.Ident("status.account_id"), m.domainAccountIDs(domain))
+	return m.points(ctx, events(q, "status.account_id", "status.created_at", start, end), false)
+}
+
+func (m *measureDB) GetInstanceFollowerPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Where("? IN (?)", bun.Ident("follow.account_id"), m.domainAccountIDs(domain)).
+		Where("? IN (?)", bun.Ident("follow.target_account_id"), m.localAccountIDs())
+	return m.points(ctx, events(q, "follow.account_id", "follow.created_at", start, end), false)
+}
+
+func (m *measureDB) GetInstanceMediaPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("? + ? AS ?",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("value"),
+		).
+		Where("? IN (?)", bun.Ident("media_attachment.account_id"), m.domainAccountIDs(domain)).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true)
+	return m.points(ctx, events(q, "media_attachment.account_id", "media_attachment.created_at", start, end), true)
+}
+
+func (m *measureDB) GetTagPoints(ctx context.Context, tagID string, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID)
+	return m.points(ctx, events(q, "status.account_id", "status.created_at", start, end), false)
+}
+
+func (m *measureDB) GetLanguageDimension(ctx context.Context, start time.Time, end time.Time, limit int) ([]db.DimensionPoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.language"), bun.Ident("key")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? IS NOT NULL", bun.Ident("status.language")).
+		Group("status.language")
+	return m.dimension(ctx, q, "status.created_at", start, end, limit)
+}
+
+func (m *measureDB) GetServerDimension(ctx context.Context, start time.Time, end time.Time, limit int) ([]db.DimensionPoint, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("key")).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Group("account.domain")
+	return m.dimension(ctx, q, "status.created_at", start, end, limit)
+}
+
+func (m *measureDB) GetDatabaseSize(ctx context.Context) (int64, error) {
+	var (
+		size  int64
+		query string
+	)
+
+	switch m.db.Dialect().Name() {
+	case dialect.PG:
+		query = "SELECT pg_database_size(current_database())"
+	case dialect.SQLite:
+		query = "SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()"
+	default:
+		log.Panic(ctx, "db dialect was neither pg nor sqlite")
+	}
+
+	if err := m.db.NewRaw(query).Scan(ctx, &size); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func (m *measureDB) GetMediaSize(ctx context.Context) (int64, error) {
+	var attachmentsSize int64
+	if err := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Scan(ctx, &attachmentsSize); err != nil {
+		return 0, gtserror.Newf("error summing attachment sizes: %w", err)
+	}
+
+	var emojisSize int64
+	if err := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("emojis"), bun.Ident("emoji")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("emoji.image_file_size"),
+			bun.Ident("emoji.image_static_file_size"),
+		).
+		Where("? = ?", bun.Ident("emoji.cached"), true).
+		Scan(ctx, &emojisSize); err != nil {
+		return 0, gtserror.Newf("error summing emoji sizes: %w", err)
+	}
+
+	return attachmentsSize + emojisSize, nil
+}
+
+// events selects accountCol AS account_id and timeCol
+// AS time on the given query, restricted to [start, end),
+// for aggregation by points. The query may also select
+// a value column, if the points are to be summed.
+func events(
+	q *bun.SelectQuery,
+	accountCol string,
+	timeCol string,
+	start time.Time,
+	end time.Time,
+) *bun.SelectQuery {
+	return q.
+		ColumnExpr("? AS ?", bun.Ident(accountCol), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident(timeCol), bun.Ident("time")).
+		Where("? >= ?", bun.Ident(timeCol), start).
+		Where("? < ?", bun.Ident(timeCol), end)
+}
+
+// unionAll combines the given events queries. It's
+// done by hand, since bun parenthesizes each query
+// of a UNION, which SQLite doesn't accept.
+func unionAll(q1 *bun.SelectQuery, q2 *bun.SelectQuery) schema.QueryAppender {
+	return schema.SafeQuery("? UNION ALL ?", []interface{}{q1, q2})
+}
+
+// activity returns events for statuses, boosts
+// and faves created by local accounts.
+func (m *measureDB) activity(start time.Time, end time.Time) schema.QueryAppender {
+	statusQ := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.local"), true)
+
+	faveQ := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Where("? IN (?)", bun.Ident("status_fave.account_id"), m.localAccountIDs())
+
+	return unionAll(
+		events(statusQ, "status.account_id", "status.created_at", start, end),
+		events(faveQ, "status_fave.account_id", "status_fave.created_at", start, end),
+	)
+}
+
+// points aggregates the given events per UTC day,
+// counting them, or summing their value if sum is
+// true, along with the distinct accounts involved.
+func (m *measureDB) points(
+	ctx context.Context,
+	eventsQ schema.QueryAppender,
+	sum bool,
+) ([]db.MeasurePoint, error) {
+	value := schema.SafeQuery("COUNT(*)", nil)
+	if sum {
+		value = schema.SafeQuery("COALESCE(SUM(?), 0)", []interface{}{bun.Ident("event.value")})
+	}
+
+	var rows []struct {
+		Day      string
+		Value    int64
+		Accounts int64
+	}
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("(?) AS ?", eventsQ, bun.Ident("event")).
+		ColumnExpr("? AS ?", m.truncTime("event.time", db.MeasureDay), bun.Ident("day")).
+		ColumnExpr("? AS ?", value, bun.Ident("value")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("event.account_id"), bun.Ident("accounts")).
+		GroupExpr("?", bun.Ident("day")).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	points := make([]db.MeasurePoint, 0, len(rows))
+	for _, row := range rows {
+		day, err := parseTruncTime(row.Day)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, db.MeasurePoint{
+			Time:     day,
+			Value:    row.Value,
+			Accounts: row.Accounts,
+		})
+	}
+
+	return points, nil
+}
+
+// truncTime returns an expression truncating the given
+// timestamp column to the start (UTC) of its day or month,
+// formatted as YYYY-MM-DD, to be parsed with parseTruncTime.
+func (m *measureDB) truncTime(col string, unit db.MeasureUnit) schema.QueryAppender {
+	switch d := m.db.Dialect().Name(); d {
+	case dialect.PG:
+		field := "day"
+		if unit == db.MeasureMonth {
+			field = "month"
+		}
+		return schema.SafeQuery(
+			"to_char(date_trunc(?, ? AT TIME ZONE 'UTC'), 'YYYY-MM-DD')",
+			[]interface{}{field, bun.Ident(col)},
+		)
+
+	case dialect.SQLite:
+		// SQLite date functions convert
+		// stored timestamps to UTC.
+		format := "%Y-%m-%d"
+		if unit == db.MeasureMonth {
+			format = "%Y-%m-01"
+		}
+		return schema.SafeQuery("strftime(?, ?)", []interface{}{format, bun.Ident(col)})
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// parseTruncTime parses a date selected by truncTime.
+func parseTruncTime(date string) (time.Time, error) {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, gtserror.Newf("error parsing truncated time %q: %w", date, err)
+	}
+	return t, nil
+}
+
+// dimension counts rows of the given grouped query AS
+// value, restricted to [start, end) on timeCol, and
+// returns the top limit results by value.
+func (m *measureDB) dimension(
+	ctx context.Context,
+	q *bun.SelectQuery,
+	timeCol string,
+	start time.Time,
+	end time.Time,
+	limit int,
+) ([]db.DimensionPoint, error) {
+	var points []db.DimensionPoint
+
+	if err := q.
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("value")).
+		Where("? >= ?", bun.Ident(timeCol), start).
+		Where("? < ?", bun.Ident(timeCol), end).
+		OrderExpr("? DESC", bun.Ident("value")).
+		Limit(limit).
+		Scan(ctx, &points); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// localAccountIDs returns a subquery
+// selecting the IDs of all local accounts.
+func (m *measureDB) localAccountIDs() *bun.SelectQuery {
+	return m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.domain"))
+}
+
+// domainAccountIDs returns a subquery selecting
+// the IDs of all accounts on the given domain.
+func (m *measureDB) domainAccountIDs(domain string) *bun.SelectQuery {
+	return m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.domain"), domain)
+}
@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"slices"
 	"time"
@@ -295,3 +296,171 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetMediaBlobByHash(ctx context.Context, hash string) (*gtsmodel.MediaBlob, error) {
+	return m.getMediaBlob(ctx, "hash", hash)
+}
+
+func (m *mediaDB) GetMediaBlobByPath(ctx context.Context, path string) (*gtsmodel.MediaBlob, error) {
+	return m.getMediaBlob(ctx, "path", path)
+}
+
+func (m *mediaDB) getMediaBlob(ctx context.Context, column string, value string) (*gtsmodel.MediaBlob, error) {
+	blob := new(gtsmodel.MediaBlob)
+
+	if err := m.db.
+		NewSelect().
+		Model(blob).
+		Where("? = ?", bun.Ident("media_blob."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+func (m *mediaDB) PutMediaBlob(ctx context.Context, blob *gtsmodel.MediaBlob) error {
+	_, err := m.db.
+		NewInsert().
+		Model(blob).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaDB) RefMediaBlob(ctx context.Context, id string) (bool, error) {
+	// Only increment if something else still
+	// references the blob, otherwise it may be
+	// about to be removed by a concurrent unref.
+	res, err := m.db.
+		NewUpdate().
+		Table("media_blobs").
+		Set("? = ? + 1", bun.Ident("ref_count"), bun.Ident("ref_count")).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? > 0", bun.Ident("ref_count")).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (m *mediaDB) UnrefMediaBlob(ctx context.Context, id string) (int, error) {
+	var refs int
+
+	if err := m.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Decrement the reference
+		// count, getting what's left.
+		if err := tx.
+			NewUpdate().
+			Table("media_blobs").
+			Set("? = ? - 1", bun.Ident("ref_count"), bun.Ident("ref_count")).
+			Set("? = ?", bun.Ident("updated_at"), time.Now()).
+			Where("? = ?", bun.Ident("id"), id).
+			Where("? > 0", bun.Ident("ref_count")).
+			Returning("?", bun.Ident("ref_count")).
+			Scan(ctx, &refs); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// Already gone,
+				// nothing to do.
+				return nil
+			}
+			return err
+		}
+
+		if refs > 0 {
+			// Still in use.
+			return nil
+		}
+
+		// Nothing left, delete the blob.
+		_, err := tx.
+			NewDelete().
+			Table("media_blobs").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return refs, nil
+}
+
+func (m *mediaDB) GetMediaUploadByID(ctx context.Context, id string) (*gtsmodel.MediaUpload, error) {
+	upload := new(gtsmodel.MediaUpload)
+
+	if err := m.db.
+		NewSelect().
+		Model(upload).
+		Where("? = ?", bun.Ident("media_upload.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (m *mediaDB) GetExpiredMediaUploads(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.MediaUpload, error) {
+	uploads := make([]*gtsmodel.MediaUpload, 0, limit)
+
+	if err := m.db.
+		NewSelect().
+		Model(&uploads).
+		Where("? < ?", bun.Ident("media_upload.expires_at"), before).
+		Order("media_upload.expires_at DESC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (m *mediaDB) CountAccountMediaUploads(ctx context.Context, accountID string) (int, error) {
+	return m.db.
+		NewSelect().
+		Table("media_uploads").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? >= ?", bun.Ident("expires_at"), time.Now()).
+		Count(ctx)
+}
+
+func (m *mediaDB) PutMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload) error {
+	_, err := m.db.
+		NewInsert().
+		Model(upload).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaDB) UpdateMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload, columns ...string) error {
+	upload.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := m.db.
+		NewUpdate().
+		Model(upload).
+		Where("? = ?", bun.Ident("media_upload.id"), upload.ID).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaDB) DeleteMediaUploadByID(ctx context.Context, id string) error {
+	_, err := m.db.
+		NewDelete().
+		Table("media_uploads").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type MediaTestSuite struct {
@@ -50,6 +52,50 @@ func (suite *MediaTestSuite) TestGetCachedAttachmentsOlderThan() {
 	suite.Len(attachments, 3)
 }
 
+func (suite *MediaTestSuite) TestMediaBlobRefs() {
+	ctx := context.Background()
+
+	blob := &gtsmodel.MediaBlob{
+		ID:       "01HYQ1X8W7Z4Y0G3FJ7M5T2B9C",
+		Hash:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Path:     "01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01HYQ1X8W7Z4Y0G3FJ7M5T2B9C.jpg",
+		Size:     1024,
+		RefCount: 1,
+	}
+	suite.NoError(suite.db.PutMediaBlob(ctx, blob))
+
+	// Same hash can't be registered twice.
+	dupe := *blob
+	dupe.ID = "01HYQ1ZJ0RZ5VQ8N2K6D3E7W4A"
+	dupe.Path = "01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01HYQ1ZJ0RZ5VQ8N2K6D3E7W4A.jpg"
+	suite.ErrorIs(suite.db.PutMediaBlob(ctx, &dupe), db.ErrAlreadyExists)
+
+	ok, err := suite.db.RefMediaBlob(ctx, blob.ID)
+	suite.NoError(err)
+	suite.True(ok)
+
+	dbBlob, err := suite.db.GetMediaBlobByHash(ctx, blob.Hash)
+	suite.NoError(err)
+	suite.Equal(2, dbBlob.RefCount)
+
+	refs, err := suite.db.UnrefMediaBlob(ctx, blob.ID)
+	suite.NoError(err)
+	suite.Equal(1, refs)
+
+	refs, err = suite.db.UnrefMediaBlob(ctx, blob.ID)
+	suite.NoError(err)
+	suite.Zero(refs)
+
+	// Blob should be gone once unreferenced,
+	// and can no longer have refs added.
+	_, err = suite.db.GetMediaBlobByPath(ctx, blob.Path)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	ok, err = suite.db.RefMediaBlob(ctx, blob.ID)
+	suite.NoError(err)
+	suite.False(ok)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -0,0 +1,342 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type mediaHashBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlockByID(ctx context.Context, id string) (*gtsmodel.MediaHashBlock, error) {
+	return m.getMediaHashBlock(ctx, "media_hash_block.id", id)
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlockByHash(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error) {
+	return m.getMediaHashBlock(ctx, "media_hash_block.hash", hash)
+}
+
+func (m *mediaHashBlockDB) getMediaHashBlock(ctx context.Context, column string, value any) (*gtsmodel.MediaHashBlock, error) {
+	block := new(gtsmodel.MediaHashBlock)
+
+	if err := m.db.
+		NewSelect().
+		Model(block).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return block, nil
+	}
+
+	if err := m.PopulateMediaHashBlock(ctx, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaHashBlock, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		blocks = make([]*gtsmodel.MediaHashBlock, 0, limit)
+	)
+
+	q := m.db.
+		NewSelect().
+		Model(&blocks)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("media_hash_block.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("media_hash_block.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("media_hash_block.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("media_hash_block.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return blocks, nil
+	}
+
+	for _, block := range blocks {
+		if err := m.PopulateMediaHashBlock(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+func (m *mediaHashBlockDB) PopulateMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error {
+	var err error
+
+	if block.CreatedByAccount == nil {
+		// Fetch the account that created this block.
+		block.CreatedByAccount, err = m.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			block.CreatedByAccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error populating media hash block account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *mediaHashBlockDB) PutMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error {
+	if _, err := m.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+	m.state.Caches.MediaHashBlocks.Clear()
+	return nil
+}
+
+func (m *mediaHashBlockDB) DeleteMediaHashBlockByID(ctx context.Context, id string) error {
+	if err := m.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Drop matches of this block
+		// from the review queue too.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("media_hash_matches"), bun.Ident("media_hash_match")).
+			Where("? = ?", bun.Ident("media_hash_match.media_hash_block_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("media_hash_blocks"), bun.Ident("media_hash_block")).
+			Where("? = ?", bun.Ident("media_hash_block.id"), id).
+			Exec(ctx)
+		return err
+	}); err != nil {
+		return err
+	}
+	m.state.Caches.MediaHashBlocks.Clear()
+	return nil
+}
+
+func (m *mediaHashBlockDB) MatchMediaHashBlock(ctx context.Context, hash uint64, maxDistance int) (*gtsmodel.MediaHashBlock, int, error) {
+	return m.state.Caches.MediaHashBlocks.Match(hash, maxDistance, func() ([]*gtsmodel.MediaHashBlock, error) {
+		var blocks []*gtsmodel.MediaHashBlock
+
+		// Load all blocks into the cache.
+		if err := m.db.
+			NewSelect().
+			Model(&blocks).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return blocks, nil
+	})
+}
+
+func (m *mediaHashBlockDB) GetMediaHashMatchByID(ctx context.Context, id string) (*gtsmodel.MediaHashMatch, error) {
+	match := new(gtsmodel.MediaHashMatch)
+
+	if err := m.db.
+		NewSelect().
+		Model(match).
+		Where("? = ?", bun.Ident("media_hash_match.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return match, nil
+	}
+
+	if err := m.PopulateMediaHashMatch(ctx, match); err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+func (m *mediaHashBlockDB) GetMediaHashMatches(
+	ctx context.Context,
+	blockID string,
+	page *paging.Page,
+) ([]*gtsmodel.MediaHashMatch, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		matches = make([]*gtsmodel.MediaHashMatch, 0, limit)
+	)
+
+	q := m.db.
+		NewSelect().
+		Model(&matches)
+
+	if blockID != "" {
+		q = q.Where("? = ?", bun.Ident("media_hash_match.media_hash_block_id"), blockID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("media_hash_match.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("media_hash_match.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("media_hash_match.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("media_hash_match.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want matches
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(matches)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return matches, nil
+	}
+
+	for _, match := range matches {
+		if err := m.PopulateMediaHashMatch(ctx, match); err != nil {
+			return nil, err
+		}
+	}
+
+	return matches, nil
+}
+
+func (m *mediaHashBlockDB) PopulateMediaHashMatch(ctx context.Context, match *gtsmodel.MediaHashMatch) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if match.MediaHashBlock == nil {
+		// Matched block is not set, fetch from the database.
+		match.MediaHashBlock, err = m.GetMediaHashBlockByID(
+			gtscontext.SetBarebones(ctx),
+			match.MediaHashBlockID,
+		)
+		if err != nil {
+			errs.Appendf("error populating media hash match block: %w", err)
+		}
+	}
+
+	if match.Attachment == nil {
+		// Dropped attachment is not set, fetch from the database.
+		match.Attachment, err = m.state.DB.GetAttachmentByID(
+			gtscontext.SetBarebones(ctx),
+			match.AttachmentID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating media hash match attachment: %w", err)
+		}
+	}
+
+	if match.Account == nil {
+		// Owning account is not set, fetch from the database.
+		match.Account, err = m.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			match.AccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating media hash match account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (m *mediaHashBlockDB) PutMediaHashMatch(ctx context.Context, match *gtsmodel.MediaHashMatch) error {
+	_, err := m.db.
+		NewInsert().
+		Model(match).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaHashBlockDB) DeleteMediaHashMatchByID(ctx context.Context, id string) error {
+	_, err := m.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("media_hash_matches"), bun.Ident("media_hash_match")).
+		Where("? = ?", bun.Ident("media_hash_match.id"), id).
+		Exec(ctx)
+	return err
+}
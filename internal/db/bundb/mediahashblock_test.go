@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type MediaHashBlockTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *MediaHashBlockTestSuite) TestMatchMediaHashBlock() {
+	ctx := context.Background()
+
+	for _, block := range []*gtsmodel.MediaHashBlock{
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA1",
+			Hash:               "f0f0f0f0f0f0f0f0",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA2",
+			Hash:               "f0f0f0f0f0f0f0ff",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		if err := suite.db.PutMediaHashBlock(ctx, block); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	for _, test := range []struct {
+		hash     uint64
+		blockID  string
+		distance int
+	}{
+		{0xf0f0f0f0f0f0f0f0, "01HXAAAAAAAAAAAAAAAAAAAAA1", 0},
+		{0xf0f0f0f0f0f0f0f1, "01HXAAAAAAAAAAAAAAAAAAAAA1", 1},
+		{0xf0f0f0f0f0f0f0fe, "01HXAAAAAAAAAAAAAAAAAAAAA2", 1}, // closest wins
+		{0x0f0f0f0f0f0f0f0f, "", 0},
+	} {
+		block, distance, err := suite.db.MatchMediaHashBlock(ctx, test.hash, 6)
+		suite.NoError(err)
+
+		if test.blockID == "" {
+			suite.Nil(block, "hash: %x", test.hash)
+			continue
+		}
+
+		if suite.NotNil(block, "hash: %x", test.hash) {
+			suite.Equal(test.blockID, block.ID, "hash: %x", test.hash)
+			suite.Equal(test.distance, distance, "hash: %x", test.hash)
+		}
+	}
+
+	// Queue up a match of the first block.
+	if err := suite.db.PutMediaHashMatch(ctx, &gtsmodel.MediaHashMatch{
+		ID:               "01HXAAAAAAAAAAAAAAAAAAAAB1",
+		MediaHashBlockID: "01HXAAAAAAAAAAAAAAAAAAAAA1",
+		AttachmentID:     suite.testAttachments["remote_account_1_status_1_attachment_1"].ID,
+		AccountID:        suite.testAccounts["remote_account_1"].ID,
+		RemoteURL:        "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg",
+		Hash:             "f0f0f0f0f0f0f0f1",
+		Distance:         1,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	matches, err := suite.db.GetMediaHashMatches(ctx, "01HXAAAAAAAAAAAAAAAAAAAAA1", &paging.Page{})
+	suite.NoError(err)
+	suite.Len(matches, 1)
+
+	// Deleting the first block should be reflected
+	// immediately in matches, and drop its queue.
+	if err := suite.db.DeleteMediaHashBlockByID(ctx, "01HXAAAAAAAAAAAAAAAAAAAAA1"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	block, _, err := suite.db.MatchMediaHashBlock(ctx, 0xf0f0f0f0f0f0f0f0, 6)
+	suite.NoError(err)
+	suite.NotNil(block)
+	suite.Equal("01HXAAAAAAAAAAAAAAAAAAAAA2", block.ID)
+
+	matches, err = suite.db.GetMediaHashMatches(ctx, "", &paging.Page{})
+	suite.NoError(err)
+	suite.Empty(matches)
+}
+
+func TestMediaHashBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaHashBlockTestSuite))
+}
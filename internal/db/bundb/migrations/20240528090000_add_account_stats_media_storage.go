@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Bytes of media stored per account.
+			if _, err := tx.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? BIGINT NOT NULL DEFAULT 0",
+				bun.Ident("account_stats"), bun.Ident("media_storage_used"),
+			); err != nil {
+				return err
+			}
+
+			// Fill in storage used for existing
+			// local accounts, so quotas apply
+			// straight away instead of waiting
+			// for the stats to be regenerated.
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE ? SET ? = (SELECT COALESCE(SUM(? + ?), 0) FROM ? WHERE ? = ?) WHERE ? IN (SELECT ? FROM ? WHERE ? IS NULL)",
+				bun.Ident("account_stats"), bun.Ident("media_storage_used"),
+				bun.Ident("file_file_size"), bun.Ident("thumbnail_file_size"),
+				bun.Ident("media_attachments"),
+				bun.Ident("media_attachments.account_id"), bun.Ident("account_stats.account_id"),
+				bun.Ident("account_stats.account_id"),
+				bun.Ident("id"), bun.Ident("accounts"), bun.Ident("domain"),
+			); err != nil {
+				return err
+			}
+
+			// Quota override is null by
+			// default, ie., use role quota.
+			_, err := tx.ExecContext(ctx,
+				"ALTER TABLE ? ADD COLUMN ? BIGINT",
+				bun.Ident("account_stats"), bun.Ident("media_storage_quota"),
+			)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
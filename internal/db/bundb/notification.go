@@ -186,6 +186,16 @@ func (n *notificationDB) PopulateNotification(ctx context.Context, notif *gtsmod
 		}
 	}
 
+	if notif.AccountWarningID != "" && notif.AccountWarning == nil {
+		notif.AccountWarning, err = n.state.DB.GetAccountWarningByID(
+			gtscontext.SetBarebones(ctx),
+			notif.AccountWarningID,
+		)
+		if err != nil {
+			errs.Appendf("error populating notif account warning: %w", err)
+		}
+	}
+
 	return errs.Combine()
 }
 
@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type quarantinedStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+// newQuarantinedQ returns a subquery selecting the quarantine of
+// the status with ID in the given column, for use in "NOT EXISTS (?)"
+// clauses to exclude quarantined statuses from listing queries.
+func newQuarantinedQ(db *bun.DB, statusIDColumn string) *bun.SelectQuery {
+	return db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Column("quarantined_status.id").
+		Where("? = ?", bun.Ident("quarantined_status.status_id"), bun.Ident(statusIDColumn))
+}
+
+func (q *quarantinedStatusDB) GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error) {
+	return q.getQuarantinedStatus(ctx, "id", id)
+}
+
+func (q *quarantinedStatusDB) GetQuarantinedStatusByStatusID(ctx context.Context, statusID string) (*gtsmodel.QuarantinedStatus, error) {
+	return q.getQuarantinedStatus(ctx, "status_id", statusID)
+}
+
+func (q *quarantinedStatusDB) getQuarantinedStatus(ctx context.Context, column string, value string) (*gtsmodel.QuarantinedStatus, error) {
+	quarantined := new(gtsmodel.QuarantinedStatus)
+
+	if err := q.db.
+		NewSelect().
+		Model(quarantined).
+		Where("? = ?", bun.Ident("quarantined_status."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return quarantined, nil
+	}
+
+	if err := q.PopulateQuarantinedStatus(ctx, quarantined); err != nil {
+		return nil, err
+	}
+
+	return quarantined, nil
+}
+
+func (q *quarantinedStatusDB) IsStatusQuarantined(ctx context.Context, statusID string) (bool, error) {
+	return exists(ctx, q.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Column("quarantined_status.id").
+		Where("? = ?", bun.Ident("quarantined_status.status_id"), statusID),
+	)
+}
+
+func (q *quarantinedStatusDB) GetQuarantinedStatuses(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.QuarantinedStatus, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		quarantined = make([]*gtsmodel.QuarantinedStatus, 0, limit)
+	)
+
+	query := q.db.
+		NewSelect().
+		Model(&quarantined)
+
+	if accountID != "" {
+		query = query.Where("? = ?", bun.Ident("quarantined_status.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		query = query.Where("? < ?", bun.Ident("quarantined_status.id"), maxID)
+	}
+
+	if minID != "" {
+		query = query.Where("? > ?", bun.Ident("quarantined_status.id"), minID)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		query = query.Order("quarantined_status.id ASC")
+	} else {
+		// Page down.
+		query = query.Order("quarantined_status.id DESC")
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want entries
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(quarantined)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return quarantined, nil
+	}
+
+	for _, quarantinedStatus := range quarantined {
+		if err := q.PopulateQuarantinedStatus(ctx, quarantinedStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	return quarantined, nil
+}
+
+func (q *quarantinedStatusDB) PopulateQuarantinedStatus(ctx context.Context, quarantined *gtsmodel.QuarantinedStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if quarantined.Status == nil {
+		// Quarantined status is not set, fetch from the database.
+		quarantined.Status, err = q.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			quarantined.StatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating quarantined status: %w", err)
+		}
+	}
+
+	if quarantined.Account == nil {
+		// Sending account is not set, fetch from the database.
+		quarantined.Account, err = q.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			quarantined.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating quarantined status account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (q *quarantinedStatusDB) PutQuarantinedStatus(ctx context.Context, quarantined *gtsmodel.QuarantinedStatus) error {
+	if _, err := q.db.
+		NewInsert().
+		Model(quarantined).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate cached visibility of the
+	// status, which is now hidden from view.
+	q.state.Caches.Visibility.Invalidate("ItemID", quarantined.StatusID)
+
+	return nil
+}
+
+func (q *quarantinedStatusDB) DeleteQuarantinedStatusByID(ctx context.Context, id string) error {
+	var statusID string
+
+	// Perform DELETE on quarantined status,
+	// returning the status ID it was for.
+	if _, err := q.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Where("? = ?", bun.Ident("quarantined_status.id"), id).
+		Returning("?", bun.Ident("status_id")).
+		Exec(ctx, &statusID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Not an issue, only due
+			// to us doing a RETURNING.
+			err = nil
+		}
+		return err
+	}
+
+	if statusID != "" {
+		// Invalidate cached visibility of the
+		// status, which is now released to view.
+		q.state.Caches.Visibility.Invalidate("ItemID", statusID)
+	}
+
+	return nil
+}
@@ -150,7 +150,7 @@ func (r *reportDB) getReport(ctx context.Context, lookup string, dbQuery func(*g
 func (r *reportDB) PopulateReport(ctx context.Context, report *gtsmodel.Report) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(4)
+		errs = gtserror.NewMultiError(6)
 	)
 
 	if report.Account == nil {
@@ -211,6 +211,18 @@ func (r *reportDB) PopulateReport(ctx context.Context, report *gtsmodel.Report)
 		}
 	}
 
+	if report.AssignedAccountID != "" &&
+		report.AssignedAccount == nil {
+		// Report assigned account is not set, fetch from the database.
+		report.AssignedAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			report.AssignedAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating report assigned account: %w", err)
+		}
+	}
+
 	return errs.Combine()
 }
 
@@ -256,10 +268,87 @@ func (r *reportDB) DeleteReportByID(ctx context.Context, id string) error {
 		return err
 	}
 
-	// Finally delete report from DB.
-	_, err = r.db.NewDelete().
-		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
-		Where("? = ?", bun.Ident("report.id"), id).
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete any notes attached to the report.
+		if _, err := tx.NewDelete().
+			TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+			Where("? = ?", bun.Ident("report_note.report_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Finally delete report from DB.
+		_, err := tx.NewDelete().
+			TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
+			Where("? = ?", bun.Ident("report.id"), id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *reportDB) GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, error) {
+	note := new(gtsmodel.ReportNote)
+	if err := r.db.
+		NewSelect().
+		Model(note).
+		Where("? = ?", bun.Ident("report_note.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return note, nil
+	}
+
+	var err error
+	note.Account, err = r.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		note.AccountID,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error populating report note account: %w", err)
+	}
+
+	return note, nil
+}
+
+func (r *reportDB) GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, error) {
+	noteIDs := []string{}
+
+	if err := r.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+		Column("report_note.id").
+		Where("? = ?", bun.Ident("report_note.report_id"), reportID).
+		Order("report_note.id ASC").
+		Scan(ctx, &noteIDs); err != nil {
+		return nil, err
+	}
+
+	notes := make([]*gtsmodel.ReportNote, 0, len(noteIDs))
+	for _, id := range noteIDs {
+		note, err := r.GetReportNoteByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting report note %q: %v", id, err)
+			continue
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
+
+func (r *reportDB) PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) error {
+	_, err := r.db.NewInsert().Model(note).Exec(ctx)
+	return err
+}
+
+func (r *reportDB) DeleteReportNoteByID(ctx context.Context, id string) error {
+	_, err := r.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+		Where("? = ?", bun.Ident("report_note.id"), id).
 		Exec(ctx)
 	return err
 }
@@ -446,6 +446,10 @@ func (s *searchDB) SearchForTags(
 	// Search using LIKE for tags that start with `name`.
 	q = whereStartsLike(q, bun.Ident("tag.name"), name)
 
+	// Leave out tags that admins
+	// have marked as not listable.
+	q = q.Where("? = ?", bun.Ident("tag.listable"), true)
+
 	if limit > 0 {
 		// Limit amount of tags returned.
 		q = q.Limit(limit)
@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type SearchTestSuite struct {
@@ -129,6 +131,21 @@ func (suite *SearchTestSuite) TestSearchTags() {
 	suite.Len(tags, 0)
 }
 
+func (suite *SearchTestSuite) TestSearchTagsNotListable() {
+	tag := new(gtsmodel.Tag)
+	*tag = *suite.testTags["welcome"]
+	tag.Listable = util.Ptr(false)
+
+	if err := suite.db.UpdateTag(context.Background(), tag, "listable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tag should no longer be found.
+	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(tags, 0)
+}
+
 func TestSearchTestSuite(t *testing.T) {
 	suite.Run(t, new(SearchTestSuite))
 }
@@ -515,6 +515,15 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// delete this status from quarantine, if it's in there
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+			Where("? = ?", bun.Ident("quarantined_status.status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// Delete links between this status
 		// and any threads it was a part of.
 		_, err = tx.
@@ -19,9 +19,12 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -102,6 +105,55 @@ func (t *tagDB) GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, err
 	return tags, nil
 }
 
+func (t *tagDB) GetAllTags(ctx context.Context, page *paging.Page) ([]*gtsmodel.Tag, error) {
+	var (
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		tagIDs = make([]string, 0, limit)
+	)
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tags"), bun.Ident("tag")).
+		// Select only IDs from table
+		Column("tag.id")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("tag.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("tag.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("tag.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("tag.id DESC")
+	}
+
+	if err := q.Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want tags
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(tagIDs)
+	}
+
+	return t.GetTags(ctx, tagIDs)
+}
+
 func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 	// Normalize 'name' string before it enters
 	// the db, without changing tag we were given.
@@ -128,6 +180,26 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 	tag.UpdatedAt = t2.UpdatedAt
 	tag.Useable = t2.Useable
 	tag.Listable = t2.Listable
+	tag.Trendable = t2.Trendable
 
 	return nil
 }
+
+func (t *tagDB) UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) error {
+	tag.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Update the tag model in the database.
+	return t.state.Caches.GTS.Tag.Store(tag, func() error {
+		_, err := t.db.
+			NewUpdate().
+			Model(tag).
+			Where("? = ?", bun.Ident("tag.id"), tag.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
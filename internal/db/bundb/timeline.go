@@ -177,6 +177,8 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		// Ignore quarantined statuses.
+		Where("NOT EXISTS (?)", newQuarantinedQ(t.db, "status.id")).
 		// Select only IDs from table
 		Column("status.id")
 
@@ -432,6 +434,7 @@ func (t *timelineDB) GetListTimeline(
 func (t *timelineDB) GetTagTimeline(
 	ctx context.Context,
 	tagID string,
+	accountID string,
 	maxID string,
 	sinceID string,
 	minID string,
@@ -460,9 +463,16 @@ func (t *timelineDB) GetTagTimeline(
 		).
 		// Public only.
 		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Ignore quarantined statuses.
+		Where("NOT EXISTS (?)", newQuarantinedQ(t.db, "status.id")).
 		// This tag only.
 		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID)
 
+	if accountID != "" {
+		// return only statuses authored by accountID
+		q = q.Where("? = ?", bun.Ident("status.account_id"), accountID)
+	}
+
 	if maxID == "" || maxID >= id.Highest {
 		const future = 24 * time.Hour
 
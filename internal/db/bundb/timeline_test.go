@@ -146,6 +146,31 @@ func (suite *TimelineTestSuite) TestGetPublicTimelineWithFutureStatus() {
 	suite.checkStatuses(s, id.Highest, id.Lowest, suite.publicCount())
 }
 
+func (suite *TimelineTestSuite) TestGetPublicTimelineWithQuarantinedStatus() {
+	ctx := context.Background()
+
+	// Quarantine a public status,
+	// it shouldn't be retrieved.
+	quarantined := suite.testStatuses["remote_account_2_status_1"]
+	if err := suite.db.PutQuarantinedStatus(ctx, &gtsmodel.QuarantinedStatus{
+		ID:        id.NewULID(),
+		StatusID:  quarantined.ID,
+		AccountID: quarantined.AccountID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, status := range s {
+		suite.NotEqual(quarantined.ID, status.ID)
+	}
+	suite.checkStatuses(s, id.Highest, id.Lowest, suite.publicCount()-1)
+}
+
 func (suite *TimelineTestSuite) TestGetHomeTimeline() {
 	var (
 		ctx            = context.Background()
@@ -314,7 +339,7 @@ func (suite *TimelineTestSuite) TestGetTagTimelineNoParams() {
 		tag = suite.testTags["welcome"]
 	)
 
-	s, err := suite.db.GetTagTimeline(ctx, tag.ID, "", "", "", 1)
+	s, err := suite.db.GetTagTimeline(ctx, tag.ID, "", "", "", "", 1)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -323,6 +348,30 @@ func (suite *TimelineTestSuite) TestGetTagTimelineNoParams() {
 	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
 }
 
+func (suite *TimelineTestSuite) TestGetTagTimelineByAccount() {
+	var (
+		ctx = context.Background()
+		tag = suite.testTags["welcome"]
+	)
+
+	// The tagged status is by admin_account.
+	s, err := suite.db.GetTagTimeline(ctx, tag.ID, suite.testAccounts["admin_account"].ID, "", "", "", 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(s, id.Highest, id.Lowest, 1)
+	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
+
+	// So nothing is returned for other accounts.
+	s, err = suite.db.GetTagTimeline(ctx, tag.ID, suite.testAccounts["local_account_1"].ID, "", "", "", 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(s)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
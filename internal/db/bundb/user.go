@@ -114,6 +114,17 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, token string) (
 	)
 }
 
+func (u *userDB) GetUserByResetPasswordToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"ResetPasswordToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("reset_password_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
 func (u *userDB) getUser(ctx context.Context, lookup string, dbQuery func(*gtsmodel.User) error, keyParts ...any) (*gtsmodel.User, error) {
 	// Fetch user from database cache with loader callback.
 	user, err := u.state.Caches.GTS.User.LoadOne(lookup, func() (*gtsmodel.User, error) {
@@ -25,21 +25,30 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountWarning
 	Admin
 	Application
+	AuditLog
 	Basic
 	Domain
+	DomainLimit
 	Emoji
 	HeaderFilter
+	InboundPolicy
 	Instance
+	Invite
+	IPBlock
 	Filter
 	List
 	Marker
+	Measure
 	Media
+	MediaHashBlock
 	Mention
 	Move
 	Notification
 	Poll
+	QuarantinedStatus
 	Relationship
 	Report
 	Rule
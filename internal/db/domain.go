@@ -60,6 +60,21 @@ type Domain interface {
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	// CreateEmailDomainBlock puts the given email domain block into the database.
+	CreateEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error
+
+	// GetEmailDomainBlock returns one email domain block with the given domain, if it exists.
+	GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlockByID returns one email domain block with the given id, if it exists.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlocks returns all email domain blocks currently enforced by this instance.
+	GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error)
+
+	// DeleteEmailDomainBlockByID deletes the email domain block with the given id, if it exists.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) error
+
 	/*
 		Block/allow checking functions.
 	*/
@@ -72,6 +87,11 @@ type Domain interface {
 	// Will return true if even one of the given domains is blocked.
 	AreDomainsBlocked(ctx context.Context, domains []string) (bool, error)
 
+	// IsEmailDomainBlocked checks whether any of the given hosts (an email
+	// domain, and optionally the mail exchange hosts it resolves to) is, or
+	// is a subdomain of, a domain that's blocked for sign-ups.
+	IsEmailDomainBlocked(ctx context.Context, hosts ...string) (bool, error)
+
 	// IsURIBlocked calls IsDomainBlocked for the host of the given URI.
 	IsURIBlocked(ctx context.Context, uri *url.URL) (bool, error)
 
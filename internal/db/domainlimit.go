@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type DomainLimit interface {
+	// GetDomainLimitByID fetches the domain limit with the given ID from the database.
+	GetDomainLimitByID(ctx context.Context, id string) (*gtsmodel.DomainLimit, error)
+
+	// GetDomainLimitByDomain fetches the domain limit for
+	// exactly the given domain (not its parents) from the database.
+	GetDomainLimitByDomain(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error)
+
+	// GetDomainLimits fetches a page of domain
+	// limits from the database, newest first.
+	GetDomainLimits(ctx context.Context, page *paging.Page) ([]*gtsmodel.DomainLimit, error)
+
+	// PopulateDomainLimit populates the struct pointers on the given domain limit.
+	PopulateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) error
+
+	// PutDomainLimit inserts the given domain limit into the database.
+	PutDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) error
+
+	// UpdateDomainLimit updates the given domain limit in the database, only updating given columns if provided.
+	UpdateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit, columns ...string) error
+
+	// DeleteDomainLimitByID deletes the domain limit with the given ID from the database.
+	DeleteDomainLimitByID(ctx context.Context, id string) error
+
+	// MatchDomainLimit returns the domain limit applying to the
+	// given domain or one of its parent domains, or nil if none
+	// do. This is served from an in-memory cache of limits.
+	MatchDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error)
+}
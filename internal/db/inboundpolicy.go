@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache/inboundpolicy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type InboundPolicy interface {
+	// GetInboundPolicyByID fetches the inbound policy with the given ID from the database.
+	GetInboundPolicyByID(ctx context.Context, id string) (*gtsmodel.InboundPolicy, error)
+
+	// GetInboundPolicies fetches all inbound policies from
+	// the database, in the order in which they are applied.
+	GetInboundPolicies(ctx context.Context) ([]*gtsmodel.InboundPolicy, error)
+
+	// PopulateInboundPolicy populates the struct pointers on the given inbound policy.
+	PopulateInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy) error
+
+	// PutInboundPolicy inserts the given inbound policy into the database.
+	PutInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy) error
+
+	// UpdateInboundPolicy updates the given inbound policy in the database, only updating given columns if provided.
+	UpdateInboundPolicy(ctx context.Context, policy *gtsmodel.InboundPolicy, columns ...string) error
+
+	// DeleteInboundPolicyByID deletes the inbound policy with the given ID from the database.
+	DeleteInboundPolicyByID(ctx context.Context, id string) error
+
+	// GetEnabledInboundPolicies returns the enabled inbound policies, in the
+	// order in which they are applied, with their matchers pre-compiled.
+	// This is served from an in-memory cache of policies, which is
+	// reloaded whenever policies are changed.
+	GetEnabledInboundPolicies(ctx context.Context) ([]inboundpolicy.Policy, error)
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Invite handles getting/creation/deletion/updating of sign-up invites.
+type Invite interface {
+	// GetInviteByID gets one invite by its db id.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode gets one invite by its invite code.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites gets invites created by the given accountID, or
+	// by all accounts if accountID is empty, newest first.
+	GetInvites(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Invite, error)
+
+	// PopulateInvite populates the struct pointers on the given invite.
+	PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// PutInvite puts the given invite in the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates one invite by its db id.
+	// If no columns are specified, every column is updated.
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// IncrementInviteUses atomically increments the uses count
+	// of the given invite, returning false if the invite was
+	// already used up or expired by the time of incrementing.
+	IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) (bool, error)
+
+	// DecrementInviteUses atomically decrements the uses
+	// count of the given invite, giving back a use taken by
+	// IncrementInviteUses for a sign-up that then failed.
+	DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DeleteInviteByID deletes one invite by its db id.
+	DeleteInviteByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type IPBlock interface {
+	// GetIPBlockByID fetches the IP block with the given ID from the database.
+	GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlockByIP fetches the IP block for exactly the given
+	// address range (in CIDR notation) from the database.
+	GetIPBlockByIP(ctx context.Context, ip string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlocks fetches a page of IP blocks
+	// from the database, newest first.
+	GetIPBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.IPBlock, error)
+
+	// PopulateIPBlock populates the struct pointers on the given IP block.
+	PopulateIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error
+
+	// PutIPBlock inserts the given IP block into the database.
+	PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error
+
+	// UpdateIPBlock updates the given IP block in the database, only updating given columns if provided.
+	UpdateIPBlock(ctx context.Context, block *gtsmodel.IPBlock, columns ...string) error
+
+	// DeleteIPBlockByID deletes the IP block with the given ID from the database.
+	DeleteIPBlockByID(ctx context.Context, id string) error
+
+	// MatchIPBlock returns the most severe unexpired IP block
+	// whose range contains the given address, or nil if none
+	// do. This is served from an in-memory cache of blocks.
+	MatchIPBlock(ctx context.Context, addr netip.Addr) (*gtsmodel.IPBlock, error)
+}
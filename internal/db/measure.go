@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// MeasurePoint is the aggregate of the events
+// of an admin dashboard measure on one UTC day.
+type MeasurePoint struct {
+	// Time is midnight (UTC) at
+	// the start of the day.
+	Time time.Time
+
+	// Value is the number of events on the day,
+	// or the sum of their values (eg., sizes in
+	// bytes) for measures where that's relevant.
+	Value int64
+
+	// Accounts is the number of distinct accounts
+	// responsible for the events on the day.
+	Accounts int64
+}
+
+// MeasureUnit is the length of the periods
+// that cohort points are aggregated into.
+type MeasureUnit int
+
+const (
+	MeasureDay MeasureUnit = iota
+	MeasureMonth
+)
+
+// CohortPoint is the number of local users who signed
+// up in one period (their cohort) and who were active,
+// ie., posted, boosted or faved, in another period.
+type CohortPoint struct {
+	// Cohort is the start (UTC) of
+	// the period the users signed up.
+	Cohort time.Time
+
+	// Period is the start (UTC) of the
+	// period the users were active in.
+	Period time.Time
+
+	// Accounts is the number of distinct
+	// users in the cohort active in the period.
+	Accounts int64
+}
+
+// DimensionPoint is one keyed value
+// used to build admin dashboard dimensions.
+type DimensionPoint struct {
+	Key   string
+	Value int64
+}
+
+// Measure contains functions for gathering data used
+// to show instance health over time to admins. Each
+// Get...Points function aggregates events with a time
+// in the half-open interval [start, end) per UTC day,
+// returning unsorted points for days with any events.
+type Measure interface {
+	// GetNewUserPoints counts local users created.
+	GetNewUserPoints(ctx context.Context, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetActiveUserPoints counts statuses, boosts and faves
+	// created by local accounts; Accounts of each point is
+	// the number of distinct local users active that day.
+	GetActiveUserPoints(ctx context.Context, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// CountActiveUsers returns the number of distinct local
+	// accounts that created a status, boost or fave in
+	// [start, end), which can't be derived from daily points.
+	CountActiveUsers(ctx context.Context, start time.Time, end time.Time) (int64, error)
+
+	// GetCohortPoints returns the number of local users who
+	// signed up in each period of [start, end), by unit,
+	// that were active in each period of [start, end).
+	GetCohortPoints(ctx context.Context, start time.Time, end time.Time, unit MeasureUnit) ([]CohortPoint, error)
+
+	// GetInteractionPoints counts replies, boosts
+	// and faves targeting statuses by local accounts.
+	GetInteractionPoints(ctx context.Context, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetOpenedReportPoints counts reports created.
+	GetOpenedReportPoints(ctx context.Context, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetResolvedReportPoints counts reports resolved.
+	GetResolvedReportPoints(ctx context.Context, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetInstanceStatusPoints counts statuses
+	// created by accounts on the given domain.
+	GetInstanceStatusPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetInstanceFollowerPoints counts follows of local
+	// accounts created by accounts on the given domain.
+	GetInstanceFollowerPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetInstanceMediaPoints sums the stored size in bytes of
+	// cached media attachments from the given domain.
+	GetInstanceMediaPoints(ctx context.Context, domain string, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetTagPoints counts statuses using the given tag;
+	// Accounts of each point is the number of authors.
+	GetTagPoints(ctx context.Context, tagID string, start time.Time, end time.Time) ([]MeasurePoint, error)
+
+	// GetLanguageDimension returns the number of local statuses created
+	// in [start, end) per language, highest first, up to limit entries.
+	GetLanguageDimension(ctx context.Context, start time.Time, end time.Time, limit int) ([]DimensionPoint, error)
+
+	// GetServerDimension returns the number of remote statuses created
+	// in [start, end) per domain, highest first, up to limit entries.
+	GetServerDimension(ctx context.Context, start time.Time, end time.Time, limit int) ([]DimensionPoint, error)
+
+	// GetDatabaseSize returns the size of the database in bytes.
+	GetDatabaseSize(ctx context.Context) (int64, error)
+
+	// GetMediaSize returns the total size in bytes of media
+	// attachments and emojis currently held in storage.
+	GetMediaSize(ctx context.Context) (int64, error)
+}
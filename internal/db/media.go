@@ -51,4 +51,43 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetMediaBlobByHash gets the media blob with the given content hash.
+	GetMediaBlobByHash(ctx context.Context, hash string) (*gtsmodel.MediaBlob, error)
+
+	// GetMediaBlobByPath gets the media blob stored at the given storage path.
+	GetMediaBlobByPath(ctx context.Context, path string) (*gtsmodel.MediaBlob, error)
+
+	// PutMediaBlob inserts the given media blob into the database.
+	PutMediaBlob(ctx context.Context, blob *gtsmodel.MediaBlob) error
+
+	// RefMediaBlob increments the reference count of the media blob with the
+	// given ID. Returns false if the blob is gone, or has no references left
+	// (ie., it's in the process of being removed), and so can't be shared.
+	RefMediaBlob(ctx context.Context, id string) (bool, error)
+
+	// UnrefMediaBlob decrements the reference count of the media blob with the
+	// given ID, returning the number of references left. If none are left the
+	// blob is deleted from the database, and its file should be removed.
+	UnrefMediaBlob(ctx context.Context, id string) (int, error)
+
+	// GetMediaUploadByID gets the resumable media upload with the given ID.
+	GetMediaUploadByID(ctx context.Context, id string) (*gtsmodel.MediaUpload, error)
+
+	// GetExpiredMediaUploads gets up to limit media uploads that expired before the given time.
+	// These will be returned in order of upload.expires_at descending (i.e. newest to oldest).
+	GetExpiredMediaUploads(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.MediaUpload, error)
+
+	// CountAccountMediaUploads returns the number of unexpired media uploads by the given account.
+	CountAccountMediaUploads(ctx context.Context, accountID string) (int, error)
+
+	// PutMediaUpload inserts the given media upload into the database.
+	PutMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload) error
+
+	// UpdateMediaUpload updates the given media upload in the database.
+	UpdateMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload, columns ...string) error
+
+	// DeleteMediaUploadByID deletes the media upload with the given ID from the database.
+	// Its staged chunks should already have been removed from storage.
+	DeleteMediaUploadByID(ctx context.Context, id string) error
 }
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type MediaHashBlock interface {
+	// GetMediaHashBlockByID fetches the media hash block with the given ID from the database.
+	GetMediaHashBlockByID(ctx context.Context, id string) (*gtsmodel.MediaHashBlock, error)
+
+	// GetMediaHashBlockByHash fetches the media hash block for
+	// exactly the given (hex formatted) hash from the database.
+	GetMediaHashBlockByHash(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error)
+
+	// GetMediaHashBlocks fetches a page of media hash
+	// blocks from the database, newest first.
+	GetMediaHashBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.MediaHashBlock, error)
+
+	// PopulateMediaHashBlock populates the struct pointers on the given media hash block.
+	PopulateMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error
+
+	// PutMediaHashBlock inserts the given media hash block into the database.
+	PutMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error
+
+	// DeleteMediaHashBlockByID deletes the media hash block with the given ID
+	// from the database, along with any review queue matches of the block.
+	DeleteMediaHashBlockByID(ctx context.Context, id string) error
+
+	// MatchMediaHashBlock returns the media hash block closest to the given
+	// perceptual hash within maxDistance bits, and the distance between them,
+	// or nil if none are close enough. This is served from an in-memory cache.
+	MatchMediaHashBlock(ctx context.Context, hash uint64, maxDistance int) (*gtsmodel.MediaHashBlock, int, error)
+
+	// GetMediaHashMatchByID fetches the media hash match with the given ID from the database.
+	GetMediaHashMatchByID(ctx context.Context, id string) (*gtsmodel.MediaHashMatch, error)
+
+	// GetMediaHashMatches fetches a page of media hash matches from the database,
+	// newest first. If blockID is set, only matches of that block are returned.
+	GetMediaHashMatches(ctx context.Context, blockID string, page *paging.Page) ([]*gtsmodel.MediaHashMatch, error)
+
+	// PopulateMediaHashMatch populates the struct pointers on the given media hash match.
+	PopulateMediaHashMatch(ctx context.Context, match *gtsmodel.MediaHashMatch) error
+
+	// PutMediaHashMatch inserts the given media hash match into the database.
+	PutMediaHashMatch(ctx context.Context, match *gtsmodel.MediaHashMatch) error
+
+	// DeleteMediaHashMatchByID deletes the media hash match with the
+	// given ID, which dismisses it from the review queue. The dropped
+	// media attachment itself is left as it is.
+	DeleteMediaHashMatchByID(ctx context.Context, id string) error
+}
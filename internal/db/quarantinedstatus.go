@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type QuarantinedStatus interface {
+	// GetQuarantinedStatusByID fetches the quarantined status with the given ID from the database.
+	GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error)
+
+	// GetQuarantinedStatusByStatusID fetches the quarantine of the status with the given ID from the database.
+	GetQuarantinedStatusByStatusID(ctx context.Context, statusID string) (*gtsmodel.QuarantinedStatus, error)
+
+	// IsStatusQuarantined returns whether the status with the given ID is held in quarantine.
+	IsStatusQuarantined(ctx context.Context, statusID string) (bool, error)
+
+	// GetQuarantinedStatuses fetches a page of quarantined statuses from the database, newest
+	// first. If accountID is set, only statuses sent by that account are returned.
+	GetQuarantinedStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.QuarantinedStatus, error)
+
+	// PopulateQuarantinedStatus populates the struct pointers on the given quarantined status.
+	PopulateQuarantinedStatus(ctx context.Context, quarantined *gtsmodel.QuarantinedStatus) error
+
+	// PutQuarantinedStatus inserts the given quarantined status into the database.
+	PutQuarantinedStatus(ctx context.Context, quarantined *gtsmodel.QuarantinedStatus) error
+
+	// DeleteQuarantinedStatusByID deletes the quarantined status with the given ID,
+	// which releases the status from quarantine. The status itself is not deleted.
+	DeleteQuarantinedStatusByID(ctx context.Context, id string) error
+}
@@ -45,6 +45,20 @@ type Report interface {
 	// as a specific column.
 	UpdateReport(ctx context.Context, report *gtsmodel.Report, columns ...string) (*gtsmodel.Report, error)
 
-	// DeleteReportByID deletes report with the given id.
+	// DeleteReportByID deletes report with the given id,
+	// along with any moderator notes attached to it.
 	DeleteReportByID(ctx context.Context, id string) error
+
+	// GetReportNoteByID gets one report note by its db id.
+	GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, error)
+
+	// GetReportNotes gets all notes attached to the
+	// given report, oldest first, with accounts populated.
+	GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, error)
+
+	// PutReportNote puts the given report note in the database.
+	PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) error
+
+	// DeleteReportNoteByID deletes report note with the given id.
+	DeleteReportNoteByID(ctx context.Context, id string) error
 }
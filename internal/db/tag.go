@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Tag contains functions for getting/creating tags in the database.
@@ -34,6 +35,13 @@ type Tag interface {
 	// PutTag inserts the given tag in the database.
 	PutTag(ctx context.Context, tag *gtsmodel.Tag) error
 
+	// UpdateTag updates the given tag in the database,
+	// optionally only updating the given columns.
+	UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) error
+
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// GetAllTags gets a page of all tags known to this instance.
+	GetAllTags(ctx context.Context, page *paging.Page) ([]*gtsmodel.Tag, error)
 }
@@ -50,6 +50,7 @@ type Timeline interface {
 	GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
 
 	// GetTagTimeline returns a slice of public-visibility statuses that use the given tagID.
+	// If accountID is set, only statuses authored by that account are returned.
 	// Statuses should be returned in descending order of when they were created (newest first).
-	GetTagTimeline(ctx context.Context, tagID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
+	GetTagTimeline(ctx context.Context, tagID string, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
 }
@@ -33,6 +33,9 @@ type ConfirmData struct {
 	// Link to present to the receiver to click on and do the confirmation.
 	// Should be a full link with protocol eg., https://example.org/confirm_email?token=some-long-token
 	ConfirmLink string
+	// Set to true if the confirmation is for a change of
+	// email address by an existing user, not a new sign-up.
+	EmailChange bool
 }
 
 func (s *sender) SendConfirmEmail(toAddress string, data ConfirmData) error {
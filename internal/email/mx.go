@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package email
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// MXResolver looks up the mail exchange
+// (MX) hosts for a given email domain.
+//
+// *net.Resolver implements this interface;
+// tests can provide their own implementation.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// mxLookupTimeout is the longest we'll wait for
+// an MX lookup before just giving up on it.
+const mxLookupTimeout = 5 * time.Second
+
+// MailHosts returns the domain part of the given email
+// address, followed by the hosts of any MX records that
+// the domain resolves to using the given resolver.
+//
+// MX lookup failures are logged but otherwise ignored,
+// since plenty of domains don't publish MX records
+// and still receive mail. If resolver is nil, no MX
+// lookup is performed.
+func MailHosts(ctx context.Context, resolver MXResolver, address string) ([]string, error) {
+	addr, err := mail.ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Domain is the part after the last '@'.
+	domain := addr.Address[strings.LastIndexByte(addr.Address, '@')+1:]
+	domain = strings.ToLower(domain)
+	hosts := []string{domain}
+
+	if resolver == nil {
+		return hosts, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mxLookupTimeout)
+	defer cancel()
+
+	mxs, err := resolver.LookupMX(ctx, domain)
+	if err != nil {
+		log.Debugf(ctx, "error looking up mx records for %s: %v", domain, err)
+	}
+
+	for _, mx := range mxs {
+		host := strings.ToLower(strings.TrimSuffix(mx.Host, "."))
+		if host == "" || host == domain {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
@@ -52,6 +52,10 @@ func (s *noopSender) SendConfirmEmail(toAddress string, data ConfirmData) error
 	return s.sendTemplate(confirmTemplate, confirmSubject, data, toAddress)
 }
 
+func (s *noopSender) SendEmailChangeEmail(toAddress string, data EmailChangeData) error {
+	return s.sendTemplate(emailChangeTemplate, emailChangeSubject, data, toAddress)
+}
+
 func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
 	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
 }
@@ -68,6 +72,10 @@ func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedDa
 	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendAccountWarningEmail(toAddress string, data AccountWarningData) error {
+	return s.sendTemplate(accountWarningTemplate, accountWarningSubject, data, toAddress)
+}
+
 func (s *noopSender) SendNewSignupEmail(toAddresses []string, data NewSignupData) error {
 	return s.sendTemplate(newSignupTemplate, newSignupSubject, data, toAddresses...)
 }
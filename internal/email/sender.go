@@ -30,6 +30,10 @@ type Sender interface {
 	// SendConfirmEmail sends a 'please confirm your email' style email to the given toAddress, with the given data.
 	SendConfirmEmail(toAddress string, data ConfirmData) error
 
+	// SendEmailChangeEmail sends an email to the given (current) address of
+	// a user, letting them know that a change of email address was requested.
+	SendEmailChangeEmail(toAddress string, data EmailChangeData) error
+
 	// SendResetEmail sends a 'reset your password' style email to the given toAddress, with the given data.
 	SendResetEmail(toAddress string, data ResetData) error
 
@@ -47,6 +51,10 @@ type Sender interface {
 	// know that a report that they created has been closed / resolved by an admin.
 	SendReportClosedEmail(toAddress string, data ReportClosedData) error
 
+	// SendAccountWarningEmail sends an email notification to the given address, letting
+	// them know that an admin has issued a moderation warning to their account.
+	SendAccountWarningEmail(toAddress string, data AccountWarningData) error
+
 	// SendNewSignupEmail sends an email notification to the given addresses,
 	// letting them know that a new sign-up has been submitted to the instance.
 	//
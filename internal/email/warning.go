@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	accountWarningTemplate = "email_account_warning.tmpl"
+	accountWarningSubject  = "GoToSocial Moderation Warning"
+)
+
+type AccountWarningData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Human-readable description of the action
+	// taken against the account, if any.
+	// Can be empty string for plain warnings.
+	Action string
+	// Explanation of the warning left by the admin.
+	Text string
+	// Text of any instance rules that were broken.
+	Rules []string
+}
+
+func (s *sender) SendAccountWarningEmail(toAddress string, data AccountWarningData) error {
+	return s.sendTemplate(accountWarningTemplate, accountWarningSubject, data, toAddress)
+}
@@ -231,6 +231,57 @@ func (d *Dereferencer) RefreshStatus(
 	return latest, statusable, nil
 }
 
+// FetchStatusable dereferences the latest version of the remote status
+// at uri, WITHOUT storing anything in the database. This allows callers
+// to check (or rewrite) the statusable before passing it to RefreshStatus().
+func (d *Dereferencer) FetchStatusable(ctx context.Context, requestUser string, uri *url.URL) (ap.Statusable, error) {
+	// Fetch a transport for requesting username.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return nil, gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	// Check whether this status URI is a blocked domain / subdomain.
+	if blocked, err := d.state.DB.IsDomainBlocked(ctx, uri.Host); err != nil {
+		return nil, gtserror.Newf("error checking blocked domain: %w", err)
+	} else if blocked {
+		err = gtserror.Newf("%s is blocked", uri.Host)
+		return nil, gtserror.SetUnretrievable(err)
+	}
+
+	// Dereference latest version of the status.
+	rsp, err := tsport.Dereference(ctx, uri)
+	if err != nil {
+		err := gtserror.Newf("error dereferencing %s: %w", uri, err)
+		return nil, gtserror.SetUnretrievable(err)
+	}
+
+	// Attempt to resolve ActivityPub status from response.
+	statusable, err := ap.ResolveStatusable(ctx, rsp.Body)
+
+	// Tidy up now done.
+	_ = rsp.Body.Close()
+
+	if err != nil {
+		// ResolveStatusable will set gtserror.WrongType
+		// on the returned error, so we don't need to do it here.
+		return nil, gtserror.Newf("error resolving statusable %s: %w", uri, err)
+	}
+
+	// The statusable will be trusted as-is from here,
+	// so ensure it's hosted where we finally fetched
+	// it from (ie., after following any redirects).
+	if statusID := ap.GetJSONLDId(statusable); statusID == nil ||
+		statusID.Host != rsp.Request.URL.Host {
+		return nil, gtserror.Newf(
+			"dereferenced status id %s does not match %s",
+			statusID, rsp.Request.URL,
+		)
+	}
+
+	return statusable, nil
+}
+
 // RefreshStatusAsync is functionally equivalent to RefreshStatus(), except that ALL
 // dereferencing is queued for asynchronous processing, (both thread AND status).
 func (d *Dereferencer) RefreshStatusAsync(
@@ -1000,7 +1051,10 @@ func (d *Dereferencer) fetchStatusAttachments(ctx context.Context, tsport transp
 
 		// Look for existing media attachment with remote URL first.
 		existing, ok := existing.GetAttachmentByRemoteURL(attachment.RemoteURL)
-		if ok && existing.ID != "" && *existing.Cached {
+		if ok && existing.ID != "" &&
+			(*existing.Cached || util.PtrValueOr(existing.Blocked, false)) {
+			// Cached already, or dropped for
+			// matching a media hash block.
 			status.Attachments[i] = existing
 			status.AttachmentIDs[i] = existing.ID
 			continue
@@ -55,6 +55,13 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 		return nil
 	}
 
+	// Run the accept through inbound
+	// policies before going any further.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAcct, ap.ActivityAccept, accept)
+	if err != nil || rejected {
+		return err
+	}
+
 	// Iterate all provided objects in the activity.
 	for _, object := range ap.ExtractObjects(accept) {
 
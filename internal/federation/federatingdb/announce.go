@@ -69,6 +69,13 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Run the announce through inbound
+	// policies before going any further.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAcct, ap.ActivityAnnounce, announce)
+	if err != nil || rejected {
+		return err
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -124,6 +124,13 @@ func (f *federatingDB) activityBlock(ctx context.Context, asType vocab.Type, rec
 		)
 	}
 
+	// Run the block through inbound
+	// policies before storing it.
+	rejected, err := f.rejectedByPolicy(ctx, requesting, ap.ActivityBlock, blockable)
+	if err != nil || rejected {
+		return err
+	}
+
 	block.ID = id.NewULID()
 
 	if err := f.state.DB.PutBlock(ctx, block); err != nil {
@@ -296,6 +303,12 @@ func (f *federatingDB) createPollOptionables(
 		choices = append(choices, choice)
 	}
 
+	// Run the vote(s) through inbound policies.
+	rejected, err := f.rejectedByPolicy(ctx, requester, ap.ActivityCreate, nil)
+	if err != nil || rejected {
+		return err
+	}
+
 	// Enqueue message to the fedi API worker with poll vote(s).
 	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
 		APActivityType: ap.ActivityCreate,
@@ -328,12 +341,16 @@ func (f *federatingDB) createStatusable(
 ) error {
 	// Check whether this status is both
 	// relevant, and doesn't look like spam.
-	err := f.spamFilter.StatusableOK(ctx,
+	score, err := f.spamFilter.StatusableOK(ctx,
 		receiver,
 		requester,
 		statusable,
 	)
 
+	// Set if the status
+	// looks like spam.
+	var quarantine *gtsmodel.QuarantinedStatus
+
 	switch {
 	case err == nil:
 		// No problem!
@@ -355,12 +372,17 @@ func (f *federatingDB) createStatusable(
 		// Log this at a higher level so admins can
 		// gauge how much spam is being sent to them.
 		//
-		// TODO: add Prometheus metrics for this.
+		// Rather than dropping the status, process
+		// it as normal but hold it in quarantine,
+		// so admins can release any false positives.
 		log.Infof(ctx,
-			"status %s looked like spam (%v); dropping it",
+			"status %s looked like spam (%v); quarantining it",
 			ap.GetJSONLDId(statusable), err,
 		)
-		return nil
+		quarantine = &gtsmodel.QuarantinedStatus{
+			Score:   score.Total,
+			Signals: score.Signals,
+		}
 
 	default:
 		// A real error has occurred.
@@ -373,6 +395,9 @@ func (f *federatingDB) createStatusable(
 	// In other words, don't automatically trust whoever sent
 	// this status to us, but fetch the authentic article from
 	// the server it originated from.
+	//
+	// Inbound policies are applied to forwards by the
+	// processor, once it has the authentic statusable.
 	if forwarded {
 
 		// Pass the statusable URI (APIri) into the processor
@@ -383,12 +408,39 @@ func (f *federatingDB) createStatusable(
 			APIRI:          ap.GetJSONLDId(statusable),
 			APObject:       nil,
 			GTSModel:       nil,
+			Quarantine:     quarantine,
 			Receiving:      receiver,
 			Requesting:     requester,
 		})
 		return nil
 	}
 
+	// Run the status through inbound policies before anything
+	// gets stored. This may rewrite statusable in place.
+	verdict, err := f.policyFilter.Apply(ctx,
+		requester,
+		ap.ActivityCreate,
+		statusable,
+	)
+	if err != nil {
+		return gtserror.Newf("error applying inbound policies: %w", err)
+	}
+
+	if verdict.Reject {
+		log.Infof(ctx,
+			"status %s rejected by inbound policy; dropping it",
+			ap.GetJSONLDId(statusable),
+		)
+		return nil
+	}
+
+	if verdict.Quarantine {
+		if quarantine == nil {
+			quarantine = new(gtsmodel.QuarantinedStatus)
+		}
+		quarantine.PolicyID = verdict.QuarantinePolicyID
+	}
+
 	// Do the rest of the processing asynchronously. The processor
 	// will handle inserting/updating + further dereferencing the status.
 	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
@@ -397,6 +449,7 @@ func (f *federatingDB) createStatusable(
 		APIRI:          nil,
 		GTSModel:       nil,
 		APObject:       statusable,
+		Quarantine:     quarantine,
 		Receiving:      receiver,
 		Requesting:     requester,
 	})
@@ -433,6 +486,13 @@ func (f *federatingDB) activityFollow(ctx context.Context, asType vocab.Type, re
 		)
 	}
 
+	// Run the follow through inbound
+	// policies before storing it.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAccount, ap.ActivityFollow, follow)
+	if err != nil || rejected {
+		return err
+	}
+
 	followRequest.ID = id.NewULID()
 
 	if err := f.state.DB.PutFollowRequest(ctx, followRequest); err != nil {
@@ -472,6 +532,13 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		)
 	}
 
+	// Run the like through inbound
+	// policies before storing it.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAccount, ap.ActivityLike, like)
+	if err != nil || rejected {
+		return err
+	}
+
 	fave.ID = id.NewULID()
 
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
@@ -530,6 +597,13 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 		)
 	}
 
+	// Run the flag through inbound
+	// policies before storing it.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAccount, ap.ActivityFlag, flag)
+	if err != nil || rejected {
+		return err
+	}
+
 	report.ID = id.NewULID()
 
 	if err := f.state.DB.PutReport(ctx, report); err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -135,6 +136,56 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 	}
 }
 
+func (suite *CreateTestSuite) TestCreateFlagRejectedByPolicy() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+
+	// Reject everything from the reporting account's domain.
+	if err := suite.db.PutInboundPolicy(context.Background(), &gtsmodel.InboundPolicy{
+		ID:                 id.NewULID(),
+		Title:              "no fossbros",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Enabled:            util.Ptr(true),
+		DryRun:             util.Ptr(false),
+		Action:             gtsmodel.InboundPolicyActionReject,
+		Domains:            []string{reportingAccount.Domain},
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "ban this sick filth ⛔",
+  "id": "http://fossbros-anonymous.io/5f3e8b5a-7d28-4c83-a5c7-1f0a4c6e3f1e",
+  "object": "` + reportedAccount.URI + `",
+  "type": "Flag"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(reportedAccount, reportingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// nothing should be heading to the processor
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+
+	// and no report should have been stored
+	_, err = suite.db.GetReports(context.Background(), nil, reportingAccount.ID, reportedAccount.ID, "", "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -48,10 +49,11 @@ type DB interface {
 // FederatingDB uses the given state interface
 // to implement the go-fed pub.Database interface.
 type federatingDB struct {
-	state      *state.State
-	converter  *typeutils.Converter
-	visFilter  *visibility.Filter
-	spamFilter *spam.Filter
+	state        *state.State
+	converter    *typeutils.Converter
+	visFilter    *visibility.Filter
+	spamFilter   *spam.Filter
+	policyFilter *policy.Filter
 }
 
 // New returns a DB that satisfies the pub.Database
@@ -61,12 +63,14 @@ func New(
 	converter *typeutils.Converter,
 	visFilter *visibility.Filter,
 	spamFilter *spam.Filter,
+	policyFilter *policy.Filter,
 ) DB {
 	fdb := federatingDB{
-		state:      state,
-		converter:  converter,
-		visFilter:  visFilter,
-		spamFilter: spamFilter,
+		state:        state,
+		converter:    converter,
+		visFilter:    visFilter,
+		spamFilter:   spamFilter,
+		policyFilter: policyFilter,
 	}
 	return &fdb
 }
@@ -157,6 +157,13 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 		return gtserror.SetMalformed(err)
 	}
 
+	// Run the move through inbound
+	// policies before passing it on.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAcct, ap.ActivityMove, move)
+	if err != nil || rejected {
+		return err
+	}
+
 	// Create a stub *gtsmodel.Move with relevant
 	// values. This will be updated / stored by the
 	// fedi api worker as necessary.
@@ -93,6 +93,13 @@ func (f *federatingDB) updateAccountable(ctx context.Context, receivingAcct *gts
 		return gtserror.Newf("update for %s was not requested by owner", accountURIStr)
 	}
 
+	// Run the update through inbound
+	// policies before passing it on.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAcct, ap.ActivityUpdate, accountable)
+	if err != nil || rejected {
+		return err
+	}
+
 	// Pass in to the processor the existing version of the requesting
 	// account that we have, plus the Accountable representation that
 	// was delivered along with the Update, for further asynchronous
@@ -153,6 +160,13 @@ func (f *federatingDB) updateStatusable(ctx context.Context, receivingAcct *gtsm
 		statusable = nil
 	}
 
+	// Run the update through inbound policies before
+	// passing it on. This may rewrite statusable in place.
+	rejected, err := f.rejectedByPolicy(ctx, requestingAcct, ap.ActivityUpdate, statusable)
+	if err != nil || rejected {
+		return err
+	}
+
 	// Queue an UPDATE NOTE activity to our fedi API worker,
 	// this will handle necessary database insertions, etc.
 	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
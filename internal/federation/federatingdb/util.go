@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -281,3 +282,31 @@ func marshalItem(item vocab.Type) (string, error) {
 
 	return string(b), nil
 }
+
+// rejectedByPolicy runs the given activity from requester
+// through the inbound policy pipeline, BEFORE anything is
+// stored for it, returning true if it should be dropped.
+func (f *federatingDB) rejectedByPolicy(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	activityType string,
+	object any,
+) (bool, error) {
+	verdict, err := f.policyFilter.Apply(ctx,
+		requester,
+		activityType,
+		object,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error applying inbound policies: %w", err)
+	}
+
+	if verdict.Reject {
+		log.Infof(ctx,
+			"%s from %s rejected by inbound policy",
+			activityType, requester.URI,
+		)
+	}
+
+	return verdict.Reject, nil
+}
@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/inboundpolicy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// content lazily extracts the parts of a
+// statusable that policies match against.
+type content struct {
+	statusable ap.Statusable
+	extracted  bool
+
+	text     string // plaintext of summary, name and content
+	lower    string // lowercase version of text
+	mentions int    // number of mentions
+}
+
+func newContent(statusable ap.Statusable) *content {
+	return &content{statusable: statusable}
+}
+
+func (c *content) extract() {
+	if c.extracted {
+		return
+	}
+	c.extracted = true
+
+	parts := []string{
+		ap.ExtractSummary(c.statusable),
+		ap.ExtractName(c.statusable),
+	}
+
+	apContent := ap.ExtractContent(c.statusable)
+	parts = append(parts, apContent.Content)
+	for _, langContent := range apContent.ContentMap {
+		parts = append(parts, langContent)
+	}
+
+	c.text = text.SanitizeToPlaintext(strings.Join(parts, "\n"))
+	c.lower = strings.ToLower(c.text)
+
+	mentions, _ := ap.ExtractMentions(c.statusable)
+	c.mentions = len(mentions)
+}
+
+// matches returns true if all set matchers of
+// policy match the given requester and statusable.
+func matches(
+	policy inboundpolicy.Policy,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+	content *content,
+) bool {
+	if len(policy.Domains) != 0 &&
+		!matchDomain(requester.Domain, policy.Domains) {
+		return false
+	}
+
+	if policy.MaxActorAge != 0 &&
+		time.Since(requester.CreatedAt) >= policy.MaxActorAge {
+		return false
+	}
+
+	if !policy.MatchesContent() {
+		// Nothing else to check.
+		return true
+	}
+
+	if statusable == nil {
+		// Content matchers can
+		// only match statuses.
+		return false
+	}
+
+	content.extract()
+
+	if len(policy.Keywords) != 0 &&
+		!matchKeyword(content.lower, policy.Keywords) {
+		return false
+	}
+
+	if policy.Regexp != nil &&
+		!policy.Regexp.MatchString(content.text) {
+		return false
+	}
+
+	if policy.MinMentions != 0 &&
+		content.mentions < policy.MinMentions {
+		return false
+	}
+
+	return true
+}
+
+// matchDomain returns true if domain is
+// one of domains, or a subdomain of one.
+func matchDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKeyword returns true if lowercase
+// text contains any of the given keywords.
+func matchKeyword(lower string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(lower, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// forceSensitive marks statusable as sensitive.
+func forceSensitive(statusable ap.Statusable) {
+	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
+	sensitiveProp.AppendXMLSchemaBoolean(true)
+	statusable.SetActivityStreamsSensitive(sensitiveProp)
+}
+
+// unlist moves the public IRI of statusable from To to
+// Cc, which turns a public status into an unlisted one.
+func unlist(statusable ap.Statusable) {
+	var (
+		to     []*url.URL
+		public *url.URL
+	)
+
+	for _, iri := range ap.GetTo(statusable) {
+		if pub.IsPublic(iri.String()) {
+			public = iri
+			continue
+		}
+		to = append(to, iri)
+	}
+
+	if public == nil {
+		// Not public,
+		// nothing to do.
+		return
+	}
+
+	statusable.SetActivityStreamsTo(nil)
+	ap.AppendTo(statusable, to...)
+	ap.AppendCc(statusable, public)
+}
+
+// rewriteHashtags renames hashtags of statusable found in
+// rewrites to their replacement, or removes them from the
+// status if their replacement is empty.
+func rewriteHashtags(statusable ap.Statusable, rewrites map[string]string) {
+	tagsProp := statusable.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return
+	}
+
+	// Iterate backwards so we
+	// can remove as we go.
+	for i := tagsProp.Len() - 1; i >= 0; i-- {
+		t := tagsProp.At(i).GetType()
+		if t == nil || t.GetTypeName() != ap.TagHashtag {
+			continue
+		}
+
+		hashtaggable, ok := t.(ap.Hashtaggable)
+		if !ok {
+			continue
+		}
+
+		name := strings.TrimPrefix(ap.ExtractName(hashtaggable), "#")
+		replacement, ok := rewrites[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+
+		if replacement == "" {
+			tagsProp.Remove(i)
+			continue
+		}
+
+		nameProp := streams.NewActivityStreamsNameProperty()
+		nameProp.AppendXMLSchemaString("#" + replacement)
+		hashtaggable.SetActivityStreamsName(nameProp)
+	}
+}
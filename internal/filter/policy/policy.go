@@ -0,0 +1,217 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/inboundpolicy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// Filter packages logic for running activities received
+// from remote instances through the pipeline of inbound
+// policies configured by admins.
+type Filter struct {
+	state *state.State
+
+	// per policy ID match stats,
+	// kept since process start.
+	stats sync.Map // map[string]*stats
+}
+
+// NewFilter returns a new inbound policy
+// Filter that will use the provided state.
+func NewFilter(state *state.State) *Filter {
+	return &Filter{state: state}
+}
+
+// Verdict is the outcome of running
+// an activity through the pipeline.
+type Verdict struct {
+	// Reject indicates that the
+	// activity should be dropped.
+	Reject bool
+
+	// Quarantine indicates that a status
+	// carried by the activity should be
+	// stored, but not timelined or notified.
+	Quarantine bool
+
+	// QuarantinePolicyID is the ID of
+	// the first policy that quarantined.
+	QuarantinePolicyID string
+}
+
+// Stats models the number of times
+// an inbound policy has matched.
+type Stats struct {
+	// Matches while not in dry-run mode.
+	Matches int64
+
+	// Matches while in dry-run mode.
+	DryRunMatches int64
+
+	// Time of the latest match, if any.
+	LastMatchedAt time.Time
+}
+
+type stats struct {
+	matches       atomic.Int64
+	dryRunMatches atomic.Int64
+	lastMatchedAt atomic.Int64 // unix nanos
+}
+
+// Stats returns the match stats of the inbound policy
+// with the given ID, since this process started.
+func (f *Filter) Stats(policyID string) Stats {
+	v, ok := f.stats.Load(policyID)
+	if !ok {
+		return Stats{}
+	}
+
+	s := v.(*stats)
+	out := Stats{
+		Matches:       s.matches.Load(),
+		DryRunMatches: s.dryRunMatches.Load(),
+	}
+
+	if last := s.lastMatchedAt.Load(); last != 0 {
+		out.LastMatchedAt = time.Unix(0, last)
+	}
+
+	return out
+}
+
+// Apply runs the given activity from requester, carrying the
+// given object, through each enabled inbound policy in turn.
+//
+// Policies that modify statuses (eg., strip_media) do so by
+// rewriting object in place, if it's an ap.Statusable, so the
+// status is stored already modified. Policies that reject or
+// quarantine are reported in the returned Verdict, and it's
+// up to the caller to act on them.
+//
+// Delete activities are never filtered, so that remote
+// instances can always retract content sent to us.
+func (f *Filter) Apply(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	activityType string,
+	object any,
+) (Verdict, error) {
+	var verdict Verdict
+
+	if requester == nil ||
+		activityType == ap.ActivityDelete {
+		return verdict, nil
+	}
+
+	policies, err := f.state.DB.GetEnabledInboundPolicies(ctx)
+	if err != nil {
+		return verdict, gtserror.Newf("error getting inbound policies: %w", err)
+	}
+
+	if len(policies) == 0 {
+		// Nothing to do.
+		return verdict, nil
+	}
+
+	statusable, _ := object.(ap.Statusable)
+	content := newContent(statusable)
+
+	for _, policy := range policies {
+		if statusable == nil && policy.Action.StatusOnly() {
+			// Policy can't do
+			// anything here.
+			continue
+		}
+
+		if !matches(policy, requester, statusable, content) {
+			continue
+		}
+
+		dryRun := *policy.DryRun
+		f.record(ctx, policy, dryRun)
+
+		l := log.
+			WithContext(ctx).
+			WithField("policy", policy.ID).
+			WithField("action", policy.Action).
+			WithField("activityType", activityType).
+			WithField("requester", requester.URI)
+
+		if dryRun {
+			l.Info("inbound policy matched (dry run)")
+			continue
+		}
+
+		l.Info("inbound policy matched")
+
+		switch policy.Action {
+		case gtsmodel.InboundPolicyActionReject:
+			// No point going further.
+			verdict.Reject = true
+			return verdict, nil
+
+		case gtsmodel.InboundPolicyActionQuarantine:
+			if !verdict.Quarantine {
+				verdict.Quarantine = true
+				verdict.QuarantinePolicyID = policy.ID
+			}
+
+		case gtsmodel.InboundPolicyActionStripMedia:
+			statusable.SetActivityStreamsAttachment(nil)
+
+		case gtsmodel.InboundPolicyActionForceSensitive:
+			forceSensitive(statusable)
+
+		case gtsmodel.InboundPolicyActionUnlist:
+			unlist(statusable)
+
+		case gtsmodel.InboundPolicyActionRewriteHashtags:
+			rewriteHashtags(statusable, policy.HashtagRewrites)
+		}
+	}
+
+	return verdict, nil
+}
+
+// record records a match of the given
+// policy in stats, and in metrics.
+func (f *Filter) record(ctx context.Context, policy inboundpolicy.Policy, dryRun bool) {
+	v, _ := f.stats.LoadOrStore(policy.ID, new(stats))
+	s := v.(*stats)
+
+	if dryRun {
+		s.dryRunMatches.Add(1)
+	} else {
+		s.matches.Add(1)
+	}
+	s.lastMatchedAt.Store(time.Now().UnixNano())
+
+	metrics.InboundPolicyMatched(ctx, policy.ID, string(policy.Action), dryRun)
+}
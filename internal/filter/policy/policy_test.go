@@ -0,0 +1,275 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// Public message with a hashtag, an attachment and one mention.
+const note = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "sensitive": "as:sensitive",
+      "Hashtag": "as:Hashtag"
+    }
+  ],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/111985188827079999",
+  "type": "Note",
+  "published": "2024-02-24T07:06:14Z",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "http://fossbros-anonymous.io/users/foss_satan/followers"
+  ],
+  "sensitive": false,
+  "content": "<p>Buy my cheap crypto coins today! <a href=\"http://fossbros-anonymous.io/tags/crypto\" class=\"mention hashtag\" rel=\"tag\">#<span>Crypto</span></a> <a href=\"http://fossbros-anonymous.io/tags/coins\" class=\"mention hashtag\" rel=\"tag\">#<span>coins</span></a></p>",
+  "attachment": [
+    {
+      "type": "Document",
+      "mediaType": "image/jpeg",
+      "url": "http://fossbros-anonymous.io/attachments/coin.jpg"
+    }
+  ],
+  "tag": [
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/the_mighty_zork",
+      "name": "@the_mighty_zork@localhost:8080"
+    },
+    {
+      "type": "Hashtag",
+      "href": "http://fossbros-anonymous.io/tags/crypto",
+      "name": "#Crypto"
+    },
+    {
+      "type": "Hashtag",
+      "href": "http://fossbros-anonymous.io/tags/coins",
+      "name": "#coins"
+    }
+  ]
+}`
+
+type PolicyTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	// standard suite models
+	testAccounts map[string]*gtsmodel.Account
+
+	filter *policy.Filter
+}
+
+func (suite *PolicyTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *PolicyTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.filter = policy.NewFilter(&suite.state)
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *PolicyTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *PolicyTestSuite) putPolicy(p *gtsmodel.InboundPolicy) {
+	p.ID = id.NewULID()
+	p.Title = "test policy"
+	p.CreatedByAccountID = suite.testAccounts["admin_account"].ID
+	if p.Enabled == nil {
+		p.Enabled = util.Ptr(true)
+	}
+	if p.DryRun == nil {
+		p.DryRun = util.Ptr(false)
+	}
+
+	if err := suite.state.DB.PutInboundPolicy(context.Background(), p); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *PolicyTestSuite) apply() (ap.Statusable, policy.Verdict) {
+	ctx := context.Background()
+
+	statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader([]byte(note))))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	verdict, err := suite.filter.Apply(ctx,
+		suite.testAccounts["remote_account_1"],
+		ap.ActivityCreate, statusable,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return statusable, verdict
+}
+
+func (suite *PolicyTestSuite) attachments(statusable ap.Statusable) int {
+	attachmentProp := statusable.GetActivityStreamsAttachment()
+	if attachmentProp == nil {
+		return 0
+	}
+	return attachmentProp.Len()
+}
+
+func (suite *PolicyTestSuite) TestNoPolicies() {
+	statusable, verdict := suite.apply()
+	suite.Equal(policy.Verdict{}, verdict)
+	suite.Equal(1, suite.attachments(statusable))
+}
+
+func (suite *PolicyTestSuite) TestRejectDomain() {
+	p := &gtsmodel.InboundPolicy{
+		Action:  gtsmodel.InboundPolicyActionReject,
+		Domains: []string{"fossbros-anonymous.io"},
+	}
+	suite.putPolicy(p)
+
+	_, verdict := suite.apply()
+	suite.True(verdict.Reject)
+	suite.EqualValues(1, suite.filter.Stats(p.ID).Matches)
+}
+
+func (suite *PolicyTestSuite) TestNoMatch() {
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Action:   gtsmodel.InboundPolicyActionReject,
+		Keywords: []string{"turtles"},
+	})
+
+	_, verdict := suite.apply()
+	suite.False(verdict.Reject)
+}
+
+func (suite *PolicyTestSuite) TestDryRun() {
+	p := &gtsmodel.InboundPolicy{
+		Action:   gtsmodel.InboundPolicyActionStripMedia,
+		Keywords: []string{"CHEAP CRYPTO"},
+		DryRun:   util.Ptr(true),
+	}
+	suite.putPolicy(p)
+
+	statusable, _ := suite.apply()
+	suite.Equal(1, suite.attachments(statusable))
+
+	stats := suite.filter.Stats(p.ID)
+	suite.EqualValues(0, stats.Matches)
+	suite.EqualValues(1, stats.DryRunMatches)
+	suite.False(stats.LastMatchedAt.IsZero())
+}
+
+func (suite *PolicyTestSuite) TestModifyStatus() {
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Priority: 0,
+		Action:   gtsmodel.InboundPolicyActionStripMedia,
+		Regex:    `crypto\s+coins`,
+	})
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Priority: 1,
+		Action:   gtsmodel.InboundPolicyActionUnlist,
+		Domains:  []string{"fossbros-anonymous.io"},
+	})
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Priority: 2,
+		Action:   gtsmodel.InboundPolicyActionForceSensitive,
+		Domains:  []string{"fossbros-anonymous.io"},
+	})
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Priority:        3,
+		Action:          gtsmodel.InboundPolicyActionRewriteHashtags,
+		MinMentions:     1,
+		HashtagRewrites: map[string]string{"crypto": "scam", "coins": ""},
+	})
+
+	statusable, verdict := suite.apply()
+	suite.Equal(policy.Verdict{}, verdict)
+
+	// Media stripped.
+	suite.Zero(suite.attachments(statusable))
+
+	// Public moved from To to Cc.
+	for _, iri := range ap.GetTo(statusable) {
+		suite.False(pub.IsPublic(iri.String()))
+	}
+	var public bool
+	for _, iri := range ap.GetCc(statusable) {
+		public = public || pub.IsPublic(iri.String())
+	}
+	suite.True(public)
+
+	// Forced sensitive.
+	suite.True(ap.ExtractSensitive(statusable))
+
+	// Hashtags rewritten.
+	hashtags, err := ap.ExtractHashtags(statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(hashtags, 1) {
+		suite.Equal("scam", hashtags[0].Name)
+	}
+}
+
+func (suite *PolicyTestSuite) TestQuarantineActorAge() {
+	suite.putPolicy(&gtsmodel.InboundPolicy{
+		Action: gtsmodel.InboundPolicyActionQuarantine,
+
+		// Test accounts are years old, so this
+		// should match anything from them.
+		MaxActorAge: 100 * 365 * 24 * time.Hour,
+	})
+
+	_, verdict := suite.apply()
+	suite.True(verdict.Quarantine)
+	suite.NotEmpty(verdict.QuarantinePolicyID)
+	suite.False(verdict.Reject)
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
@@ -17,16 +17,114 @@
 
 package spam
 
-import "github.com/superseriousbusiness/gotosocial/internal/state"
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// Window within which the same content from
+	// this many accounts counts as duplicate.
+	duplicateWindow   = time.Hour
+	duplicateAccounts = 3
+
+	// Window within which first contact with
+	// this many receivers counts as a burst.
+	burstWindow    = time.Hour
+	burstReceivers = 5
+)
+
+// Names of the signals that can
+// contribute to a spam Score.
+const (
+	SignalUnknownMentions   = "unknown_mentions"
+	SignalMedia             = "media"
+	SignalErrantLinks       = "errant_links"
+	SignalNewAccount        = "new_account"
+	SignalDuplicateContent  = "duplicate_content"
+	SignalFirstContactBurst = "first_contact_burst"
+)
 
 // Filter packages logic for checking whether
 // given statuses should be considered spam.
 type Filter struct {
 	state *state.State
+
+	// Content fingerprints mapped to
+	// IDs of accounts that sent them.
+	fingerprints *recentSets
+
+	// Requester account IDs mapped to IDs
+	// of receivers they first contacted.
+	contacts *recentSets
 }
 
 // NewFilter returns a new spam Filter
 // that will use the provided state.
 func NewFilter(state *state.State) *Filter {
-	return &Filter{state: state}
+	return &Filter{
+		state:        state,
+		fingerprints: newRecentSets(duplicateWindow),
+		contacts:     newRecentSets(burstWindow),
+	}
+}
+
+// Score is the spam score of a message, along
+// with the signals that contributed to it.
+type Score struct {
+	// Sum of the weights
+	// of matched signals.
+	Total int
+
+	// Names of matched signals.
+	Signals []string
+}
+
+// add adds the given signal with the given
+// weight to score. Signals with a weight of
+// 0 or less are disabled, and not added.
+func (s *Score) add(signal string, weight int) {
+	if weight <= 0 {
+		return
+	}
+
+	s.Total += weight
+	s.Signals = append(s.Signals, signal)
+}
+
+// recentSets tracks, per key, the set of
+// distinct IDs seen recently with that key.
+type recentSets struct {
+	sets cache.TTLCache[string, []string]
+	mu   sync.Mutex
+}
+
+func newRecentSets(window time.Duration) *recentSets {
+	sets := cache.NewTTL[string, []string](0, 10000, window)
+	if !sets.Start(time.Minute) {
+		log.Panic(nil, "could not start spam filter cache")
+	}
+	return &recentSets{sets: sets}
+}
+
+// add adds id to the set for key, returning
+// the number of distinct IDs in the set.
+func (r *recentSets) add(key string, id string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids, _ := r.sets.Get(key)
+	if !slices.Contains(ids, id) {
+		// Clone to avoid modifying
+		// a slice that's in the cache.
+		ids = append(slices.Clone(ids), id)
+	}
+
+	r.sets.Set(key, ids)
+	return len(ids)
 }
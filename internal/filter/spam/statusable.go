@@ -19,10 +19,14 @@ package spam
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -33,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -47,7 +52,8 @@ type preppedMention struct {
 }
 
 // StatusableOK returns no error if the given statusable looks OK,
-// ie., relevant to the receiver, and not spam.
+// ie., relevant to the receiver, and not spam, along with its
+// spam Score, which is only set if the spam filter is enabled.
 //
 // This should only be used for Creates of statusables, NOT Announces!
 //
@@ -67,26 +73,37 @@ type preppedMention struct {
 // Otherwise check:
 //
 //  3. Receiver is locked and is followed by requester. Return nil.
-//  4. Five or more people are mentioned. Return Spam.
-//  5. Receiver follow (requests) a mentioned account. Return nil.
-//  6. Statusable has a media attachment. Return Spam.
-//  7. Statusable contains non-mention, non-hashtag links. Return Spam.
+//  4. Receiver follow (requests) a mentioned account. Return nil.
+//
+// Otherwise, the statusable is scored by adding up the weights
+// of the following signals, as set in the config:
+//
+//   - unknown_mentions: for each mentioned account other than receiver.
+//   - media: statusable has a media attachment.
+//   - errant_links: statusable contains non-mention, non-hashtag links.
+//   - new_account: requester was first seen only recently.
+//   - duplicate_content: the same content was recently sent by several accounts.
+//   - first_contact_burst: requester recently contacted many accounts out of the blue.
+//
+// If the total is at or above the threshold, return Spam.
 func (f *Filter) StatusableOK(
 	ctx context.Context,
 	receiver *gtsmodel.Account,
 	requester *gtsmodel.Account,
 	statusable ap.Statusable,
-) error {
+) (Score, error) {
+	var score Score
+
 	// HEURISTIC 1: Check whether receiving account follows the requesting account.
 	// If so, we know it's OK and don't need to do any other checks.
 	follows, err := f.state.DB.IsFollowing(ctx, receiver.ID, requester.ID)
 	if err != nil {
-		return gtserror.Newf("db error checking follow status: %w", err)
+		return score, gtserror.Newf("db error checking follow status: %w", err)
 	}
 
 	if follows {
 		// Looks fine.
-		return nil
+		return score, nil
 	}
 
 	// HEURISTIC 2: Check whether statusable mentions the
@@ -98,7 +115,7 @@ func (f *Filter) StatusableOK(
 		// This is a random message fired
 		// into our inbox, just drop it.
 		err := errors.New("receiver does not follow requester, and is not mentioned")
-		return gtserror.SetNotRelevant(err)
+		return score, gtserror.SetNotRelevant(err)
 	}
 
 	// Receiver is mentioned, but not by someone
@@ -107,7 +124,7 @@ func (f *Filter) StatusableOK(
 	if !config.GetInstanceFederationSpamFilter() {
 		// Filter is not enabled, allow it
 		// through without further checks.
-		return nil
+		return score, nil
 	}
 
 	// More granular spam filtering time!
@@ -115,52 +132,106 @@ func (f *Filter) StatusableOK(
 	// HEURISTIC 3: Does requester follow locked receiver?
 	followedBy, err := f.lockedFollowedBy(ctx, receiver, requester)
 	if err != nil {
-		return gtserror.Newf("db error checking follow status: %w", err)
+		return score, gtserror.Newf("db error checking follow status: %w", err)
 	}
 
 	// If receiver is locked, and is followed
 	// by requester, this likely means they're
 	// interested in the message. Allow it.
 	if followedBy {
-		return nil
-	}
-
-	// HEURISTIC 4: How many people are mentioned?
-	// If it's 5 or more we can assume this is spam.
-	mentionsLen := len(mentions)
-	if mentionsLen >= 5 {
-		err := errors.New("status mentions 5 or more people")
-		return gtserror.SetSpam(err)
+		return score, nil
 	}
 
-	// HEURISTIC 5: Four or fewer people are mentioned,
-	// do we follow (request) at least one of them?
-	// If so, we're probably interested in the message.
+	// HEURISTIC 4: Do we follow (request) at least
+	// one of the mentioned accounts? If so, we're
+	// probably interested in the message.
 	knowsOne := f.knowsOneMentioned(ctx, receiver, mentions)
 	if knowsOne {
-		return nil
+		return score, nil
+	}
+
+	// Nothing vouches for this message,
+	// so score it on the spam signals.
+	//
+	// Each mentioned account other than the
+	// receiver is one the receiver doesn't know.
+	var unknown int
+	for _, mention := range mentions {
+		if !mention.targets(receiver) {
+			unknown++
+		}
+	}
+	if unknown != 0 {
+		weight := config.GetInstanceFederationSpamUnknownMentionWeight()
+		score.add(SignalUnknownMentions, unknown*weight)
 	}
 
-	// HEURISTIC 6: Are there any media attachments?
+	// Are there any media attachments?
 	attachments, _ := ap.ExtractAttachments(statusable)
-	hasAttachments := len(attachments) != 0
-	if hasAttachments {
-		err := errors.New("status has attachment(s)")
-		return gtserror.SetSpam(err)
+	if len(attachments) != 0 {
+		score.add(SignalMedia, config.GetInstanceFederationSpamMediaWeight())
 	}
 
-	// HEURISTIC 7: Are there any links in the post
-	// aside from mentions and hashtags? Include the
-	// summary/content warning when checking.
+	// Are there any links in the post aside from
+	// mentions and hashtags? Include the summary/
+	// content warning when checking.
 	hashtags, _ := ap.ExtractHashtags(statusable)
-	hasErrantLinks := f.errantLinks(ctx, statusable, mentions, hashtags)
-	if hasErrantLinks {
-		err := errors.New("status has one or more non-mention, non-hashtag links")
-		return gtserror.SetSpam(err)
+	if f.errantLinks(ctx, statusable, mentions, hashtags) {
+		score.add(SignalErrantLinks, config.GetInstanceFederationSpamErrantLinkWeight())
+	}
+
+	// Did we first see requester only recently?
+	if time.Since(requester.CreatedAt) < config.GetInstanceFederationSpamNewAccountAge() {
+		score.add(SignalNewAccount, config.GetInstanceFederationSpamNewAccountWeight())
+	}
+
+	// Has this content recently been
+	// sent by several different accounts?
+	if fp := fingerprint(statusable); fp != "" &&
+		f.fingerprints.add(fp, requester.ID) >= duplicateAccounts {
+		score.add(SignalDuplicateContent, config.GetInstanceFederationSpamDuplicateWeight())
+	}
+
+	// Has requester recently contacted many
+	// accounts that have nothing to do with them?
+	if f.contacts.add(requester.ID, receiver.ID) >= burstReceivers {
+		score.add(SignalFirstContactBurst, config.GetInstanceFederationSpamBurstWeight())
+	}
+
+	if threshold := config.GetInstanceFederationSpamThreshold(); score.Total >= threshold {
+		err := fmt.Errorf(
+			"spam score %d reached threshold %d (%s)",
+			score.Total, threshold, strings.Join(score.Signals, ", "),
+		)
+		return score, gtserror.SetSpam(err)
 	}
 
 	// Looks OK.
-	return nil
+	return score, nil
+}
+
+// fingerprint returns a fingerprint of the text of
+// statusable, ignoring case, whitespace and mentions,
+// so that copies of the same message sent to different
+// accounts have the same fingerprint. Returns an empty
+// string if statusable has no text.
+func fingerprint(statusable ap.Statusable) string {
+	plain := text.SanitizeToPlaintext(
+		ap.ExtractSummary(statusable) + "\n" +
+			ap.ExtractContent(statusable).Content,
+	)
+
+	words := strings.Fields(strings.ToLower(plain))
+	words = slices.DeleteFunc(words, func(word string) bool {
+		return strings.HasPrefix(word, "@")
+	})
+
+	if len(words) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:])
 }
 
 // prepMentions prepares a slice of mentions
@@ -243,24 +314,30 @@ func (f *Filter) isMentioned(
 	return slices.ContainsFunc(
 		mentions,
 		func(mention preppedMention) bool {
-			// Check if receiver mentioned by URI.
-			if accURI := mention.TargetAccountURI; accURI != "" &&
-				(accURI == receiver.URI || accURI == receiver.URL) {
-				return true
-			}
-
-			// Check if receiver mentioned by namestring.
-			if mention.local && strings.EqualFold(mention.user, receiver.Username) {
-				return true
-			}
-
-			// Mention doesn't
-			// target receiver.
-			return false
+			return mention.targets(receiver)
 		},
 	)
 }
 
+// targets returns true if
+// mention targets receiver.
+func (mention preppedMention) targets(receiver *gtsmodel.Account) bool {
+	// Check if receiver mentioned by URI.
+	if accURI := mention.TargetAccountURI; accURI != "" &&
+		(accURI == receiver.URI || accURI == receiver.URL) {
+		return true
+	}
+
+	// Check if receiver mentioned by namestring.
+	if mention.local && strings.EqualFold(mention.user, receiver.Username) {
+		return true
+	}
+
+	// Mention doesn't
+	// target receiver.
+	return false
+}
+
 // lockedFollowedBy returns true
 // if receiver account is locked,
 // and requester follows receiver.
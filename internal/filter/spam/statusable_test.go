@@ -22,9 +22,12 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -696,7 +699,7 @@ func (suite *StatusableTestSuite) TestStatusableOK() {
 			suite.FailNow(err.Error())
 		}
 
-		err = suite.filter.StatusableOK(ctx, receiver, requester, statusable)
+		_, err = suite.filter.StatusableOK(ctx, receiver, requester, statusable)
 		test.check(err)
 	}
 
@@ -770,11 +773,59 @@ func (suite *StatusableTestSuite) TestStatusableOK() {
 			suite.FailNow(err.Error())
 		}
 
-		err = suite.filter.StatusableOK(ctx, receiver, requester, statusable)
+		_, err = suite.filter.StatusableOK(ctx, receiver, requester, statusable)
 		test.check(err)
 	}
 }
 
+func (suite *StatusableTestSuite) TestStatusableScore() {
+	var (
+		ctx      = context.Background()
+		receiver = suite.testAccounts["local_account_1"]
+	)
+
+	resolve := func(message string) ap.Statusable {
+		rc := io.NopCloser(bytes.NewReader([]byte(message)))
+		statusable, err := ap.ResolveStatusable(ctx, rc)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return statusable
+	}
+
+	// spam3 scores under the threshold
+	// on unknown mentions alone.
+	score, err := suite.filter.StatusableOK(ctx, receiver, suite.testAccounts["remote_account_1"], resolve(spam3))
+	suite.NoError(err)
+	suite.Equal(9, score.Total)
+	suite.Equal([]string{spam.SignalUnknownMentions}, score.Signals)
+
+	// From a brand new account,
+	// the same message is spam.
+	newAccount := new(gtsmodel.Account)
+	*newAccount = *suite.testAccounts["remote_account_2"]
+	newAccount.CreatedAt = time.Now()
+
+	score, err = suite.filter.StatusableOK(ctx, receiver, newAccount, resolve(spam3))
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+	suite.Equal(13, score.Total)
+	suite.Equal([]string{spam.SignalUnknownMentions, spam.SignalNewAccount}, score.Signals)
+
+	// Sent by a third account, the
+	// message counts as duplicate.
+	score, err = suite.filter.StatusableOK(ctx, receiver, suite.testAccounts["remote_account_3"], resolve(spam3))
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+	suite.Equal(14, score.Total)
+	suite.Equal([]string{spam.SignalUnknownMentions, spam.SignalDuplicateContent}, score.Signals)
+
+	// With unknown mentions weighted 0, the signal is off.
+	config.SetInstanceFederationSpamUnknownMentionWeight(0)
+	score, err = suite.filter.StatusableOK(ctx, receiver, suite.testAccounts["remote_account_1"], resolve(spam3))
+	suite.NoError(err)
+	suite.Equal(5, score.Total)
+	suite.Equal([]string{spam.SignalDuplicateContent}, score.Signals)
+}
+
 func TestStatusableTestSuite(t *testing.T) {
 	suite.Run(t, &StatusableTestSuite{})
 }
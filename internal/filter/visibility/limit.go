@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AccountLimited returns whether the given account is subject
+// to a moderation limit on this instance, either because it
+// has been silenced, or because its domain has been limited.
+func (f *Filter) AccountLimited(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if account.IsSilenced() {
+		return true, nil
+	}
+
+	if account.IsLocal() {
+		// Local accounts can
+		// only be silenced.
+		return false, nil
+	}
+
+	limit, err := f.state.DB.MatchDomainLimit(ctx, account.Domain)
+	if err != nil {
+		return false, gtserror.Newf("error matching domain limit for %s: %w", account.Domain, err)
+	}
+
+	return limit != nil, nil
+}
+
+// isAccountLimitedFor returns whether the given account is
+// limited, and the requester is not permitted to see past
+// this limit, ie. they are not the account nor a follower.
+func (f *Filter) isAccountLimitedFor(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	limited, err := f.AccountLimited(ctx, account)
+	if err != nil || !limited {
+		return false, err
+	}
+
+	if requester == nil {
+		// Limited accounts are never
+		// visible to unauthed requests.
+		return true, nil
+	}
+
+	if requester.ID == account.ID {
+		// Can always see past own limit.
+		return false, nil
+	}
+
+	// Followers can see past limit.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		account.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, account.ID, err)
+	}
+
+	return !follows, nil
+}
+
+// areStatusAccountsLimitedFor calls Filter{}.isAccountLimitedFor() on status author and the status boost-of (if set) author.
+func (f *Filter) areStatusAccountsLimitedFor(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	limited, err := f.isAccountLimitedFor(ctx, requester, status.Account)
+	if err != nil {
+		return false, gtserror.Newf("error checking status author limit: %w", err)
+	}
+
+	if limited {
+		log.Trace(ctx, "status author limited for requester")
+		return true, nil
+	}
+
+	if status.BoostOfID != "" && status.AccountID != status.BoostOfAccountID {
+		limited, err := f.isAccountLimitedFor(ctx, requester, status.BoostOfAccount)
+		if err != nil {
+			return false, gtserror.Newf("error checking boosted author limit: %w", err)
+		}
+
+		if limited {
+			log.Trace(ctx, "boosted status author limited for requester")
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type LimitTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *LimitTestSuite) TestSilencedAccountStatuses() {
+	ctx := context.Background()
+
+	// Silence local_account_1, which is
+	// followed by local_account_2 but
+	// not by remote_account_1.
+	account := suite.testAccounts["local_account_1"]
+	account.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, account, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Public status by local_account_1.
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		requester *gtsmodel.Account
+		visible   bool
+	}{
+		{nil, false},
+		{suite.testAccounts["remote_account_1"], false},
+		{suite.testAccounts["local_account_1"], true},
+		{suite.testAccounts["local_account_2"], true},
+	} {
+		visible, err := suite.filter.StatusVisible(ctx, test.requester, status)
+		suite.NoError(err)
+		suite.Equal(test.visible, visible, "requester: %+v", test.requester)
+
+		// Silenced account statuses are never
+		// public timelineable, even to followers.
+		timelineable, err := suite.filter.StatusPublicTimelineable(ctx, test.requester, status)
+		suite.NoError(err)
+		suite.False(timelineable, "requester: %+v", test.requester)
+	}
+}
+
+func (suite *LimitTestSuite) TestLimitedDomainStatuses() {
+	ctx := context.Background()
+
+	// Status by remote_account_1, which
+	// is on fossbros-anonymous.io.
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["remote_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Not limited yet.
+	visible, err := suite.filter.StatusVisible(ctx, suite.testAccounts["local_account_1"], status)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Limit the domain, this should clear
+	// cached visibility for the status.
+	if err := suite.db.PutDomainLimit(ctx, &gtsmodel.DomainLimit{
+		ID:                 "01HXD4K8M2N6P0R4T8V2X6Z0B4",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["local_account_1"], status)
+	suite.NoError(err)
+	suite.False(visible)
+
+	limited, err := suite.filter.AccountLimited(ctx, suite.testAccounts["remote_account_1"])
+	suite.NoError(err)
+	suite.True(limited)
+
+	// Other domains shouldn't be affected.
+	limited, err = suite.filter.AccountLimited(ctx, suite.testAccounts["remote_account_2"])
+	suite.NoError(err)
+	suite.False(limited)
+}
+
+func TestLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(LimitTestSuite))
+}
@@ -91,6 +91,19 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Statuses from limited accounts are
+	// never shown on public timelines, even
+	// if the requester can otherwise see them.
+	limited, err := f.AccountLimited(ctx, status.Account)
+	if err != nil {
+		return false, err
+	}
+
+	if limited {
+		log.Trace(ctx, "status author limited")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
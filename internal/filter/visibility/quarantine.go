@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// isStatusQuarantinedFor returns whether the given status (or the
+// status it boosts) is held in quarantine, and the requester is not
+// permitted to see past this, ie. they are not an instance admin.
+func (f *Filter) isStatusQuarantinedFor(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	quarantined, err := f.state.DB.IsStatusQuarantined(ctx, status.ID)
+	if err != nil {
+		return false, gtserror.Newf("error checking status quarantine: %w", err)
+	}
+
+	if !quarantined && status.BoostOfID != "" {
+		// Check whether boosted status is quarantined.
+		quarantined, err = f.state.DB.IsStatusQuarantined(ctx, status.BoostOfID)
+		if err != nil {
+			return false, gtserror.Newf("error checking boosted status quarantine: %w", err)
+		}
+	}
+
+	if !quarantined {
+		return false, nil
+	}
+
+	if requester == nil || !requester.IsLocal() {
+		// Only local admins can
+		// see past a quarantine.
+		log.Trace(ctx, "quarantined status not visible to requester")
+		return true, nil
+	}
+
+	user, err := f.state.DB.GetUserByAccountID(ctx, requester.ID)
+	if err != nil {
+		return false, gtserror.Newf("error getting user for account %s: %w", requester.ID, err)
+	}
+
+	return !*user.Admin, nil
+}
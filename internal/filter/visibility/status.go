@@ -41,7 +41,7 @@ func (f *Filter) StatusesVisible(ctx context.Context, requester *gtsmodel.Accoun
 	return filtered, errs.Combine()
 }
 
-// StatusVisible will check if given status is visible to requester, accounting for requester with no auth (i.e is nil), suspensions, disabled local users, account blocks and status privacy.
+// StatusVisible will check if given status is visible to requester, accounting for requester with no auth (i.e is nil), suspensions, limits, disabled local users, account blocks and status privacy.
 func (f *Filter) StatusVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	const vtype = cache.VisibilityTypeStatus
 
@@ -90,6 +90,22 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	// Check whether status accounts are limited for the requester.
+	limited, err := f.areStatusAccountsLimitedFor(ctx, requester, status)
+	if err != nil {
+		return false, gtserror.Newf("error checking status %s account limits: %w", status.ID, err)
+	} else if limited {
+		return false, nil
+	}
+
+	// Check whether status is quarantined for the requester.
+	quarantined, err := f.isStatusQuarantinedFor(ctx, requester, status)
+	if err != nil {
+		return false, gtserror.Newf("error checking status %s quarantine: %w", status.ID, err)
+	} else if quarantined {
+		return false, nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
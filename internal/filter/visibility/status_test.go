@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 type StatusVisibleTestSuite struct {
@@ -156,6 +157,33 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestQuarantinedStatusVisibleOnlyToAdmin() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["remote_account_2_status_1"]
+
+	err := suite.db.PutQuarantinedStatus(ctx, &gtsmodel.QuarantinedStatus{
+		ID:        id.NewULID(),
+		StatusID:  testStatus.ID,
+		AccountID: testStatus.AccountID,
+	})
+	suite.NoError(err)
+
+	// Quarantined status shouldn't be visible without auth.
+	visible, err := suite.filter.StatusVisible(ctx, nil, testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// Nor to a regular (non-admin) user.
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// But admins can still see it.
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
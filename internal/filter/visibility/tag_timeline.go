@@ -55,6 +55,19 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Statuses from limited accounts are
+	// never shown on tag timelines, even
+	// if the requester can otherwise see them.
+	limited, err := f.AccountLimited(ctx, status.Account)
+	if err != nil {
+		return false, err
+	}
+
+	if limited {
+		log.Trace(ctx, "status author limited")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
@@ -165,6 +165,45 @@ func NewErrorNotAcceptable(original error, helpText ...string) WithCode {
 	}
 }
 
+// NewErrorPreconditionFailed returns an ErrorWithCode 412 with the given original error and optional help text.
+func NewErrorPreconditionFailed(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusPreconditionFailed)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusPreconditionFailed,
+	}
+}
+
+// NewErrorRequestEntityTooLarge returns an ErrorWithCode 413 with the given original error and optional help text.
+func NewErrorRequestEntityTooLarge(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusRequestEntityTooLarge)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusRequestEntityTooLarge,
+	}
+}
+
+// NewErrorUnsupportedMediaType returns an ErrorWithCode 415 with the given original error and optional help text.
+func NewErrorUnsupportedMediaType(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusUnsupportedMediaType)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusUnsupportedMediaType,
+	}
+}
+
 // NewErrorUnprocessableEntity returns an ErrorWithCode 422 with the given original error and optional help text.
 func NewErrorUnprocessableEntity(original error, helpText ...string) WithCode {
 	safe := http.StatusText(http.StatusUnprocessableEntity)
@@ -191,6 +230,19 @@ func NewErrorGone(original error, helpText ...string) WithCode {
 	}
 }
 
+// NewErrorTooManyRequests returns an ErrorWithCode 429 with the given original error and optional help text.
+func NewErrorTooManyRequests(original error, helpText ...string) WithCode {
+	safe := http.StatusText(http.StatusTooManyRequests)
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusTooManyRequests,
+	}
+}
+
 // NewErrorClientClosedRequest returns an ErrorWithCode 499 with the given original error.
 // This error type should only be used when an http caller has already hung up their request.
 // See: https://en.wikipedia.org/wiki/List_of_HTTP_status_codes#nginx
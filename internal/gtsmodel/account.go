@@ -77,6 +77,7 @@ type Account struct {
 	PublicKeyExpiresAt      time.Time        `bun:"type:timestamptz,nullzero"`                                   // PublicKey will expire/has expired at given time, and should be fetched again as appropriate. Only ever set for remote accounts.
 	SensitizedAt            time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account set to have all its media shown as sensitive?
 	SilencedAt              time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account silenced (eg., statuses only visible to followers, not public)?
+	SilencedContentWarning  string           `bun:",nullzero"`                                                   // Content warning to prepend to this account's statuses while it is silenced, if any.
 	SuspendedAt             time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
 	SuspensionOrigin        string           `bun:"type:CHAR(26),nullzero"`                                      // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
 	Settings                *AccountSettings `bun:"-"`                                                           // gtsmodel.AccountSettings for this account.
@@ -181,6 +182,14 @@ func (a *Account) IsSuspended() bool {
 	return !a.SuspendedAt.IsZero()
 }
 
+// IsSilenced returns true if account
+// has been silenced (aka. limited) on
+// this instance. Note this does not take
+// account of limits on the account's domain.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
 // IsMoving returns true if
 // account is Moving or has Moved.
 func (a *Account) IsMoving() bool {
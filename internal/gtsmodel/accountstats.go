@@ -30,4 +30,6 @@ type AccountStats struct {
 	StatusesCount       *int      `bun:",nullzero,notnull"`                        // Number of statuses created by AccountID.
 	StatusesPinnedCount *int      `bun:",nullzero,notnull"`                        // Number of statuses pinned by AccountID.
 	LastStatusAt        time.Time `bun:"type:timestamptz,nullzero"`                // Time of most recent status created by AccountID.
+	MediaStorageUsed    *int64    `bun:",nullzero,notnull,default:0"`              // Bytes of media (files + thumbnails) stored for AccountID.
+	MediaStorageQuota   *int64    `bun:",nullzero"`                                // Admin override of media storage quota in bytes for AccountID (0 = unlimited). If nil, the default for the account's role is used.
 }
@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountWarning models a moderation warning (aka. strike)
+// issued by an admin to a local account, explaining what
+// action was taken against the account and why. The owner
+// of the account may appeal the warning once.
+type AccountWarning struct {
+	ID                        string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt                 time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt                 time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID                 string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that received this warning.
+	Account                   *Account        `bun:"-"`                                                           // Account corresponding to AccountID.
+	CreatedByAccountID        string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the admin account that issued this warning.
+	CreatedByAccount          *Account        `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+	Action                    AdminActionType `bun:",nullzero,notnull"`                                           // Type of action taken against the account with this warning.
+	AdminActionID             string          `bun:"type:CHAR(26),nullzero"`                                      // ID of the admin action taken with this warning, if any.
+	Text                      string          `bun:",nullzero"`                                                   // Explanation of this warning, visible to the account.
+	ReportID                  string          `bun:"type:CHAR(26),nullzero"`                                      // ID of the report that led to this warning, if any.
+	Report                    *Report         `bun:"-"`                                                           // Report corresponding to ReportID.
+	StatusIDs                 []string        `bun:"statuses,array"`                                              // database IDs of any statuses this warning pertains to.
+	Statuses                  []*Status       `bun:"-"`                                                           // statuses corresponding to StatusIDs.
+	RuleIDs                   []string        `bun:"rules,array"`                                                 // database IDs of any instance rules that were broken.
+	Rules                     []*Rule         `bun:"-"`                                                           // rules corresponding to RuleIDs.
+	AppealState               AppealState     `bun:",nullzero"`                                                   // State of the appeal against this warning, if any.
+	AppealText                string          `bun:",nullzero"`                                                   // Text of the appeal against this warning, if any.
+	AppealedAt                time.Time       `bun:"type:timestamptz,nullzero"`                                   // When was this warning appealed?
+	AppealResolvedAt          time.Time       `bun:"type:timestamptz,nullzero"`                                   // When was the appeal against this warning approved or rejected?
+	AppealResolvedByAccountID string          `bun:"type:CHAR(26),nullzero"`                                      // ID of the admin account that approved or rejected the appeal.
+}
+
+// IsAppealed returns true if the
+// warning has been appealed, whether
+// or not the appeal has been resolved.
+func (w *AccountWarning) IsAppealed() bool {
+	return w.AppealState != AppealStateNone
+}
+
+// AppealState describes the state of
+// an appeal against an account warning.
+type AppealState uint8
+
+// Only ever add new appeal states to the *END* of the list
+// below, DO NOT insert them before/between other entries!
+
+const (
+	AppealStateNone AppealState = iota
+	AppealStatePending
+	AppealStateApproved
+	AppealStateRejected
+)
+
+func (s AppealState) String() string {
+	switch s {
+	case AppealStatePending:
+		return "pending"
+	case AppealStateApproved:
+		return "approved"
+	case AppealStateRejected:
+		return "rejected"
+	default:
+		return ""
+	}
+}
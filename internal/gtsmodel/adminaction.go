@@ -73,6 +73,7 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionWarn
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +92,8 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionWarn:
+		return "warn"
 	default:
 		return "unknown"
 	}
@@ -112,6 +115,8 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "warn":
+		return AdminActionWarn
 	default:
 		return AdminActionUnknown
 	}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AuditLogEntry records one mutation performed by an
+// admin of this instance, so that admins can later
+// see who changed what, and when.
+type AuditLogEntry struct {
+	ID         string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt  time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Creation time of this item.
+	AccountID  string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the admin account that performed the mutation.
+	Account    *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	Action     string             `bun:",nullzero,notnull"`                                           // What was done to the target, eg., "create", "update", "delete", "silence".
+	TargetType AuditLogTargetType `bun:",nullzero,notnull"`                                           // Type of the entity targeted by the mutation.
+	TargetID   string             `bun:",nullzero,notnull"`                                           // ID of the target. Usually a ULID, but may be a domain name or similar.
+	Before     string             `bun:",nullzero"`                                                   // JSON summary of the target before the mutation, if it existed.
+	After      string             `bun:",nullzero"`                                                   // JSON summary of the target after the mutation, if it still exists.
+}
+
+// AuditLogTargetType describes the type
+// of entity targeted by an admin mutation.
+type AuditLogTargetType string
+
+const (
+	AuditLogTargetAccount          AuditLogTargetType = "account"
+	AuditLogTargetDomainBlock      AuditLogTargetType = "domain_block"
+	AuditLogTargetDomainAllow      AuditLogTargetType = "domain_allow"
+	AuditLogTargetDomainLimit      AuditLogTargetType = "domain_limit"
+	AuditLogTargetEmailDomainBlock AuditLogTargetType = "email_domain_block"
+	AuditLogTargetIPBlock          AuditLogTargetType = "ip_block"
+	AuditLogTargetEmoji            AuditLogTargetType = "emoji"
+	AuditLogTargetRule             AuditLogTargetType = "rule"
+	AuditLogTargetHeaderAllow      AuditLogTargetType = "header_allow"
+	AuditLogTargetHeaderBlock      AuditLogTargetType = "header_block"
+	AuditLogTargetInstance         AuditLogTargetType = "instance"
+	AuditLogTargetReport           AuditLogTargetType = "report"
+	AuditLogTargetAccountWarning   AuditLogTargetType = "account_warning"
+	AuditLogTargetInvite           AuditLogTargetType = "invite"
+	AuditLogTargetPolicy           AuditLogTargetType = "inbound_policy"
+	AuditLogTargetQuarantine       AuditLogTargetType = "quarantined_status"
+	AuditLogTargetTag              AuditLogTargetType = "tag"
+	AuditLogTargetMediaHash        AuditLogTargetType = "media_hash_block"
+)
+
+// Audit log actions performed on targets
+// that don't have a more specific action.
+const (
+	AuditLogActionCreate = "create"
+	AuditLogActionUpdate = "update"
+	AuditLogActionDelete = "delete"
+)
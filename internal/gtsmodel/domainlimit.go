@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainLimit represents a moderation limit (aka. silence)
+// on a domain and all of its subdomains. Unlike a domain block,
+// accounts on a limited domain can still federate with this
+// instance, but their statuses are hidden from public timelines
+// and from non-followers, their media is always shown as
+// sensitive, and their follows always require approval.
+type DomainLimit struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string    `bun:",nullzero,notnull,unique"`                                    // domain to limit. Eg. 'whatever.com'
+	ContentWarning     string    `bun:",nullzero"`                                                   // Content warning to prepend to statuses from accounts on this domain, if any.
+	PrivateComment     string    `bun:",nullzero"`                                                   // Private comment on this limit, viewable to admins
+	PublicComment      string    `bun:",nullzero"`                                                   // Public comment on this limit, viewable (optionally) by everyone
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this limit
+	CreatedByAccount   *Account  `bun:"-"`                                                           // Account corresponding to createdByAccountID
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"regexp"
+	"time"
+)
+
+// InboundPolicyAction describes what an inbound
+// policy does to activities that it matches.
+type InboundPolicyAction string
+
+const (
+	// Drop the activity entirely.
+	InboundPolicyActionReject InboundPolicyAction = "reject"
+	// Remove media attachments from statuses.
+	InboundPolicyActionStripMedia InboundPolicyAction = "strip_media"
+	// Mark statuses as sensitive.
+	InboundPolicyActionForceSensitive InboundPolicyAction = "force_sensitive"
+	// Make public statuses unlisted, keeping
+	// them out of the public timelines.
+	InboundPolicyActionUnlist InboundPolicyAction = "unlist"
+	// Rename or remove hashtags on statuses.
+	InboundPolicyActionRewriteHashtags InboundPolicyAction = "rewrite_hashtags"
+	// Store statuses, but don't put them
+	// in timelines or notify about them.
+	InboundPolicyActionQuarantine InboundPolicyAction = "quarantine"
+)
+
+// InboundPolicyActions lists all valid inbound policy actions.
+var InboundPolicyActions = []InboundPolicyAction{
+	InboundPolicyActionReject,
+	InboundPolicyActionStripMedia,
+	InboundPolicyActionForceSensitive,
+	InboundPolicyActionUnlist,
+	InboundPolicyActionRewriteHashtags,
+	InboundPolicyActionQuarantine,
+}
+
+// StatusOnly returns true if this action
+// only applies to activities carrying a status.
+func (a InboundPolicyAction) StatusOnly() bool {
+	return a != InboundPolicyActionReject
+}
+
+// InboundPolicy represents an admin-created policy in the
+// pipeline that every activity received from a remote
+// instance passes through before it is processed.
+//
+// A policy matches an activity when all of its set matchers
+// match; an unset matcher (empty / zero) matches everything.
+type InboundPolicy struct {
+	ID                 string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Title              string              `bun:",nullzero,notnull"`                                           // Short title of this policy, for admins.
+	Priority           int                 `bun:",notnull,default:0"`                                          // Policies run in ascending order of priority.
+	Enabled            *bool               `bun:",nullzero,notnull,default:true"`                              // Whether this policy runs at all.
+	DryRun             *bool               `bun:",nullzero,notnull,default:false"`                             // Only log + count matches, don't apply Action.
+	Action             InboundPolicyAction `bun:",nullzero,notnull"`                                           // What to do with matching activities.
+	Domains            []string            `bun:"domains,array"`                                               // Match activities from these domains, or their subdomains.
+	Keywords           []string            `bun:"keywords,array"`                                              // Match statuses containing any of these keywords (case-insensitive).
+	Regex              string              `bun:",nullzero"`                                                   // Match statuses whose text matches this regular expression.
+	MaxActorAge        time.Duration       `bun:",nullzero"`                                                   // Match activities from actors first seen less than this long ago.
+	MinMentions        int                 `bun:",nullzero"`                                                   // Match statuses mentioning at least this many accounts.
+	HashtagRewrites    map[string]string   `bun:",nullzero"`                                                   // For rewrite_hashtags: lowercase tag name -> replacement name, or "" to remove.
+	CreatedByAccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this policy.
+	CreatedByAccount   *Account            `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+}
+
+// Regexp compiles and returns the Regex of this policy,
+// case-insensitively. Returns nil, nil if Regex is unset.
+func (p *InboundPolicy) Regexp() (*regexp.Regexp, error) {
+	if p.Regex == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + p.Regex)
+}
+
+// MatchesContent returns true if this policy has
+// matchers that need the content of a status.
+func (p *InboundPolicy) MatchesContent() bool {
+	return len(p.Keywords) != 0 ||
+		p.Regex != "" ||
+		p.MinMentions != 0
+}
+
+// HasMatchers returns true if
+// this policy has any matchers set.
+func (p *InboundPolicy) HasMatchers() bool {
+	return len(p.Domains) != 0 ||
+		p.MaxActorAge != 0 ||
+		p.MatchesContent()
+}
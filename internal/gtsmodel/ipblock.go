@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"net/netip"
+	"time"
+)
+
+// IPBlockSeverity describes what
+// an IP block prevents a client from doing.
+type IPBlockSeverity uint8
+
+// Only ever add new severities to the *END* of the list
+// below, DO NOT insert them before/between other entries!
+//
+// Severities are ordered from least to most severe.
+
+const (
+	IPBlockSeverityUnknown IPBlockSeverity = iota
+	IPBlockSeveritySignUpRequiresApproval
+	IPBlockSeveritySignUpBlock
+	IPBlockSeverityNoAccess
+)
+
+func (s IPBlockSeverity) String() string {
+	switch s {
+	case IPBlockSeveritySignUpRequiresApproval:
+		return "sign_up_requires_approval"
+	case IPBlockSeveritySignUpBlock:
+		return "sign_up_block"
+	case IPBlockSeverityNoAccess:
+		return "no_access"
+	default:
+		return "unknown"
+	}
+}
+
+func NewIPBlockSeverity(in string) IPBlockSeverity {
+	switch in {
+	case "sign_up_requires_approval":
+		return IPBlockSeveritySignUpRequiresApproval
+	case "sign_up_block":
+		return IPBlockSeveritySignUpBlock
+	case "no_access":
+		return IPBlockSeverityNoAccess
+	default:
+		return IPBlockSeverityUnknown
+	}
+}
+
+// IPBlock represents an admin-created block on
+// an IP address or range (CIDR) of IP addresses.
+type IPBlock struct {
+	ID                 string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	IP                 string          `bun:",nullzero,notnull,unique"`                                    // Blocked address range in CIDR notation, eg. '192.0.2.0/24'. Single addresses are stored as /32 or /128.
+	Severity           IPBlockSeverity `bun:",nullzero,notnull"`                                           // What this block prevents clients in the range from doing.
+	Comment            string          `bun:",nullzero"`                                                   // Private comment on this block, visible to admins only.
+	ExpiresAt          time.Time       `bun:"type:timestamptz,nullzero"`                                   // Time after which this block no longer applies. Zero means never.
+	CreatedByAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block.
+	CreatedByAccount   *Account        `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+}
+
+// Expired returns whether this block has passed its expiry time.
+func (b *IPBlock) Expired() bool {
+	return !b.ExpiresAt.IsZero() && !time.Now().Before(b.ExpiresAt)
+}
+
+// Prefix returns the address range
+// covered by this block, parsed.
+func (b *IPBlock) Prefix() (netip.Prefix, error) {
+	return netip.ParsePrefix(b.IP)
+}
@@ -42,6 +42,10 @@ type MediaAttachment struct {
 	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
 	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
 	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
+	Proxied           *bool            `bun:",nullzero,notnull,default:false"`                             // Is the original file of this remote attachment streamed from its remote URL on demand, instead of cached? (Thumbnail is still cached.)
+	PerceptualHash    string           `bun:",nullzero"`                                                   // Perceptual hash (dHash) of the image or video thumbnail, as 16 hex characters.
+	Blocked           *bool            `bun:",nullzero,notnull,default:false"`                             // Was this remote attachment dropped for matching a media hash block? (Won't be recached.)
+	Derivatives       []*Derivative    `bun:""`                                                            // Scaled-down copies of the image at other widths, smallest first.
 }
 
 // File refers to the metadata for the whole file
@@ -62,6 +66,16 @@ type Thumbnail struct {
 	RemoteURL   string    `bun:",nullzero"`                                                   // What is the remote URL of the thumbnail (empty for local media)
 }
 
+// Derivative refers to a scaled-down copy of an image, at one of the configured
+// derivative widths, which is served instead of the thumbnail when asked for.
+type Derivative struct {
+	Width       int    // width in pixels
+	Height      int    // height in pixels
+	Path        string // Path of the file in storage.
+	ContentType string // MIME content type of the file.
+	FileSize    int    // File size in bytes
+}
+
 // ProcessingStatus refers to how far along in the processing stage the attachment is.
 type ProcessingStatus int
 
@@ -106,9 +120,10 @@ type Original struct {
 	Height    int      // height in pixels
 	Size      int      // size in pixels (width * height)
 	Aspect    float32  // aspect ratio (width / height)
-	Duration  *float32 // video-specific: duration of the video in seconds
+	Duration  *float32 // video/audio-specific: duration in seconds
 	Framerate *float32 // video-specific: fps
-	Bitrate   *uint64  // video-specific: bitrate
+	Bitrate   *uint64  // video/audio-specific: bitrate
+	Channels  *int     // audio-specific: number of audio channels
 }
 
 // Focus describes the 'center' of the image for display purposes.
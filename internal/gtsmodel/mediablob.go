@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// MediaBlob represents a single file in storage, which may be
+// shared between any number of media attachments and emojis
+// with identical content. Files are identified by the hash of
+// their content, and are only removed from storage once the
+// last attachment or emoji referencing them is gone.
+type MediaBlob struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Hash      string    `bun:",nullzero,notnull,unique"`                                    // Hex-encoded SHA-256 hash of the file content.
+	Path      string    `bun:",nullzero,notnull,unique"`                                    // Storage path of the file.
+	Size      int64     `bun:",notnull,default:0"`                                          // Size of the file in bytes.
+	RefCount  int       `bun:",notnull,default:0"`                                          // Number of references to the file from attachments and emojis.
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MediaHashBlock represents an admin-created block on media
+// whose perceptual hash is within a configured Hamming
+// distance of the blocked hash. Local uploads of matching
+// media are rejected, remote matching media is dropped.
+type MediaHashBlock struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Hash               string    `bun:",nullzero,notnull,unique"`                                    // Blocked perceptual hash, as 16 hex characters.
+	Comment            string    `bun:",nullzero"`                                                   // Private comment on this block, visible to admins only.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block.
+	CreatedByAccount   *Account  `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+}
+
+// HashValue returns the blocked
+// perceptual hash of this block, parsed.
+func (b *MediaHashBlock) HashValue() (uint64, error) {
+	return ParseMediaHash(b.Hash)
+}
+
+// MediaHashMatch represents remote media that was dropped
+// because it matched a media hash block, kept in a queue
+// for admins to review and dismiss.
+type MediaHashMatch struct {
+	ID               string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt        time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	MediaHashBlockID string           `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the matched media hash block.
+	MediaHashBlock   *MediaHashBlock  `bun:"-"`                                                           // Block corresponding to MediaHashBlockID.
+	AttachmentID     string           `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the dropped media attachment.
+	Attachment       *MediaAttachment `bun:"-"`                                                           // Attachment corresponding to AttachmentID.
+	AccountID        string           `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that owns the dropped media.
+	Account          *Account         `bun:"-"`                                                           // Account corresponding to AccountID.
+	RemoteURL        string           `bun:",nullzero,notnull"`                                           // Remote URL of the dropped media.
+	Hash             string           `bun:",nullzero,notnull"`                                           // Perceptual hash of the dropped media.
+	Distance         int              `bun:",notnull,default:0"`                                          // Hamming distance between Hash and the blocked hash.
+}
+
+// ParseMediaHash parses the given 16 hex
+// character string as a perceptual hash.
+func ParseMediaHash(in string) (uint64, error) {
+	if len(in) != 16 {
+		return 0, errors.New("media hash must be 16 hex characters")
+	}
+
+	hash, err := strconv.ParseUint(in, 16, 64)
+	if err != nil {
+		return 0, errors.New("media hash must be 16 hex characters")
+	}
+
+	return hash, nil
+}
+
+// FormatMediaHash formats the given perceptual
+// hash as a string of 16 hex characters.
+func FormatMediaHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
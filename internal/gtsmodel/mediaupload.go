@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// MediaUpload represents a resumable upload of a media file by
+// a local account, which is sent in chunks over any number of
+// requests. Chunks are staged in storage until the upload is
+// complete, and then processed together as one media file.
+type MediaUpload struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ExpiresAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // when will the upload be removed if not completed and used
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the local account doing the upload
+	Length    int64     `bun:",notnull,default:0"`                                          // Total size of the file being uploaded, in bytes.
+	Offset    int64     `bun:",notnull,default:0"`                                          // Number of bytes of the file received so far.
+	ChunkIDs  []string  `bun:"chunks,array"`                                                // IDs of the chunks staged in storage so far, in order.
+}
+
+// Complete returns whether the whole file has been received.
+func (u *MediaUpload) Complete() bool {
+	return u.Offset >= u.Length
+}
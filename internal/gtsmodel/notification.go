@@ -31,6 +31,8 @@ type Notification struct {
 	OriginAccount    *Account         `bun:"-"`                                                           // Account corresponding to OriginAccountID. Can be nil, always check first + select using ID if necessary.
 	StatusID         string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusID. Can be nil, always check first + select using ID if necessary.
+	AccountWarningID string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to an account warning, what is the database ID of that warning?
+	AccountWarning   *AccountWarning  `bun:"-"`                                                           // AccountWarning corresponding to AccountWarningID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
 }
 
@@ -39,12 +41,13 @@ type NotificationType string
 
 // Notification Types
 const (
-	NotificationFollow        NotificationType = "follow"         // NotificationFollow -- someone followed you
-	NotificationFollowRequest NotificationType = "follow_request" // NotificationFollowRequest -- someone requested to follow you
-	NotificationMention       NotificationType = "mention"        // NotificationMention -- someone mentioned you in their status
-	NotificationReblog        NotificationType = "reblog"         // NotificationReblog -- someone boosted one of your statuses
-	NotificationFave          NotificationType = "favourite"      // NotificationFave -- someone faved/liked one of your statuses
-	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
-	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
-	NotificationSignup        NotificationType = "admin.sign_up"  // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationFollow        NotificationType = "follow"             // NotificationFollow -- someone followed you
+	NotificationFollowRequest NotificationType = "follow_request"     // NotificationFollowRequest -- someone requested to follow you
+	NotificationMention       NotificationType = "mention"            // NotificationMention -- someone mentioned you in their status
+	NotificationReblog        NotificationType = "reblog"             // NotificationReblog -- someone boosted one of your statuses
+	NotificationFave          NotificationType = "favourite"          // NotificationFave -- someone faved/liked one of your statuses
+	NotificationPoll          NotificationType = "poll"               // NotificationPoll -- a poll you voted in or created has ended
+	NotificationStatus        NotificationType = "status"             // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationSignup        NotificationType = "admin.sign_up"      // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationWarning       NotificationType = "moderation_warning" // NotificationWarning -- an admin has issued a moderation warning to you.
 )
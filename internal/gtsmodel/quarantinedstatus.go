@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QuarantinedStatus represents a remote status that was
+// stored, but held back from timelines and notifications
+// until an admin reviews it, either because it looked like
+// spam, or because an inbound policy quarantined it.
+type QuarantinedStatus struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	StatusID  string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the quarantined status.
+	Status    *Status   `bun:"-"`                                                           // Status corresponding to StatusID.
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that sent the status.
+	Account   *Account  `bun:"-"`                                                           // Account corresponding to AccountID.
+	Score     int       `bun:",notnull,default:0"`                                          // Spam score of the status, if quarantined as spam.
+	Signals   []string  `bun:"signals,array"`                                               // Spam signals that contributed to Score.
+	PolicyID  string    `bun:"type:CHAR(26),nullzero"`                                      // ID of the inbound policy that quarantined the status, if any.
+}
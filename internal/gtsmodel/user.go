@@ -94,6 +94,7 @@ type NewSignup struct {
 	SignUpIP      net.IP // IP address from which the sign up request occurred (optional).
 	Locale        string // Locale code for the new account/user (optional).
 	AppID         string // ID of the application used to create this account (optional).
+	InviteID      string // ID of the invite used to create this account (optional).
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
 	ExternalID    string // ID of this user in external OIDC system (optional).
 	Admin         bool   // Mark new user as an admin user (optional).
@@ -0,0 +1,836 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/abema/go-mp4"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// maxCoverSize is the largest embedded cover
+// art image that we'll try to use as a thumbnail.
+const maxCoverSize = 16 * 1024 * 1024
+
+type gtsAudio struct {
+	cover    []byte  // embedded cover art, if any
+	duration float32 // in seconds
+	bitrate  uint64  // in bits per second
+	channels int
+}
+
+// decodeAudio probes the given audio stream of the given content
+// type, returning its duration, bitrate, number of channels and
+// embedded cover art (if any). This is done in pure Go, by reading
+// just enough of the container format to find these values.
+func decodeAudio(r io.Reader, contentType string) (*gtsAudio, error) {
+	// Check if audio stream supports
+	// seeking, usually when *os.File.
+	rsc, ok := r.(io.ReadSeekCloser)
+	if !ok {
+		var err error
+
+		// Store stream to temporary location
+		// in order that we can get seek-reads.
+		rsc, err = iotools.TempFileSeeker(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+		}
+
+		defer func() {
+			// Ensure temp. read seeker closed.
+			if err := rsc.Close(); err != nil {
+				log.Errorf(nil, "error closing temp file seeker: %s", err)
+			}
+		}()
+	}
+
+	// Get total size of the
+	// stream by seeking to end.
+	size, err := rsc.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking to end of audio: %w", err)
+	}
+
+	var audio *gtsAudio
+
+	switch contentType {
+	case mimeAudioMpeg:
+		audio, err = probeMP3(rsc, size)
+	case mimeAudioFlac:
+		audio, err = probeFLAC(rsc, size)
+	case mimeAudioOgg:
+		audio, err = probeOgg(rsc, size)
+	case mimeAudioMp4:
+		audio, err = probeM4A(rsc)
+	default:
+		err = fmt.Errorf("unsupported audio type %s", contentType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for empty audio metadata.
+	var empty []string
+	if audio.duration == 0 {
+		empty = append(empty, "duration")
+	}
+	if audio.bitrate == 0 {
+		empty = append(empty, "bitrate")
+	}
+	if audio.channels == 0 {
+		empty = append(empty, "channels")
+	}
+	if len(empty) > 0 {
+		return nil, fmt.Errorf("error determining audio metadata: %v", empty)
+	}
+
+	return audio, nil
+}
+
+// readAt reads exactly n bytes from rs at offset off.
+func readAt(rs io.ReadSeeker, off int64, n int) ([]byte, error) {
+	if _, err := rs.Seek(off, io.SeekStart); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rs, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// bitrateOf returns the average bitrate of
+// the given number of bytes over duration.
+func bitrateOf(n int64, duration float64) uint64 {
+	if duration <= 0 || n <= 0 {
+		return 0
+	}
+	return uint64(float64(n*8) / duration)
+}
+
+/*
+	MP3
+*/
+
+var (
+	// MPEG audio bitrates in kbps, indexed by
+	// [MPEG-1?][layer-1][bitrate index].
+	mp3Bitrates = [2][3][16]int{
+		{ // MPEG-2 and MPEG-2.5
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		},
+		{ // MPEG-1
+			{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+			{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+		},
+	}
+
+	// MPEG-1 sample rates in Hz, halved
+	// for MPEG-2 and quartered for MPEG-2.5.
+	mp3SampleRates = [3]int{44100, 48000, 32000}
+)
+
+// mp3Frame is a parsed MPEG audio frame header.
+type mp3Frame struct {
+	mpeg1      bool
+	layer      int
+	bitrate    int // bits per second
+	sampleRate int // Hz
+	samples    int // samples per frame
+	channels   int
+}
+
+// parseMP3Frame parses the 4 byte MPEG
+// audio frame header at the start of b.
+func parseMP3Frame(b []byte) (mp3Frame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+
+	var (
+		version  = (b[1] >> 3) & 0x3 // 0: 2.5, 2: 2, 3: 1
+		layerIdx = (b[1] >> 1) & 0x3 // 1: III, 2: II, 3: I
+		brIdx    = b[2] >> 4
+		srIdx    = (b[2] >> 2) & 0x3
+		mode     = b[3] >> 6
+	)
+
+	if version == 1 || layerIdx == 0 || brIdx == 0 || brIdx == 15 || srIdx == 3 {
+		// Reserved or free-format values.
+		return mp3Frame{}, false
+	}
+
+	f := mp3Frame{
+		mpeg1:      version == 3,
+		layer:      4 - int(layerIdx),
+		sampleRate: mp3SampleRates[srIdx],
+		channels:   2,
+	}
+
+	mpeg1 := 0
+	if f.mpeg1 {
+		mpeg1 = 1
+	}
+	f.bitrate = mp3Bitrates[mpeg1][f.layer-1][brIdx] * 1000
+
+	switch version {
+	case 2:
+		f.sampleRate /= 2
+	case 0:
+		f.sampleRate /= 4
+	}
+
+	switch {
+	case f.layer == 1:
+		f.samples = 384
+	case f.layer == 3 && !f.mpeg1:
+		f.samples = 576
+	default:
+		f.samples = 1152
+	}
+
+	if mode == 3 {
+		f.channels = 1
+	}
+
+	return f, true
+}
+
+func probeMP3(rs io.ReadSeeker, size int64) (*gtsAudio, error) {
+	var (
+		audio gtsAudio
+		start int64
+	)
+
+	// Skip over (and look for cover art in)
+	// any ID3v2 tag at the start of the file.
+	hdr, err := readAt(rs, 0, 10)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mp3 header: %w", err)
+	}
+
+	if string(hdr[:3]) == "ID3" {
+		tagSize := int64(syncsafe(hdr[6:10]))
+		if hdr[5]&0x10 != 0 {
+			// Footer present.
+			tagSize += 10
+		}
+
+		if tagSize <= maxCoverSize {
+			tag, err := readAt(rs, 10, int(tagSize))
+			if err != nil {
+				return nil, fmt.Errorf("error reading id3 tag: %w", err)
+			}
+			audio.cover = id3Cover(hdr[3], hdr[5], tag)
+		}
+
+		start = 10 + tagSize
+	}
+
+	// Look for the first frame header
+	// within a reasonable distance.
+	const scan = 64 * 1024
+	n := int64(scan)
+	if size-start < n {
+		n = size - start
+	}
+
+	buf, err := readAt(rs, start, int(n))
+	if err != nil {
+		return nil, fmt.Errorf("error reading mp3 frames: %w", err)
+	}
+
+	var (
+		frame mp3Frame
+		found bool
+		off   int
+	)
+
+	for ; off+4 <= len(buf); off++ {
+		if frame, found = parseMP3Frame(buf[off:]); found {
+			break
+		}
+	}
+
+	if !found {
+		return nil, errors.New("no mp3 frame header found")
+	}
+
+	audio.channels = frame.channels
+	audioBytes := size - start - int64(off)
+
+	// Look for a Xing / Info or VBRI header in the
+	// first frame, giving the total number of frames.
+	// Layer III side info comes after the 4 byte header.
+	var frames uint32
+	if frame.layer == 3 {
+		side := 17
+		switch {
+		case frame.mpeg1 && frame.channels == 2:
+			side = 32
+		case !frame.mpeg1 && frame.channels == 1:
+			side = 9
+		}
+
+		if x := buf[off:]; len(x) >= 4+side+12 {
+			xing := x[4+side:]
+			if tag := string(xing[:4]); tag == "Xing" || tag == "Info" {
+				if binary.BigEndian.Uint32(xing[4:8])&0x1 != 0 {
+					frames = binary.BigEndian.Uint32(xing[8:12])
+				}
+			}
+		}
+
+		if x := buf[off:]; frames == 0 && len(x) >= 36+18 {
+			if vbri := x[36:]; string(vbri[:4]) == "VBRI" {
+				frames = binary.BigEndian.Uint32(vbri[14:18])
+			}
+		}
+	}
+
+	if frames > 0 {
+		// Exact duration from frame count,
+		// average bitrate over the frames.
+		duration := float64(frames) * float64(frame.samples) / float64(frame.sampleRate)
+		audio.duration = float32(duration)
+		audio.bitrate = bitrateOf(audioBytes, duration)
+	} else {
+		// Assume constant bitrate
+		// from first frame header.
+		audio.bitrate = uint64(frame.bitrate)
+		audio.duration = float32(float64(audioBytes*8) / float64(frame.bitrate))
+	}
+
+	return &audio, nil
+}
+
+// syncsafe decodes a 4 byte ID3v2 syncsafe integer.
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 |
+		uint32(b[1]&0x7F)<<14 |
+		uint32(b[2]&0x7F)<<7 |
+		uint32(b[3]&0x7F)
+}
+
+// id3Cover returns the front cover picture
+// (or failing that, the first picture) from
+// the given ID3v2 tag body, if any.
+func id3Cover(version byte, flags byte, tag []byte) []byte {
+	if flags&0x80 != 0 {
+		// Unsynchronised tags
+		// are rare, don't bother.
+		return nil
+	}
+
+	if flags&0x40 != 0 && len(tag) >= 4 {
+		// Skip extended header.
+		switch version {
+		case 3:
+			tag = tag[min(len(tag), 4+int(binary.BigEndian.Uint32(tag[:4]))):]
+		case 4:
+			tag = tag[min(len(tag), int(syncsafe(tag[:4]))):]
+		}
+	}
+
+	// ID3v2.2 uses 3 byte frame IDs and sizes.
+	idLen, hdrLen, picID := 4, 10, "APIC"
+	if version == 2 {
+		idLen, hdrLen, picID = 3, 6, "PIC"
+	}
+
+	var first []byte
+	for len(tag) >= hdrLen && tag[0] != 0 {
+		id := string(tag[:idLen])
+
+		var size int
+		switch version {
+		case 2:
+			size = int(tag[3])<<16 | int(tag[4])<<8 | int(tag[5])
+		case 4:
+			size = int(syncsafe(tag[4:8]))
+		default:
+			size = int(binary.BigEndian.Uint32(tag[4:8]))
+		}
+
+		if size < 0 || hdrLen+size > len(tag) {
+			break
+		}
+
+		body := tag[hdrLen : hdrLen+size]
+		tag = tag[hdrLen+size:]
+
+		if id != picID {
+			continue
+		}
+
+		picType, data, ok := id3Picture(version, body)
+		if !ok {
+			continue
+		}
+
+		if picType == 3 {
+			// Front cover.
+			return data
+		}
+
+		if first == nil {
+			first = data
+		}
+	}
+
+	return first
+}
+
+// id3Picture parses an APIC (or v2.2 PIC) frame
+// body, returning its picture type and data.
+func id3Picture(version byte, b []byte) (byte, []byte, bool) {
+	if len(b) < 2 {
+		return 0, nil, false
+	}
+	enc := b[0]
+	b = b[1:]
+
+	// Skip image format / MIME type.
+	if version == 2 {
+		if len(b) < 3 {
+			return 0, nil, false
+		}
+		b = b[3:]
+	} else {
+		i := bytes.IndexByte(b, 0)
+		if i < 0 {
+			return 0, nil, false
+		}
+		b = b[i+1:]
+	}
+
+	if len(b) < 1 {
+		return 0, nil, false
+	}
+	picType := b[0]
+	b = b[1:]
+
+	// Skip description, terminated by
+	// a double zero for UTF-16 encodings.
+	if enc == 1 || enc == 2 {
+		i := 0
+		for ; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				break
+			}
+		}
+		if i+1 >= len(b) {
+			return 0, nil, false
+		}
+		b = b[i+2:]
+	} else {
+		i := bytes.IndexByte(b, 0)
+		if i < 0 {
+			return 0, nil, false
+		}
+		b = b[i+1:]
+	}
+
+	return picType, b, len(b) > 0
+}
+
+/*
+	FLAC
+*/
+
+func probeFLAC(rs io.ReadSeeker, size int64) (*gtsAudio, error) {
+	magic, err := readAt(rs, 0, 4)
+	if err != nil {
+		return nil, fmt.Errorf("error reading flac header: %w", err)
+	}
+
+	if string(magic) != "fLaC" {
+		return nil, errors.New("not a flac stream")
+	}
+
+	var (
+		audio      gtsAudio
+		sampleRate uint64
+		samples    uint64
+		off        = int64(4)
+	)
+
+	// Read metadata blocks until the last one.
+	for last := false; !last; {
+		hdr, err := readAt(rs, off, 4)
+		if err != nil {
+			return nil, fmt.Errorf("error reading flac metadata block: %w", err)
+		}
+
+		var (
+			blockType = hdr[0] & 0x7F
+			length    = int64(hdr[1])<<16 | int64(hdr[2])<<8 | int64(hdr[3])
+		)
+		last = hdr[0]&0x80 != 0
+		off += 4
+
+		switch blockType {
+		case 0: // STREAMINFO
+			info, err := readAt(rs, off, 34)
+			if err != nil {
+				return nil, fmt.Errorf("error reading flac streaminfo: %w", err)
+			}
+
+			// 20 bits sample rate, 3 bits channels - 1,
+			// 5 bits bits per sample - 1, 36 bits samples.
+			u := binary.BigEndian.Uint64(info[10:18])
+			sampleRate = u >> 44
+			audio.channels = int((u>>41)&0x7) + 1
+			samples = u & (1<<36 - 1)
+
+		case 6: // PICTURE
+			if audio.cover != nil || length > maxCoverSize {
+				break
+			}
+
+			pic, err := readAt(rs, off, int(length))
+			if err != nil {
+				return nil, fmt.Errorf("error reading flac picture: %w", err)
+			}
+			audio.cover = flacPicture(pic)
+		}
+
+		off += length
+	}
+
+	if sampleRate == 0 || samples == 0 {
+		return nil, errors.New("flac streaminfo missing sample rate or count")
+	}
+
+	duration := float64(samples) / float64(sampleRate)
+	audio.duration = float32(duration)
+	audio.bitrate = bitrateOf(size-off, duration)
+
+	return &audio, nil
+}
+
+// flacPicture returns the picture data from the
+// given FLAC (or Vorbis comment) picture block.
+func flacPicture(b []byte) []byte {
+	// Picture type, MIME type
+	// and description come first.
+	if len(b) < 8 {
+		return nil
+	}
+	mimeLen := int(binary.BigEndian.Uint32(b[4:8]))
+	b = b[min(len(b), 8+mimeLen):]
+
+	if len(b) < 4 {
+		return nil
+	}
+	descLen := int(binary.BigEndian.Uint32(b[:4]))
+	b = b[min(len(b), 4+descLen):]
+
+	// Then width, height, depth,
+	// colors and data length.
+	if len(b) < 20 {
+		return nil
+	}
+	dataLen := int(binary.BigEndian.Uint32(b[16:20]))
+	b = b[20:]
+
+	if dataLen <= 0 || dataLen > len(b) {
+		return nil
+	}
+	return b[:dataLen]
+}
+
+/*
+	OGG (Vorbis, Opus)
+*/
+
+// oggPage is a parsed Ogg page header.
+type oggPage struct {
+	granule  int64
+	serial   uint32
+	segments []byte // lacing values
+	size     int    // header + body size
+}
+
+// parseOggPage parses the Ogg page header at the start of b.
+func parseOggPage(b []byte) (oggPage, bool) {
+	if len(b) < 27 || string(b[:4]) != "OggS" {
+		return oggPage{}, false
+	}
+
+	nsegs := int(b[26])
+	if len(b) < 27+nsegs {
+		return oggPage{}, false
+	}
+
+	p := oggPage{
+		granule:  int64(binary.LittleEndian.Uint64(b[6:14])),
+		serial:   binary.LittleEndian.Uint32(b[14:18]),
+		segments: b[27 : 27+nsegs],
+		size:     27 + nsegs,
+	}
+
+	for _, l := range p.segments {
+		p.size += int(l)
+	}
+
+	return p, true
+}
+
+func probeOgg(rs io.ReadSeeker, size int64) (*gtsAudio, error) {
+	// Read the first two packets of the first logical
+	// stream: the identification and comment headers.
+	var (
+		packets [][]byte
+		packet  []byte
+		serial  uint32
+		off     int64
+		total   int
+	)
+
+	for len(packets) < 2 {
+		hdr, err := readAt(rs, off, 27)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ogg page: %w", err)
+		}
+
+		segs, err := readAt(rs, off+27, int(hdr[26]))
+		if err != nil {
+			return nil, fmt.Errorf("error reading ogg page: %w", err)
+		}
+
+		page, ok := parseOggPage(append(hdr, segs...))
+		if !ok {
+			return nil, errors.New("invalid ogg page")
+		}
+
+		if off == 0 {
+			serial = page.serial
+		}
+
+		body, err := readAt(rs, off+27+int64(len(segs)), page.size-27-len(segs))
+		if err != nil {
+			return nil, fmt.Errorf("error reading ogg page: %w", err)
+		}
+		off += int64(page.size)
+
+		if page.serial != serial {
+			// Some other stream.
+			continue
+		}
+
+		// Packets end on lacing
+		// values less than 255.
+		for _, l := range page.segments {
+			packet = append(packet, body[:l]...)
+			body = body[l:]
+			if l < 255 {
+				packets = append(packets, packet)
+				packet = nil
+			}
+		}
+
+		if total += page.size; total > maxCoverSize {
+			return nil, errors.New("ogg headers too large")
+		}
+	}
+
+	var (
+		audio    gtsAudio
+		rate     float64
+		preskip  int64
+		comments []byte
+	)
+
+	ident := packets[0]
+	switch {
+	case len(ident) >= 16 && string(ident[:7]) == "\x01vorbis":
+		audio.channels = int(ident[11])
+		rate = float64(binary.LittleEndian.Uint32(ident[12:16]))
+		comments = bytes.TrimPrefix(packets[1], []byte("\x03vorbis"))
+
+	case len(ident) >= 19 && string(ident[:8]) == "OpusHead":
+		audio.channels = int(ident[9])
+		preskip = int64(binary.LittleEndian.Uint16(ident[10:12]))
+		// Opus granule positions
+		// are always at 48kHz.
+		rate = 48000
+		comments = bytes.TrimPrefix(packets[1], []byte("OpusTags"))
+
+	default:
+		return nil, errors.New("ogg stream is neither vorbis nor opus")
+	}
+
+	if rate == 0 {
+		return nil, errors.New("ogg stream has no sample rate")
+	}
+
+	audio.cover = vorbisCommentCover(comments)
+
+	// Get the duration from the granule position
+	// of the last page of the stream, near the end.
+	const tail = 64 * 1024
+	start := size - tail
+	if start < off {
+		start = off
+	}
+
+	buf, err := readAt(rs, start, int(size-start))
+	if err != nil {
+		return nil, fmt.Errorf("error reading ogg tail: %w", err)
+	}
+
+	var granule int64 = -1
+	for i := bytes.LastIndex(buf, []byte("OggS")); i >= 0; i = bytes.LastIndex(buf[:i], []byte("OggS")) {
+		if page, ok := parseOggPage(buf[i:]); ok && page.serial == serial && page.granule >= 0 {
+			granule = page.granule
+			break
+		}
+	}
+
+	if granule <= preskip {
+		return nil, errors.New("could not find ogg stream end")
+	}
+
+	duration := float64(granule-preskip) / rate
+	audio.duration = float32(duration)
+	audio.bitrate = bitrateOf(size, duration)
+
+	return &audio, nil
+}
+
+// vorbisCommentCover returns cover art from the given
+// Vorbis comment block, stored as a base64 encoded FLAC
+// picture block in METADATA_BLOCK_PICTURE, if any.
+func vorbisCommentCover(b []byte) []byte {
+	if len(b) < 4 {
+		return nil
+	}
+	vendorLen := int(binary.LittleEndian.Uint32(b[:4]))
+	b = b[min(len(b), 4+vendorLen):]
+
+	if len(b) < 4 {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint32(b[:4]))
+	b = b[4:]
+
+	const key = "METADATA_BLOCK_PICTURE="
+	for i := 0; i < count && len(b) >= 4; i++ {
+		l := int(binary.LittleEndian.Uint32(b[:4]))
+		if l < 0 || 4+l > len(b) {
+			return nil
+		}
+		comment := string(b[4 : 4+l])
+		b = b[4+l:]
+
+		if len(comment) <= len(key) || !strings.EqualFold(comment[:len(key)], key) {
+			continue
+		}
+
+		pic, err := base64.StdEncoding.DecodeString(comment[len(key):])
+		if err != nil {
+			continue
+		}
+
+		if cover := flacPicture(pic); cover != nil {
+			return cover
+		}
+	}
+
+	return nil
+}
+
+/*
+	M4A
+*/
+
+func probeM4A(rs io.ReadSeeker) (*gtsAudio, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	info, err := mp4.Probe(rs)
+	if err != nil {
+		return nil, fmt.Errorf("error during m4a probe: %w", err)
+	}
+
+	var audio gtsAudio
+
+	for _, tr := range info.Tracks {
+		if tr.AVC != nil || tr.Timescale == 0 {
+			// Not audio.
+			continue
+		}
+
+		if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(audio.duration) {
+			audio.duration = float32(d)
+		}
+
+		if br := tr.Samples.GetBitrate(tr.Timescale); br > audio.bitrate {
+			audio.bitrate = br
+		} else if br := info.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > audio.bitrate {
+			audio.bitrate = br
+		}
+
+		if tr.MP4A != nil && int(tr.MP4A.ChannelCount) > audio.channels {
+			audio.channels = int(tr.MP4A.ChannelCount)
+		}
+	}
+
+	// Cover art lives in the iTunes-style metadata.
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	boxes, err := mp4.ExtractBoxWithPayload(rs, nil, mp4.BoxPath{
+		mp4.BoxTypeMoov(),
+		mp4.BoxTypeUdta(),
+		mp4.BoxTypeMeta(),
+		mp4.BoxTypeIlst(),
+		mp4.StrToBoxType("covr"),
+		mp4.BoxTypeData(),
+	})
+	if err != nil {
+		// Not fatal, we just
+		// won't have a cover.
+		log.Warnf(nil, "error extracting m4a cover: %v", err)
+	}
+
+	for _, box := range boxes {
+		if data, ok := box.Payload.(*mp4.Data); ok && len(data.Data) > 0 {
+			audio.cover = data.Data
+			break
+		}
+	}
+
+	return &audio, nil
+}
@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// ShareFile registers the file that was just written to the given storage
+// path, with the given hex-encoded SHA-256 content hash and size in bytes.
+//
+// If an identical file is already stored elsewhere, the new copy is removed
+// from storage, a reference is added to the existing file, and the path of
+// the existing file is returned to be used instead. Otherwise the new file
+// is registered with a single reference, and the given path is returned.
+func ShareFile(ctx context.Context, state *state.State, path string, hash string, size int64) (string, error) {
+	newPath, err := RegisterFile(ctx, state, path, hash, size)
+	if err != nil {
+		return "", err
+	}
+
+	if newPath != path {
+		// Remove our now redundant copy of the file.
+		if err := state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error removing duplicate file %s: %v", path, err)
+		}
+	}
+
+	return newPath, nil
+}
+
+// RegisterFile is like ShareFile, but leaves any now redundant copy of the
+// file at the given path in storage. This is for files that something is
+// already pointed at, so the copy can be removed only once that's updated
+// to point at the returned path instead. If the update fails, the reference
+// added to the returned path should be dropped again with ReleaseFile.
+func RegisterFile(ctx context.Context, state *state.State, path string, hash string, size int64) (string, error) {
+	blob, err := state.DB.GetMediaBlobByHash(ctx, hash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", gtserror.Newf("error getting media blob: %w", err)
+	}
+
+	if blob != nil && blob.Path != path {
+		shared, err := refBlob(ctx, state, blob)
+		if err != nil {
+			return "", err
+		}
+
+		if shared {
+			return blob.Path, nil
+		}
+
+		// The existing file can't be shared, so
+		// keep our own copy. We can't register it
+		// under the same hash, but it'll be treated
+		// as unshared just like any file stored
+		// before deduplication was introduced.
+		return path, nil
+	}
+
+	if blob != nil {
+		// Already registered at this path,
+		// (eg., identical file recached to
+		// where it was before), just add a ref.
+		if _, err := refBlob(ctx, state, blob); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	// Register new file.
+	blob = &gtsmodel.MediaBlob{
+		ID:       id.NewULID(),
+		Hash:     hash,
+		Path:     path,
+		Size:     size,
+		RefCount: 1,
+	}
+
+	if err := state.DB.PutMediaBlob(ctx, blob); err != nil {
+		if !errors.Is(err, db.ErrAlreadyExists) {
+			return "", gtserror.Newf("error putting media blob: %w", err)
+		}
+
+		// An identical file was registered concurrently,
+		// just keep ours as an unshared file (see above).
+		log.Warnf(ctx, "media blob %s already exists", hash)
+	}
+
+	return path, nil
+}
+
+// refBlob adds a reference to the given media blob, if
+// its file is still in storage and can still be shared.
+func refBlob(ctx context.Context, state *state.State, blob *gtsmodel.MediaBlob) (bool, error) {
+	// Make sure existing file is still
+	// there before pointing anything at it.
+	have, err := state.Storage.Has(ctx, blob.Path)
+	if err != nil {
+		return false, gtserror.Newf("error checking storage for %s: %w", blob.Path, err)
+	}
+
+	if !have {
+		log.Warnf(ctx, "media blob %s missing from storage", blob.Path)
+		return false, nil
+	}
+
+	ok, err := state.DB.RefMediaBlob(ctx, blob.ID)
+	if err != nil {
+		return false, gtserror.Newf("error referencing media blob: %w", err)
+	}
+
+	return ok, nil
+}
+
+// ReleaseFile drops a reference to the file at the given storage path,
+// only removing it from storage once nothing else references it. Files
+// with no record of references (ie., stored before deduplication was
+// introduced) are simply removed. Returns whether the file was removed.
+func ReleaseFile(ctx context.Context, state *state.State, path string) (bool, error) {
+	blob, err := state.DB.GetMediaBlobByPath(ctx, path)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting media blob: %w", err)
+	}
+
+	if blob != nil {
+		refs, err := state.DB.UnrefMediaBlob(ctx, blob.ID)
+		if err != nil {
+			return false, gtserror.Newf("error unreferencing media blob: %w", err)
+		}
+
+		if refs > 0 {
+			// Still in use
+			// elsewhere.
+			return false, nil
+		}
+	}
+
+	if err := state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+		return false, gtserror.Newf("error removing %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// IsSharedFile returns whether the file at the given storage
+// path is registered as a shared file, still in use by media.
+func IsSharedFile(ctx context.Context, state *state.State, path string) (bool, error) {
+	blob, err := state.DB.GetMediaBlobByPath(ctx, path)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting media blob: %w", err)
+	}
+	return blob != nil && blob.RefCount > 0, nil
+}
+
+// prepPath gets the given storage path ready for a new file to be written
+// to it. Any existing file at the path is removed, unless it's a shared file
+// still in use by other media, in which case the path returned by alt is
+// returned to be written to instead.
+func (m *Manager) prepPath(ctx context.Context, path string, alt func() string) (string, error) {
+	// This shouldn't already exist, but
+	// we do a check as it's worth logging.
+	if have, _ := m.state.Storage.Has(ctx, path); !have {
+		return path, nil
+	}
+
+	shared, err := IsSharedFile(ctx, m.state, path)
+	if err != nil {
+		return "", err
+	}
+
+	if shared {
+		// Leave it well alone.
+		return alt(), nil
+	}
+
+	log.Warnf(ctx, "media already exists at storage path: %s", path)
+
+	// Attempt to remove existing media at storage path (might be broken / out-of-date)
+	if err := m.state.Storage.Delete(ctx, path); err != nil {
+		return "", gtserror.Newf("error removing media from storage: %v", err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,219 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/jpeg"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// derivativeMaxAspect is the most extreme aspect ratio, either way,
+// of image derivatives. Images beyond this (eg., long screenshots or
+// panoramas) are cropped around their focus point, as they would be
+// when shown as a preview anyway.
+const derivativeMaxAspect = 3
+
+// DerivativeSize returns the media size key
+// for image derivatives of the given width.
+func DerivativeSize(width int) Size {
+	return Size("w" + strconv.Itoa(width))
+}
+
+// CropFocus returns a copy of gtsImage{} cropped to the given max
+// aspect ratio (either way) around the given focus point, or the
+// image itself if its aspect ratio is within the max already.
+func (m *gtsImage) CropFocus(focus gtsmodel.Focus, maxAspect float32) *gtsImage {
+	var (
+		width  = int(m.Width())
+		height = int(m.Height())
+		aspect = m.AspectRatio()
+		rect   image.Rectangle
+	)
+
+	// Focus point in pixels. Focus x runs
+	// from -1 (left) to 1 (right), and y
+	// runs from 1 (top) to -1 (bottom).
+	focusX := int((focus.X + 1) / 2 * float32(width))
+	focusY := int((1 - focus.Y) / 2 * float32(height))
+
+	switch {
+	case aspect > maxAspect:
+		// Too wide, crop the sides.
+		w := int(float32(height) * maxAspect)
+		x := clamp(focusX-w/2, 0, width-w)
+		rect = image.Rect(x, 0, x+w, height)
+
+	case aspect < 1/maxAspect:
+		// Too tall, crop top and bottom.
+		h := int(float32(width) * maxAspect)
+		y := clamp(focusY-h/2, 0, height-h)
+		rect = image.Rect(0, y, width, y+h)
+
+	default:
+		// Nothing to crop.
+		return m
+	}
+
+	// Image bounds may not start at 0,0.
+	rect = rect.Add(m.image.Bounds().Min)
+
+	img := imaging.Crop(m.image, rect)
+	return &gtsImage{image: img, blank: m.blank}
+}
+
+// ResizeWidth returns a copy of gtsImage{}
+// scaled to the given width, keeping its
+// aspect ratio.
+func (m *gtsImage) ResizeWidth(width int) *gtsImage {
+	img := imaging.Resize(m.image, width, 0, imaging.Linear)
+	return &gtsImage{image: img, blank: m.blank}
+}
+
+// storeDerivatives encodes and stores scaled-down copies of the given
+// full-size image at each of the configured derivative widths narrower
+// than the image, setting them on the attachment.
+func (p *ProcessingMedia) storeDerivatives(ctx context.Context, fullImg *gtsImage) error {
+	// Widths to make, smallest first.
+	widths := slices.Clone(config.GetMediaDerivativeWidths())
+	slices.Sort(widths)
+	widths = slices.Compact(widths)
+
+	// Crop once up front, so
+	// all derivatives match.
+	img := fullImg.CropFocus(
+		p.media.FileMeta.Focus,
+		derivativeMaxAspect,
+	)
+
+	derivatives := make([]*gtsmodel.Derivative, 0, len(widths))
+	for _, width := range widths {
+		if width <= 0 || width >= int(img.Width()) {
+			// Never scale up, the
+			// original will do.
+			continue
+		}
+
+		derivative, err := p.storeDerivative(ctx, img.ResizeWidth(width))
+		if err != nil {
+			return err
+		}
+
+		derivatives = append(derivatives, derivative)
+	}
+
+	p.media.Derivatives = derivatives
+	return nil
+}
+
+// storeDerivative encodes the given scaled-down image as JPEG and
+// stores it as a derivative of the attachment, sharing the file
+// with any identical derivative already in storage.
+func (p *ProcessingMedia) storeDerivative(ctx context.Context, img *gtsImage) (*gtsmodel.Derivative, error) {
+	var (
+		width = int(img.Width())
+		size  = string(DerivativeSize(width))
+		err   error
+	)
+
+	path := uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		size,
+		p.media.ID,
+		"jpg",
+	)
+
+	// Derivative shouldn't already exist in storage at this point,
+	// but we do a check as it's worth logging / cleaning up.
+	path, err = p.mgr.prepPath(ctx, path, func() string {
+		return uris.StoragePathForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			size,
+			id.NewULID(),
+			"jpg",
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a JPEG encoder stream,
+	// hashing the encoded file as we go.
+	hash := sha256.New()
+	enc := io.TeeReader(img.ToJPEG(&jpeg.Options{
+		// A little better than
+		// thumbnails, as these
+		// may be shown larger.
+		Quality: 80,
+	}), hash)
+
+	// Stream-encode the JPEG derivative into storage.
+	sz, err := p.mgr.state.Storage.PutStream(ctx, path, enc)
+	if err != nil {
+		return nil, gtserror.Newf("error stream-encoding derivative to storage: %w", err)
+	}
+
+	// Share the derivative with any identical
+	// media derivative already in storage.
+	path, err = ShareFile(ctx,
+		p.mgr.state,
+		path,
+		hex.EncodeToString(hash.Sum(nil)),
+		sz,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error sharing derivative file: %w", err)
+	}
+
+	return &gtsmodel.Derivative{
+		Width:       width,
+		Height:      int(img.Height()),
+		Path:        path,
+		ContentType: mimeImageJpeg,
+		FileSize:    int(sz),
+	}, nil
+}
+
+// DerivativePaths returns the storage
+// paths of the given attachment's derivatives.
+func DerivativePaths(attachment *gtsmodel.MediaAttachment) []string {
+	paths := make([]string, 0, len(attachment.Derivatives))
+	for _, derivative := range attachment.Derivatives {
+		paths = append(paths, derivative.Path)
+	}
+	return paths
+}
+
+// clamp returns i clamped to within [lo, hi].
+func clamp(i, lo, hi int) int {
+	return max(lo, min(i, hi))
+}
@@ -0,0 +1,115 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"image/jpeg"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type DerivativeTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *DerivativeTestSuite) TestJpegDerivatives() {
+	ctx := context.Background()
+
+	// Duplicates, nonsense and widths larger
+	// than the image should all be skipped.
+	config.SetMediaDerivativeWidths([]int{640, 0, 4000, 320, 640})
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	processing := suite.manager.PreProcessMedia(data, "01FS1X72SK9ZPW0J1QQ68BD264", nil)
+	attachment, err := processing.LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// 1920x1080 test image scaled to width.
+	suite.Len(attachment.Derivatives, 2)
+	for i, expect := range []struct{ width, height int }{
+		{320, 180},
+		{640, 360},
+	} {
+		derivative := attachment.Derivatives[i]
+		suite.Equal(expect.width, derivative.Width)
+		suite.Equal(expect.height, derivative.Height)
+		suite.Equal("image/jpeg", derivative.ContentType)
+		suite.True(strings.Contains(derivative.Path, "/attachment/"+string(media.DerivativeSize(expect.width))+"/"))
+
+		// Derivative should be stored
+		// as a JPEG of the right size.
+		b, err := suite.storage.Get(ctx, derivative.Path)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Len(b, derivative.FileSize)
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(b))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(expect.width, cfg.Width)
+		suite.Equal(expect.height, cfg.Height)
+	}
+
+	// Derivatives are counted toward quotas.
+	used := media.StorageUsed(attachment)
+	suite.Equal(int64(attachment.File.FileSize+attachment.Thumbnail.FileSize+
+		attachment.Derivatives[0].FileSize+attachment.Derivatives[1].FileSize), used)
+}
+
+func (suite *DerivativeTestSuite) TestNoDerivativesForVideo() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		b, err := os.ReadFile("./test/test-mp4-original.mp4")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	processing := suite.manager.PreProcessMedia(data, "01FS1X72SK9ZPW0J1QQ68BD264", nil)
+	attachment, err := processing.LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(attachment.Derivatives)
+}
+
+func TestDerivativeTestSuite(t *testing.T) {
+	suite.Run(t, new(DerivativeTestSuite))
+}
@@ -19,6 +19,7 @@ package media
 
 import (
 	"bufio"
+	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
@@ -30,6 +31,7 @@ import (
 	"github.com/buckket/go-blurhash"
 	"github.com/disintegration/imaging"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 
 	// import to init webp encode/decoding.
 	_ "golang.org/x/image/webp"
@@ -55,7 +57,13 @@ var (
 // interface to provide our own useful helper functions for image
 // size and aspect ratio calculations, streamed encoding to various
 // types, and creating reduced size thumbnail images.
-type gtsImage struct{ image image.Image }
+type gtsImage struct {
+	image image.Image
+
+	// blank is set for placeholder
+	// images, not decoded from media.
+	blank bool
+}
 
 // blankImage generates a blank image of given dimensions.
 func blankImage(width int, height int) *gtsImage {
@@ -67,7 +75,7 @@ func blankImage(width int, height int) *gtsImage {
 		color.RGBA{42, 43, 47, 0},
 	}, image.Point{}, draw.Src)
 
-	return &gtsImage{image: img}
+	return &gtsImage{image: img, blank: true}
 }
 
 // decodeImage will decode image from reader stream and return image wrapped in our own gtsImage{} type.
@@ -79,6 +87,47 @@ func decodeImage(r io.Reader, opts ...imaging.DecodeOption) (*gtsImage, error) {
 	return &gtsImage{image: img}, nil
 }
 
+// decodeHEIFImage probes the dimensions of the HEIF or AVIF image in the
+// given reader stream, returning a blank image of the same dimensions.
+// (note: we can't decode HEVC or AV1 coded images in pure Go, so as
+// with video frames this only returns a blank image for now).
+func decodeHEIFImage(r io.Reader) (*gtsImage, error) {
+	// Check if image stream supports
+	// seeking, usually when *os.File.
+	rsc, ok := r.(io.ReadSeekCloser)
+	if !ok {
+		var err error
+
+		// Store stream to temporary location
+		// in order that we can get seek-reads.
+		rsc, err = iotools.TempFileSeeker(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating temp file seeker: %w", err)
+		}
+
+		defer func() {
+			// Ensure temp. read seeker closed.
+			if err := rsc.Close(); err != nil {
+				log.Errorf(nil, "error closing temp file seeker: %s", err)
+			}
+		}()
+	}
+
+	// Get total size of the
+	// stream by seeking to end.
+	size, err := rsc.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking to end of image: %w", err)
+	}
+
+	width, height, err := probeHEIF(rsc, size)
+	if err != nil {
+		return nil, fmt.Errorf("error during heif probe: %w", err)
+	}
+
+	return blankImage(width, height), nil
+}
+
 // Width returns the image width in pixels.
 func (m *gtsImage) Width() uint32 {
 	return uint32(m.image.Bounds().Size().X)
@@ -112,12 +161,12 @@ func (m *gtsImage) Thumbnail() *gtsImage {
 
 	// Check the receiving image is within max thumnail bounds.
 	if m.Width() <= maxWidth && m.Height() <= maxHeight {
-		return &gtsImage{image: imaging.Clone(m.image)}
+		return &gtsImage{image: imaging.Clone(m.image), blank: m.blank}
 	}
 
 	// Image is too large, needs to be resized to thumbnail max.
 	img := imaging.Fit(m.image, maxWidth, maxHeight, imaging.Linear)
-	return &gtsImage{image: img}
+	return &gtsImage{image: img, blank: m.blank}
 }
 
 // Blurhash calculates the blurhash for the receiving image data.
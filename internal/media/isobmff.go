@@ -0,0 +1,334 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers"
+	"github.com/h2non/filetype/matchers/isobmff"
+	"github.com/h2non/filetype/types"
+)
+
+// maxMetaSize is the largest ISOBMFF 'meta'
+// box that we'll read into memory when probing
+// HEIF and AVIF images. In practice these are
+// only a handful of kilobytes.
+const maxMetaSize = 4 * 1024 * 1024
+
+// typeAvif is the filetype type for AVIF images,
+// which the filetype library doesn't know about.
+var typeAvif = types.NewType(mimeAvif, mimeImageAvif)
+
+func init() {
+	// Register our AVIF matcher. Custom
+	// matchers are checked before those
+	// built in to the filetype library.
+	filetype.AddMatcher(typeAvif, matchAvif)
+
+	// Replace library's mov matcher.
+	filetype.AddMatcher(matchers.TypeMov, matchQuicktime)
+}
+
+// matchAvif returns whether the given
+// file header belongs to an AVIF image.
+func matchAvif(buf []byte) bool {
+	if !isobmff.IsISOBMFF(buf) {
+		return false
+	}
+
+	major, _, compatible := isobmff.GetFtyp(buf)
+	switch major {
+	case "avif", "avis":
+		return true
+	case "mif1", "msf1":
+		for _, brand := range compatible {
+			if brand == "avif" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchQuicktime returns whether the given file
+// header belongs to a QuickTime movie. This is
+// stricter than the filetype library's own mov
+// matcher, which can also match some mp4 files.
+func matchQuicktime(buf []byte) bool {
+	if !isobmff.IsISOBMFF(buf) {
+		return false
+	}
+	major, _, _ := isobmff.GetFtyp(buf)
+	return major == "qt  "
+}
+
+// isoBox is a single box
+// in an ISOBMFF structure.
+type isoBox struct {
+	typ  string
+	data []byte // payload, without header
+}
+
+// parseISOBoxes parses the sequence of ISOBMFF boxes
+// contained in b, stopping at the first malformed box.
+func parseISOBoxes(b []byte) []isoBox {
+	var boxes []isoBox
+
+	for len(b) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(b[0:4]))
+		typ := string(b[4:8])
+		hdr := uint64(8)
+
+		switch size {
+		case 0:
+			// Box extends to end.
+			size = uint64(len(b))
+		case 1:
+			// 64-bit largesize.
+			if len(b) < 16 {
+				return boxes
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			hdr = 16
+		}
+
+		if size < hdr || size > uint64(len(b)) {
+			return boxes
+		}
+
+		boxes = append(boxes, isoBox{
+			typ:  typ,
+			data: b[hdr:size],
+		})
+		b = b[size:]
+	}
+
+	return boxes
+}
+
+// findISOBox looks through the top-level boxes in rs for
+// the first box of the given type, returning the offset
+// and length of its payload, or an error if not found.
+func findISOBox(rs io.ReadSeeker, size int64, typ string) (int64, int64, error) {
+	var off int64
+
+	for off+8 <= size {
+		hdr, err := readAt(rs, off, 8)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(hdr[0:4]))
+		hdrSize := int64(8)
+
+		switch boxSize {
+		case 0:
+			// Box extends to end.
+			boxSize = size - off
+		case 1:
+			// 64-bit largesize.
+			large, err := readAt(rs, off+8, 8)
+			if err != nil {
+				return 0, 0, err
+			}
+			boxSize = int64(binary.BigEndian.Uint64(large))
+			hdrSize = 16
+		}
+
+		if boxSize < hdrSize || off+boxSize > size {
+			return 0, 0, errors.New("malformed box")
+		}
+
+		if string(hdr[4:8]) == typ {
+			return off + hdrSize, boxSize - hdrSize, nil
+		}
+
+		off += boxSize
+	}
+
+	return 0, 0, fmt.Errorf("%s box not found", typ)
+}
+
+// probeHEIF reads the dimensions of the primary image
+// in the given HEIF (or AVIF) image, taking into account
+// any rotation which should be applied on display.
+//
+// See ISO/IEC 23008-12 for the details of the format.
+func probeHEIF(rs io.ReadSeeker, size int64) (int, int, error) {
+	off, n, err := findISOBox(rs, size, "meta")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if n < 4 || n > maxMetaSize {
+		return 0, 0, fmt.Errorf("invalid meta box size %d", n)
+	}
+
+	meta, err := readAt(rs, off, int(n))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		primary uint32
+		props   []isoBox
+		assocs  map[uint32][]int
+	)
+
+	// Skip meta full box version + flags.
+	for _, box := range parseISOBoxes(meta[4:]) {
+		switch box.typ {
+		case "pitm":
+			primary = parsePitm(box.data)
+		case "iprp":
+			for _, child := range parseISOBoxes(box.data) {
+				switch child.typ {
+				case "ipco":
+					props = parseISOBoxes(child.data)
+				case "ipma":
+					assocs = parseIpma(child.data)
+				}
+			}
+		}
+	}
+
+	var (
+		width  int
+		height int
+		rotate bool
+	)
+
+	for _, idx := range assocs[primary] {
+		// Property indices are 1-based.
+		if idx < 1 || idx > len(props) {
+			continue
+		}
+
+		prop := props[idx-1]
+		switch prop.typ {
+		case "ispe":
+			// Version + flags, then width + height.
+			if len(prop.data) >= 12 {
+				w := binary.BigEndian.Uint32(prop.data[4:8])
+				h := binary.BigEndian.Uint32(prop.data[8:12])
+				if err := checkDimensions(uint64(w), uint64(h)); err != nil {
+					return 0, 0, err
+				}
+				width, height = int(w), int(h)
+			}
+		case "irot":
+			// Anticlockwise rotation in units of 90 degrees.
+			if len(prop.data) >= 1 {
+				rotate = (prop.data[0]&0x3)%2 == 1
+			}
+		}
+	}
+
+	if width == 0 || height == 0 {
+		return 0, 0, errors.New("primary image dimensions not found")
+	}
+
+	if rotate {
+		width, height = height, width
+	}
+
+	return width, height, nil
+}
+
+// parsePitm returns the primary
+// item ID from a 'pitm' box payload.
+func parsePitm(b []byte) uint32 {
+	switch {
+	case len(b) >= 8 && b[0] != 0:
+		return binary.BigEndian.Uint32(b[4:8])
+	case len(b) >= 6:
+		return uint32(binary.BigEndian.Uint16(b[4:6]))
+	default:
+		return 0
+	}
+}
+
+// parseIpma parses an 'ipma' box payload, returning
+// property indices associated with each item ID.
+func parseIpma(b []byte) map[uint32][]int {
+	if len(b) < 8 {
+		return nil
+	}
+
+	var (
+		version = b[0]
+		flags   = b[3]
+		count   = binary.BigEndian.Uint32(b[4:8])
+		assocs  = make(map[uint32][]int)
+	)
+
+	b = b[8:]
+	for i := uint32(0); i < count; i++ {
+		var itemID uint32
+
+		if version < 1 {
+			if len(b) < 2 {
+				break
+			}
+			itemID = uint32(binary.BigEndian.Uint16(b))
+			b = b[2:]
+		} else {
+			if len(b) < 4 {
+				break
+			}
+			itemID = binary.BigEndian.Uint32(b)
+			b = b[4:]
+		}
+
+		if len(b) < 1 {
+			break
+		}
+		n := int(b[0])
+		b = b[1:]
+
+		for j := 0; j < n; j++ {
+			var idx int
+
+			// Top bit of each entry is the 'essential'
+			// flag, the rest is the property index.
+			if flags&1 == 1 {
+				if len(b) < 2 {
+					return assocs
+				}
+				idx = int(binary.BigEndian.Uint16(b) & 0x7fff)
+				b = b[2:]
+			} else {
+				if len(b) < 1 {
+					return assocs
+				}
+				idx = int(b[0] & 0x7f)
+				b = b[1:]
+			}
+
+			assocs[itemID] = append(assocs[itemID], idx)
+		}
+	}
+
+	return assocs
+}
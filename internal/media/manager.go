@@ -23,12 +23,12 @@ import (
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
-	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -38,7 +38,16 @@ var SupportedMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
 	mimeImageWebp,
+	mimeImageAvif,
+	mimeImageHeif,
 	mimeVideoMp4,
+	mimeVideoQuicktime,
+	mimeVideoWebm,
+	mimeVideoMatroska,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
+	mimeAudioMp4,
 }
 
 var SupportedEmojiMIMETypes = []string{
@@ -49,11 +58,21 @@ var SupportedEmojiMIMETypes = []string{
 
 type Manager struct {
 	state *state.State
+
+	// In-memory cache of
+	// proxied remote media.
+	proxy *ProxyCache
 }
 
 // NewManager returns a media manager with given state.
 func NewManager(state *state.State) *Manager {
-	return &Manager{state: state}
+	return &Manager{
+		state: state,
+		proxy: NewProxyCache(
+			config.GetMediaRemoteProxyCacheTTL(),
+			int64(config.GetMediaRemoteProxyCacheMaxSize()),
+		),
+	}
 }
 
 // PreProcessMedia begins the process of decoding
@@ -96,6 +115,7 @@ func (m *Manager) PreProcessMedia(
 		Avatar:    util.Ptr(false),
 		Header:    util.Ptr(false),
 		Cached:    util.Ptr(false),
+		Proxied:   util.Ptr(false),
 	}
 
 	attachment.URL = uris.URIForAttachment(
@@ -249,6 +269,7 @@ func (m *Manager) PreProcessEmoji(
 		originalData := data
 		originalImagePath := emoji.ImagePath
 		originalImageStaticPath := emoji.ImageStaticPath
+		originalCached := *emoji.Cached
 
 		data = func(ctx context.Context) (io.ReadCloser, int64, error) {
 			// Call original data func.
@@ -259,11 +280,17 @@ func (m *Manager) PreProcessEmoji(
 
 			// Wrap closer to cleanup old data.
 			c := iotools.CloserCallback(rc, func() {
-				if err := m.state.Storage.Delete(ctx, originalImagePath); err != nil && !storage.IsNotFound(err) {
+				if !originalCached {
+					// Old images were already
+					// released when uncached.
+					return
+				}
+
+				if _, err := ReleaseFile(ctx, m.state, originalImagePath); err != nil {
 					log.Errorf(ctx, "error removing old emoji %s@%s from storage: %v", emoji.Shortcode, emoji.Domain, err)
 				}
 
-				if err := m.state.Storage.Delete(ctx, originalImageStaticPath); err != nil && !storage.IsNotFound(err) {
+				if _, err := ReleaseFile(ctx, m.state, originalImageStaticPath); err != nil {
 					log.Errorf(ctx, "error removing old static emoji %s@%s from storage: %v", emoji.Shortcode, emoji.Domain, err)
 				}
 			})
@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -786,13 +787,155 @@ func (suite *ManagerTestSuite) TestBirdnestMp4ProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
-func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
-	// try to load an 'mp4' that's actually an mkv in disguise
+func (suite *ManagerTestSuite) TestMp3ProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test audio file
+		b, err := os.ReadFile("./test/test-mp3-original.mp3")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+	// fetch the attachment id from the processing media
+	attachmentID := processingMedia.AttachmentID()
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// make sure it's got the stuff set on it that we expect
+	// the attachment ID and accountID we expect
+	suite.Equal(attachmentID, attachment.ID)
+	suite.Equal(accountID, attachment.AccountID)
+
+	// file meta should be correctly derived from the audio,
+	// with the small image taken from the embedded cover art
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.EqualValues(float32(2.6122448), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(127706, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(2, *attachment.FileMeta.Original.Channels)
+	suite.Nil(attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/mpeg", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(62807, attachment.File.FileSize)
+	suite.Equal("LiB::C#6V[WF_Nv|V@WY_3v}V@a$", attachment.Blurhash)
 
+	// now make sure the attachment is in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	suite.NoError(err)
+	suite.NotNil(dbAttachment)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// audio is stored as-is, so it should match the original
+	processedFullBytesExpected, err := os.ReadFile("./test/test-mp3-original.mp3")
+	suite.NoError(err)
+	suite.Equal(processedFullBytesExpected, processedFullBytes)
+
+	// the thumbnail should be in storage too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestFlacProcessBlocking() {
 	ctx := context.Background()
 
 	data := func(_ context.Context) (io.ReadCloser, int64, error) {
-		// load bytes from a test video
+		// load bytes from a test audio file
+		b, err := os.ReadFile("./test/test-flac-original.flac")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the audio,
+	// with the small image taken from the embedded cover art
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(2), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(120008, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(2, *attachment.FileMeta.Original.Channels)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/flac", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(51063, attachment.File.FileSize)
+	suite.Equal("LiB::C#6V[WF_Nv|V@WY_3v}V@a$", attachment.Blurhash)
+}
+
+func (suite *ManagerTestSuite) TestOpusProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test audio file
+		b, err := os.ReadFile("./test/test-opus-original.opus")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the audio;
+	// there's no cover art so we should get a blank thumbnail
+	suite.Equal(gtsmodel.FileTypeAudio, attachment.Type)
+	suite.EqualValues(float32(3), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(68349, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(2, *attachment.FileMeta.Original.Channels)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 512, Size: 262144, Aspect: 1,
+	}, attachment.FileMeta.Small)
+	suite.Equal("audio/ogg", attachment.File.ContentType)
+	suite.Equal("ogg", attachment.File.Path[len(attachment.File.Path)-3:])
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(25631, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+}
+
+func (suite *ManagerTestSuite) TestVP9Mp4ProcessBlocking() {
+	// load an mp4 containing vp9 video, which has no avc info
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
 		b, err := os.ReadFile("./test/not-an.mp4")
 		if err != nil {
 			panic(err)
@@ -802,17 +945,386 @@ func (suite *ManagerTestSuite) TestNotAnMp4ProcessBlocking() {
 
 	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
 
-	// pre processing should go fine but...
+	// process the media with no additional info provided
 	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
 
-	// we should get an error while loading
+	// do a blocking call to fetch the attachment
 	attachment, err := processingMedia.LoadAttachment(ctx)
-	suite.EqualError(err, "finish: error decoding video: error determining video metadata: [width height framerate]")
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(1920, attachment.FileMeta.Original.Width)
+	suite.Equal(1080, attachment.FileMeta.Original.Height)
+	suite.Equal(2073600, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(1.7777778), attachment.FileMeta.Original.Aspect)
+	suite.EqualValues(float32(3.878875), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(float32(23.976025), *attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(3743915, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("video/mp4", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(1819035, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestTruncatedWebmProcessBlocking() {
+	// load just the headers of a webm, with no tracks to probe
+
+	ctx := context.Background()
 
-	// partial attachment should be
-	// returned, with 'unknown' type.
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-webm-original.webm")
+		if err != nil {
+			panic(err)
+		}
+		b = b[:48]
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
 	suite.NotNil(attachment)
-	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
+
+	// still a video, but with no metadata
+	// and a generic placeholder thumbnail
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Zero(attachment.FileMeta.Original.Width)
+	suite.Zero(attachment.FileMeta.Original.Height)
+	suite.Nil(attachment.FileMeta.Original.Duration)
+	suite.Nil(attachment.FileMeta.Original.Framerate)
+	suite.Nil(attachment.FileMeta.Original.Bitrate)
+	suite.Equal("video/webm", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+
+	// make sure the thumbnail is in storage
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestMovProcessBlocking() {
+	// load a portrait quicktime movie, rotated by its track matrix
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-mov-original.mov")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(330, attachment.FileMeta.Original.Width)
+	suite.Equal(600, attachment.FileMeta.Original.Height)
+	suite.Equal(198000, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(0.55), attachment.FileMeta.Original.Aspect)
+	suite.EqualValues(float32(16.6), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(float32(10), *attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(51451, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 281, Height: 512, Size: 143872, Aspect: 0.5488281,
+	}, attachment.FileMeta.Small)
+	suite.Equal("video/quicktime", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(109549, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestWebmProcessBlocking() {
+	// load a webm recorded in the browser, with no declared duration
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-webm-original.webm")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(640, attachment.FileMeta.Original.Width)
+	suite.Equal(360, attachment.FileMeta.Original.Height)
+	suite.Equal(230400, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(1.7777778), attachment.FileMeta.Original.Aspect)
+	suite.EqualValues(float32(2), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(float32(25), *attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(134696, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("video/webm", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(33674, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestMkvProcessBlocking() {
+	// load an mkv with declared duration and frame length
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-mkv-original.mkv")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.Equal(640, attachment.FileMeta.Original.Width)
+	suite.Equal(360, attachment.FileMeta.Original.Height)
+	suite.Equal(230400, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(1.7777778), attachment.FileMeta.Original.Aspect)
+	suite.EqualValues(float32(3), *attachment.FileMeta.Original.Duration)
+	suite.EqualValues(float32(30), *attachment.FileMeta.Original.Framerate)
+	suite.EqualValues(161565, *attachment.FileMeta.Original.Bitrate)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("video/x-matroska", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(60587, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestHeicProcessBlocking() {
+	// load a heic image, rotated by its irot property
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-heic-original.heic")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal(600, attachment.FileMeta.Original.Width)
+	suite.Equal(800, attachment.FileMeta.Original.Height)
+	suite.Equal(480000, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(0.75), attachment.FileMeta.Original.Aspect)
+	suite.Nil(attachment.FileMeta.Original.Duration)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 384, Height: 512, Size: 196608, Aspect: 0.75,
+	}, attachment.FileMeta.Small)
+	suite.Equal("image/heif", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(4264, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestAvifProcessBlocking() {
+	// load an avif image
+
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test file
+		b, err := os.ReadFile("./test/test-avif-original.avif")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// file meta should be correctly derived from the file
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal(1280, attachment.FileMeta.Original.Width)
+	suite.Equal(720, attachment.FileMeta.Original.Height)
+	suite.Equal(921600, attachment.FileMeta.Original.Size)
+	suite.EqualValues(float32(1.7777778), attachment.FileMeta.Original.Aspect)
+	suite.Nil(attachment.FileMeta.Original.Duration)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777778,
+	}, attachment.FileMeta.Small)
+	suite.Equal("image/avif", attachment.File.ContentType)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+	suite.Equal(4258, attachment.File.FileSize)
+	suite.Equal("L00000fQfQfQfQfQfQfQfQfQfQfQ", attachment.Blurhash)
+
+	// make sure the processed file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	// and the thumbnail too
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+}
+
+func (suite *ManagerTestSuite) TestDuplicateJpegProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the same media twice
+	attachment1, err := suite.manager.PreProcessMedia(data, accountID, nil).LoadAttachment(ctx)
+	suite.NoError(err)
+	attachment2, err := suite.manager.PreProcessMedia(data, accountID, nil).LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotEqual(attachment1.ID, attachment2.ID)
+
+	// the second should share the files of the first
+	suite.Equal(attachment1.File.Path, attachment2.File.Path)
+	suite.Equal(attachment1.Thumbnail.Path, attachment2.Thumbnail.Path)
+
+	// and nothing should be stored under its own paths
+	ownPath := uris.StoragePathForAttachment(accountID, string(media.TypeAttachment), string(media.SizeOriginal), attachment2.ID, "jpg")
+	have, err := suite.storage.Has(ctx, ownPath)
+	suite.NoError(err)
+	suite.False(have)
+
+	// releasing the first should leave the shared file in place
+	removed, err := media.ReleaseFile(ctx, &suite.state, attachment1.File.Path)
+	suite.NoError(err)
+	suite.False(removed)
+	have, err = suite.storage.Has(ctx, attachment2.File.Path)
+	suite.NoError(err)
+	suite.True(have)
+
+	// releasing the last reference should remove it
+	removed, err = media.ReleaseFile(ctx, &suite.state, attachment2.File.Path)
+	suite.NoError(err)
+	suite.True(removed)
+	have, err = suite.storage.Has(ctx, attachment2.File.Path)
+	suite.NoError(err)
+	suite.False(have)
 }
 
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoContentLengthGiven() {
@@ -1389,6 +1901,36 @@ func (suite *ManagerTestSuite) TestNoReportedSizeSmallMedia() {
 	suite.Equal(actualSize, attachment.File.FileSize)
 }
 
+func (suite *ManagerTestSuite) TestProxyMediaUnknownLength() {
+	ctx := context.Background()
+
+	// Stored size no longer matches the file at the origin.
+	attachment := &gtsmodel.MediaAttachment{ID: "01J0PROXYUNKNOWNLENGTH00000"}
+	attachment.File.FileSize = 4
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// no content length given
+		return io.NopCloser(bytes.NewBufferString("0123456789")), 0, nil
+	}
+
+	rc, sz, err := suite.manager.ProxyMedia(ctx, attachment, data)
+	suite.NoError(err)
+	b, err := io.ReadAll(rc)
+	suite.NoError(err)
+	suite.Equal("0123456789", string(b))
+	suite.EqualValues(10, sz)
+
+	// Whole file should have been cached.
+	rc, sz, err = suite.manager.ProxyMedia(ctx, attachment, func(context.Context) (io.ReadCloser, int64, error) {
+		panic("should be cached")
+	})
+	suite.NoError(err)
+	b, err = io.ReadAll(rc)
+	suite.NoError(err)
+	suite.Equal("0123456789", string(b))
+	suite.EqualValues(10, sz)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
@@ -0,0 +1,431 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Matroska (and WebM) element IDs that we care
+// about; see https://www.matroska.org/technical/elements.html
+const (
+	ebmlIDHeader          = 0x1A45DFA3
+	ebmlIDSegment         = 0x18538067
+	ebmlIDInfo            = 0x1549A966
+	ebmlIDTimestampScale  = 0x2AD7B1
+	ebmlIDDuration        = 0x4489
+	ebmlIDTracks          = 0x1654AE6B
+	ebmlIDTrackEntry      = 0xAE
+	ebmlIDTrackNumber     = 0xD7
+	ebmlIDTrackType       = 0x83
+	ebmlIDDefaultDuration = 0x23E383
+	ebmlIDVideo           = 0xE0
+	ebmlIDPixelWidth      = 0xB0
+	ebmlIDPixelHeight     = 0xBA
+	ebmlIDCluster         = 0x1F43B675
+	ebmlIDTimestamp       = 0xE7
+	ebmlIDBlockGroup      = 0xA0
+	ebmlIDBlock           = 0xA1
+	ebmlIDSimpleBlock     = 0xA3
+
+	// Track type of video tracks.
+	matroskaTrackTypeVideo = 1
+
+	// Largest non-block element we'll
+	// read into memory while probing.
+	maxEBMLElementSize = 4 * 1024 * 1024
+)
+
+// ebmlElement is a single
+// element in an EBML document.
+type ebmlElement struct {
+	id   uint32
+	data []byte
+}
+
+// ebmlVint parses a variable length EBML integer from b, returning
+// the value, its length in bytes, and whether it's the reserved "all
+// ones" value (meaning unknown size). If keepMarker is set the length
+// marker bit is left in, as is the convention for element IDs.
+func ebmlVint(b []byte, keepMarker bool) (uint64, int, bool) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0, false
+	}
+
+	// Number of leading zero bits in
+	// first byte gives us the length.
+	n := 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+
+	if len(b) < n {
+		return 0, 0, false
+	}
+
+	v := uint64(b[0])
+	if !keepMarker {
+		v &= uint64(0xFF >> n)
+	}
+
+	allOnes := v == uint64(0xFF>>n)
+	for _, c := range b[1:n] {
+		v = v<<8 | uint64(c)
+		allOnes = allOnes && c == 0xFF
+	}
+
+	return v, n, allOnes && !keepMarker
+}
+
+// parseEBMLElements parses the sequence of elements
+// contained in b, stopping at the first malformed one.
+func parseEBMLElements(b []byte) []ebmlElement {
+	var elems []ebmlElement
+
+	for len(b) > 0 {
+		id, n, _ := ebmlVint(b, true)
+		if n == 0 {
+			break
+		}
+		b = b[n:]
+
+		size, n, unknown := ebmlVint(b, false)
+		if n == 0 || unknown || size > uint64(len(b)-n) {
+			break
+		}
+		b = b[n:]
+
+		elems = append(elems, ebmlElement{
+			id:   uint32(id),
+			data: b[:size],
+		})
+		b = b[size:]
+	}
+
+	return elems
+}
+
+// ebmlUint decodes an EBML unsigned integer element.
+func ebmlUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// ebmlFloat decodes an EBML float element.
+func ebmlFloat(b []byte) float64 {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	default:
+		return 0
+	}
+}
+
+// ebmlReader reads element
+// headers from an EBML stream.
+type ebmlReader struct {
+	r   *bufio.Reader
+	off int64
+}
+
+// next reads the next element header, returning its ID, its
+// data size, and whether the data size is unknown (which can
+// be the case for segments and clusters in live recordings).
+func (e *ebmlReader) next() (uint32, int64, bool, error) {
+	id, _, err := e.vint(true)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	size, unknown, err := e.vint(false)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return uint32(id), int64(size), unknown, nil
+}
+
+// vint reads a single variable length integer,
+// see ebmlVint() for the meaning of the values.
+func (e *ebmlReader) vint(keepMarker bool) (uint64, bool, error) {
+	first, err := e.r.Peek(1)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if first[0] == 0 {
+		return 0, false, errors.New("invalid ebml vint")
+	}
+
+	// Number of leading zero bits in
+	// first byte gives us the length.
+	n := 1
+	for mask := byte(0x80); first[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+
+	b, err := e.r.Peek(n)
+	if err != nil {
+		return 0, false, err
+	}
+
+	v, _, unknown := ebmlVint(b, keepMarker)
+	return v, unknown, e.skip(int64(n))
+}
+
+// read reads n bytes of element data.
+func (e *ebmlReader) read(n int64) ([]byte, error) {
+	if n > maxEBMLElementSize {
+		return nil, fmt.Errorf("ebml element too large: %d", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(e.r, b); err != nil {
+		return nil, err
+	}
+	e.off += n
+	return b, nil
+}
+
+// skip discards n bytes of element data.
+func (e *ebmlReader) skip(n int64) error {
+	d, err := e.r.Discard(int(n))
+	e.off += int64(d)
+	return err
+}
+
+// probeMatroska probes the given Matroska or WebM video,
+// returning its dimensions and metadata. If the file
+// doesn't declare its duration or framerate (as is often
+// the case for clips recorded in the browser) these are
+// worked out from the timestamps of the video frames.
+func probeMatroska(rs io.ReadSeeker) (*gtsVideo, error) {
+	// Get total size of the
+	// stream by seeking to end.
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("error seeking to end of video: %w", err)
+	}
+
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	e := &ebmlReader{r: bufio.NewReader(rs)}
+
+	// Check + skip the EBML header.
+	id, n, unknown, err := e.next()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ebml header: %w", err)
+	}
+
+	if id != ebmlIDHeader || unknown {
+		return nil, errors.New("invalid ebml header")
+	}
+
+	if err := e.skip(n); err != nil {
+		return nil, fmt.Errorf("error reading ebml header: %w", err)
+	}
+
+	var (
+		video gtsVideo
+
+		// From segment info.
+		scale    uint64  = 1000000 // ns per timestamp unit
+		duration float64           // in timestamp units
+
+		// From video track entry.
+		track       uint64
+		frameLength uint64 // in ns
+
+		// From cluster scan.
+		clusterTS uint64
+		minTS     int64 = math.MaxInt64
+		maxTS     int64
+		frames    int
+	)
+
+	for {
+		// Stop once we've found everything that
+		// we need, which in well-formed files is
+		// before the first cluster, else keep
+		// reading until the end of the file.
+		if track != 0 && duration > 0 && frameLength > 0 {
+			break
+		}
+
+		id, n, unknown, err := e.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading ebml element: %w", err)
+		}
+
+		switch id {
+
+		// Master elements that we look inside,
+		// which may be of unknown size. Their
+		// children don't share IDs with other
+		// elements so we can just carry on.
+		case ebmlIDSegment, ebmlIDCluster, ebmlIDBlockGroup:
+
+		case ebmlIDInfo:
+			b, err := e.read(n)
+			if err != nil {
+				return nil, fmt.Errorf("error reading segment info: %w", err)
+			}
+
+			for _, elem := range parseEBMLElements(b) {
+				switch elem.id {
+				case ebmlIDTimestampScale:
+					if v := ebmlUint(elem.data); v > 0 {
+						scale = v
+					}
+				case ebmlIDDuration:
+					duration = ebmlFloat(elem.data)
+				}
+			}
+
+		case ebmlIDTracks:
+			b, err := e.read(n)
+			if err != nil {
+				return nil, fmt.Errorf("error reading tracks: %w", err)
+			}
+
+			for _, entry := range parseEBMLElements(b) {
+				if entry.id != ebmlIDTrackEntry || track != 0 {
+					continue
+				}
+
+				var (
+					number   uint64
+					typ      uint64
+					length   uint64
+					width    uint64
+					height   uint64
+					children = parseEBMLElements(entry.data)
+				)
+
+				for _, elem := range children {
+					switch elem.id {
+					case ebmlIDTrackNumber:
+						number = ebmlUint(elem.data)
+					case ebmlIDTrackType:
+						typ = ebmlUint(elem.data)
+					case ebmlIDDefaultDuration:
+						length = ebmlUint(elem.data)
+					case ebmlIDVideo:
+						for _, v := range parseEBMLElements(elem.data) {
+							switch v.id {
+							case ebmlIDPixelWidth:
+								width = ebmlUint(v.data)
+							case ebmlIDPixelHeight:
+								height = ebmlUint(v.data)
+							}
+						}
+					}
+				}
+
+				if typ != matroskaTrackTypeVideo {
+					continue
+				}
+
+				if err := checkDimensions(width, height); err != nil {
+					return nil, err
+				}
+
+				// Use the first video track.
+				track = number
+				frameLength = length
+				video.width = int(width)
+				video.height = int(height)
+			}
+
+		case ebmlIDTimestamp:
+			b, err := e.read(n)
+			if err != nil {
+				return nil, fmt.Errorf("error reading cluster timestamp: %w", err)
+			}
+			clusterTS = ebmlUint(b)
+
+		case ebmlIDSimpleBlock, ebmlIDBlock:
+			// Block starts with track number
+			// vint, then int16 relative timestamp.
+			hdr, err := e.r.Peek(int(min(n, 10)))
+			if err != nil {
+				return nil, fmt.Errorf("error reading block: %w", err)
+			}
+
+			num, l, _ := ebmlVint(hdr, false)
+			if l > 0 && len(hdr) >= l+2 && num == track {
+				rel := int16(binary.BigEndian.Uint16(hdr[l:]))
+				ts := int64(clusterTS) + int64(rel)
+				minTS = min(minTS, ts)
+				maxTS = max(maxTS, ts)
+				frames++
+			}
+
+			if err := e.skip(n); err != nil {
+				return nil, fmt.Errorf("error reading block: %w", err)
+			}
+
+		default:
+			if unknown {
+				return nil, fmt.Errorf("unknown size for ebml element %x", id)
+			}
+
+			if err := e.skip(n); err != nil {
+				return nil, fmt.Errorf("error reading ebml element: %w", err)
+			}
+		}
+	}
+
+	if frameLength == 0 && frames > 1 && maxTS > minTS {
+		// Frame length not declared, take the average
+		// from the timestamps of the video frames.
+		frameLength = uint64(maxTS-minTS) * scale / uint64(frames-1)
+	}
+
+	if duration <= 0 && frames > 0 {
+		// Duration not declared, use timestamp
+		// of last video frame, plus its length.
+		duration = float64(maxTS) + float64(frameLength)/float64(scale)
+	}
+
+	seconds := duration * float64(scale) / 1e9
+	video.duration = float32(seconds)
+
+	if frameLength > 0 {
+		video.framerate = float32(1e9 / float64(frameLength))
+	}
+
+	// Matroska doesn't store per-track bitrates,
+	// so take the average over the whole file.
+	video.bitrate = bitrateOf(size, seconds)
+
+	return &video, nil
+}
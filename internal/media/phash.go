@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"codeberg.org/gruf/go-kv"
+	"github.com/disintegration/imaging"
+	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ErrMediaHashBlocked is returned when loading local
+// media whose perceptual hash matches a media hash block.
+var ErrMediaHashBlocked = errors.New("media matches a blocked media hash")
+
+// PerceptualHash calculates a 64-bit difference hash (dHash) of the
+// receiving image, by shrinking it to 9x8 grayscale and comparing the
+// brightness of each pixel to its right-hand neighbour. Similar looking
+// images have hashes differing by only a few bits. Returns false if
+// this is a blank placeholder image, which has nothing worth hashing.
+func (m *gtsImage) PerceptualHash() (uint64, bool) {
+	if m.blank {
+		return 0, false
+	}
+
+	// Shrink to 9x8 so each row gives 8 comparisons.
+	tiny := imaging.Grayscale(imaging.Resize(m.image, 9, 8, imaging.Box))
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		row := tiny.Pix[y*tiny.Stride:]
+		for x := 0; x < 8; x++ {
+			// Grayscale so R == G == B,
+			// just compare the R values.
+			hash <<= 1
+			if row[x*4] > row[(x+1)*4] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash, true
+}
+
+// SampleHash returns the perceptual hash of the given sample image, as it
+// would be calculated when processing the same image as media, formatted
+// for use in a media hash block. Only jpeg, png, gif and webp are supported.
+func SampleHash(r io.Reader) (string, error) {
+	// Read file header to determine type.
+	hdrBuf := newHdrBuf(0)
+	n, err := io.ReadFull(r, hdrBuf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("error reading sample: %w", err)
+	}
+	hdrBuf = hdrBuf[:n]
+
+	info, err := filetype.Match(hdrBuf)
+	if err != nil {
+		return "", fmt.Errorf("error parsing sample file type: %w", err)
+	}
+
+	// Recombine header bytes with remaining stream.
+	r = io.MultiReader(bytes.NewReader(hdrBuf), r)
+
+	var img *gtsImage
+	switch info.MIME.Value {
+	case mimeImageJpeg, mimeImageGif, mimeImageWebp:
+		img, err = decodeImage(r, imaging.AutoOrientation(true))
+	case mimeImagePng:
+		img, err = decodeImage(
+			&pngAncillaryChunkStripper{Reader: r},
+			imaging.AutoOrientation(true),
+		)
+	default:
+		return "", fmt.Errorf("sample must be a jpeg, png, gif or webp image")
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("error decoding sample: %w", err)
+	}
+
+	// Hash the thumbnail, as in processing.
+	hash, _ := img.Thumbnail().PerceptualHash()
+	return gtsmodel.FormatMediaHash(hash), nil
+}
+
+// dropHashBlocked checks the perceptual hash of the given just-processed
+// media against media hash blocks. If it matches, the media's stored files
+// are released and it's marked as blocked, and true is returned. Matching
+// remote media is also added to the media hash match review queue.
+func (m *Manager) dropHashBlocked(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.PerceptualHash == "" {
+		// Nothing
+		// to match.
+		return false, nil
+	}
+
+	hash, err := gtsmodel.ParseMediaHash(media.PerceptualHash)
+	if err != nil {
+		return false, gtserror.Newf("error parsing media hash: %w", err)
+	}
+
+	block, distance, err := m.state.DB.MatchMediaHashBlock(ctx,
+		hash,
+		config.GetMediaHashBlockDistance(),
+	)
+	if err != nil {
+		return false, gtserror.Newf("error matching media hash blocks: %w", err)
+	}
+
+	if block == nil {
+		// Not blocked.
+		return false, nil
+	}
+
+	// Release stored files, we
+	// mustn't serve any of them.
+	errs := gtserror.NewMultiError(2 + len(media.Derivatives))
+	if *media.Cached {
+		if !util.PtrValueOr(media.Proxied, false) {
+			if _, err := ReleaseFile(ctx, m.state, media.File.Path); err != nil {
+				errs.Append(err)
+			}
+		}
+
+		if _, err := ReleaseFile(ctx, m.state, media.Thumbnail.Path); err != nil {
+			errs.Append(err)
+		}
+
+		for _, path := range DerivativePaths(media) {
+			if _, err := ReleaseFile(ctx, m.state, path); err != nil {
+				errs.Append(err)
+			}
+		}
+	}
+
+	// Derivatives are gone
+	// along with their files.
+	media.Derivatives = nil
+
+	media.Cached = util.Ptr(false)
+	media.Proxied = util.Ptr(false)
+	media.Blocked = util.Ptr(true)
+
+	l := log.WithContext(ctx).
+		WithFields(kv.Fields{
+			{"mediaID", media.ID},
+			{"accountID", media.AccountID},
+			{"hash", media.PerceptualHash},
+			{"blockID", block.ID},
+			{"distance", distance},
+		}...)
+
+	if media.RemoteURL == "" {
+		// Local upload, will
+		// be rejected outright.
+		l.Info("rejected local media matching media hash block")
+		return true, errs.Combine()
+	}
+
+	l.Infof("dropped remote media %s matching media hash block", media.RemoteURL)
+
+	// Queue up the match for admins to review.
+	if err := m.state.DB.PutMediaHashMatch(ctx, &gtsmodel.MediaHashMatch{
+		ID:               id.NewULID(),
+		MediaHashBlockID: block.ID,
+		AttachmentID:     media.ID,
+		AccountID:        media.AccountID,
+		RemoteURL:        media.RemoteURL,
+		Hash:             media.PerceptualHash,
+		Distance:         distance,
+	}); err != nil {
+		errs.Appendf("error putting media hash match: %w", err)
+	}
+
+	return true, errs.Combine()
+}
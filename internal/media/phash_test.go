@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/bits"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type PHashTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *PHashTestSuite) sampleHash(path string) uint64 {
+	f, err := os.Open(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	hashStr, err := media.SampleHash(f)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	hash, err := gtsmodel.ParseMediaHash(hashStr)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return hash
+}
+
+func (suite *PHashTestSuite) TestSampleHashSimilar() {
+	// The processed version of the test jpeg
+	// should hash (almost) the same as the original,
+	// and both quite differently to another image.
+	original := suite.sampleHash("./test/test-jpeg.jpg")
+	processed := suite.sampleHash("./test/test-jpeg-processed.jpg")
+	other := suite.sampleHash("./test/rainbow-original.png")
+
+	maxDistance := config.GetMediaHashBlockDistance()
+	suite.LessOrEqual(bits.OnesCount64(original^processed), maxDistance)
+	suite.Greater(bits.OnesCount64(original^other), maxDistance)
+}
+
+func (suite *PHashTestSuite) TestSampleHashUnsupported() {
+	f, err := os.Open("./test/Frantz-Fanon-The-Wretched-of-the-Earth-1965.pdf")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer f.Close()
+
+	_, err = media.SampleHash(f)
+	suite.EqualError(err, "sample must be a jpeg, png, gif or webp image")
+}
+
+func (suite *PHashTestSuite) TestProcessHashBlocked() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	// Block the image we're about to upload.
+	if err := suite.db.PutMediaHashBlock(ctx, &gtsmodel.MediaHashBlock{
+		ID:                 "01HXAAAAAAAAAAAAAAAAAAAAA1",
+		Hash:               gtsmodel.FormatMediaHash(suite.sampleHash("./test/test-jpeg.jpg")),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Local upload should be rejected.
+	processing := suite.manager.PreProcessMedia(data, "01FS1X72SK9ZPW0J1QQ68BD264", nil)
+	attachment, err := processing.LoadAttachment(ctx)
+	suite.ErrorIs(err, media.ErrMediaHashBlocked)
+	suite.True(*attachment.Blocked)
+	suite.False(*attachment.Cached)
+
+	// And not stored anywhere.
+	_, err = suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	have, err := suite.storage.Has(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.False(have)
+
+	// Remote media should be dropped.
+	remoteURL := "http://example.org/media/some_image.jpg"
+	processing = suite.manager.PreProcessMedia(data, suite.testAccounts["remote_account_1"].ID, &media.AdditionalMediaInfo{
+		RemoteURL: &remoteURL,
+	})
+	attachment, err = processing.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.True(*attachment.Blocked)
+	suite.False(*attachment.Cached)
+	suite.False(util.PtrValueOr(attachment.Proxied, false))
+
+	// And queued up for review.
+	matches, err := suite.db.GetMediaHashMatches(ctx, "01HXAAAAAAAAAAAAAAAAAAAAA1", nil)
+	suite.NoError(err)
+	if suite.Len(matches, 1) {
+		suite.Equal(attachment.ID, matches[0].AttachmentID)
+		suite.Equal(remoteURL, matches[0].RemoteURL)
+	}
+}
+
+func TestPHashTestSuite(t *testing.T) {
+	suite.Run(t, new(PHashTestSuite))
+}
@@ -20,6 +20,8 @@ package media
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"slices"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -240,15 +243,23 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 	)
 
 	// This shouldn't already exist, but we do a check as it's worth logging.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.emoji.ImagePath); have {
-		log.Warnf(ctx, "emoji already exists at storage path: %s", p.emoji.ImagePath)
-
-		// Attempt to remove existing emoji at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImagePath); err != nil {
-			return gtserror.Newf("error removing emoji from storage: %v", err)
-		}
+	p.emoji.ImagePath, err = p.mgr.prepPath(ctx, p.emoji.ImagePath, func() string {
+		return uris.StoragePathForAttachment(
+			instanceAccID,
+			string(TypeEmoji),
+			string(SizeOriginal),
+			id.NewULID(),
+			info.Extension,
+		)
+	})
+	if err != nil {
+		return err
 	}
 
+	// Hash the image as we write it.
+	hash := sha256.New()
+	r = io.TeeReader(r, hash)
+
 	// Write the final image reader stream to our storage.
 	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.emoji.ImagePath, r)
 	if err != nil {
@@ -264,6 +275,18 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 		return gtserror.Newf("calculated emoji size %s greater than max allowed %s", size, maxSize)
 	}
 
+	// Share the image with any identical
+	// media already in storage.
+	p.emoji.ImagePath, err = ShareFile(ctx,
+		p.mgr.state,
+		p.emoji.ImagePath,
+		hex.EncodeToString(hash.Sum(nil)),
+		wroteSize,
+	)
+	if err != nil {
+		return gtserror.Newf("error sharing emoji file: %w", err)
+	}
+
 	// Fill in remaining attachment data now it's stored.
 	p.emoji.ImageURL = uris.URIForAttachment(
 		instanceAccID,
@@ -298,18 +321,27 @@ func (p *ProcessingEmoji) finish(ctx context.Context) error {
 		return gtserror.Newf("error closing file: %w", err)
 	}
 
-	// This shouldn't already exist, but we do a check as it's worth logging.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.emoji.ImageStaticPath); have {
-		log.Warnf(ctx, "static emoji already exists at storage path: %s", p.emoji.ImagePath)
+	// Determine instance account ID from already generated image static path.
+	instanceAccID := regexes.FilePath.FindStringSubmatch(p.emoji.ImageStaticPath)[1]
 
-		// Attempt to remove static existing emoji at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImageStaticPath); err != nil {
-			return gtserror.Newf("error removing static emoji from storage: %v", err)
-		}
+	// This shouldn't already exist, but we do a check as it's worth logging.
+	p.emoji.ImageStaticPath, err = p.mgr.prepPath(ctx, p.emoji.ImageStaticPath, func() string {
+		return uris.StoragePathForAttachment(
+			instanceAccID,
+			string(TypeEmoji),
+			string(SizeStatic),
+			id.NewULID(),
+			"png",
+		)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Create an emoji PNG encoder stream.
-	enc := staticImg.ToPNG()
+	// Create an emoji PNG encoder stream,
+	// hashing the encoded image as we go.
+	hash := sha256.New()
+	enc := io.TeeReader(staticImg.ToPNG(), hash)
 
 	// Stream-encode the PNG static image into storage.
 	sz, err := p.mgr.state.Storage.PutStream(ctx, p.emoji.ImageStaticPath, enc)
@@ -317,6 +349,18 @@ func (p *ProcessingEmoji) finish(ctx context.Context) error {
 		return gtserror.Newf("error stream-encoding static emoji to storage: %w", err)
 	}
 
+	// Share the static image with any
+	// identical media already in storage.
+	p.emoji.ImageStaticPath, err = ShareFile(ctx,
+		p.mgr.state,
+		p.emoji.ImageStaticPath,
+		hex.EncodeToString(hash.Sum(nil)),
+		sz,
+	)
+	if err != nil {
+		return gtserror.Newf("error sharing static emoji file: %w", err)
+	}
+
 	// Set written image size.
 	p.emoji.ImageStaticFileSize = int(sz)
 
@@ -20,6 +20,8 @@ package media
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"image/jpeg"
 	"io"
 	"time"
@@ -31,6 +33,7 @@ import (
 	"github.com/h2non/filetype"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -154,9 +157,48 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 		// was interrupted halfway through and so it was
 		// never decoded). Try to clean up in this case.
 		if p.media.Type == gtsmodel.FileTypeUnknown {
-			deleteErr := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
-			if deleteErr != nil && !storage.IsNotFound(deleteErr) {
-				errs.Append(deleteErr)
+			if *p.media.Cached {
+				// File was fully stored, so release
+				// our (possibly shared) reference.
+				_, releaseErr := ReleaseFile(ctx, p.mgr.state, p.media.File.Path)
+				if releaseErr != nil {
+					errs.Append(releaseErr)
+				}
+
+				// File is gone, so
+				// no longer cached.
+				p.media.Cached = util.Ptr(false)
+			} else {
+				deleteErr := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
+				if deleteErr != nil && !storage.IsNotFound(deleteErr) {
+					errs.Append(deleteErr)
+				}
+			}
+		}
+
+		// Drop media matching a media hash block.
+		// Local uploads are rejected outright.
+		if len(errs) == 0 {
+			blocked, blockErr := p.mgr.dropHashBlocked(ctx, p.media)
+			if blockErr != nil {
+				errs.Append(blockErr)
+			}
+
+			if blocked && p.media.RemoteURL == "" {
+				// Don't bother putting
+				// rejected upload in db.
+				errs.Append(ErrMediaHashBlocked)
+				err = errs.Combine()
+				return err
+			}
+		}
+
+		// Now we've got a thumbnail and blurhash,
+		// drop the original file if this is remote
+		// media that we proxy instead of storing.
+		if len(errs) == 0 {
+			if proxyErr := p.mgr.proxyRemote(ctx, p.media); proxyErr != nil {
+				errs.Append(proxyErr)
 			}
 		}
 
@@ -256,7 +298,13 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	store := true
 
 	switch info.Extension {
-	case "mp4":
+	case "mp4", "mov", "webm", "mkv":
+		// No problem.
+
+	case "heif", "avif":
+		// No problem.
+
+	case "mp3", "ogg", "flac", "m4a":
 		// No problem.
 
 	case "gif":
@@ -298,7 +346,16 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Prefer discovered mime type, fall back to
 	// generic "this contains some bytes" type.
 	mime := info.MIME.Value
-	if mime == "" {
+	switch {
+	case info.Extension == "flac":
+		// Use registered type
+		// over "audio/x-flac".
+		mime = mimeAudioFlac
+	case info.Extension == "m4a":
+		// Use registered type
+		// over "audio/m4a".
+		mime = mimeAudioMp4
+	case mime == "":
 		mime = "application/octet-stream"
 	}
 	p.media.File.ContentType = mime
@@ -322,15 +379,23 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 
 	// File shouldn't already exist in storage at this point,
 	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.File.Path); have {
-		log.Warnf(ctx, "media already exists at storage path: %s", p.media.File.Path)
-
-		// Attempt to remove existing media at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path); err != nil {
-			return gtserror.Newf("error removing media from storage: %v", err)
-		}
+	p.media.File.Path, err = p.mgr.prepPath(ctx, p.media.File.Path, func() string {
+		return uris.StoragePathForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			string(SizeOriginal),
+			id.NewULID(),
+			info.Extension,
+		)
+	})
+	if err != nil {
+		return err
 	}
 
+	// Hash the file as we write it.
+	hash := sha256.New()
+	r = io.TeeReader(r, hash)
+
 	// Write the final reader stream to our storage.
 	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, r)
 	if err != nil {
@@ -341,8 +406,22 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// as authoritative file size.
 	p.media.File.FileSize = int(wroteSize)
 
-	// We can now consider this cached.
+	// Share the file with any identical media
+	// already in storage, which may change path.
+	p.media.File.Path, err = ShareFile(ctx,
+		p.mgr.state,
+		p.media.File.Path,
+		hex.EncodeToString(hash.Sum(nil)),
+		wroteSize,
+	)
+	if err != nil {
+		return gtserror.Newf("error sharing media file: %w", err)
+	}
+
+	// We can now consider this cached,
+	// with the original file in storage.
 	p.media.Cached = util.Ptr(true)
+	p.media.Proxied = util.Ptr(false)
 
 	return nil
 }
@@ -410,6 +489,17 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeImage
 
+	// .heif, .avif image type
+	case mimeImageHeif, mimeImageAvif:
+		fullImg, err = decodeHEIFImage(rc)
+		if err != nil {
+			return gtserror.Newf("error decoding image: %w", err)
+		}
+
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeImage
+
 	// .png image (requires ancillary chunk stripping)
 	case mimeImagePng:
 		fullImg, err = decodeImage(
@@ -424,24 +514,69 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeImage
 
-	// .mp4 video type
-	case mimeVideoMp4:
-		video, err := decodeVideoFrame(rc)
+	// .mp4, .mov, .webm, .mkv video type
+	case mimeVideoMp4, mimeVideoQuicktime, mimeVideoWebm, mimeVideoMatroska:
+		video, err := decodeVideoFrame(rc, p.media.File.ContentType)
 		if err != nil {
 			return gtserror.Newf("error decoding video: %w", err)
 		}
 
-		// Set video frame as image.
+		// Set video frame as image. This is a
+		// generic placeholder if the video's
+		// dimensions couldn't be determined.
 		fullImg = video.frame
 
-		// Set video metadata in attachment info.
-		p.media.FileMeta.Original.Duration = &video.duration
-		p.media.FileMeta.Original.Framerate = &video.framerate
-		p.media.FileMeta.Original.Bitrate = &video.bitrate
+		// Set whatever video metadata
+		// could be determined in info.
+		if video.width > 0 && video.height > 0 {
+			p.media.FileMeta.Original.Width = video.width
+			p.media.FileMeta.Original.Height = video.height
+			p.media.FileMeta.Original.Size = video.width * video.height
+			p.media.FileMeta.Original.Aspect = float32(video.width) / float32(video.height)
+		}
+		if video.duration > 0 {
+			p.media.FileMeta.Original.Duration = &video.duration
+		}
+		if video.framerate > 0 {
+			p.media.FileMeta.Original.Framerate = &video.framerate
+		}
+		if video.bitrate > 0 {
+			p.media.FileMeta.Original.Bitrate = &video.bitrate
+		}
+
+		// Mark as video, even if it couldn't
+		// be probed, as its content type says
+		// it is one and clients can try it.
+		p.media.Type = gtsmodel.FileTypeVideo
+
+	// .mp3, .ogg, .flac, .m4a audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac, mimeAudioMp4:
+		audio, err := decodeAudio(rc, p.media.File.ContentType)
+		if err != nil {
+			return gtserror.Newf("error decoding audio: %w", err)
+		}
+
+		// Use embedded cover art as image if we
+		// can decode it, else use a blank square.
+		if audio.cover != nil {
+			fullImg, err = decodeImage(bytes.NewReader(audio.cover))
+			if err != nil {
+				log.Warnf(ctx, "error decoding audio cover art: %v", err)
+			}
+		}
+
+		if fullImg == nil {
+			fullImg = blankImage(512, 512)
+		}
+
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &audio.duration
+		p.media.FileMeta.Original.Bitrate = &audio.bitrate
+		p.media.FileMeta.Original.Channels = &audio.channels
 
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
-		p.media.Type = gtsmodel.FileTypeVideo
+		p.media.Type = gtsmodel.FileTypeAudio
 	}
 
 	// fullImg should be in-memory by
@@ -451,14 +586,34 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	}
 
 	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = int(fullImg.Width())
-	p.media.FileMeta.Original.Height = int(fullImg.Height())
-	p.media.FileMeta.Original.Size = int(fullImg.Size())
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	// Audio has no dimensions, and video has them
+	// set above, so their image is only used for
+	// the thumbnail.
+	if p.media.Type == gtsmodel.FileTypeImage {
+		p.media.FileMeta.Original.Width = int(fullImg.Width())
+		p.media.FileMeta.Original.Height = int(fullImg.Height())
+		p.media.FileMeta.Original.Size = int(fullImg.Size())
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	}
 
 	// Get smaller thumbnail image
 	thumbImg := fullImg.Thumbnail()
 
+	// Set perceptual hash of the thumbnail,
+	// if it's not just a blank placeholder.
+	if hash, ok := thumbImg.PerceptualHash(); ok {
+		p.media.PerceptualHash = gtsmodel.FormatMediaHash(hash)
+	}
+
+	// Store scaled-down derivatives of
+	// still images, for clients and web
+	// views that don't need full-size.
+	if p.media.Type == gtsmodel.FileTypeImage && !fullImg.blank {
+		if err := p.storeDerivatives(ctx, fullImg); err != nil {
+			return err
+		}
+	}
+
 	// Garbage collector, you may
 	// now take our large son.
 	fullImg = nil
@@ -477,21 +632,27 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	// Thumbnail shouldn't already exist in storage at this point,
 	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.Thumbnail.Path); have {
-		log.Warnf(ctx, "thumbnail already exists at storage path: %s", p.media.Thumbnail.Path)
-
-		// Attempt to remove existing thumbnail at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.Thumbnail.Path); err != nil {
-			return gtserror.Newf("error removing thumbnail from storage: %v", err)
-		}
+	p.media.Thumbnail.Path, err = p.mgr.prepPath(ctx, p.media.Thumbnail.Path, func() string {
+		return uris.StoragePathForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			string(SizeSmall),
+			id.NewULID(),
+			"jpg",
+		)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
+	// Create a thumbnail JPEG encoder stream,
+	// hashing the encoded thumbnail as we go.
+	hash := sha256.New()
+	enc := io.TeeReader(thumbImg.ToJPEG(&jpeg.Options{
 		// Good enough for
 		// a thumbnail.
 		Quality: 70,
-	})
+	}), hash)
 
 	// Stream-encode the JPEG thumbnail image into storage.
 	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
@@ -499,6 +660,18 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
 	}
 
+	// Share the thumbnail with any identical
+	// media thumbnail already in storage.
+	p.media.Thumbnail.Path, err = ShareFile(ctx,
+		p.mgr.state,
+		p.media.Thumbnail.Path,
+		hex.EncodeToString(hash.Sum(nil)),
+		sz,
+	)
+	if err != nil {
+		return gtserror.Newf("error sharing thumbnail file: %w", err)
+	}
+
 	// Set thumbnail dimensions in attachment info.
 	p.media.FileMeta.Small = gtsmodel.Small{
 		Width:  int(thumbImg.Width()),
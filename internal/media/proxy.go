@@ -0,0 +1,283 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ProxyCache is a size-bounded, short-lived in-memory cache
+// of the original files of proxied remote media, so that a
+// burst of requests for the same file (eg., a video being
+// seeked through, or media on a popular status) doesn't
+// send every single request through to the origin.
+type ProxyCache struct {
+	ttl     time.Duration
+	maxSize int64
+
+	// LRU list of cache entries,
+	// most recently used at front.
+	lru     list.List
+	entries map[string]*list.Element
+	size    int64
+	mutex   sync.Mutex
+}
+
+// proxyEntry is a file in the proxy cache.
+type proxyEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+// NewProxyCache returns a new proxy cache that keeps files
+// for ttl, with total size of all files bounded by maxSize.
+func NewProxyCache(ttl time.Duration, maxSize int64) *ProxyCache {
+	return &ProxyCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// MaxFileSize returns the size in bytes of the largest file
+// that will be cached. This is a quarter of the total size,
+// so one big file doesn't push everything else out.
+func (c *ProxyCache) MaxFileSize() int64 {
+	return c.maxSize / 4
+}
+
+// Get returns the cached file data at key, if any.
+func (c *ProxyCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*proxyEntry)
+	if time.Now().After(entry.expires) {
+		// Stale, drop it.
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.data, true
+}
+
+// Put caches the file data at key, evicting expired and then
+// least recently used files to stay within the max size. Data
+// larger than MaxFileSize() is not cached.
+func (c *ProxyCache) Put(key string, data []byte) {
+	if c.ttl <= 0 || int64(len(data)) > c.MaxFileSize() {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		// Replace existing.
+		c.remove(elem)
+	}
+
+	c.entries[key] = c.lru.PushFront(&proxyEntry{
+		key:     key,
+		data:    data,
+		expires: time.Now().Add(c.ttl),
+	})
+	c.size += int64(len(data))
+
+	c.evict()
+}
+
+// Invalidate drops any cached file data at key.
+func (c *ProxyCache) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// evict drops expired entries, then least recently used
+// entries until within max size. Must hold the mutex.
+func (c *ProxyCache) evict() {
+	now := time.Now()
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if now.After(elem.Value.(*proxyEntry).expires) {
+			c.remove(elem)
+		}
+		elem = next
+	}
+
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove drops the given entry
+// from the cache. Must hold mutex.
+func (c *ProxyCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*proxyEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.data))
+}
+
+// ProxyRemote returns whether the original file of the given
+// remote status attachment, owned by an account on domain,
+// should be proxied from its remote URL rather than stored.
+func ProxyRemote(attachment *gtsmodel.MediaAttachment, domain string) bool {
+	if attachment.RemoteURL == "" ||
+		*attachment.Avatar || *attachment.Header {
+		// Only proxy remote status attachments;
+		// avatars + headers are shown in so many
+		// places that they're better off stored.
+		return false
+	}
+
+	if config.GetMediaRemoteProxy() {
+		// Instance-wide proxying.
+		return true
+	}
+
+	for _, proxyDomain := range config.GetMediaRemoteProxyDomains() {
+		if domain == proxyDomain ||
+			strings.HasSuffix(domain, "."+proxyDomain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proxyRemote checks whether the given just-processed remote
+// media should be proxied, and if so releases its original
+// file from storage and marks it as proxied.
+func (m *Manager) proxyRemote(ctx context.Context, media *gtsmodel.MediaAttachment) error {
+	if media.RemoteURL == "" ||
+		media.Type == gtsmodel.FileTypeUnknown ||
+		!*media.Cached {
+		// Nothing stored
+		// to proxy instead.
+		return nil
+	}
+
+	account, err := m.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		media.AccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting account %s: %w", media.AccountID, err)
+	}
+
+	if !ProxyRemote(media, account.Domain) {
+		return nil
+	}
+
+	// We've got our thumbnail and blurhash,
+	// so release our reference to the original.
+	if _, err := ReleaseFile(ctx, m.state, media.File.Path); err != nil {
+		return gtserror.Newf("error releasing original file: %w", err)
+	}
+
+	media.Proxied = util.Ptr(true)
+	return nil
+}
+
+// ProxyMedia returns a stream of the original file of the given
+// proxied remote attachment, and its size, or -1 if not known. It's
+// served from the in-memory proxy cache if possible, otherwise fetched
+// from the origin using the given data function and cached for next
+// time if small enough. Cached files are returned as a seekable reader.
+func (m *Manager) ProxyMedia(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+	data DataFunc,
+) (io.ReadCloser, int64, error) {
+	if b, ok := m.proxy.Get(attachment.ID); ok {
+		return &bytesReadCloser{bytes.NewReader(b)}, int64(len(b)), nil
+	}
+
+	rc, sz, err := data(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if sz <= 0 {
+		// Origin didn't give
+		// a content length.
+		sz = -1
+	}
+
+	maxSize := m.proxy.MaxFileSize()
+	if sz > maxSize {
+		// Too big to cache,
+		// stream it through.
+		return rc, sz, nil
+	}
+
+	// Read up to one byte more than can be
+	// cached, to tell whether we reached the
+	// end of the file within the max size.
+	b, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		_ = rc.Close()
+		return nil, 0, gtserror.Newf("error reading proxied media: %w", err)
+	}
+
+	if int64(len(b)) > maxSize {
+		// Too big to cache after all, stream
+		// through what we've read and the rest.
+		r := io.MultiReader(bytes.NewReader(b), rc)
+		return iotools.ReadFnCloser(r, rc.Close), sz, nil
+	}
+
+	// We read the whole file.
+	_ = rc.Close()
+
+	if len(b) > 0 {
+		m.proxy.Put(attachment.ID, b)
+	}
+
+	return &bytesReadCloser{bytes.NewReader(b)}, int64(len(b)), nil
+}
+
+// bytesReadCloser wraps a bytes.Reader to add a no-op
+// Close, while keeping the reader seekable for serving.
+type bytesReadCloser struct{ *bytes.Reader }
+
+func (bytesReadCloser) Close() error { return nil }
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ProxyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ProxyTestSuite) TestProxyCachePutGet() {
+	cache := media.NewProxyCache(time.Minute, 40)
+
+	cache.Put("a", []byte("0123456789"))
+	data, ok := cache.Get("a")
+	suite.True(ok)
+	suite.Equal("0123456789", string(data))
+
+	cache.Invalidate("a")
+	_, ok = cache.Get("a")
+	suite.False(ok)
+}
+
+func (suite *ProxyTestSuite) TestProxyCacheTooLarge() {
+	cache := media.NewProxyCache(time.Minute, 40)
+	suite.EqualValues(10, cache.MaxFileSize())
+
+	cache.Put("a", []byte("0123456789a"))
+	_, ok := cache.Get("a")
+	suite.False(ok)
+}
+
+func (suite *ProxyTestSuite) TestProxyCacheExpiry() {
+	cache := media.NewProxyCache(time.Millisecond, 40)
+
+	cache.Put("a", []byte("0123456789"))
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := cache.Get("a")
+	suite.False(ok)
+}
+
+func (suite *ProxyTestSuite) TestProxyCacheEviction() {
+	cache := media.NewProxyCache(time.Minute, 20)
+
+	cache.Put("a", []byte("01234"))
+	cache.Put("b", []byte("01234"))
+	cache.Put("c", []byte("01234"))
+	cache.Put("d", []byte("01234"))
+
+	// Touch "a" so "b" is least recently used.
+	_, ok := cache.Get("a")
+	suite.True(ok)
+
+	cache.Put("e", []byte("01234"))
+
+	_, ok = cache.Get("b")
+	suite.False(ok)
+
+	for _, key := range []string{"a", "c", "d", "e"} {
+		_, ok := cache.Get(key)
+		suite.True(ok, key)
+	}
+}
+
+func (suite *ProxyTestSuite) TestProxyRemote() {
+	defer config.SetMediaRemoteProxyDomains(nil)
+
+	attachment := &gtsmodel.MediaAttachment{
+		RemoteURL: "https://example.org/fileserver/some_file.jpg",
+		Avatar:    util.Ptr(false),
+		Header:    util.Ptr(false),
+	}
+
+	suite.False(media.ProxyRemote(attachment, "example.org"))
+
+	config.SetMediaRemoteProxyDomains([]string{"example.org"})
+	suite.True(media.ProxyRemote(attachment, "example.org"))
+	suite.True(media.ProxyRemote(attachment, "media.example.org"))
+	suite.False(media.ProxyRemote(attachment, "notexample.org"))
+
+	attachment.Avatar = util.Ptr(true)
+	suite.False(media.ProxyRemote(attachment, "example.org"))
+}
+
+func TestProxyTestSuite(t *testing.T) {
+	suite.Run(t, new(ProxyTestSuite))
+}
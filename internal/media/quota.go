@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// StorageQuota returns the max total bytes of media that
+// the account with the given user and stats may store,
+// or 0 if storage for the account is unlimited.
+//
+// A quota override set on the stats by an admin takes
+// precedence over the configured quota for the user's
+// role. Accounts without a user (ie., remote accounts)
+// have no quota, since we only ever cache their media.
+func StorageQuota(user *gtsmodel.User, stats *gtsmodel.AccountStats) int64 {
+	if stats != nil && stats.MediaStorageQuota != nil {
+		return *stats.MediaStorageQuota
+	}
+
+	switch {
+	case user == nil:
+		return 0
+	case *user.Admin:
+		return int64(config.GetMediaQuotaAdmin())
+	case *user.Moderator:
+		return int64(config.GetMediaQuotaModerator())
+	default:
+		return int64(config.GetMediaQuotaUser())
+	}
+}
+
+// StorageUsed returns the total bytes of media
+// (files + thumbnails + derivatives) stored for
+// the given attachment, for accounting against quotas.
+func StorageUsed(attachment *gtsmodel.MediaAttachment) int64 {
+	used := int64(attachment.File.FileSize) +
+		int64(attachment.Thumbnail.FileSize)
+	for _, derivative := range attachment.Derivatives {
+		used += int64(derivative.FileSize)
+	}
+	return used
+}
+
+// UpdateStorageUsed adds delta (which may be negative) bytes
+// to the media storage used by the given local account.
+//
+// The caller MUST hold the processing lock for the account's
+// URI, so that concurrent changes to usage aren't lost.
+func UpdateStorageUsed(
+	ctx context.Context,
+	state *state.State,
+	account *gtsmodel.Account,
+	delta int64,
+) error {
+	// Always (re)load stats, as any already
+	// on the account may be out of date by
+	// the time the caller acquired the lock.
+	if err := state.DB.PopulateAccountStats(ctx, account); err != nil {
+		return gtserror.Newf("db error getting account stats: %w", err)
+	}
+
+	// Update stats by adding delta
+	// bytes to the storage used.
+	//
+	// Clamp to 0 to avoid funny business.
+	*account.Stats.MediaStorageUsed += delta
+	if *account.Stats.MediaStorageUsed < 0 {
+		*account.Stats.MediaStorageUsed = 0
+	}
+	if err := state.DB.UpdateAccountStats(
+		ctx,
+		account.Stats,
+		"media_storage_used",
+	); err != nil {
+		return gtserror.Newf("db error updating account stats: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseStorageUsed removes the bytes stored for the given
+// deleted attachment from its owning account's usage, if
+// it's a local attachment (remote media has no quota).
+func ReleaseStorageUsed(
+	ctx context.Context,
+	state *state.State,
+	attachment *gtsmodel.MediaAttachment,
+) error {
+	if attachment.RemoteURL != "" {
+		// Remote media,
+		// nothing to do.
+		return nil
+	}
+
+	account, err := state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		attachment.AccountID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account already
+			// gone, nothing to do.
+			return nil
+		}
+		return gtserror.Newf("db error getting account %s: %w", attachment.AccountID, err)
+	}
+
+	// Lock on this account since we're changing stats.
+	unlock := state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	return UpdateStorageUsed(ctx, state, account, -StorageUsed(attachment))
+}
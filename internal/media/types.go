@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -40,8 +41,34 @@ const (
 	mimeWebp      = "webp"
 	mimeImageWebp = mimeImage + "/" + mimeWebp
 
+	mimeAvif      = "avif"
+	mimeImageAvif = mimeImage + "/" + mimeAvif
+
+	mimeHeif      = "heif"
+	mimeImageHeif = mimeImage + "/" + mimeHeif
+
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeQuicktime      = "quicktime"
+	mimeVideoQuicktime = mimeVideo + "/" + mimeQuicktime
+
+	mimeWebm      = "webm"
+	mimeVideoWebm = mimeVideo + "/" + mimeWebm
+
+	mimeMatroska      = "x-matroska"
+	mimeVideoMatroska = mimeVideo + "/" + mimeMatroska
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
+
+	mimeAudioMp4 = mimeAudio + "/" + mimeMp4
 )
 
 type Size string
@@ -59,6 +86,7 @@ const (
 	TypeHeader     Type = "header"     // TypeHeader is the key for profile header requests
 	TypeAvatar     Type = "avatar"     // TypeAvatar is the key for profile avatar requests
 	TypeEmoji      Type = "emoji"      // TypeEmoji is the key for emoji type requests
+	TypeUpload     Type = "upload"     // TypeUpload is the key for staged chunks of resumable uploads
 )
 
 // AdditionalMediaInfo represents additional information that should be added to an attachment
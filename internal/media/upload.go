@@ -0,0 +1,205 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// ErrUploadTooLarge is returned when a chunk of a resumable
+// upload would take it past its declared length, or past
+// the max size of media that may be uploaded.
+var ErrUploadTooLarge = errors.New("upload exceeds its length or the max media size")
+
+// UploadMaxSize returns the max size in bytes of a file sent as a
+// resumable upload. As these are mostly for large videos over poor
+// connections, this is the max size of videos.
+func UploadMaxSize() int64 {
+	return int64(config.GetMediaVideoMaxSize())
+}
+
+// UploadChunkPath returns the storage path of the
+// given staged chunk of a resumable media upload.
+func UploadChunkPath(upload *gtsmodel.MediaUpload, chunkID string) string {
+	return uris.StoragePathForAttachment(
+		upload.AccountID,
+		string(TypeUpload),
+		string(SizeOriginal),
+		chunkID,
+		"part",
+	)
+}
+
+// PutUploadChunk stages data read from r in storage as the next chunk of
+// the given resumable upload, and updates the upload's offset and chunk
+// IDs (but doesn't store the changes in the database). Chunks are all or
+// nothing: if reading r fails part-way, nothing is kept and the upload
+// can be resumed from its previous offset.
+//
+// If r contains more data than the upload has left before reaching its
+// declared length, or the max upload size, ErrUploadTooLarge is returned.
+func PutUploadChunk(ctx context.Context, state *state.State, upload *gtsmodel.MediaUpload, r io.Reader) error {
+	limit := upload.Length
+	if max := UploadMaxSize(); max < limit {
+		// Max size lowered
+		// since upload began.
+		limit = max
+	}
+
+	chunkID := id.NewULID()
+	path := UploadChunkPath(upload, chunkID)
+
+	// Stage as much as we're allowed
+	// to in storage, no more than that.
+	remaining := limit - upload.Offset
+	n, err := state.Storage.PutStream(ctx, path, io.LimitReader(r, remaining))
+	if err != nil {
+		_ = deleteUploadChunk(ctx, state, path)
+		return gtserror.Newf("error storing upload chunk: %w", err)
+	}
+
+	// Check whether there's anything left over,
+	// ie., the upload is bigger than it's allowed.
+	if m, _ := io.ReadFull(r, make([]byte, 1)); m > 0 {
+		_ = deleteUploadChunk(ctx, state, path)
+		return ErrUploadTooLarge
+	}
+
+	if n == 0 {
+		// Nothing sent,
+		// nothing to keep.
+		return deleteUploadChunk(ctx, state, path)
+	}
+
+	upload.Offset += n
+	upload.ChunkIDs = append(upload.ChunkIDs, chunkID)
+	return nil
+}
+
+// UploadData returns a DataFunc that streams the staged chunks
+// of the given complete resumable upload, in order, as one file.
+func UploadData(state *state.State, upload *gtsmodel.MediaUpload) DataFunc {
+	return func(ctx context.Context) (io.ReadCloser, int64, error) {
+		paths := make([]string, len(upload.ChunkIDs))
+		for i, chunkID := range upload.ChunkIDs {
+			paths[i] = UploadChunkPath(upload, chunkID)
+		}
+
+		return &chunkReader{
+			ctx:     ctx,
+			storage: state.Storage,
+			paths:   paths,
+		}, upload.Length, nil
+	}
+}
+
+// DeleteUpload removes the staged chunks of the given
+// resumable upload from storage, then the upload itself
+// from the database.
+func DeleteUpload(ctx context.Context, state *state.State, upload *gtsmodel.MediaUpload) error {
+	var errs gtserror.MultiError
+
+	for _, chunkID := range upload.ChunkIDs {
+		path := UploadChunkPath(upload, chunkID)
+		if err := deleteUploadChunk(ctx, state, path); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		// Keep the upload around so
+		// removing it can be retried.
+		return err
+	}
+
+	if err := state.DB.DeleteMediaUploadByID(ctx, upload.ID); err != nil {
+		return gtserror.Newf("error deleting media upload %s: %w", upload.ID, err)
+	}
+
+	return nil
+}
+
+// deleteUploadChunk removes the
+// chunk at path from storage.
+func deleteUploadChunk(ctx context.Context, state *state.State, path string) error {
+	if err := state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+		return gtserror.Newf("error removing %s: %w", path, err)
+	}
+	return nil
+}
+
+// chunkReader reads the files at the given
+// storage paths, in order, as a single stream.
+type chunkReader struct {
+	ctx     context.Context
+	storage *storage.Driver
+	paths   []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.paths) == 0 {
+				// All chunks read.
+				return 0, io.EOF
+			}
+
+			// Open the next chunk.
+			rc, err := r.storage.GetStream(r.ctx, r.paths[0])
+			if err != nil {
+				return 0, gtserror.Newf("error opening upload chunk %s: %w", r.paths[0], err)
+			}
+
+			r.paths = r.paths[1:]
+			r.current = rc
+		}
+
+		n, err := r.current.Read(p)
+		if err != io.EOF {
+			return n, err
+		}
+
+		// Finished this chunk,
+		// move on to the next.
+		err = r.current.Close()
+		r.current = nil
+		if err != nil || n > 0 {
+			return n, err
+		}
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
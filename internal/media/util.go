@@ -17,6 +17,8 @@
 
 package media
 
+import "fmt"
+
 // newHdrBuf returns a buffer of suitable size to
 // read bytes from a file header or magic number.
 //
@@ -40,3 +42,20 @@ func newHdrBuf(fileSize int) []byte {
 
 	return make([]byte, bufSize)
 }
+
+// maxDimension is the largest width or height, in
+// pixels, accepted from a probed image or video.
+// Dimensions are read from file headers that can
+// claim anything, and are used to allocate a blank
+// image, so they must be bounded before that.
+const maxDimension = 16384
+
+// checkDimensions returns an error if the given
+// probed width or height exceeds maxDimension.
+func checkDimensions(width uint64, height uint64) error {
+	if width > maxDimension || height > maxDimension {
+		return fmt.Errorf("dimensions %dx%d exceed max of %dx%d",
+			width, height, maxDimension, maxDimension)
+	}
+	return nil
+}
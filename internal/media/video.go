@@ -28,14 +28,20 @@ import (
 
 type gtsVideo struct {
 	frame     *gtsImage
+	width     int
+	height    int
 	duration  float32 // in seconds
 	bitrate   uint64
 	framerate float32
 }
 
-// decodeVideoFrame decodes and returns an image from a single frame in the given video stream.
+// decodeVideoFrame decodes and returns an image from a single frame in the given video stream,
+// of the given content type, along with the video duration, bitrate and framerate.
 // (note: currently this only returns a blank image resized to fit video dimensions).
-func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
+//
+// If the video can't be probed, a generic placeholder image is returned along with
+// whatever metadata could be determined, so the video can still be processed.
+func decodeVideoFrame(r io.Reader, contentType string) (*gtsVideo, error) {
 	// Check if video stream supports
 	// seeking, usually when *os.File.
 	rsc, ok := r.(io.ReadSeekCloser)
@@ -57,23 +63,139 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 		}()
 	}
 
+	var (
+		video *gtsVideo
+		err   error
+	)
+
+	switch contentType {
+	case mimeVideoMp4, mimeVideoQuicktime:
+		video, err = probeMP4(rsc)
+	case mimeVideoWebm, mimeVideoMatroska:
+		video, err = probeMatroska(rsc)
+	default:
+		err = fmt.Errorf("unsupported video type %s", contentType)
+	}
+
+	if err != nil {
+		log.Warnf(nil, "error probing video, using placeholder: %v", err)
+		video = new(gtsVideo)
+	}
+
+	// Check for empty video metadata.
+	var empty []string
+	if video.width == 0 {
+		empty = append(empty, "width")
+	}
+	if video.height == 0 {
+		empty = append(empty, "height")
+	}
+	if video.duration == 0 {
+		empty = append(empty, "duration")
+	}
+	if video.framerate == 0 {
+		empty = append(empty, "framerate")
+	}
+	if video.bitrate == 0 {
+		empty = append(empty, "bitrate")
+	}
+	if len(empty) > 0 {
+		log.Warnf(nil, "couldn't determine video metadata: %v", empty)
+	}
+
+	if video.width == 0 || video.height == 0 {
+		// Dimensions unknown, use
+		// a generic placeholder.
+		video.width = 0
+		video.height = 0
+		video.frame = blankImage(512, 512)
+		return video, nil
+	}
+
+	// Create new empty "frame" image.
+	// TODO: decode frame from video file.
+	video.frame = blankImage(video.width, video.height)
+
+	return video, nil
+}
+
+// probeMP4 probes the given MP4 or QuickTime
+// video, returning its dimensions and metadata.
+func probeMP4(rs io.ReadSeeker) (*gtsVideo, error) {
 	// probe the video file to extract useful metadata from it; for methodology, see:
 	// https://github.com/abema/go-mp4/blob/7d8e5a7c5e644e0394261b0cf72fef79ce246d31/mp4tool/probe/probe.go#L85-L154
-	info, err := mp4.Probe(rsc)
+	info, err := mp4.Probe(rs)
 	if err != nil {
 		return nil, fmt.Errorf("error during mp4 probe: %w", err)
 	}
 
+	// The probe only gives us dimensions for AVC
+	// video tracks, so look at track headers too.
+	// These give us the display size of any kind
+	// of video track, and are zero for audio.
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	boxes, err := mp4.ExtractBoxWithPayload(rs, nil, mp4.BoxPath{
+		mp4.BoxTypeMoov(),
+		mp4.BoxTypeTrak(),
+		mp4.BoxTypeTkhd(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error extracting mp4 track headers: %w", err)
+	}
+
+	tkhds := make(map[uint32]*mp4.Tkhd, len(boxes))
+	for _, box := range boxes {
+		if tkhd, ok := box.Payload.(*mp4.Tkhd); ok {
+			tkhds[tkhd.TrackID] = tkhd
+		}
+	}
+
 	var (
-		width        int
-		height       int
-		videoBitrate uint64
-		audioBitrate uint64
-		video        gtsVideo
+		videoBitrate  uint64
+		audioBitrate  uint64
+		videoDuration float64
+		video         gtsVideo
 	)
 
 	for _, tr := range info.Tracks {
-		if tr.AVC == nil {
+		if tr.Timescale == 0 {
+			// Can't do
+			// anything.
+			continue
+		}
+
+		var (
+			width  int
+			height int
+			rotate bool
+		)
+
+		if tkhd := tkhds[tr.TrackID]; tkhd != nil {
+			// Dimensions are 16.16 fixed-point.
+			width = int(tkhd.Width >> 16)
+			height = int(tkhd.Height >> 16)
+
+			// A transformation matrix with zeroes
+			// on the diagonal rotates by 90 or 270
+			// degrees, eg., portrait phone videos.
+			rotate = tkhd.Matrix[0] == 0 && tkhd.Matrix[4] == 0 &&
+				tkhd.Matrix[1] != 0 && tkhd.Matrix[3] != 0
+		}
+
+		if tr.AVC != nil {
+			// Prefer coded AVC dimensions.
+			width = int(tr.AVC.Width)
+			height = int(tr.AVC.Height)
+		}
+
+		if err := checkDimensions(uint64(width), uint64(height)); err != nil {
+			return nil, err
+		}
+
+		if width == 0 || height == 0 {
 			// audio track
 			if br := tr.Samples.GetBitrate(tr.Timescale); br > audioBitrate {
 				audioBitrate = br
@@ -88,12 +210,16 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 		}
 
 		// video track
-		if w := int(tr.AVC.Width); w > width {
-			width = w
+		if rotate {
+			width, height = height, width
 		}
 
-		if h := int(tr.AVC.Height); h > height {
-			height = h
+		if width > video.width {
+			video.width = width
+		}
+
+		if height > video.height {
+			video.height = height
 		}
 
 		if br := tr.Samples.GetBitrate(tr.Timescale); br > videoBitrate {
@@ -102,8 +228,17 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 			videoBitrate = br
 		}
 
-		if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(video.duration) {
+		d := float64(tr.Duration) / float64(tr.Timescale)
+
+		// Take framerate from the longest
+		// video track, which may be shorter
+		// than an accompanying audio track.
+		if d > videoDuration {
 			video.framerate = float32(len(tr.Samples)) / float32(d)
+			videoDuration = d
+		}
+
+		if d > float64(video.duration) {
 			video.duration = float32(d)
 		}
 	}
@@ -112,30 +247,5 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 	// (since they're both playing at the same time)
 	video.bitrate = audioBitrate + videoBitrate
 
-	// Check for empty video metadata.
-	var empty []string
-	if width == 0 {
-		empty = append(empty, "width")
-	}
-	if height == 0 {
-		empty = append(empty, "height")
-	}
-	if video.duration == 0 {
-		empty = append(empty, "duration")
-	}
-	if video.framerate == 0 {
-		empty = append(empty, "framerate")
-	}
-	if video.bitrate == 0 {
-		empty = append(empty, "bitrate")
-	}
-	if len(empty) > 0 {
-		return nil, fmt.Errorf("error determining video metadata: %v", empty)
-	}
-
-	// Create new empty "frame" image.
-	// TODO: decode frame from video file.
-	video.frame = blankImage(width, height)
-
 	return &video, nil
 }
@@ -24,6 +24,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// ActivityForward is the APActivityType of a FromClientAPI
+// message asking for something that already exists locally
+// to be sent on to a remote instance, eg., forwarding a
+// closed report (APObjectType ap.ActivityFlag) to the
+// reported account's instance. It's not an ActivityStreams
+// type: the activity to deliver is built when processed.
+const ActivityForward = "Forward"
+
 // FromClientAPI wraps a message that
 // travels from the client API into the processor.
 type FromClientAPI struct {
@@ -82,6 +90,11 @@ type FromFediAPI struct {
 	// Targeted object URI.
 	TargetURI string
 
+	// Optional quarantine of the status
+	// in this Activity, set if the spam
+	// filter flagged it for admin review.
+	Quarantine *gtsmodel.QuarantinedStatus
+
 	// Remote account that posted
 	// this Activity to the inbox.
 	Requesting *gtsmodel.Account
@@ -26,10 +26,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,7 +43,15 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+// signupChallengeFailures counts sign-up form submissions
+// that failed the sign-up challenge. Nil until initialized.
+var signupChallengeFailures metric.Int64Counter
+
+// inboundPolicyMatches counts activities matched
+// by inbound policies. Nil until initialized.
+var inboundPolicyMatches metric.Int64Counter
+
+func Initialize(db db.DB, storage *storage.Driver) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -124,9 +134,68 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	if cache := storage.Cache; cache != nil {
+		_, err = meter.Int64ObservableCounter(
+			"gotosocial.storage.cache_reads",
+			metric.WithDescription("Number of media files read through the on-disk storage cache, by whether they were a cache hit"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				hits, misses := cache.Stats()
+				o.Observe(hits, metric.WithAttributes(attribute.Bool("hit", true)))
+				o.Observe(misses, metric.WithAttributes(attribute.Bool("hit", false)))
+				return nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	signupChallengeFailures, err = meter.Int64Counter(
+		"gotosocial.signup.challenge_failures",
+		metric.WithDescription("Number of sign-up form submissions that failed the sign-up challenge"),
+	)
+	if err != nil {
+		return err
+	}
+
+	inboundPolicyMatches, err = meter.Int64Counter(
+		"gotosocial.federation.inbound_policy_matches",
+		metric.WithDescription("Number of inbound activities matched by each inbound policy"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// SignupChallengeFailed records a sign-up form submission
+// that failed the given challenge for the given reason.
+func SignupChallengeFailed(ctx context.Context, challenge string, reason string) {
+	if signupChallengeFailures == nil {
+		return
+	}
+
+	signupChallengeFailures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("challenge", challenge),
+		attribute.String("reason", reason),
+	))
+}
+
+// InboundPolicyMatched records an inbound activity matched by
+// the given inbound policy, which may be in dry-run mode.
+func InboundPolicyMatched(ctx context.Context, policyID string, action string, dryRun bool) {
+	if inboundPolicyMatches == nil {
+		return
+	}
+
+	inboundPolicyMatches.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("policy_id", policyID),
+		attribute.String("action", action),
+		attribute.Bool("dry_run", dryRun),
+	))
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return otelginmetrics.Middleware(serviceName)
 }
@@ -20,21 +20,27 @@
 package metrics
 
 import (
+	"context"
 	"errors"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/uptrace/bun"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(db db.DB, storage *storage.Driver) error {
 	if config.GetMetricsEnabled() {
 		return errors.New("metrics was disabled at build time")
 	}
 	return nil
 }
 
+func SignupChallengeFailed(ctx context.Context, challenge string, reason string) {}
+
+func InboundPolicyMatched(ctx context.Context, policyID string, action string, dryRun bool) {}
+
 func InstrumentGin() gin.HandlerFunc {
 	return func(c *gin.Context) {}
 }
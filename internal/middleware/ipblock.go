@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// IPBlock returns a gin middleware handler that refuses
+// any requests from client IPs covered by a no_access
+// IP block, responding with status forbidden.
+//
+// The client IP is as resolved by gin, ie., taking
+// account of the trusted-proxies config setting.
+//
+// Less severe IP blocks, which only concern sign-ups,
+// are checked when the sign-up is processed instead.
+func IPBlock(state *state.State) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		addr, err := netip.ParseAddr(c.ClientIP())
+		if err != nil {
+			// Should never happen, gin
+			// always gives us a valid IP.
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		block, err := state.DB.MatchIPBlock(ctx, addr)
+		if err != nil {
+			err := gtserror.Newf("error checking ip blocks: %w", err)
+			respondInternalServerError(c, err)
+			return
+		}
+
+		if block != nil && block.Severity >= gtsmodel.IPBlockSeverityNoAccess {
+			log.Debugf(ctx, "refusing request from %s: ip block %s", addr, block.ID)
+			respondBlocked(c)
+			return
+		}
+
+		// Allowed!
+		c.Next()
+	}
+}
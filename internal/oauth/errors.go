@@ -21,3 +21,9 @@ import "github.com/superseriousbusiness/oauth2/v4/errors"
 
 // ErrInvalidRequest is an oauth spec compliant 'invalid_request' error.
 var ErrInvalidRequest = errors.New("invalid_request")
+
+// ErrInvalidClient is an oauth spec compliant 'invalid_client' error.
+var ErrInvalidClient = errors.New("invalid_client")
+
+// ErrUnauthorizedClient is an oauth spec compliant 'unauthorized_client' error.
+var ErrUnauthorizedClient = errors.New("unauthorized_client")
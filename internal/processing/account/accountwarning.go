@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// AccountWarningsGet returns a page of warnings
+// issued to the requesting account, newest first.
+func (p *Processor) AccountWarningsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	warnings, err := p.state.DB.GetAccountWarnings(ctx, requester.ID, nil, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account warnings: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(warnings)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = warnings[count-1].ID
+		hi = warnings[0].ID
+
+		// Prepare a slice of account warning API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, warning := range warnings {
+		apiWarning, err := p.converter.AccountWarningToAPIAccountWarning(ctx, warning)
+		if err != nil {
+			err := gtserror.Newf("error converting account warning to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiWarning)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/account_warnings",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// AccountWarningGet returns the warning with the
+// given ID, if it was issued to the requesting account.
+func (p *Processor) AccountWarningGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	warning, errWithCode := p.getOwnAccountWarning(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAccountWarning(ctx, warning)
+}
+
+// AccountWarningAppeal files an appeal against the warning with
+// the given ID, issued to the requesting account. Only one appeal
+// may be filed per warning, to be approved or rejected by an admin.
+func (p *Processor) AccountWarningAppeal(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	form *apimodel.AccountWarningAppealRequest,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	if err := validate.AppealText(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	warning, errWithCode := p.getOwnAccountWarning(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if warning.IsAppealed() {
+		const help = "warning has already been appealed"
+		err := gtserror.Newf("%s: %s", help, id)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	warning.AppealState = gtsmodel.AppealStatePending
+	warning.AppealText = text.SanitizeToPlaintext(form.Text)
+	warning.AppealedAt = time.Now()
+
+	if err := p.state.DB.UpdateAccountWarning(
+		ctx,
+		warning,
+		"appeal_state",
+		"appeal_text",
+		"appealed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating account warning: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccountWarning(ctx, warning)
+}
+
+// getOwnAccountWarning gets the warning with the given ID,
+// returning 404 if it doesn't exist or wasn't issued to requester.
+func (p *Processor) getOwnAccountWarning(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*gtsmodel.AccountWarning, gtserror.WithCode) {
+	warning, err := p.state.DB.GetAccountWarningByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account warning %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if warning == nil || warning.AccountID != requester.ID {
+		err := gtserror.Newf("account warning %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return warning, nil
+}
+
+func (p *Processor) apiAccountWarning(
+	ctx context.Context,
+	warning *gtsmodel.AccountWarning,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	apiWarning, err := p.converter.AccountWarningToAPIAccountWarning(ctx, warning)
+	if err != nil {
+		err := gtserror.Newf("error converting account warning to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiWarning, nil
+}
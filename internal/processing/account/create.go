@@ -20,13 +20,16 @@ package account
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -50,28 +53,87 @@ func (p *Processor) Create(
 		regBacklog  = 20
 	)
 
-	// Ensure no more than usersPerDay
-	// have registered in the last 24h.
-	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
-	if err != nil {
-		err := fmt.Errorf("db error counting new users: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	var (
+		invite  *gtsmodel.Invite
+		ipBlock *gtsmodel.IPBlock
+		err     error
+	)
+
+	// Check whether sign-ups from
+	// this address are blocked or limited.
+	if addr, ok := netip.AddrFromSlice(form.IP); ok {
+		ipBlock, err = p.state.DB.MatchIPBlock(ctx, addr)
+		if err != nil {
+			err := fmt.Errorf("db error checking ip blocks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
 	}
 
-	if newUsersCount >= usersPerDay {
-		err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	if ipBlock != nil && ipBlock.Severity >= gtsmodel.IPBlockSeveritySignUpBlock {
+		err := fmt.Errorf("sign-up ip %s is blocked by ip block %s", form.IP, ipBlock.ID)
+		return nil, gtserror.NewErrorForbidden(err, "sign-ups are not permitted from your network")
+	}
+
+	if form.InviteCode != "" {
+		// Ensure the given invite is valid.
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.InviteGetUsable(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	} else if !config.GetAccountsRegistrationOpen() {
+		err := fmt.Errorf("registration is not open for this server")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	// Sign-ups using an auto-approve invite don't
+	// join the approval queue, and the invite's own
+	// limits apply instead of the daily limits,
+	// unless sign-ups from this IP require approval.
+	preApproved := invite != nil && *invite.AutoApprove && ipBlock == nil
+
+	if !preApproved {
+		// Ensure no more than usersPerDay
+		// have registered in the last 24h.
+		newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			err := fmt.Errorf("db error counting new users: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if newUsersCount >= usersPerDay {
+			err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		// Ensure the new users backlog isn't full.
+		backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+		if err != nil {
+			err := fmt.Errorf("db error counting registration backlog length: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if backlogLen >= regBacklog {
+			err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
-	// Ensure the new users backlog isn't full.
-	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+	// Ensure the email domain, and any mail
+	// hosts it resolves to, aren't blocked.
+	hosts, err := email.MailHosts(ctx, p.state.MXResolver, form.Email)
 	if err != nil {
-		err := fmt.Errorf("db error counting registration backlog length: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+		err := fmt.Errorf("error parsing email address: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	if backlogLen >= regBacklog {
-		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+	emailBlocked, err := p.state.DB.IsEmailDomainBlocked(ctx, hosts...)
+	if err != nil {
+		err := fmt.Errorf("db error checking email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if emailBlocked {
+		err := fmt.Errorf("email address %s is not permitted on this instance", form.Email)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
@@ -110,16 +172,45 @@ func (p *Processor) Create(
 		}
 	}
 
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
-		Username: form.Username,
-		Email:    form.Email,
-		Password: form.Password,
-		Reason:   text.SanitizeToPlaintext(reason),
-		SignUpIP: form.IP,
-		Locale:   form.Locale,
-		AppID:    app.ID,
-	})
+	newSignup := gtsmodel.NewSignup{
+		Username:    form.Username,
+		Email:       form.Email,
+		Password:    form.Password,
+		Reason:      text.SanitizeToPlaintext(reason),
+		PreApproved: preApproved,
+		SignUpIP:    form.IP,
+		Locale:      form.Locale,
+		AppID:       app.ID,
+	}
+
+	if invite != nil {
+		// Use up one of the invite's uses. This is
+		// done atomically, so concurrent sign-ups can't
+		// go over the invite's max uses between us
+		// checking the invite above and now.
+		ok, err := p.state.DB.IncrementInviteUses(ctx, invite)
+		if err != nil {
+			err := fmt.Errorf("db error incrementing invite uses: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !ok {
+			err := fmt.Errorf("invite %s has expired or been used up", form.InviteCode)
+			return nil, gtserror.NewErrorForbidden(err, "invite has expired or been used up")
+		}
+
+		newSignup.InviteID = invite.ID
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
+		if invite != nil {
+			// Give the use back.
+			if err := p.state.DB.DecrementInviteUses(ctx, invite); err != nil {
+				log.Errorf(ctx, "db error restoring invite uses: %v", err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
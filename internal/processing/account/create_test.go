@@ -0,0 +1,220 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package account_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type CreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// mxResolver is a stub email.MXResolver
+// that serves MX records from a map.
+type mxResolver map[string][]string
+
+func (r mxResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	hosts, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	mxs := make([]*net.MX, len(hosts))
+	for i, host := range hosts {
+		mxs[i] = &net.MX{Host: host + ".", Pref: uint16(i)}
+	}
+	return mxs, nil
+}
+
+func (suite *CreateTestSuite) putInvite(maxUses int, expiresAt time.Time, autoApprove bool) *gtsmodel.Invite {
+	invite := &gtsmodel.Invite{
+		ID:          "01HWQ2V7AYCD8TVC1MTBGF45ZD",
+		Code:        "Z7mhrNAv6w",
+		AccountID:   suite.testAccounts["admin_account"].ID,
+		MaxUses:     maxUses,
+		ExpiresAt:   expiresAt,
+		AutoApprove: util.Ptr(autoApprove),
+	}
+
+	if err := suite.db.PutInvite(context.Background(), invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return invite
+}
+
+func (suite *CreateTestSuite) putIPBlock(ip string, severity gtsmodel.IPBlockSeverity) {
+	if err := suite.db.PutIPBlock(context.Background(), &gtsmodel.IPBlock{
+		ID:                 "01HXB1N4T7Q2W9E6R3Y8U5I0OP",
+		IP:                 ip,
+		Severity:           severity,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *CreateTestSuite) newForm(inviteCode string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Reason:     "I'd like to join please, it looks like a very nice instance.",
+		Username:   "invited_user",
+		Email:      "invited@example.org",
+		Password:   "very-good-password-123",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: inviteCode,
+		IP:         net.ParseIP("192.0.2.1"),
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateWithAutoApproveInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	invite := suite.putInvite(1, time.Time{}, true)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(invite.Code))
+	suite.NoError(errWithCode)
+	suite.True(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+
+	// Invite should now be used up.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	suite.NoError(err)
+	suite.Equal(1, dbInvite.Uses)
+	suite.False(dbInvite.Usable())
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	invite := suite.putInvite(0, time.Now().Add(time.Hour), false)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(invite.Code))
+	suite.NoError(errWithCode)
+	suite.False(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+}
+
+func (suite *CreateTestSuite) TestCreateWithUsedUpInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	invite := suite.putInvite(1, time.Time{}, true)
+	invite.Uses = 1
+	if err := suite.db.UpdateInvite(ctx, invite, "uses"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(invite.Code))
+	suite.Nil(user)
+	suite.Equal("Forbidden: invite is not valid, or has expired or been used up", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateWithExpiredInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	invite := suite.putInvite(0, time.Now().Add(-time.Minute), true)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(invite.Code))
+	suite.Nil(user)
+	suite.Equal("Forbidden: invite is not valid, or has expired or been used up", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateRegistrationClosed() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(""))
+	suite.Nil(user)
+	suite.Equal("Forbidden: registration is not open for this server", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateEmailDomainBlockedByMX() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(true)
+
+	suite.state.MXResolver = mxResolver{
+		"throwaway.example": {"mx1.blocked.example", "mx2.blocked.example"},
+	}
+	defer func() { suite.state.MXResolver = nil }()
+
+	if err := suite.db.CreateEmailDomainBlock(ctx, &gtsmodel.EmailDomainBlock{
+		ID:                 "01HXA3F1K2ZQ0H6Y2V8C4M9TBN",
+		Domain:             "blocked.example",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The domain itself isn't blocked, but
+	// its mail is handled by one that is.
+	form := suite.newForm("")
+	form.Email = "someone@throwaway.example"
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, form)
+	suite.Nil(user)
+	suite.Equal("Unprocessable Entity: email address someone@throwaway.example is not permitted on this instance", errWithCode.Safe())
+
+	// A domain without MX records, or with
+	// unblocked ones, should still be fine.
+	user, errWithCode = suite.accountProcessor.Create(ctx, nil, suite.newForm(""))
+	suite.NoError(errWithCode)
+	suite.NotNil(user)
+}
+
+func (suite *CreateTestSuite) TestCreateIPBlocked() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(true)
+
+	suite.putIPBlock("192.0.2.0/24", gtsmodel.IPBlockSeveritySignUpBlock)
+
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(""))
+	suite.Nil(user)
+	suite.Equal("Forbidden: sign-ups are not permitted from your network", errWithCode.Safe())
+}
+
+func (suite *CreateTestSuite) TestCreateIPRequiresApprovalWithAutoApproveInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+
+	suite.putIPBlock("192.0.2.1/32", gtsmodel.IPBlockSeveritySignUpRequiresApproval)
+	invite := suite.putInvite(1, time.Time{}, true)
+
+	// Sign-up should be permitted, but the
+	// invite should not auto-approve it.
+	user, errWithCode := suite.accountProcessor.Create(ctx, nil, suite.newForm(invite.Code))
+	suite.NoError(errWithCode)
+	suite.False(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
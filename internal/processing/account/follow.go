@@ -103,10 +103,18 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
-		// If the target account is local and not locked,
-		// we can already accept the follow request and
-		// skip any further processing.
+	// Follow requests from limited
+	// accounts always need approval.
+	limited, err := p.filter.AccountLimited(ctx, requestingAccount)
+	if err != nil {
+		err = gtserror.Newf("error checking requesting account limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if targetAccount.IsLocal() && !*targetAccount.Locked && !limited {
+		// If the target account is local, not locked, and
+		// the requester is not limited, we can already accept
+		// the follow request and skip any further processing.
 		//
 		// Because we know the requestingAccount is also
 		// local, we don't need to federate the accept out.
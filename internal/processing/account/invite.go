@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// InviteGetUsable returns the invite with the given code,
+// if it exists and can currently be used to sign up.
+func (p *Processor) InviteGetUsable(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	const help = "invite is not valid, or has expired or been used up"
+
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || !invite.Usable() {
+		err := gtserror.Newf("invite %s not found or not usable", code)
+		return nil, gtserror.NewErrorForbidden(err, help)
+	}
+
+	if config.GetAccountsAllowUserInvites() {
+		// Anyone's invites
+		// may be used, done.
+		return invite, nil
+	}
+
+	// Only invites created by staff may be used,
+	// check the creator is (still) admin or mod.
+	user, err := p.state.DB.GetUserByAccountID(gtscontext.SetBarebones(ctx), invite.AccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite creator: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user == nil || !(*user.Admin || *user.Moderator) {
+		err := gtserror.Newf("invite %s created by non-staff account while user invites disabled", code)
+		return nil, gtserror.NewErrorForbidden(err, help)
+	}
+
+	return invite, nil
+}
@@ -20,6 +20,7 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -27,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 func (p *Processor) AccountAction(
@@ -40,15 +42,52 @@ func (p *Processor) AccountAction(
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	switch gtsmodel.NewAdminActionType(request.Type) {
+	actionType := gtsmodel.NewAdminActionType(request.Type)
+
+	// Local accounts which are warned or silenced
+	// receive a warning explaining the action. The
+	// warning is built first to validate the request.
+	var warning *gtsmodel.AccountWarning
+	if actionType == gtsmodel.AdminActionWarn ||
+		(actionType == gtsmodel.AdminActionSilence && targetAcct.IsLocal()) {
+		var errWithCode gtserror.WithCode
+		warning, errWithCode = p.newAccountWarning(
+			ctx, adminAcct, targetAcct, actionType, request.Text,
+			request.ReportID, request.StatusIDs, request.RuleIDs,
+		)
+		if errWithCode != nil {
+			return "", errWithCode
+		}
+	}
+
+	var (
+		actionID    string
+		errWithCode gtserror.WithCode
+	)
+
+	switch actionType {
 	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+		actionID, errWithCode = p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionSilence:
+		actionID, errWithCode = p.accountActionSilence(ctx, adminAcct, targetAcct, request.Text,
+			text.SanitizeToPlaintext(request.ContentWarning),
+		)
+
+	case gtsmodel.AdminActionUnsilence:
+		actionID, errWithCode = p.accountActionUnsilence(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionWarn:
+		actionID, errWithCode = p.accountActionWarn(ctx, adminAcct, targetAcct, request.Text)
 
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
+			gtsmodel.AdminActionWarn.String(),
 		}
 
 		err := fmt.Errorf(
@@ -58,6 +97,71 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		actionType.String(),
+		gtsmodel.AuditLogTargetAccount, targetAcct.ID,
+		nil, &accountActionAuditSummary{
+			Acct:          auditLogAcct(targetAcct),
+			AdminActionID: actionID,
+			Text:          request.Text,
+			ReportID:      request.ReportID,
+		},
+	)
+
+	if warning != nil {
+		// Action started,
+		// issue the warning.
+		warning.AdminActionID = actionID
+		if errWithCode := p.issueAccountWarning(ctx, warning); errWithCode != nil {
+			return "", errWithCode
+		}
+	}
+
+	return actionID, nil
+}
+
+// accountActionAuditSummary summarizes an
+// action taken on an account for the audit log.
+type accountActionAuditSummary struct {
+	Acct          string `json:"acct"`
+	AdminActionID string `json:"admin_action_id,omitempty"`
+	Text          string `json:"text,omitempty"`
+	ReportID      string `json:"report_id,omitempty"`
+}
+
+func (p *Processor) accountActionWarn(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	// Nothing to do for a plain warning
+	// beyond recording the action itself;
+	// the warning is issued by the caller.
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionWarn,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
 }
 
 func (p *Processor) accountActionSuspend(
@@ -100,3 +204,93 @@ func (p *Processor) accountActionSuspend(
 
 	return actionID, errWithCode
 }
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+	contentWarning string,
+) (string, gtserror.WithCode) {
+	if targetAcct.IsSuspended() {
+		const help = "account is suspended"
+		err := gtserror.Newf("cannot silence account %s: %s", targetAcct.ID, help)
+		return "", gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	return p.accountActionSetSilenced(
+		ctx, adminAcct, targetAcct,
+		gtsmodel.AdminActionSilence, text,
+		time.Now(), contentWarning,
+	)
+}
+
+func (p *Processor) accountActionUnsilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsSilenced() {
+		const help = "account is not silenced"
+		err := gtserror.Newf("cannot unsilence account %s: %s", targetAcct.ID, help)
+		return "", gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	return p.accountActionSetSilenced(
+		ctx, adminAcct, targetAcct,
+		gtsmodel.AdminActionUnsilence, text,
+		time.Time{}, "",
+	)
+}
+
+// accountActionSetSilenced runs an admin action of the given type,
+// which sets the silenced time and content warning of the target.
+// A zero silencedAt time unsilences the account.
+func (p *Processor) accountActionSetSilenced(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	actionType gtsmodel.AdminActionType,
+	text string,
+	silencedAt time.Time,
+	contentWarning string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           actionType,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			targetAcct.SilencedAt = silencedAt
+			targetAcct.SilencedContentWarning = contentWarning
+			if err := p.state.DB.UpdateAccount(
+				ctx,
+				targetAcct,
+				"silenced_at",
+				"silenced_content_warning",
+			); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Append(err)
+				return errs
+			}
+
+			// Cached visibility of the
+			// account's statuses may have
+			// changed, so drop all of it.
+			p.state.Caches.Visibility.Clear()
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
@@ -61,6 +61,14 @@ func (p *Processor) AccountApprove(
 			Origin:         adminAcct,
 			Target:         user.Account,
 		})
+
+		p.AuditLog(ctx, adminAcct,
+			"approve",
+			gtsmodel.AuditLogTargetAccount, accountID,
+			nil, &accountActionAuditSummary{
+				Acct: auditLogAcct(user.Account),
+			},
+		)
 	}
 
 	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, user.Account)
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountMediaQuotaSet overrides the media storage quota of the
+// given local account with quota bytes (0 = unlimited). If quota
+// is nil, any override is removed, and the quota for the role
+// of the account applies again.
+func (p *Processor) AccountMediaQuotaSet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+	quota *int64,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if account.IsRemote() || account.IsInstance() {
+		const text = "media storage quotas only apply to local user accounts"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Lock on this account since we're changing stats.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+
+	if account.Stats == nil {
+		if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+			unlock()
+			err := gtserror.Newf("db error getting stats for account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	before := &accountMediaQuotaAuditSummary{
+		Acct:  auditLogAcct(account),
+		Quota: account.Stats.MediaStorageQuota,
+	}
+
+	account.Stats.MediaStorageQuota = quota
+	err = p.state.DB.UpdateAccountStats(ctx,
+		account.Stats,
+		"media_storage_quota",
+	)
+	unlock()
+
+	if err != nil {
+		err := gtserror.Newf("db error updating stats for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		"media_quota",
+		gtsmodel.AuditLogTargetAccount, accountID,
+		before, &accountMediaQuotaAuditSummary{
+			Acct:  auditLogAcct(account),
+			Quota: quota,
+		},
+	)
+
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account %s to admin api model: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
+
+// accountMediaQuotaAuditSummary summarizes the media
+// quota override of an account for the audit log.
+// A nil quota means no override was / is set.
+type accountMediaQuotaAuditSummary struct {
+	Acct  string `json:"acct"`
+	Quota *int64 `json:"quota"`
+}
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AccountMediaQuotaTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountMediaQuotaTestSuite) TestAccountMediaQuotaSetAndRemove() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	// Role quota applies by default.
+	config.SetMediaQuotaUser(10 * 1024 * 1024)
+
+	apiAcct, errWithCode := suite.adminProcessor.AccountGet(ctx, targetAcct.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(10*1024*1024, apiAcct.MediaStorage.Quota)
+	suite.False(*apiAcct.MediaStorage.QuotaOverride)
+
+	// Set an override.
+	apiAcct, errWithCode = suite.adminProcessor.AccountMediaQuotaSet(ctx, adminAcct, targetAcct.ID, util.Ptr(int64(1024)))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(1024, apiAcct.MediaStorage.Quota)
+	suite.True(*apiAcct.MediaStorage.QuotaOverride)
+
+	// Override should survive stats being regenerated.
+	dbAcct, err := suite.state.DB.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.state.DB.RegenerateAccountStats(ctx, dbAcct); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(1024, *dbAcct.Stats.MediaStorageQuota)
+
+	// Remove the override again.
+	apiAcct, errWithCode = suite.adminProcessor.AccountMediaQuotaSet(ctx, adminAcct, targetAcct.ID, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(10*1024*1024, apiAcct.MediaStorage.Quota)
+	suite.False(*apiAcct.MediaStorage.QuotaOverride)
+}
+
+func (suite *AccountMediaQuotaTestSuite) TestAccountMediaQuotaSetRemote() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["remote_account_1"]
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountMediaQuotaSet(ctx, adminAcct, targetAcct.ID, util.Ptr(int64(1024)))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAccountMediaQuotaTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMediaQuotaTestSuite))
+}
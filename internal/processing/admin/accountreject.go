@@ -109,5 +109,14 @@ func (p *Processor) AccountReject(
 		Target:         user.Account,
 	})
 
+	p.AuditLog(ctx, adminAcct,
+		"reject",
+		gtsmodel.AuditLogTargetAccount, accountID,
+		nil, &accountActionAuditSummary{
+			Acct: auditLogAcct(user.Account),
+			Text: privateComment,
+		},
+	)
+
 	return apiAccount, nil
 }
@@ -0,0 +1,386 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// AccountWarningsGet returns a page of account warnings, newest first.
+// If accountID is set, only warnings issued to that account are returned.
+// If pendingAppeal is true, only warnings with a pending appeal are returned.
+func (p *Processor) AccountWarningsGet(
+	ctx context.Context,
+	accountID string,
+	pendingAppeal bool,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	var appealState *gtsmodel.AppealState
+	if pendingAppeal {
+		pending := gtsmodel.AppealStatePending
+		appealState = &pending
+	}
+
+	warnings, err := p.state.DB.GetAccountWarnings(ctx, accountID, appealState, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account warnings: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(warnings)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = warnings[count-1].ID
+		hi = warnings[0].ID
+
+		// Prepare a slice of account warning API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, warning := range warnings {
+		apiWarning, err := p.converter.AccountWarningToAPIAccountWarning(ctx, warning)
+		if err != nil {
+			err := gtserror.Newf("error converting account warning to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiWarning)
+	}
+
+	// Preserve filters in paging links.
+	query := make(url.Values, 2)
+	if accountID != "" {
+		query["account_id"] = []string{accountID}
+	}
+	if pendingAppeal {
+		query["pending_appeal"] = []string{"true"}
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/account_warnings",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// AccountWarningGet returns the account warning with the given ID.
+func (p *Processor) AccountWarningGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	warning, errWithCode := p.getAccountWarning(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAccountWarning(ctx, warning)
+}
+
+// AccountWarningAppealApprove approves the pending appeal against
+// the account warning with the given ID, reversing any action that
+// was taken against the account along with the warning.
+func (p *Processor) AccountWarningAppealApprove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	warning, errWithCode := p.getPendingAppeal(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reverse the action taken with the warning.
+	// Plain warnings have nothing to reverse,
+	// and only the most recent silence can be
+	// reversed if the account's been silenced
+	// more than once, so check it still is.
+	if warning.Action == gtsmodel.AdminActionSilence &&
+		warning.Account.IsSilenced() {
+		text := "appeal approved against warning " + warning.ID
+		actionID, errWithCode := p.accountActionSetSilenced(
+			ctx, adminAcct, warning.Account,
+			gtsmodel.AdminActionUnsilence,
+			text, time.Time{}, "",
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		p.AuditLog(ctx, adminAcct,
+			gtsmodel.AdminActionUnsilence.String(),
+			gtsmodel.AuditLogTargetAccount, warning.AccountID,
+			nil, &accountActionAuditSummary{
+				Acct:          auditLogAcct(warning.Account),
+				AdminActionID: actionID,
+				Text:          text,
+			},
+		)
+	}
+
+	return p.resolveAppeal(ctx, adminAcct, warning, gtsmodel.AppealStateApproved)
+}
+
+// AccountWarningAppealReject rejects the pending appeal
+// against the account warning with the given ID.
+func (p *Processor) AccountWarningAppealReject(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	warning, errWithCode := p.getPendingAppeal(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.resolveAppeal(ctx, adminAcct, warning, gtsmodel.AppealStateRejected)
+}
+
+// getPendingAppeal gets the account warning with the
+// given ID, returning 422 if it has no pending appeal.
+func (p *Processor) getPendingAppeal(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.AccountWarning, gtserror.WithCode) {
+	warning, errWithCode := p.getAccountWarning(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if warning.AppealState != gtsmodel.AppealStatePending {
+		const help = "warning has no pending appeal"
+		err := gtserror.Newf("%s: %s", help, id)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	return warning, nil
+}
+
+// resolveAppeal stores the given state on the appeal
+// against the given warning, resolved by the given admin.
+func (p *Processor) resolveAppeal(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	warning *gtsmodel.AccountWarning,
+	state gtsmodel.AppealState,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	before := newAppealAuditSummary(warning)
+
+	warning.AppealState = state
+	warning.AppealResolvedAt = time.Now()
+	warning.AppealResolvedByAccountID = adminAcct.ID
+
+	if err := p.state.DB.UpdateAccountWarning(
+		ctx,
+		warning,
+		"appeal_state",
+		"appeal_resolved_at",
+		"appeal_resolved_by_account_id",
+	); err != nil {
+		err := gtserror.Newf("db error updating account warning: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	action := "approve_appeal"
+	if state == gtsmodel.AppealStateRejected {
+		action = "reject_appeal"
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		action,
+		gtsmodel.AuditLogTargetAccountWarning, warning.ID,
+		before, newAppealAuditSummary(warning),
+	)
+
+	return p.apiAccountWarning(ctx, warning)
+}
+
+// appealAuditSummary summarizes the state of the
+// appeal against a warning for the audit log.
+type appealAuditSummary struct {
+	Acct             string `json:"acct"`
+	Action           string `json:"action"`
+	AppealState      string `json:"appeal_state"`
+	AppealResolvedBy string `json:"appeal_resolved_by_account_id,omitempty"`
+}
+
+func newAppealAuditSummary(warning *gtsmodel.AccountWarning) *appealAuditSummary {
+	return &appealAuditSummary{
+		Acct:             auditLogAcct(warning.Account),
+		Action:           warning.Action.String(),
+		AppealState:      warning.AppealState.String(),
+		AppealResolvedBy: warning.AppealResolvedByAccountID,
+	}
+}
+
+// newAccountWarning validates the given parameters, and returns
+// a new (not yet stored) warning to be issued to targetAcct for
+// the given action type. If a report is given, its statuses and
+// rules are used where no status or rule IDs are provided.
+func (p *Processor) newAccountWarning(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	action gtsmodel.AdminActionType,
+	warningText string,
+	reportID string,
+	statusIDs []string,
+	ruleIDs []string,
+) (*gtsmodel.AccountWarning, gtserror.WithCode) {
+	if targetAcct.IsRemote() {
+		const help = "warnings can only be issued to local accounts"
+		err := gtserror.Newf("%s: %s", help, targetAcct.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	if reportID != "" {
+		report, err := p.state.DB.GetReportByID(gtscontext.SetBarebones(ctx), reportID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting report: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if report == nil || report.TargetAccountID != targetAcct.ID {
+			err := fmt.Errorf("report %s not found for account %s", reportID, targetAcct.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if len(statusIDs) == 0 {
+			statusIDs = report.StatusIDs
+		}
+
+		if len(ruleIDs) == 0 {
+			ruleIDs = report.RuleIDs
+		}
+	}
+
+	for _, statusID := range statusIDs {
+		status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting status: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status == nil || status.AccountID != targetAcct.ID {
+			err := fmt.Errorf("status %s not found for account %s", statusID, targetAcct.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	for _, ruleID := range ruleIDs {
+		rule, err := p.state.DB.GetRuleByID(ctx, ruleID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting rule: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if rule == nil {
+			err := fmt.Errorf("rule %s not found", ruleID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	return &gtsmodel.AccountWarning{
+		ID:                 id.NewULID(),
+		AccountID:          targetAcct.ID,
+		Account:            targetAcct,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		Action:             action,
+		Text:               text.SanitizeToPlaintext(warningText),
+		ReportID:           reportID,
+		StatusIDs:          statusIDs,
+		RuleIDs:            ruleIDs,
+	}, nil
+}
+
+// issueAccountWarning stores the given warning, and
+// queues it for delivery to the owner of the account.
+func (p *Processor) issueAccountWarning(
+	ctx context.Context,
+	warning *gtsmodel.AccountWarning,
+) gtserror.WithCode {
+	if err := p.state.DB.PutAccountWarning(ctx, warning); err != nil {
+		err := gtserror.Newf("db error putting account warning: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Notify + email the warned account.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityFlag,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       warning,
+		Origin:         warning.CreatedByAccount,
+		Target:         warning.Account,
+	})
+
+	return nil
+}
+
+// getAccountWarning gets the account warning with the
+// given ID, returning 404 if it doesn't exist.
+func (p *Processor) getAccountWarning(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.AccountWarning, gtserror.WithCode) {
+	warning, err := p.state.DB.GetAccountWarningByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account warning %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if warning == nil {
+		err := gtserror.Newf("account warning %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return warning, nil
+}
+
+func (p *Processor) apiAccountWarning(
+	ctx context.Context,
+	warning *gtsmodel.AccountWarning,
+) (*apimodel.AccountWarning, gtserror.WithCode) {
+	apiWarning, err := p.converter.AccountWarningToAPIAccountWarning(ctx, warning)
+	if err != nil {
+		err := gtserror.Newf("error converting account warning to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiWarning, nil
+}
@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountWarningTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// getWarning waits for, and returns, the
+// first warning issued to the given account.
+func (suite *AccountWarningTestSuite) getWarning(accountID string) *gtsmodel.AccountWarning {
+	var warnings []*gtsmodel.AccountWarning
+	if !testrig.WaitFor(func() bool {
+		warnings, _ = suite.state.DB.GetAccountWarnings(context.Background(), accountID, nil, nil)
+		return len(warnings) != 0
+	}) {
+		suite.FailNow("timed out waiting for account warning")
+	}
+	return warnings[0]
+}
+
+func (suite *AccountWarningTestSuite) TestSilenceWarningAppealApprove() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+		targetUser = suite.testUsers["local_account_1"]
+		statusID   = suite.testStatuses["local_account_1_status_1"].ID
+		ruleID     = testrig.NewTestRules()["rule1"].ID
+	)
+
+	// Silence the account,
+	// linking a status + rule.
+	if _, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		&apimodel.AdminActionRequest{
+			Type:      gtsmodel.AdminActionSilence.String(),
+			Text:      "please stop posting spam",
+			StatusIDs: []string{statusID},
+			RuleIDs:   []string{ruleID},
+			TargetID:  targetAcct.ID,
+		},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	warning := suite.getWarning(targetAcct.ID)
+	suite.Equal(gtsmodel.AdminActionSilence, warning.Action)
+	suite.Equal("please stop posting spam", warning.Text)
+	suite.Equal([]string{statusID}, warning.StatusIDs)
+	suite.Equal([]string{ruleID}, warning.RuleIDs)
+	suite.NotEmpty(warning.AdminActionID)
+
+	// The user should be emailed about the warning,
+	// including the text of the broken rule.
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[targetUser.Email] != ""
+	}) {
+		suite.FailNow("timed out waiting for warning email")
+	}
+	suite.Contains(suite.sentEmails[targetUser.Email], "please stop posting spam")
+	suite.Contains(suite.sentEmails[targetUser.Email], "Be gay")
+
+	// And notified with a moderation warning.
+	if !testrig.WaitFor(func() bool {
+		notif, err := suite.state.DB.GetAccountNotifications(ctx, targetAcct.ID, "", "", "", 1, nil)
+		return err == nil && len(notif) == 1 &&
+			notif[0].NotificationType == gtsmodel.NotificationWarning &&
+			notif[0].AccountWarningID == warning.ID
+	}) {
+		suite.FailNow("timed out waiting for warning notification")
+	}
+
+	// Wait for the silence to complete.
+	if !testrig.WaitFor(func() bool {
+		acct, err := suite.state.DB.GetAccountByID(ctx, targetAcct.ID)
+		return err == nil && acct.IsSilenced()
+	}) {
+		suite.FailNow("timed out waiting for account to be silenced")
+	}
+
+	// Appeal the warning.
+	appealed, errWithCode := suite.processor.Account().AccountWarningAppeal(
+		ctx, targetAcct, warning.ID,
+		&apimodel.AccountWarningAppealRequest{Text: "it wasn't spam, it was art"},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("pending", appealed.Appeal.State)
+	suite.Equal("silence", appealed.Action)
+
+	// Only one appeal may be filed per warning.
+	_, errWithCode = suite.processor.Account().AccountWarningAppeal(
+		ctx, targetAcct, warning.ID,
+		&apimodel.AccountWarningAppealRequest{Text: "pretty please"},
+	)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Approve the appeal.
+	approved, errWithCode := suite.adminProcessor.AccountWarningAppealApprove(ctx, adminAcct, warning.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("approved", approved.Appeal.State)
+	suite.NotNil(approved.Appeal.ResolvedAt)
+
+	// The silence should be reversed.
+	if !testrig.WaitFor(func() bool {
+		acct, err := suite.state.DB.GetAccountByID(ctx, targetAcct.ID)
+		return err == nil && !acct.IsSilenced()
+	}) {
+		suite.FailNow("timed out waiting for account to be unsilenced")
+	}
+
+	// An approved appeal can't be rejected.
+	_, errWithCode = suite.adminProcessor.AccountWarningAppealReject(ctx, adminAcct, warning.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *AccountWarningTestSuite) TestReportResolveWarning() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		report     = testrig.NewTestReports()["remote_account_1_report_local_account_2"]
+		targetAcct = suite.testAccounts["local_account_2"]
+	)
+
+	if _, errWithCode := suite.adminProcessor.ReportResolve(
+		ctx, adminAcct, report.ID,
+		&apimodel.AdminReportResolveRequest{
+			WarningText: util.Ptr("don't be a turtle"),
+		},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	warning := suite.getWarning(targetAcct.ID)
+	suite.Equal(gtsmodel.AdminActionWarn, warning.Action)
+	suite.Equal("don't be a turtle", warning.Text)
+	suite.Equal(report.ID, warning.ReportID)
+
+	// Other accounts can't see the warning.
+	_, errWithCode := suite.processor.Account().AccountWarningGet(
+		ctx, suite.testAccounts["local_account_1"], warning.ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Reject the appeal.
+	if _, errWithCode := suite.processor.Account().AccountWarningAppeal(
+		ctx, targetAcct, warning.ID,
+		&apimodel.AccountWarningAppealRequest{Text: "but i am a turtle"},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	rejected, errWithCode := suite.adminProcessor.AccountWarningAppealReject(ctx, adminAcct, warning.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("rejected", rejected.Appeal.State)
+	suite.Equal("none", rejected.Action)
+}
+
+func (suite *AccountWarningTestSuite) TestWarnRemoteAccount() {
+	_, errWithCode := suite.adminProcessor.AccountAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Type:     gtsmodel.AdminActionWarn.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		},
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestAccountWarningTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountWarningTestSuite))
+}
@@ -18,8 +18,10 @@
 package admin
 
 import (
+	"codeberg.org/gruf/go-cache/v3"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -34,10 +36,15 @@ type Processor struct {
 	mediaManager        *media.Manager
 	transportController transport.Controller
 	emailSender         email.Sender
+	policyFilter        *policy.Filter
 
 	// admin Actions currently
 	// undergoing processing
 	actions *Actions
+
+	// cached admin dashboard
+	// measures, dimensions etc
+	dashboard cache.TTLCache[string, any]
 }
 
 func (p *Processor) Actions() *Actions {
@@ -52,6 +59,7 @@ func New(
 	mediaManager *media.Manager,
 	transportController transport.Controller,
 	emailSender email.Sender,
+	policyFilter *policy.Filter,
 ) Processor {
 	return Processor{
 		state:               state,
@@ -60,10 +68,13 @@ func New(
 		mediaManager:        mediaManager,
 		transportController: transportController,
 		emailSender:         emailSender,
+		policyFilter:        policyFilter,
 
 		actions: &Actions{
 			r:     make(map[string]*gtsmodel.AdminAction),
 			state: state,
 		},
+
+		dashboard: newDashboardCache(),
 	}
 }
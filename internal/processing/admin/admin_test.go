@@ -23,6 +23,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -63,6 +64,7 @@ type AdminStandardTestSuite struct {
 	testAttachments  map[string]*gtsmodel.MediaAttachment
 	testStatuses     map[string]*gtsmodel.Status
 	testEmojis       map[string]*gtsmodel.Emoji
+	testTags         map[string]*gtsmodel.Tag
 
 	// module being tested
 	adminProcessor *admin.Processor
@@ -78,6 +80,7 @@ func (suite *AdminStandardTestSuite) SetupSuite() {
 	suite.testAttachments = testrig.NewTestAttachments()
 	suite.testStatuses = testrig.NewTestStatuses()
 	suite.testEmojis = testrig.NewTestEmojis()
+	suite.testTags = testrig.NewTestTags()
 }
 
 func (suite *AdminStandardTestSuite) SetupTest() {
@@ -114,6 +117,7 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 		suite.mediaManager,
 		&suite.state,
 		suite.emailSender,
+		policy.NewFilter(&suite.state),
 	)
 
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
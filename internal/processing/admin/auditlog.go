@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// AuditLogGet returns a page of audit log entries,
+// newest first, filtered by the given parameters.
+// Parameters that are empty / zero are ignored.
+func (p *Processor) AuditLogGet(
+	ctx context.Context,
+	accountID string,
+	targetTypeStr string,
+	start time.Time,
+	end time.Time,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	targetType := gtsmodel.AuditLogTargetType(targetTypeStr)
+	switch targetType {
+	case "",
+		gtsmodel.AuditLogTargetAccount,
+		gtsmodel.AuditLogTargetDomainBlock,
+		gtsmodel.AuditLogTargetDomainAllow,
+		gtsmodel.AuditLogTargetDomainLimit,
+		gtsmodel.AuditLogTargetEmailDomainBlock,
+		gtsmodel.AuditLogTargetIPBlock,
+		gtsmodel.AuditLogTargetEmoji,
+		gtsmodel.AuditLogTargetRule,
+		gtsmodel.AuditLogTargetHeaderAllow,
+		gtsmodel.AuditLogTargetHeaderBlock,
+		gtsmodel.AuditLogTargetInstance,
+		gtsmodel.AuditLogTargetReport,
+		gtsmodel.AuditLogTargetAccountWarning,
+		gtsmodel.AuditLogTargetInvite,
+		gtsmodel.AuditLogTargetPolicy,
+		gtsmodel.AuditLogTargetQuarantine,
+		gtsmodel.AuditLogTargetTag,
+		gtsmodel.AuditLogTargetMediaHash:
+		// No problem.
+
+	default:
+		err := fmt.Errorf("target_type %s not recognized", targetTypeStr)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	entries, err := p.state.DB.GetAuditLogEntries(ctx, accountID, targetType, start, end, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting audit log entries: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(entries)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Set next + prev values before filtering and API
+		// converting, so caller can still page properly.
+		nextMaxIDValue = entries[count-1].ID
+		prevMinIDValue = entries[0].ID
+	)
+
+	for _, entry := range entries {
+		apiEntry, err := p.converter.AuditLogEntryToAdminAPIAuditLogEntry(ctx, entry)
+		if err != nil {
+			log.Errorf(ctx, "error converting audit log entry to api: %v", err)
+			continue
+		}
+		items = append(items, apiEntry)
+	}
+
+	query := make(url.Values)
+	if accountID != "" {
+		query.Set("account_id", accountID)
+	}
+	if targetType != "" {
+		query.Set("target_type", string(targetType))
+	}
+	if !start.IsZero() {
+		query.Set("start", start.Format(time.RFC3339))
+	}
+	if !end.IsZero() {
+		query.Set("end", end.Format(time.RFC3339))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/audit_log",
+		Next:  page.Next(nextMaxIDValue, prevMinIDValue),
+		Prev:  page.Prev(nextMaxIDValue, prevMinIDValue),
+		Query: query,
+	}), nil
+}
+
+// AuditLog records the given mutation, performed by the
+// given admin account, in the audit log. Before and after
+// are summaries of the target, which are stored as JSON;
+// either may be nil, eg., when creating or deleting.
+//
+// Recording is best-effort: by the time this is called the
+// mutation has already happened, so errors are only logged.
+func (p *Processor) AuditLog(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	action string,
+	targetType gtsmodel.AuditLogTargetType,
+	targetID string,
+	before any,
+	after any,
+) {
+	entry := &gtsmodel.AuditLogEntry{
+		ID:         id.NewULID(),
+		AccountID:  account.ID,
+		Account:    account,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+
+	var err error
+	if entry.Before, err = auditLogSummary(before); err != nil {
+		log.Errorf(ctx, "error summarizing audit log target: %v", err)
+	}
+	if entry.After, err = auditLogSummary(after); err != nil {
+		log.Errorf(ctx, "error summarizing audit log target: %v", err)
+	}
+
+	if err := p.state.DB.PutAuditLogEntry(ctx, entry); err != nil {
+		log.Errorf(ctx, "db error putting audit log entry: %v", err)
+	}
+}
+
+// auditLogSummary marshals the given audit log target
+// summary to JSON, returning "" for nil (including
+// typed nil pointers, which marshal to "null").
+func auditLogSummary(summary any) (string, error) {
+	if summary == nil {
+		return "", nil
+	}
+
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+
+	if s := string(b); s != "null" {
+		return s, nil
+	}
+
+	return "", nil
+}
+
+// auditLogAcct returns the username, or
+// username@domain for remote accounts, of
+// the given account, for audit log summaries.
+func auditLogAcct(account *gtsmodel.Account) string {
+	if account.IsLocal() {
+		return account.Username
+	}
+	return account.Username + "@" + account.Domain
+}
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type AuditLogTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AuditLogTestSuite) auditLog(accountID string, targetType string, start time.Time) []*apimodel.AdminAuditLogEntry {
+	resp, errWithCode := suite.adminProcessor.AuditLogGet(
+		context.Background(),
+		accountID, targetType,
+		start, time.Time{},
+		&paging.Page{Limit: 100},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	entries := make([]*apimodel.AdminAuditLogEntry, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		entries = append(entries, item.(*apimodel.AdminAuditLogEntry))
+	}
+
+	return entries
+}
+
+func (suite *AuditLogTestSuite) ruleText(summary json.RawMessage) string {
+	rule := new(apimodel.AdminInstanceRule)
+	if err := json.Unmarshal(summary, rule); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return rule.Text
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogRule() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	rule, errWithCode := suite.adminProcessor.RuleCreate(ctx, adminAcct,
+		&apimodel.InstanceRuleCreateRequest{Text: "no turtles"},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if _, errWithCode := suite.adminProcessor.RuleUpdate(ctx, adminAcct, rule.ID,
+		&apimodel.InstanceRuleCreateRequest{Text: "no tortoises"},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	entries := suite.auditLog(adminAcct.ID, "rule", time.Time{})
+	if !suite.Len(entries, 2) {
+		suite.FailNow("")
+	}
+
+	// Newest first.
+	update, create := entries[0], entries[1]
+
+	suite.Equal("create", create.Action)
+	suite.Equal(rule.ID, create.TargetID)
+	suite.Nil(create.Before)
+	suite.Equal("no turtles", suite.ruleText(create.After))
+
+	suite.Equal("update", update.Action)
+	suite.Equal(adminAcct.ID, update.Account.ID)
+	suite.Equal("no turtles", suite.ruleText(update.Before))
+	suite.Equal("no tortoises", suite.ruleText(update.After))
+
+	// Nothing logged for other target
+	// types, or before the given start.
+	suite.Empty(suite.auditLog("", "emoji", time.Time{}))
+	suite.Empty(suite.auditLog("", "", time.Now().Add(time.Hour)))
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogDomainBlock() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	block, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx, gtsmodel.DomainPermissionBlock, adminAcct,
+		"turtles.example.org", false, "", "too many turtles", "",
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	entries := suite.auditLog("", "domain_block", time.Time{})
+	if !suite.Len(entries, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("create", entries[0].Action)
+	suite.Equal(block.ID, entries[0].TargetID)
+
+	after := new(apimodel.DomainPermission)
+	if err := json.Unmarshal(entries[0].After, after); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("turtles.example.org", after.Domain.Domain)
+	suite.Equal("too many turtles", after.PrivateComment)
+}
+
+func (suite *AuditLogTestSuite) ipBlockIP(summary json.RawMessage) string {
+	block := new(apimodel.IPBlock)
+	if err := json.Unmarshal(summary, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return block.IP
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogIPBlock() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	block, errWithCode := suite.adminProcessor.IPBlockCreate(ctx, adminAcct,
+		&apimodel.IPBlockCreateRequest{IP: "192.0.2.0/24", Severity: "sign_up_block"},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	newIP := "198.51.100.0/24"
+	if _, errWithCode := suite.adminProcessor.IPBlockUpdate(ctx, adminAcct, block.ID,
+		&apimodel.IPBlockUpdateRequest{IP: &newIP},
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if _, errWithCode := suite.adminProcessor.IPBlockDelete(ctx, adminAcct, block.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	entries := suite.auditLog(adminAcct.ID, "ip_block", time.Time{})
+	if !suite.Len(entries, 3) {
+		suite.FailNow("")
+	}
+
+	// Newest first.
+	del, update, create := entries[0], entries[1], entries[2]
+
+	suite.Equal("create", create.Action)
+	suite.Equal(block.ID, create.TargetID)
+	suite.Nil(create.Before)
+	suite.Equal("192.0.2.0/24", suite.ipBlockIP(create.After))
+
+	suite.Equal("update", update.Action)
+	suite.Equal("192.0.2.0/24", suite.ipBlockIP(update.Before))
+	suite.Equal(newIP, suite.ipBlockIP(update.After))
+
+	suite.Equal("delete", del.Action)
+	suite.Equal(newIP, suite.ipBlockIP(del.Before))
+	suite.Nil(del.After)
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogUnknownTargetType() {
+	_, errWithCode := suite.adminProcessor.AuditLogGet(
+		context.Background(),
+		"", "turtle",
+		time.Time{}, time.Time{},
+		&paging.Page{Limit: 100},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, &AuditLogTestSuite{})
+}
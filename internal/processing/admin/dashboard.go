@@ -0,0 +1,625 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-cache/v3"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	day = 24 * time.Hour
+
+	// How long computed measures, dimensions
+	// and cohorts are cached for. Dashboards
+	// tend to re-request the same ranges.
+	dashboardCacheTTL = 5 * time.Minute
+
+	// Default and maximum length
+	// of a dashboard period, in days.
+	dashboardDefaultDays = 30
+	dashboardMaxDays     = 366
+
+	// Default and maximum number of
+	// categories returned per dimension.
+	dimensionDefaultLimit = 10
+	dimensionMaxLimit     = 100
+)
+
+var (
+	measureKeys = []string{
+		"new_users",
+		"active_users",
+		"interactions",
+		"opened_reports",
+		"resolved_reports",
+		"instance_statuses",
+		"instance_followers",
+		"instance_media_attachments",
+	}
+
+	dimensionKeys = []string{
+		"languages",
+		"servers",
+		"space_usage",
+		"software_versions",
+	}
+)
+
+func newDashboardCache() cache.TTLCache[string, any] {
+	c := cache.NewTTL[string, any](0, 1000, dashboardCacheTTL)
+	if !c.Start(time.Minute) {
+		log.Panic(nil, "could not start admin dashboard cache")
+	}
+	return c
+}
+
+// dashboardCached returns the cached value under key if
+// set, else computes it with fn and caches the result.
+func dashboardCached[T any](
+	p *Processor,
+	key string,
+	fn func() (T, gtserror.WithCode),
+) (T, gtserror.WithCode) {
+	if v, ok := p.dashboard.Get(key); ok {
+		return v.(T), nil
+	}
+
+	v, errWithCode := fn()
+	if errWithCode != nil {
+		return v, errWithCode
+	}
+
+	p.dashboard.Set(key, v)
+	return v, nil
+}
+
+// dashboardPeriod parses the given start_at and end_at
+// values into a range of whole UTC days, defaulting
+// to the 30 days up to and including today.
+func dashboardPeriod(startAt string, endAt string) (time.Time, time.Time, gtserror.WithCode) {
+	start, errWithCode := apiutil.ParseAdminStartAt(startAt)
+	if errWithCode != nil {
+		return time.Time{}, time.Time{}, errWithCode
+	}
+
+	end, errWithCode := apiutil.ParseAdminEndAt(endAt)
+	if errWithCode != nil {
+		return time.Time{}, time.Time{}, errWithCode
+	}
+
+	if end.IsZero() {
+		end = time.Now().AddDate(0, 0, 1)
+	}
+
+	// Round end up to the next midnight.
+	end = end.UTC()
+	if t := end.Truncate(day); !t.Equal(end) {
+		end = t.Add(day)
+	}
+
+	if start.IsZero() {
+		start = end.AddDate(0, 0, -dashboardDefaultDays)
+	}
+
+	// Round start down to midnight.
+	start = start.UTC().Truncate(day)
+
+	if !end.After(start) {
+		const text = "end_at must be after start_at"
+		return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if days := end.Sub(start) / day; days > dashboardMaxDays {
+		text := fmt.Sprintf("period of %d days is too long, maximum is %d days", days, dashboardMaxDays)
+		return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return start, end, nil
+}
+
+// MeasuresGet returns the requested measures, with
+// daily values over the requested period.
+func (p *Processor) MeasuresGet(
+	ctx context.Context,
+	form *apimodel.AdminMeasuresRequest,
+) ([]*apimodel.AdminMeasure, gtserror.WithCode) {
+	start, end, errWithCode := dashboardPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	measures := make([]*apimodel.AdminMeasure, 0, len(form.Keys))
+	for _, key := range form.Keys {
+		var params map[string]string
+		switch key {
+		case "instance_statuses":
+			params = form.InstanceStatuses
+		case "instance_followers":
+			params = form.InstanceFollowers
+		case "instance_media_attachments":
+			params = form.InstanceMediaAttachments
+		}
+
+		measure, errWithCode := p.measure(ctx, key, params, start, end)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		measures = append(measures, measure)
+	}
+
+	return measures, nil
+}
+
+// measureAggregate describes which values of
+// the daily points of a measure are used.
+type measureAggregate int
+
+const (
+	aggregateValue    measureAggregate = iota // Count or sum of events.
+	aggregateAccounts                         // Distinct accounts.
+)
+
+func (p *Processor) measure(
+	ctx context.Context,
+	key string,
+	params map[string]string,
+	start time.Time,
+	end time.Time,
+) (*apimodel.AdminMeasure, gtserror.WithCode) {
+	var (
+		getPoints func(context.Context, time.Time, time.Time) ([]db.MeasurePoint, error)
+		aggregate = aggregateValue
+		unit      string
+
+		// getTotal, if set, gets the total for a period
+		// where it can't be summed from the daily values.
+		getTotal func(context.Context, time.Time, time.Time) (int64, error)
+	)
+
+	// Instance measures take a domain param.
+	var domain string
+	if strings.HasPrefix(key, "instance_") {
+		var err error
+		domain, err = util.Punify(strings.TrimSpace(params["domain"]))
+		if err != nil || domain == "" {
+			text := fmt.Sprintf("measure %s requires a valid %s[domain] parameter", key, key)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	switch key {
+	case "new_users":
+		getPoints = p.state.DB.GetNewUserPoints
+	case "active_users":
+		getPoints = p.state.DB.GetActiveUserPoints
+		aggregate = aggregateAccounts
+		getTotal = p.state.DB.CountActiveUsers
+	case "interactions":
+		getPoints = p.state.DB.GetInteractionPoints
+	case "opened_reports":
+		getPoints = p.state.DB.GetOpenedReportPoints
+	case "resolved_reports":
+		getPoints = p.state.DB.GetResolvedReportPoints
+	case "instance_statuses":
+		getPoints = func(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+			return p.state.DB.GetInstanceStatusPoints(ctx, domain, start, end)
+		}
+	case "instance_followers":
+		getPoints = func(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+			return p.state.DB.GetInstanceFollowerPoints(ctx, domain, start, end)
+		}
+	case "instance_media_attachments":
+		getPoints = func(ctx context.Context, start time.Time, end time.Time) ([]db.MeasurePoint, error) {
+			return p.state.DB.GetInstanceMediaPoints(ctx, domain, start, end)
+		}
+		unit = "bytes"
+	default:
+		text := fmt.Sprintf(
+			"measure key %s not recognized, valid keys are: %s",
+			key, strings.Join(measureKeys, ", "),
+		)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	cacheKey := fmt.Sprintf("measure:%s:%s:%d:%d", key, domain, start.Unix(), end.Unix())
+	return dashboardCached(p, cacheKey, func() (*apimodel.AdminMeasure, gtserror.WithCode) {
+		points, err := getPoints(ctx, start, end)
+		if err != nil {
+			err := gtserror.Newf("db error getting points for measure %s: %w", key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Get points for the preceding
+		// period of the same length.
+		prevStart := start.Add(-end.Sub(start))
+		prevPoints, err := getPoints(ctx, prevStart, start)
+		if err != nil {
+			err := gtserror.Newf("db error getting previous points for measure %s: %w", key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		values, total := dailyValues(points, aggregate, start, end)
+		_, prevTotal := dailyValues(prevPoints, aggregate, prevStart, start)
+
+		if getTotal != nil {
+			total, err = getTotal(ctx, start, end)
+			if err != nil {
+				err := gtserror.Newf("db error getting total for measure %s: %w", key, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			prevTotal, err = getTotal(ctx, prevStart, start)
+			if err != nil {
+				err := gtserror.Newf("db error getting previous total for measure %s: %w", key, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		measure := &apimodel.AdminMeasure{
+			Key:           key,
+			Unit:          unit,
+			Total:         strconv.FormatInt(total, 10),
+			PreviousTotal: strconv.FormatInt(prevTotal, 10),
+			Data:          make([]apimodel.AdminMeasureData, len(values)),
+		}
+
+		if unit == "bytes" {
+			measure.HumanValue = bytesize.Size(total).String()
+		}
+
+		for i, value := range values {
+			measure.Data[i] = apimodel.AdminMeasureData{
+				Date:  util.FormatISO8601(start.Add(time.Duration(i) * day)),
+				Value: strconv.FormatInt(value, 10),
+			}
+		}
+
+		return measure, nil
+	})
+}
+
+// dailyValues spreads the given daily points into
+// values for each day between start and end, and
+// sums them. Note that when aggregating accounts,
+// the sum may count the same account more than once.
+func dailyValues(
+	points []db.MeasurePoint,
+	aggregate measureAggregate,
+	start time.Time,
+	end time.Time,
+) ([]int64, int64) {
+	var (
+		values = make([]int64, end.Sub(start)/day)
+		total  int64
+	)
+
+	for _, point := range points {
+		i := int(point.Time.Sub(start) / day)
+		if i < 0 || i >= len(values) {
+			continue
+		}
+
+		value := point.Value
+		if aggregate == aggregateAccounts {
+			value = point.Accounts
+		}
+
+		values[i] += value
+		total += value
+	}
+
+	return values, total
+}
+
+// DimensionsGet returns the requested dimensions
+// for data within the requested period.
+func (p *Processor) DimensionsGet(
+	ctx context.Context,
+	form *apimodel.AdminDimensionsRequest,
+) ([]*apimodel.AdminDimension, gtserror.WithCode) {
+	start, end, errWithCode := dashboardPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	limit := form.Limit
+	switch {
+	case limit <= 0:
+		limit = dimensionDefaultLimit
+	case limit > dimensionMaxLimit:
+		limit = dimensionMaxLimit
+	}
+
+	dimensions := make([]*apimodel.AdminDimension, 0, len(form.Keys))
+	for _, key := range form.Keys {
+		if !slices.Contains(dimensionKeys, key) {
+			text := fmt.Sprintf(
+				"dimension key %s not recognized, valid keys are: %s",
+				key, strings.Join(dimensionKeys, ", "),
+			)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		cacheKey := fmt.Sprintf("dimension:%s:%d:%d:%d", key, limit, start.Unix(), end.Unix())
+		dimension, errWithCode := dashboardCached(p, cacheKey, func() (*apimodel.AdminDimension, gtserror.WithCode) {
+			data, err := p.dimensionData(ctx, key, start, end, limit)
+			if err != nil {
+				err := gtserror.Newf("error getting data for dimension %s: %w", key, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			return &apimodel.AdminDimension{Key: key, Data: data}, nil
+		})
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+func (p *Processor) dimensionData(
+	ctx context.Context,
+	key string,
+	start time.Time,
+	end time.Time,
+	limit int,
+) ([]apimodel.AdminDimensionData, error) {
+	switch key {
+	case "languages":
+		points, err := p.state.DB.GetLanguageDimension(ctx, start, end, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return dimensionPointsData(points, func(key string) string {
+			if lang, err := language.Parse(key); err == nil {
+				return lang.DisplayStr
+			}
+			return key
+		}), nil
+
+	case "servers":
+		points, err := p.state.DB.GetServerDimension(ctx, start, end, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return dimensionPointsData(points, func(key string) string {
+			return key
+		}), nil
+
+	case "space_usage":
+		dbSize, err := p.state.DB.GetDatabaseSize(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaSize, err := p.state.DB.GetMediaSize(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		dbName := "SQLite"
+		if strings.EqualFold(config.GetDbType(), "postgres") {
+			dbName = "PostgreSQL"
+		}
+
+		return []apimodel.AdminDimensionData{
+			bytesDimensionData("database", dbName, dbSize),
+			bytesDimensionData("media", "Media storage", mediaSize),
+		}, nil
+
+	case "software_versions":
+		return []apimodel.AdminDimensionData{
+			{
+				Key:        "gotosocial",
+				HumanKey:   "GoToSocial",
+				Value:      config.GetSoftwareVersion(),
+				HumanValue: config.GetSoftwareVersion(),
+			},
+			{
+				Key:        "golang",
+				HumanKey:   "Go",
+				Value:      runtime.Version(),
+				HumanValue: runtime.Version(),
+			},
+		}, nil
+
+	default:
+		panic("unreachable")
+	}
+}
+
+func dimensionPointsData(
+	points []db.DimensionPoint,
+	humanKey func(string) string,
+) []apimodel.AdminDimensionData {
+	data := make([]apimodel.AdminDimensionData, len(points))
+	for i, point := range points {
+		value := strconv.FormatInt(point.Value, 10)
+		data[i] = apimodel.AdminDimensionData{
+			Key:        point.Key,
+			HumanKey:   humanKey(point.Key),
+			Value:      value,
+			HumanValue: value,
+		}
+	}
+	return data
+}
+
+func bytesDimensionData(key string, humanKey string, size int64) apimodel.AdminDimensionData {
+	return apimodel.AdminDimensionData{
+		Key:        key,
+		HumanKey:   humanKey,
+		Value:      strconv.FormatInt(size, 10),
+		Unit:       "bytes",
+		HumanValue: bytesize.Size(size).String(),
+	}
+}
+
+// RetentionGet returns cohorts of users who signed up
+// in each day or month of the requested period, with
+// the rate at which each cohort was active (posted,
+// boosted or faved) in that and subsequent periods.
+func (p *Processor) RetentionGet(
+	ctx context.Context,
+	form *apimodel.AdminRetentionRequest,
+) ([]*apimodel.AdminCohort, gtserror.WithCode) {
+	start, end, errWithCode := dashboardPeriod(form.StartAt, form.EndAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	frequency := form.Frequency
+	if frequency == "" {
+		frequency = "day"
+	}
+
+	// periodOf returns the index of the period
+	// t falls in, counting from the start.
+	var (
+		periodOf func(t time.Time) int
+		unit     db.MeasureUnit
+	)
+
+	switch frequency {
+	case "day":
+		unit = db.MeasureDay
+		periodOf = func(t time.Time) int {
+			return int(t.Sub(start) / day)
+		}
+
+	case "month":
+		unit = db.MeasureMonth
+
+		// Widen period to whole months.
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if t := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.UTC); !t.Equal(end) {
+			end = t.AddDate(0, 1, 0)
+		}
+		periodOf = func(t time.Time) int {
+			t = t.UTC()
+			return (t.Year()-start.Year())*12 + int(t.Month()-start.Month())
+		}
+
+	default:
+		text := fmt.Sprintf("frequency %s not recognized, valid frequencies are: day, month", frequency)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	cacheKey := fmt.Sprintf("retention:%s:%d:%d", frequency, start.Unix(), end.Unix())
+	return dashboardCached(p, cacheKey, func() ([]*apimodel.AdminCohort, gtserror.WithCode) {
+		signups, err := p.state.DB.GetNewUserPoints(ctx, start, end)
+		if err != nil {
+			err := gtserror.Newf("db error getting new users: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		activity, err := p.state.DB.GetCohortPoints(ctx, start, end, unit)
+		if err != nil {
+			err := gtserror.Newf("db error getting active users: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		periods := periodOf(end.Add(-time.Nanosecond)) + 1
+
+		// Size of each cohort, from
+		// daily counts of signups.
+		cohortSizes := make([]int64, periods)
+		for _, signup := range signups {
+			cohortSizes[periodOf(signup.Time)] += signup.Value
+		}
+
+		// Accounts in each cohort active
+		// in each period, by cohort.
+		active := make([][]int64, periods)
+		for _, point := range activity {
+			cohort := periodOf(point.Cohort)
+			i := periodOf(point.Period)
+			if cohort < 0 || cohort >= periods ||
+				i < cohort || i >= periods {
+				continue
+			}
+
+			if active[cohort] == nil {
+				active[cohort] = make([]int64, periods)
+			}
+
+			active[cohort][i] = point.Accounts
+		}
+
+		periodStart := func(i int) time.Time {
+			if frequency == "month" {
+				return start.AddDate(0, i, 0)
+			}
+			return start.Add(time.Duration(i) * day)
+		}
+
+		cohorts := make([]*apimodel.AdminCohort, periods)
+		for cohort := range cohorts {
+			data := make([]apimodel.AdminCohortData, 0, periods-cohort)
+			for i := cohort; i < periods; i++ {
+				var value int64
+				if active[cohort] != nil {
+					value = active[cohort][i]
+				}
+
+				var rate float64
+				if cohortSizes[cohort] != 0 {
+					rate = float64(value) / float64(cohortSizes[cohort])
+				}
+
+				data = append(data, apimodel.AdminCohortData{
+					Date:  util.FormatISO8601(periodStart(i)),
+					Rate:  rate,
+					Value: strconv.FormatInt(value, 10),
+				})
+			}
+
+			cohorts[cohort] = &apimodel.AdminCohort{
+				Period:    util.FormatISO8601(periodStart(cohort)),
+				Frequency: frequency,
+				Data:      data,
+			}
+		}
+
+		return cohorts, nil
+	})
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DashboardTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DashboardTestSuite) TestMeasuresOpenedReports() {
+	measures, errWithCode := suite.adminProcessor.MeasuresGet(
+		context.Background(),
+		&apimodel.AdminMeasuresRequest{
+			Keys:    []string{"opened_reports"},
+			StartAt: "2022-05-01",
+			EndAt:   "2022-05-31",
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(measures, 1)
+	measure := measures[0]
+	suite.Equal("opened_reports", measure.Key)
+	suite.Equal("2", measure.Total)
+	suite.Equal("0", measure.PreviousTotal)
+
+	// One value per day of May,
+	// with both test reports
+	// on the 14th and 15th.
+	suite.Len(measure.Data, 31)
+	suite.Equal("2022-05-01T00:00:00.000Z", measure.Data[0].Date)
+	suite.Equal("1", measure.Data[13].Value)
+	suite.Equal("1", measure.Data[14].Value)
+	suite.Equal("0", measure.Data[15].Value)
+}
+
+func (suite *DashboardTestSuite) TestMeasuresBadRequest() {
+	for _, test := range []struct {
+		form     *apimodel.AdminMeasuresRequest
+		expected string
+	}{
+		{
+			form:     &apimodel.AdminMeasuresRequest{Keys: []string{"turtles"}},
+			expected: "Bad Request: measure key turtles not recognized, valid keys are: new_users, active_users, interactions, opened_reports, resolved_reports, instance_statuses, instance_followers, instance_media_attachments",
+		},
+		{
+			form:     &apimodel.AdminMeasuresRequest{Keys: []string{"instance_statuses"}},
+			expected: "Bad Request: measure instance_statuses requires a valid instance_statuses[domain] parameter",
+		},
+		{
+			form:     &apimodel.AdminMeasuresRequest{StartAt: "2022-05-02", EndAt: "2022-05-01"},
+			expected: "Bad Request: end_at must be after start_at",
+		},
+		{
+			form:     &apimodel.AdminMeasuresRequest{StartAt: "2020-01-01", EndAt: "2022-01-01"},
+			expected: "Bad Request: period of 732 days is too long, maximum is 366 days",
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.MeasuresGet(context.Background(), test.form)
+		if !suite.Error(errWithCode) {
+			continue
+		}
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		suite.Equal(test.expected, errWithCode.Safe())
+	}
+}
+
+func (suite *DashboardTestSuite) TestDimensionsSpaceUsage() {
+	dimensions, errWithCode := suite.adminProcessor.DimensionsGet(
+		context.Background(),
+		&apimodel.AdminDimensionsRequest{Keys: []string{"space_usage"}},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(dimensions, 1)
+	data := dimensions[0].Data
+	suite.Len(data, 2)
+	suite.Equal("database", data[0].Key)
+	suite.Equal("bytes", data[0].Unit)
+	suite.NotEqual("0", data[0].Value)
+	suite.Equal("media", data[1].Key)
+}
+
+func (suite *DashboardTestSuite) TestRetentionMonthly() {
+	cohorts, errWithCode := suite.adminProcessor.RetentionGet(
+		context.Background(),
+		&apimodel.AdminRetentionRequest{
+			StartAt:   "2022-05-10",
+			EndAt:     "2022-07-20",
+			Frequency: "month",
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Period is widened to whole
+	// months: May, June and July.
+	suite.Len(cohorts, 3)
+	suite.Equal("2022-05-01T00:00:00.000Z", cohorts[0].Period)
+	suite.Equal("month", cohorts[0].Frequency)
+	suite.Len(cohorts[0].Data, 3)
+	suite.Len(cohorts[1].Data, 2)
+	suite.Len(cohorts[2].Data, 1)
+	suite.Equal("2022-07-01T00:00:00.000Z", cohorts[2].Data[0].Date)
+}
+
+func (suite *DashboardTestSuite) TestRetentionBadFrequency() {
+	_, errWithCode := suite.adminProcessor.RetentionGet(
+		context.Background(),
+		&apimodel.AdminRetentionRequest{Frequency: "year"},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: frequency year not recognized, valid frequencies are: day, month", errWithCode.Safe())
+}
+
+func TestDashboardTestSuite(t *testing.T) {
+	suite.Run(t, &DashboardTestSuite{})
+}
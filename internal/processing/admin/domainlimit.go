@@ -0,0 +1,262 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// DomainLimitsGet returns a page of domain limits, newest first.
+func (p *Processor) DomainLimitsGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	limits, err := p.state.DB.GetDomainLimits(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain limits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(limits)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = limits[count-1].ID
+		hi = limits[0].ID
+
+		// Prepare a slice of domain limit API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, limit := range limits {
+		apiLimit, errWithCode := p.apiDomainLimit(limit)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, apiLimit)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/domain_limits",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// DomainLimitGet returns the domain limit with the given ID.
+func (p *Processor) DomainLimitGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainLimit(limit)
+}
+
+// DomainLimitCreate creates a new domain limit
+// from the given form, by the given admin.
+func (p *Processor) DomainLimitCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainLimitCreateRequest,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	domain, err := normalizeDomain(form.Domain)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	existing, err := p.state.DB.GetDomainLimitByDomain(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const help = "a limit already exists for this domain"
+		err := gtserror.Newf("%s: %s", help, domain)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	limit := &gtsmodel.DomainLimit{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		ContentWarning:     text.SanitizeToPlaintext(form.ContentWarning),
+		PrivateComment:     text.SanitizeToPlaintext(form.PrivateComment),
+		PublicComment:      text.SanitizeToPlaintext(form.PublicComment),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if err := p.state.DB.PutDomainLimit(ctx, limit); err != nil {
+		err := gtserror.Newf("db error putting domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLimit, errWithCode := p.apiDomainLimit(limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetDomainLimit, limit.ID,
+		nil, apiLimit,
+	)
+
+	return apiLimit, nil
+}
+
+// DomainLimitUpdate updates the domain limit with
+// the given ID, using the fields set in form.
+func (p *Processor) DomainLimitUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.DomainLimitUpdateRequest,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, errWithCode := p.apiDomainLimit(limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var columns []string
+
+	if form.ContentWarning != nil {
+		limit.ContentWarning = text.SanitizeToPlaintext(*form.ContentWarning)
+		columns = append(columns, "content_warning")
+	}
+
+	if form.PrivateComment != nil {
+		limit.PrivateComment = text.SanitizeToPlaintext(*form.PrivateComment)
+		columns = append(columns, "private_comment")
+	}
+
+	if form.PublicComment != nil {
+		limit.PublicComment = text.SanitizeToPlaintext(*form.PublicComment)
+		columns = append(columns, "public_comment")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return before, nil
+	}
+
+	if err := p.state.DB.UpdateDomainLimit(ctx, limit, columns...); err != nil {
+		err := gtserror.Newf("db error updating domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLimit, errWithCode := p.apiDomainLimit(limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetDomainLimit, limit.ID,
+		before, apiLimit,
+	)
+
+	return apiLimit, nil
+}
+
+// DomainLimitDelete deletes the domain limit with
+// the given ID, returning the deleted limit.
+func (p *Processor) DomainLimitDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainLimitByID(ctx, limit.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLimit, errWithCode := p.apiDomainLimit(limit)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetDomainLimit, limit.ID,
+		apiLimit, nil,
+	)
+
+	return apiLimit, nil
+}
+
+func (p *Processor) getDomainLimit(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainLimit, gtserror.WithCode) {
+	limit, err := p.state.DB.GetDomainLimitByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain limit %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if limit == nil {
+		err := gtserror.Newf("domain limit %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return limit, nil
+}
+
+// apiDomainLimit is a shortcut for returning the API
+// version of the given domain limit, or an appropriate
+// error if something goes wrong.
+func (p *Processor) apiDomainLimit(
+	limit *gtsmodel.DomainLimit,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	apiLimit, err := p.converter.DomainLimitToAPIDomainLimit(limit)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain limit to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiLimit, nil
+}
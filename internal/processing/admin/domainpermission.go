@@ -69,11 +69,17 @@ func (p *Processor) DomainPermissionCreate(
 	privateComment string,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	var (
+		apiDomainPerm *apimodel.DomainPermission
+		actionID      string
+		errWithCode   gtserror.WithCode
+	)
+
 	switch permissionType {
 
 	// Explicitly block a domain.
 	case gtsmodel.DomainPermissionBlock:
-		return p.createDomainBlock(
+		apiDomainPerm, actionID, errWithCode = p.createDomainBlock(
 			ctx,
 			adminAcct,
 			domain,
@@ -85,7 +91,7 @@ func (p *Processor) DomainPermissionCreate(
 
 	// Explicitly allow a domain.
 	case gtsmodel.DomainPermissionAllow:
-		return p.createDomainAllow(
+		apiDomainPerm, actionID, errWithCode = p.createDomainAllow(
 			ctx,
 			adminAcct,
 			domain,
@@ -100,6 +106,18 @@ func (p *Processor) DomainPermissionCreate(
 		err := gtserror.Newf("unrecognized permission type %d", permissionType)
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
+
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		domainPermissionAuditLogTarget(permissionType), apiDomainPerm.ID,
+		nil, apiDomainPerm,
+	)
+
+	return apiDomainPerm, actionID, nil
 }
 
 // DomainPermissionDelete removes one domain block with the given ID,
@@ -113,11 +131,17 @@ func (p *Processor) DomainPermissionDelete(
 	adminAcct *gtsmodel.Account,
 	domainBlockID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	var (
+		apiDomainPerm *apimodel.DomainPermission
+		actionID      string
+		errWithCode   gtserror.WithCode
+	)
+
 	switch permissionType {
 
 	// Delete explicit domain block.
 	case gtsmodel.DomainPermissionBlock:
-		return p.deleteDomainBlock(
+		apiDomainPerm, actionID, errWithCode = p.deleteDomainBlock(
 			ctx,
 			adminAcct,
 			domainBlockID,
@@ -125,7 +149,7 @@ func (p *Processor) DomainPermissionDelete(
 
 	// Delete explicit domain allow.
 	case gtsmodel.DomainPermissionAllow:
-		return p.deleteDomainAllow(
+		apiDomainPerm, actionID, errWithCode = p.deleteDomainAllow(
 			ctx,
 			adminAcct,
 			domainBlockID,
@@ -137,6 +161,27 @@ func (p *Processor) DomainPermissionDelete(
 		err := gtserror.Newf("unrecognized permission type %d", permissionType)
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
+
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		domainPermissionAuditLogTarget(permissionType), apiDomainPerm.ID,
+		apiDomainPerm, nil,
+	)
+
+	return apiDomainPerm, actionID, nil
+}
+
+// domainPermissionAuditLogTarget returns the audit
+// log target type for the given permission type.
+func domainPermissionAuditLogTarget(permissionType gtsmodel.DomainPermissionType) gtsmodel.AuditLogTargetType {
+	if permissionType == gtsmodel.DomainPermissionAllow {
+		return gtsmodel.AuditLogTargetDomainAllow
+	}
+	return gtsmodel.AuditLogTargetDomainBlock
 }
 
 // DomainPermissionsImport handles the import of multiple
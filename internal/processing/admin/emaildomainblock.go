@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// EmailDomainBlocksGet returns all email domain
+// blocks currently enforced by this instance.
+func (p *Processor) EmailDomainBlocksGet(
+	ctx context.Context,
+) ([]*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.EmailDomainBlock, len(blocks))
+	for i, block := range blocks {
+		apiBlocks[i] = p.converter.EmailDomainBlockToAPIEmailDomainBlock(block)
+	}
+
+	return apiBlocks, nil
+}
+
+// EmailDomainBlockGet returns the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.EmailDomainBlockToAPIEmailDomainBlock(block), nil
+}
+
+// EmailDomainBlockCreate blocks sign-ups, and email address
+// changes, using email addresses at the given domain or any
+// of its subdomains, or whose mail exchange hosts are there.
+func (p *Processor) EmailDomainBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	domain, err := normalizeEmailDomain(domain)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Check if a block already exists for this domain.
+	existing, err := p.state.DB.GetEmailDomainBlock(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const help = "email domain block already exists for this domain"
+		err := gtserror.Newf("%s: %s", help, domain)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	block := &gtsmodel.EmailDomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.CreateEmailDomainBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.EmailDomainBlockToAPIEmailDomainBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetEmailDomainBlock, block.ID,
+		nil, apiBlock,
+	)
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlockDelete removes the email domain
+// block with the given ID, returning the removed block.
+func (p *Processor) EmailDomainBlockDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.EmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.EmailDomainBlockToAPIEmailDomainBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetEmailDomainBlock, block.ID,
+		apiBlock, nil,
+	)
+
+	return apiBlock, nil
+}
+
+func (p *Processor) getEmailDomainBlock(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := gtserror.Newf("email domain block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return block, nil
+}
+
+// normalizeEmailDomain trims the given email domain,
+// drops any leading '@', and converts it to punycode,
+// returning an error if it doesn't look like a domain.
+func normalizeEmailDomain(domain string) (string, error) {
+	domain = strings.TrimSpace(domain)
+	domain = strings.TrimPrefix(domain, "@")
+	return normalizeDomain(domain)
+}
+
+// normalizeDomain trims and punifies the given
+// domain, returning an error if it's not valid.
+func normalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSpace(domain)
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" {
+		return "", errors.New("no domain given")
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return "", errors.New("domain could not be converted to punycode")
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || strings.ContainsAny(label, "@/:? ") {
+			return "", errors.New("domain is not valid")
+		}
+	}
+
+	return domain, nil
+}
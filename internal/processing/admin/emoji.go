@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -107,6 +108,12 @@ func (p *Processor) EmojiCreate(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.AuditLog(ctx, account,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetEmoji, emoji.ID,
+		nil, apiEmoji,
+	)
+
 	return &apiEmoji, nil
 }
 
@@ -249,6 +256,7 @@ func (p *Processor) EmojiGet(
 // from the database, with the given id.
 func (p *Processor) EmojiDelete(
 	ctx context.Context,
+	account *gtsmodel.Account,
 	id string,
 ) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	emoji, err := p.state.DB.GetEmojiByID(ctx, id)
@@ -280,6 +288,22 @@ func (p *Processor) EmojiDelete(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if *emoji.Cached {
+		// Release the emoji's (possibly shared) image files,
+		// so they're removed once nothing else references them.
+		for _, path := range []string{emoji.ImagePath, emoji.ImageStaticPath} {
+			if _, err := media.ReleaseFile(ctx, p.state, path); err != nil {
+				log.Errorf(ctx, "error releasing emoji file %s: %v", path, err)
+			}
+		}
+	}
+
+	p.AuditLog(ctx, account,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetEmoji, emoji.ID,
+		adminEmoji, nil,
+	)
+
 	return adminEmoji, nil
 }
 
@@ -287,6 +311,7 @@ func (p *Processor) EmojiDelete(
 // given id, using the provided form parameters.
 func (p *Processor) EmojiUpdate(
 	ctx context.Context,
+	account *gtsmodel.Account,
 	id string,
 	form *apimodel.EmojiUpdateRequest,
 ) (*apimodel.AdminEmoji, gtserror.WithCode) {
@@ -301,21 +326,48 @@ func (p *Processor) EmojiUpdate(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	// Convert to admin emoji before
+	// update, for the audit log.
+	before, err := p.converter.EmojiToAdminAPIEmoji(ctx, emoji)
+	if err != nil {
+		err := gtserror.Newf("error converting emoji to admin api emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		after       *apimodel.AdminEmoji
+		errWithCode gtserror.WithCode
+	)
+
 	switch t := form.Type; t {
 
 	case apimodel.EmojiUpdateCopy:
-		return p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
+		after, errWithCode = p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
 
 	case apimodel.EmojiUpdateDisable:
-		return p.emojiUpdateDisable(ctx, emoji)
+		after, errWithCode = p.emojiUpdateDisable(ctx, emoji)
 
 	case apimodel.EmojiUpdateModify:
-		return p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
+		after, errWithCode = p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
 
 	default:
 		err := fmt.Errorf("unrecognized emoji action type %s", t)
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Action is the update type, eg., "copy". In
+	// case of copy, after is the new local emoji.
+	p.AuditLog(ctx, account,
+		string(form.Type),
+		gtsmodel.AuditLogTargetEmoji, emoji.ID,
+		before, after,
+	)
+
+	return after, nil
 }
 
 // EmojiCategoriesGet returns all custom emoji
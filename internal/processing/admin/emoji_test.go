@@ -48,6 +48,7 @@ func (suite *EmojiTestSuite) TestUpdateEmojiCategory() {
 		"",
 	} {
 		emoji, err := suite.adminProcessor.EmojiUpdate(ctx,
+			suite.testAccounts["admin_account"],
 			testEmoji.ID,
 			&apimodel.EmojiUpdateRequest{
 				Type:         apimodel.EmojiUpdateModify,
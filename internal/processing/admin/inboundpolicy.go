@@ -0,0 +1,394 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// InboundPoliciesGet returns all inbound
+// policies, in the order that they run.
+func (p *Processor) InboundPoliciesGet(
+	ctx context.Context,
+) ([]*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetInboundPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting inbound policies: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.AdminInboundPolicy, 0, len(policies))
+	for _, policy := range policies {
+		apiPolicies = append(apiPolicies, p.apiInboundPolicy(policy))
+	}
+
+	return apiPolicies, nil
+}
+
+// InboundPolicyGet returns the inbound policy with the given ID.
+func (p *Processor) InboundPolicyGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getInboundPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiInboundPolicy(policy), nil
+}
+
+// InboundPolicyCreate creates a new inbound
+// policy from the given form, by the given admin.
+func (p *Processor) InboundPolicyCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminInboundPolicyCreateRequest,
+) (*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	policy := &gtsmodel.InboundPolicy{
+		ID:                 id.NewULID(),
+		Title:              form.Title,
+		Priority:           form.Priority,
+		Enabled:            util.Ptr(util.PtrValueOr(form.Enabled, true)),
+		DryRun:             util.Ptr(form.DryRun),
+		Action:             gtsmodel.InboundPolicyAction(form.Action),
+		Keywords:           form.Keywords,
+		Regex:              form.Regex,
+		MaxActorAge:        time.Duration(form.MaxActorAge) * time.Second,
+		MinMentions:        form.MinMentions,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	var errWithCode gtserror.WithCode
+
+	policy.Domains, errWithCode = normalizeInboundPolicyDomains(form.Domains)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	policy.HashtagRewrites, errWithCode = normalizeInboundPolicyRewrites(form.HashtagRewrites)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := validateInboundPolicy(policy); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutInboundPolicy(ctx, policy); err != nil {
+		err := gtserror.Newf("db error putting inbound policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicy := p.apiInboundPolicy(policy)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetPolicy, policy.ID,
+		nil, apiPolicy,
+	)
+
+	return apiPolicy, nil
+}
+
+// InboundPolicyUpdate updates the inbound policy
+// with the given ID, using the fields set in form.
+func (p *Processor) InboundPolicyUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.AdminInboundPolicyUpdateRequest,
+) (*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getInboundPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before := p.apiInboundPolicy(policy)
+
+	var columns []string
+
+	if form.Title != nil {
+		policy.Title = *form.Title
+		columns = append(columns, "title")
+	}
+
+	if form.Priority != nil {
+		policy.Priority = *form.Priority
+		columns = append(columns, "priority")
+	}
+
+	if form.Enabled != nil {
+		policy.Enabled = form.Enabled
+		columns = append(columns, "enabled")
+	}
+
+	if form.DryRun != nil {
+		policy.DryRun = form.DryRun
+		columns = append(columns, "dry_run")
+	}
+
+	if form.Action != nil {
+		policy.Action = gtsmodel.InboundPolicyAction(*form.Action)
+		columns = append(columns, "action")
+	}
+
+	if form.Domains != nil {
+		policy.Domains, errWithCode = normalizeInboundPolicyDomains(*form.Domains)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		columns = append(columns, "domains")
+	}
+
+	if form.Keywords != nil {
+		policy.Keywords = *form.Keywords
+		columns = append(columns, "keywords")
+	}
+
+	if form.Regex != nil {
+		policy.Regex = *form.Regex
+		columns = append(columns, "regex")
+	}
+
+	if form.MaxActorAge != nil {
+		policy.MaxActorAge = time.Duration(*form.MaxActorAge) * time.Second
+		columns = append(columns, "max_actor_age")
+	}
+
+	if form.MinMentions != nil {
+		policy.MinMentions = *form.MinMentions
+		columns = append(columns, "min_mentions")
+	}
+
+	if form.HashtagRewrites != nil {
+		policy.HashtagRewrites, errWithCode = normalizeInboundPolicyRewrites(form.HashtagRewrites)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		columns = append(columns, "hashtag_rewrites")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return before, nil
+	}
+
+	if errWithCode := validateInboundPolicy(policy); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	policy.UpdatedAt = time.Now()
+	columns = append(columns, "updated_at")
+
+	if err := p.state.DB.UpdateInboundPolicy(ctx, policy, columns...); err != nil {
+		err := gtserror.Newf("db error updating inbound policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicy := p.apiInboundPolicy(policy)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetPolicy, policy.ID,
+		before, apiPolicy,
+	)
+
+	return apiPolicy, nil
+}
+
+// InboundPolicyDelete deletes the inbound policy with
+// the given ID, returning the deleted policy.
+func (p *Processor) InboundPolicyDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getInboundPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteInboundPolicyByID(ctx, policy.ID); err != nil {
+		err := gtserror.Newf("db error deleting inbound policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicy := p.apiInboundPolicy(policy)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetPolicy, policy.ID,
+		apiPolicy, nil,
+	)
+
+	return apiPolicy, nil
+}
+
+// InboundPoliciesReload drops the in-memory inbound
+// policies, so that they are reloaded from the database
+// on the next inbound activity. This is only needed if
+// policies were changed directly in the database, as
+// changes through the API take effect immediately.
+func (p *Processor) InboundPoliciesReload(
+	ctx context.Context,
+) ([]*apimodel.AdminInboundPolicy, gtserror.WithCode) {
+	p.state.Caches.InboundPolicies.Clear()
+	return p.InboundPoliciesGet(ctx)
+}
+
+func (p *Processor) getInboundPolicy(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.InboundPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetInboundPolicyByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting inbound policy %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		err := gtserror.Newf("inbound policy %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return policy, nil
+}
+
+// apiInboundPolicy converts the given policy to
+// its API model, filling in its in-memory stats.
+func (p *Processor) apiInboundPolicy(policy *gtsmodel.InboundPolicy) *apimodel.AdminInboundPolicy {
+	apiPolicy := p.converter.InboundPolicyToAdminAPIInboundPolicy(policy)
+
+	stats := p.policyFilter.Stats(policy.ID)
+	apiPolicy.Stats = apimodel.AdminInboundPolicyStats{
+		Matches:       stats.Matches,
+		DryRunMatches: stats.DryRunMatches,
+	}
+
+	if !stats.LastMatchedAt.IsZero() {
+		lastMatchedAt := util.FormatISO8601(stats.LastMatchedAt)
+		apiPolicy.Stats.LastMatchedAt = &lastMatchedAt
+	}
+
+	return apiPolicy
+}
+
+// validateInboundPolicy checks that the
+// fields of the given policy make sense
+// together, before it's stored.
+func validateInboundPolicy(policy *gtsmodel.InboundPolicy) gtserror.WithCode {
+	badRequest := func(help string) gtserror.WithCode {
+		return gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	if policy.Title == "" {
+		return badRequest("title must be set")
+	}
+
+	if !slices.Contains(gtsmodel.InboundPolicyActions, policy.Action) {
+		return badRequest("action must be one of reject, strip_media, force_sensitive, unlist, rewrite_hashtags, quarantine")
+	}
+
+	if policy.MaxActorAge < 0 {
+		return badRequest("max_actor_age must be a positive number of seconds, or 0 to not match on actor age")
+	}
+
+	if policy.MinMentions < 0 {
+		return badRequest("min_mentions must be a positive number, or 0 to not match on mentions")
+	}
+
+	if _, err := policy.Regexp(); err != nil {
+		return badRequest(fmt.Sprintf("regex could not be compiled: %v", err))
+	}
+
+	if !policy.HasMatchers() {
+		// Don't allow policies that match
+		// everything by accident; a reject
+		// policy like that would defederate.
+		return badRequest("at least one of domains, keywords, regex, max_actor_age or min_mentions must be set")
+	}
+
+	if policy.Action == gtsmodel.InboundPolicyActionRewriteHashtags &&
+		len(policy.HashtagRewrites) == 0 {
+		return badRequest("hashtag_rewrites must be set for action rewrite_hashtags")
+	}
+
+	return nil
+}
+
+// normalizeInboundPolicyDomains returns the given
+// domains lowercased and punified, without empties.
+func normalizeInboundPolicyDomains(in []string) ([]string, gtserror.WithCode) {
+	domains := make([]string, 0, len(in))
+	for _, domain := range in {
+		domain = strings.TrimSpace(domain)
+		if domain == "" {
+			continue
+		}
+
+		punified, err := util.Punify(domain)
+		if err != nil {
+			help := fmt.Sprintf("invalid domain %s", domain)
+			return nil, gtserror.NewErrorBadRequest(err, help)
+		}
+
+		domains = append(domains, punified)
+	}
+	return domains, nil
+}
+
+// normalizeInboundPolicyRewrites returns the given
+// hashtag rewrites keyed by lowercase hashtag name,
+// checking that each replacement is a valid hashtag.
+func normalizeInboundPolicyRewrites(in map[string]string) (map[string]string, gtserror.WithCode) {
+	rewrites := make(map[string]string, len(in))
+	for name, replacement := range in {
+		name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+		if name == "" {
+			continue
+		}
+
+		replacement = strings.TrimSpace(replacement)
+		if replacement == "" {
+			// Remove the hashtag.
+			rewrites[name] = ""
+			continue
+		}
+
+		normalized, ok := text.NormalizeHashtag(replacement)
+		if !ok {
+			help := fmt.Sprintf("invalid hashtag_rewrites replacement %s", replacement)
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+
+		rewrites[name] = normalized
+	}
+	return rewrites, nil
+}
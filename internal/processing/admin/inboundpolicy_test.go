@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type InboundPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *InboundPolicyTestSuite) TestInboundPolicyCreateUpdateDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	policy, errWithCode := suite.adminProcessor.InboundPolicyCreate(ctx, adminAcct,
+		&apimodel.AdminInboundPolicyCreateRequest{
+			Title:           "no crypto",
+			Action:          "rewrite_hashtags",
+			Domains:         []string{" Example.ORG ", ""},
+			HashtagRewrites: map[string]string{"#Crypto": "scam", "coins": ""},
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("no crypto", policy.Title)
+	suite.True(policy.Enabled)
+	suite.False(policy.DryRun)
+	suite.Equal([]string{"example.org"}, policy.Domains)
+	suite.Equal(map[string]string{"crypto": "scam", "coins": ""}, policy.HashtagRewrites)
+	suite.Empty(policy.Keywords)
+
+	// Policy should now be loaded by the filter.
+	enabled, err := suite.state.DB.GetEnabledInboundPolicies(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(enabled, 1)
+
+	policy, errWithCode = suite.adminProcessor.InboundPolicyUpdate(ctx, adminAcct, policy.ID,
+		&apimodel.AdminInboundPolicyUpdateRequest{
+			Enabled:     util.Ptr(false),
+			MaxActorAge: util.Ptr(int64(86400)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.False(policy.Enabled)
+	suite.EqualValues(86400, policy.MaxActorAge)
+
+	// Disabled policy should be dropped
+	// by the filter without a restart.
+	enabled, err = suite.state.DB.GetEnabledInboundPolicies(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(enabled)
+
+	if _, errWithCode := suite.adminProcessor.InboundPolicyDelete(ctx, adminAcct, policy.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.InboundPolicyGet(ctx, policy.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *InboundPolicyTestSuite) TestInboundPolicyCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, form := range []*apimodel.AdminInboundPolicyCreateRequest{
+		{
+			// Unknown action.
+			Title:   "bad",
+			Action:  "explode",
+			Domains: []string{"example.org"},
+		},
+		{
+			// No matchers.
+			Title:  "bad",
+			Action: "reject",
+		},
+		{
+			// Invalid regex.
+			Title:  "bad",
+			Action: "reject",
+			Regex:  "(unclosed",
+		},
+		{
+			// No rewrites.
+			Title:   "bad",
+			Action:  "rewrite_hashtags",
+			Domains: []string{"example.org"},
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.InboundPolicyCreate(ctx, adminAcct, form)
+		if suite.NotNil(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		}
+	}
+}
+
+func TestInboundPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(InboundPolicyTestSuite))
+}
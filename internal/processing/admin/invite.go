@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// InvitesGet returns a page of invites created by
+// the given account, or by any account if empty.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	accountID string,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(ctx, accountID, maxID, sinceID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(invites)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	items := make([]interface{}, 0, count)
+	for _, invite := range invites {
+		apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+		if err != nil {
+			err := gtserror.Newf("error converting invite to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiInvite)
+	}
+
+	var extraQueryParams []string
+	if accountID != "" {
+		extraQueryParams = append(extraQueryParams, "account_id="+accountID)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/admin/invites",
+		NextMaxIDValue:   invites[count-1].ID,
+		PrevMinIDValue:   invites[0].ID,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
+
+// InviteDelete revokes, as the given admin,
+// the invite with the given id.
+//
+// Unused invites are deleted outright, while invites
+// that have been used are expired instead, so that
+// it remains possible to see who invited whom.
+func (p *Processor) InviteDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	inviteID string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, inviteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := gtserror.Newf("invite %s not found", inviteID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	before, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Summary of the invite after revocation,
+	// left nil if the invite is deleted outright.
+	var after *apimodel.Invite
+
+	if invite.Uses == 0 {
+		err = p.state.DB.DeleteInviteByID(ctx, invite.ID)
+	} else if !invite.Expired() {
+		invite.ExpiresAt = time.Now()
+		err = p.state.DB.UpdateInvite(ctx, invite, "expires_at")
+	}
+
+	if err != nil {
+		err := gtserror.Newf("db error revoking invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite.Uses != 0 {
+		after = apiInvite
+	}
+
+	p.AuditLog(ctx, adminAcct,
+		"revoke",
+		gtsmodel.AuditLogTargetInvite, invite.ID,
+		before, after,
+	)
+
+	return apiInvite, nil
+}
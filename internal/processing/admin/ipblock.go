@@ -0,0 +1,313 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// IPBlocksGet returns a page of IP blocks, newest first.
+func (p *Processor) IPBlocksGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetIPBlocks(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting ip blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = blocks[count-1].ID
+		hi = blocks[0].ID
+
+		// Prepare a slice of IP block API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, block := range blocks {
+		items = append(items, p.converter.IPBlockToAPIIPBlock(block))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/ip_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// IPBlockGet returns the IP block with the given ID.
+func (p *Processor) IPBlockGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.IPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.IPBlockToAPIIPBlock(block), nil
+}
+
+// IPBlockCreate creates a new IP block
+// from the given form, by the given admin.
+func (p *Processor) IPBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.IPBlockCreateRequest,
+) (*apimodel.IPBlock, gtserror.WithCode) {
+	ip, err := normalizeIPBlockIP(form.IP)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	severity := gtsmodel.NewIPBlockSeverity(form.Severity)
+	if severity == gtsmodel.IPBlockSeverityUnknown {
+		const help = "severity must be one of sign_up_requires_approval, sign_up_block, no_access"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	if errWithCode := p.checkIPBlockUnique(ctx, ip, ""); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	block := &gtsmodel.IPBlock{
+		ID:                 id.NewULID(),
+		IP:                 ip,
+		Severity:           severity,
+		Comment:            form.Comment,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if form.ExpiresIn != nil {
+		if *form.ExpiresIn <= 0 {
+			const help = "expires_in must be a positive number of seconds"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+		block.ExpiresAt = time.Now().Add(time.Duration(*form.ExpiresIn) * time.Second)
+	}
+
+	if err := p.state.DB.PutIPBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.IPBlockToAPIIPBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetIPBlock, block.ID,
+		nil, apiBlock,
+	)
+
+	return apiBlock, nil
+}
+
+// IPBlockUpdate updates the IP block with
+// the given ID, using the fields set in form.
+func (p *Processor) IPBlockUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.IPBlockUpdateRequest,
+) (*apimodel.IPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before := p.converter.IPBlockToAPIIPBlock(block)
+
+	var columns []string
+
+	if form.IP != nil {
+		ip, err := normalizeIPBlockIP(*form.IP)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if ip != block.IP {
+			if errWithCode := p.checkIPBlockUnique(ctx, ip, block.ID); errWithCode != nil {
+				return nil, errWithCode
+			}
+
+			block.IP = ip
+			columns = append(columns, "ip")
+		}
+	}
+
+	if form.Severity != nil {
+		severity := gtsmodel.NewIPBlockSeverity(*form.Severity)
+		if severity == gtsmodel.IPBlockSeverityUnknown {
+			const help = "severity must be one of sign_up_requires_approval, sign_up_block, no_access"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+
+		block.Severity = severity
+		columns = append(columns, "severity")
+	}
+
+	if form.Comment != nil {
+		block.Comment = *form.Comment
+		columns = append(columns, "comment")
+	}
+
+	if form.ExpiresIn != nil {
+		switch expiresIn := *form.ExpiresIn; {
+		case expiresIn < 0:
+			const help = "expires_in must be a positive number of seconds, or 0 to never expire"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+
+		case expiresIn == 0:
+			block.ExpiresAt = time.Time{}
+
+		default:
+			block.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+		}
+
+		columns = append(columns, "expires_at")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return before, nil
+	}
+
+	if err := p.state.DB.UpdateIPBlock(ctx, block, columns...); err != nil {
+		err := gtserror.Newf("db error updating ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.IPBlockToAPIIPBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetIPBlock, block.ID,
+		before, apiBlock,
+	)
+
+	return apiBlock, nil
+}
+
+// IPBlockDelete deletes the IP block with the
+// given ID, returning the deleted block.
+func (p *Processor) IPBlockDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.IPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteIPBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.IPBlockToAPIIPBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetIPBlock, block.ID,
+		apiBlock, nil,
+	)
+
+	return apiBlock, nil
+}
+
+func (p *Processor) getIPBlock(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.IPBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetIPBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting ip block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := gtserror.Newf("ip block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return block, nil
+}
+
+// checkIPBlockUnique returns a conflict error if an IP
+// block other than the one with exceptID exists for ip.
+func (p *Processor) checkIPBlockUnique(
+	ctx context.Context,
+	ip string,
+	exceptID string,
+) gtserror.WithCode {
+	existing, err := p.state.DB.GetIPBlockByIP(ctx, ip)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing ip block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil && existing.ID != exceptID {
+		const help = "an ip block already exists for this address range"
+		err := gtserror.Newf("%s: %s", help, ip)
+		return gtserror.NewErrorConflict(err, help)
+	}
+
+	return nil
+}
+
+// normalizeIPBlockIP parses the given IP address
+// or CIDR range, returning it as a masked CIDR
+// range, so that eg. "192.0.2.1/24" and "192.0.2.0/24"
+// (or "192.0.2.1" and "192.0.2.1/32") are the same.
+func normalizeIPBlockIP(in string) (string, error) {
+	if in == "" {
+		return "", errors.New("no ip given")
+	}
+
+	prefix, err := netip.ParsePrefix(in)
+	if err != nil {
+		// Not a range, try parsing as a single address.
+		addr, err := netip.ParseAddr(in)
+		if err != nil {
+			return "", errors.New("ip must be an ip address or range in CIDR notation")
+		}
+
+		addr = addr.Unmap()
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	return prefix.Masked().String(), nil
+}
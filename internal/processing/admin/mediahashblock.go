@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// MediaHashBlocksGet returns a page of media hash blocks, newest first.
+func (p *Processor) MediaHashBlocksGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetMediaHashBlocks(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = blocks[count-1].ID
+		hi = blocks[0].ID
+
+		// Prepare a slice of media hash block API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, block := range blocks {
+		items = append(items, p.converter.MediaHashBlockToAPIMediaHashBlock(block))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/media_hash_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// MediaHashBlockGet returns the media hash block with the given ID.
+func (p *Processor) MediaHashBlockGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.MediaHashBlock, gtserror.WithCode) {
+	block, errWithCode := p.getMediaHashBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.MediaHashBlockToAPIMediaHashBlock(block), nil
+}
+
+// MediaHashBlockCreate creates a new media hash block from
+// the given form, by the given admin. The blocked hash is
+// either given directly, or calculated from a sample image.
+func (p *Processor) MediaHashBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.MediaHashBlockCreateRequest,
+) (*apimodel.MediaHashBlock, gtserror.WithCode) {
+	var hash string
+
+	switch {
+	case form.Hash != "" && form.File != nil:
+		const help = "provide either hash or file, not both"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+
+	case form.Hash != "":
+		// Parse + reformat given hash
+		// so that case doesn't matter.
+		h, err := gtsmodel.ParseMediaHash(strings.TrimSpace(form.Hash))
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		hash = gtsmodel.FormatMediaHash(h)
+
+	case form.File != nil:
+		f, err := form.File.Open()
+		if err != nil {
+			err := gtserror.Newf("error opening sample file: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		defer f.Close()
+
+		hash, err = media.SampleHash(f)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+	default:
+		const help = "provide either hash or file"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	existing, err := p.state.DB.GetMediaHashBlockByHash(ctx, hash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const help = "a media hash block already exists for this hash"
+		err := gtserror.Newf("%s: %s", help, hash)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	block := &gtsmodel.MediaHashBlock{
+		ID:                 id.NewULID(),
+		Hash:               hash,
+		Comment:            form.Comment,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if err := p.state.DB.PutMediaHashBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.MediaHashBlockToAPIMediaHashBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetMediaHash, block.ID,
+		nil, apiBlock,
+	)
+
+	return apiBlock, nil
+}
+
+// MediaHashBlockDelete deletes the media hash block with the
+// given ID, and any of its matches in the review queue,
+// returning the deleted block. Media that was already
+// dropped for matching the block is not restored.
+func (p *Processor) MediaHashBlockDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.MediaHashBlock, gtserror.WithCode) {
+	block, errWithCode := p.getMediaHashBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteMediaHashBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.MediaHashBlockToAPIMediaHashBlock(block)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetMediaHash, block.ID,
+		apiBlock, nil,
+	)
+
+	return apiBlock, nil
+}
+
+// MediaHashMatchesGet returns a page of remote media dropped for
+// matching media hash blocks, newest first. If blockID is set,
+// only matches of that block are returned.
+func (p *Processor) MediaHashMatchesGet(
+	ctx context.Context,
+	blockID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	matches, err := p.state.DB.GetMediaHashMatches(ctx, blockID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash matches: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(matches)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = matches[count-1].ID
+		hi = matches[0].ID
+
+		// Prepare a slice of media hash match API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, match := range matches {
+		apiMatch, err := p.converter.MediaHashMatchToAdminAPIMediaHashMatch(ctx, match)
+		if err != nil {
+			err := gtserror.Newf("error converting media hash match: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiMatch)
+	}
+
+	// Preserve filters in paging links.
+	query := make(url.Values, 1)
+	if blockID != "" {
+		query["media_hash_block_id"] = []string{blockID}
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/media_hash_matches",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// MediaHashMatchDismiss removes the media hash match with the
+// given ID from the review queue, returning the dismissed match.
+// The dropped media stays dropped.
+func (p *Processor) MediaHashMatchDismiss(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminMediaHashMatch, gtserror.WithCode) {
+	match, err := p.state.DB.GetMediaHashMatchByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash match %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if match == nil {
+		err := gtserror.Newf("media hash match %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiMatch, err := p.converter.MediaHashMatchToAdminAPIMediaHashMatch(ctx, match)
+	if err != nil {
+		err := gtserror.Newf("error converting media hash match: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteMediaHashMatchByID(ctx, match.ID); err != nil {
+		err := gtserror.Newf("db error deleting media hash match: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiMatch, nil
+}
+
+func (p *Processor) getMediaHashBlock(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.MediaHashBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetMediaHashBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := gtserror.Newf("media hash block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return block, nil
+}
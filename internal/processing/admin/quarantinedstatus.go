@@ -0,0 +1,203 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// QuarantinedStatusesGet returns a page of quarantined statuses,
+// newest first. If accountID is set, only statuses sent by that
+// account are returned.
+func (p *Processor) QuarantinedStatusesGet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	quarantined, err := p.state.DB.GetQuarantinedStatuses(ctx, accountID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting quarantined statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(quarantined)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = quarantined[count-1].ID
+		hi = quarantined[0].ID
+
+		// Prepare a slice of quarantined status API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, q := range quarantined {
+		apiQuarantined, errWithCode := p.apiQuarantinedStatus(ctx, q, adminAcct)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, apiQuarantined)
+	}
+
+	// Preserve filters in paging links.
+	query := make(url.Values, 1)
+	if accountID != "" {
+		query["account_id"] = []string{accountID}
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/quarantine",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// QuarantinedStatusGet returns the quarantined status with the given ID.
+func (p *Processor) QuarantinedStatusGet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	quarantined, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiQuarantinedStatus(ctx, quarantined, adminAcct)
+}
+
+// QuarantinedStatusRelease releases the quarantined status with
+// the given ID, putting it into timelines and sending notifications
+// about it, as though it had just been received.
+func (p *Processor) QuarantinedStatusRelease(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	quarantined, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting, so
+	// the status is still populated.
+	apiQuarantined, errWithCode := p.apiQuarantinedStatus(ctx, quarantined, adminAcct)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteQuarantinedStatusByID(ctx, quarantined.ID); err != nil {
+		err := gtserror.Newf("db error deleting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Timeline + notify the status.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       quarantined.Status,
+		Origin:         adminAcct,
+		Target:         quarantined.Account,
+	})
+
+	p.AuditLog(ctx, adminAcct,
+		"release",
+		gtsmodel.AuditLogTargetQuarantine, quarantined.ID,
+		apiQuarantined, nil,
+	)
+
+	return apiQuarantined, nil
+}
+
+// QuarantinedStatusSuspend suspends the account that sent the
+// quarantined status with the given ID. This removes all of the
+// account's statuses, including any that are in quarantine.
+func (p *Processor) QuarantinedStatusSuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	quarantined, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiQuarantined, errWithCode := p.apiQuarantinedStatus(ctx, quarantined, adminAcct)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if _, errWithCode := p.AccountAction(ctx, adminAcct, &apimodel.AdminActionRequest{
+		Type:     gtsmodel.AdminActionSuspend.String(),
+		Text:     "suspended from quarantine review",
+		TargetID: quarantined.AccountID,
+	}); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return apiQuarantined, nil
+}
+
+func (p *Processor) getQuarantinedStatus(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.QuarantinedStatus, gtserror.WithCode) {
+	quarantined, err := p.state.DB.GetQuarantinedStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting quarantined status %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if quarantined == nil {
+		err := gtserror.Newf("quarantined status %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return quarantined, nil
+}
+
+func (p *Processor) apiQuarantinedStatus(
+	ctx context.Context,
+	quarantined *gtsmodel.QuarantinedStatus,
+	adminAcct *gtsmodel.Account,
+) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	apiQuarantined, err := p.converter.QuarantinedStatusToAdminAPIQuarantinedStatus(ctx, quarantined, adminAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting quarantined status to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiQuarantined, nil
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type QuarantinedStatusTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *QuarantinedStatusTestSuite) quarantine(statusKey string) *gtsmodel.QuarantinedStatus {
+	status := suite.testStatuses[statusKey]
+	quarantined := &gtsmodel.QuarantinedStatus{
+		ID:        id.NewULID(),
+		StatusID:  status.ID,
+		AccountID: status.AccountID,
+		Score:     13,
+		Signals:   []string{spam.SignalUnknownMentions, spam.SignalNewAccount},
+	}
+
+	if err := suite.state.DB.PutQuarantinedStatus(context.Background(), quarantined); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return quarantined
+}
+
+func (suite *QuarantinedStatusTestSuite) TestQuarantinedStatusRelease() {
+	var (
+		ctx         = context.Background()
+		adminAcct   = suite.testAccounts["admin_account"]
+		quarantined = suite.quarantine("remote_account_1_status_1")
+	)
+
+	resp, errWithCode := suite.adminProcessor.QuarantinedStatusesGet(ctx, adminAcct, "", &paging.Page{Limit: 20})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(resp.Items, 1) {
+		suite.FailNow("")
+	}
+
+	apiQuarantined := resp.Items[0].(*apimodel.AdminQuarantinedStatus)
+	suite.Equal(quarantined.ID, apiQuarantined.ID)
+	suite.Equal(13, apiQuarantined.Score)
+	suite.Equal([]string{"unknown_mentions", "new_account"}, apiQuarantined.Signals)
+	suite.Nil(apiQuarantined.PolicyID)
+	suite.Equal(quarantined.StatusID, apiQuarantined.Status.ID)
+	suite.Equal(quarantined.AccountID, apiQuarantined.Account.ID)
+
+	if _, errWithCode := suite.adminProcessor.QuarantinedStatusRelease(ctx, adminAcct, quarantined.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Quarantine entry should be gone...
+	_, err := suite.state.DB.GetQuarantinedStatusByID(ctx, quarantined.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// ...but the status should still be there.
+	if _, err := suite.state.DB.GetStatusByID(ctx, quarantined.StatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Releasing again is a 404.
+	_, errWithCode = suite.adminProcessor.QuarantinedStatusRelease(ctx, adminAcct, quarantined.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *QuarantinedStatusTestSuite) TestQuarantinedStatusDeletedWithStatus() {
+	var (
+		ctx         = context.Background()
+		quarantined = suite.quarantine("remote_account_1_status_1")
+	)
+
+	if err := suite.state.DB.DeleteStatusByID(ctx, quarantined.StatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.state.DB.GetQuarantinedStatusByID(ctx, quarantined.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestQuarantinedStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(QuarantinedStatusTestSuite))
+}
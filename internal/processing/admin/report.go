@@ -109,10 +109,18 @@ func (p *Processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id
 }
 
 // ReportResolve marks a report with the given id as resolved,
-// and stores the provided actionTakenComment (if not null).
+// and stores the provided action taken comment (if not null).
 // If the report creator is from this instance, an email will
 // be sent to them to let them know that the report is resolved.
-func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string, actionTakenComment *string) (*apimodel.AdminReport, gtserror.WithCode) {
+// If the report is not yet assigned, it will be assigned to
+// the resolving account.
+//
+// If warning text is not null and the report target is from this
+// instance, a warning linked to the report will be issued to them.
+//
+// If forward is set and the report target is from another instance,
+// the report will be forwarded there (if not already forwarded).
+func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.AdminReportResolveRequest) (*apimodel.AdminReport, gtserror.WithCode) {
 	report, err := p.state.DB.GetReportByID(ctx, id)
 	if err != nil {
 		if err == db.ErrNoEntries {
@@ -121,6 +129,26 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	forward := form.Forward && !*report.Forwarded
+	if forward && report.TargetAccount.IsLocal() {
+		const text = "cannot forward a report targeting a local account"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	var warning *gtsmodel.AccountWarning
+	if form.WarningText != nil && report.TargetAccount.IsLocal() {
+		var errWithCode gtserror.WithCode
+		warning, errWithCode = p.newAccountWarning(
+			ctx, account, report.TargetAccount,
+			gtsmodel.AdminActionWarn, *form.WarningText,
+			report.ID, nil, nil,
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	before := newReportAuditSummary(report)
 	columns := []string{
 		"action_taken_at",
 		"action_taken_by_account_id",
@@ -129,11 +157,22 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 	report.ActionTakenAt = time.Now()
 	report.ActionTakenByAccountID = account.ID
 
-	if actionTakenComment != nil {
-		report.ActionTaken = *actionTakenComment
+	if form.ActionTakenComment != nil {
+		report.ActionTaken = *form.ActionTakenComment
 		columns = append(columns, "action_taken")
 	}
 
+	if report.AssignedAccountID == "" {
+		report.AssignedAccountID = account.ID
+		report.AssignedAccount = account
+		columns = append(columns, "assigned_account_id")
+	}
+
+	if forward {
+		report.Forwarded = util.Ptr(true)
+		columns = append(columns, "forwarded")
+	}
+
 	updatedReport, err := p.state.DB.UpdateReport(ctx, report, columns...)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -148,6 +187,86 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 		Target:         report.Account,
 	})
 
+	p.AuditLog(ctx, account,
+		"resolve",
+		gtsmodel.AuditLogTargetReport, report.ID,
+		before, newReportAuditSummary(updatedReport),
+	)
+
+	if forward {
+		// Forward the report to the
+		// target account's instance.
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ActivityFlag,
+			APActivityType: messages.ActivityForward,
+			GTSModel:       report,
+			Origin:         account,
+			Target:         report.TargetAccount,
+		})
+	}
+
+	if warning != nil {
+		if errWithCode := p.issueAccountWarning(ctx, warning); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	apimodelReport, err := p.converter.ReportToAdminAPIReport(ctx, updatedReport, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apimodelReport, nil
+}
+
+// ReportAssign assigns the report with the given
+// id to the given (moderator) account.
+func (p *Processor) ReportAssign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.reportSetAssigned(ctx, account, id, "assign", account)
+}
+
+// ReportUnassign clears the assigned
+// account of the report with the given id.
+func (p *Processor) ReportUnassign(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
+	return p.reportSetAssigned(ctx, account, id, "unassign", nil)
+}
+
+func (p *Processor) reportSetAssigned(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	action string,
+	assignee *gtsmodel.Account,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, err := p.state.DB.GetReportByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	before := newReportAuditSummary(report)
+
+	if assignee != nil {
+		report.AssignedAccountID = assignee.ID
+		report.AssignedAccount = assignee
+	} else {
+		report.AssignedAccountID = ""
+		report.AssignedAccount = nil
+	}
+
+	updatedReport, err := p.state.DB.UpdateReport(ctx, report, "assigned_account_id")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.AuditLog(ctx, account,
+		action,
+		gtsmodel.AuditLogTargetReport, report.ID,
+		before, newReportAuditSummary(updatedReport),
+	)
+
 	apimodelReport, err := p.converter.ReportToAdminAPIReport(ctx, updatedReport, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -155,3 +274,21 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 
 	return apimodelReport, nil
 }
+
+// reportAuditSummary summarizes the
+// state of a report for the audit log.
+type reportAuditSummary struct {
+	Resolved          bool   `json:"resolved"`
+	ActionTaken       string `json:"action_taken,omitempty"`
+	AssignedAccountID string `json:"assigned_account_id,omitempty"`
+	Forwarded         bool   `json:"forwarded"`
+}
+
+func newReportAuditSummary(report *gtsmodel.Report) *reportAuditSummary {
+	return &reportAuditSummary{
+		Resolved:          !report.ActionTakenAt.IsZero(),
+		ActionTaken:       report.ActionTaken,
+		AssignedAccountID: report.AssignedAccountID,
+		Forwarded:         util.PtrValueOr(report.Forwarded, false),
+	}
+}
@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// putReport stores a new, unforwarded
+// report targeting the given account.
+func (suite *ReportTestSuite) putReport(targetAccountID string) *gtsmodel.Report {
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             "http://localhost:8080/reports/" + reportID,
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: targetAccountID,
+		Category:        gtsmodel.ReportCategorySpam,
+		Forwarded:       util.Ptr(false),
+	}
+
+	if err := suite.state.DB.PutReport(context.Background(), report); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return report
+}
+
+func (suite *ReportTestSuite) TestReportNotes() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		reportID  = testrig.NewTestReports()["local_account_2_report_remote_account_1"].ID
+	)
+
+	note, errWithCode := suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, reportID, "reached out to their admin")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("reached out to their admin", note.Content)
+	suite.Equal(adminAcct.ID, note.Account.ID)
+
+	// Empty notes are not allowed.
+	_, errWithCode = suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, reportID, "")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	notes, errWithCode := suite.adminProcessor.ReportNotesGet(ctx, reportID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(notes, 1)
+	suite.Equal(note.ID, notes[0].ID)
+
+	// Notes can't be deleted via another report.
+	otherReportID := testrig.NewTestReports()["remote_account_1_report_local_account_2"].ID
+	errWithCode = suite.adminProcessor.ReportNoteDelete(ctx, adminAcct, otherReportID, note.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	if errWithCode := suite.adminProcessor.ReportNoteDelete(ctx, adminAcct, reportID, note.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	notes, errWithCode = suite.adminProcessor.ReportNotesGet(ctx, reportID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(notes)
+}
+
+func (suite *ReportTestSuite) TestReportAssignResolve() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.putReport(suite.testAccounts["remote_account_1"].ID)
+	)
+
+	assigned, errWithCode := suite.adminProcessor.ReportAssign(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(adminAcct.ID, assigned.AssignedAccount.ID)
+	suite.Equal("spam", assigned.Category)
+
+	unassigned, errWithCode := suite.adminProcessor.ReportUnassign(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Nil(unassigned.AssignedAccount)
+
+	// Resolving an unassigned report
+	// assigns it to the resolver, and
+	// forwarding marks it as forwarded.
+	resolved, errWithCode := suite.adminProcessor.ReportResolve(
+		ctx, adminAcct, report.ID,
+		&apimodel.AdminReportResolveRequest{Forward: true},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(adminAcct.ID, resolved.AssignedAccount.ID)
+	suite.True(resolved.Forwarded)
+
+	dbReport, err := suite.state.DB.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbReport.Forwarded)
+}
+
+func (suite *ReportTestSuite) TestReportResolveForwardLocal() {
+	report := suite.putReport(suite.testAccounts["local_account_2"].ID)
+
+	_, errWithCode := suite.adminProcessor.ReportResolve(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		report.ID,
+		&apimodel.AdminReportResolveRequest{Forward: true},
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, &ReportTestSuite{})
+}
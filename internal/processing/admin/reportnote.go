@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ReportNotesGet returns all moderator notes
+// attached to the report with the given id.
+func (p *Processor) ReportNotesGet(ctx context.Context, reportID string) ([]*apimodel.AdminReportNote, gtserror.WithCode) {
+	if _, errWithCode := p.getReport(ctx, reportID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	notes, err := p.state.DB.GetReportNotes(ctx, reportID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report notes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNotes := make([]*apimodel.AdminReportNote, 0, len(notes))
+	for _, note := range notes {
+		apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+		if err != nil {
+			err := gtserror.Newf("error converting report note to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiNotes = append(apiNotes, apiNote)
+	}
+
+	return apiNotes, nil
+}
+
+// ReportNoteCreate attaches a new moderator note, authored
+// by the given account, to the report with the given id.
+func (p *Processor) ReportNoteCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reportID string,
+	content string,
+) (*apimodel.AdminReportNote, gtserror.WithCode) {
+	if err := validate.ReportNote(content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if _, errWithCode := p.getReport(ctx, reportID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	note := &gtsmodel.ReportNote{
+		ID:        id.NewULID(),
+		ReportID:  reportID,
+		AccountID: account.ID,
+		Account:   account,
+		Content:   content,
+	}
+
+	if err := p.state.DB.PutReportNote(ctx, note); err != nil {
+		err := gtserror.Newf("db error putting report note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		err := gtserror.Newf("error converting report note to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.AuditLog(ctx, account,
+		"create_note",
+		gtsmodel.AuditLogTargetReport, reportID,
+		nil, apiNote,
+	)
+
+	return apiNote, nil
+}
+
+// ReportNoteDelete deletes, as the given account, the moderator
+// note with the given id from the report with the given id.
+func (p *Processor) ReportNoteDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reportID string,
+	noteID string,
+) gtserror.WithCode {
+	note, err := p.state.DB.GetReportNoteByID(ctx, noteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report note: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if note == nil || note.ReportID != reportID {
+		err := gtserror.Newf("report note %s not found on report %s", noteID, reportID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		err := gtserror.Newf("error converting report note to api: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteReportNoteByID(ctx, noteID); err != nil {
+		err := gtserror.Newf("db error deleting report note: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.AuditLog(ctx, account,
+		"delete_note",
+		gtsmodel.AuditLogTargetReport, reportID,
+		apiNote, nil,
+	)
+
+	return nil
+}
+
+func (p *Processor) getReport(ctx context.Context, id string) (*gtsmodel.Report, gtserror.WithCode) {
+	report, err := p.state.DB.GetReportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if report == nil {
+		err := gtserror.Newf("report %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return report, nil
+}
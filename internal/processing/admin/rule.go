@@ -62,7 +62,7 @@ func (p *Processor) RuleGet(ctx context.Context, id string) (*apimodel.AdminInst
 }
 
 // RuleCreate adds a new rule to the instance.
-func (p *Processor) RuleCreate(ctx context.Context, form *apimodel.InstanceRuleCreateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+func (p *Processor) RuleCreate(ctx context.Context, adminAcct *gtsmodel.Account, form *apimodel.InstanceRuleCreateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
 	ruleID, err := id.NewRandomULID()
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error creating id for new instance rule: %s", err), "error creating rule ID")
@@ -77,11 +77,18 @@ func (p *Processor) RuleCreate(ctx context.Context, form *apimodel.InstanceRuleC
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	return p.converter.InstanceRuleToAdminAPIRule(rule), nil
+	apiRule := p.converter.InstanceRuleToAdminAPIRule(rule)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetRule, rule.ID,
+		nil, apiRule,
+	)
+
+	return apiRule, nil
 }
 
 // RuleUpdate updates text for an existing rule.
-func (p *Processor) RuleUpdate(ctx context.Context, id string, form *apimodel.InstanceRuleCreateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+func (p *Processor) RuleUpdate(ctx context.Context, adminAcct *gtsmodel.Account, id string, form *apimodel.InstanceRuleCreateRequest) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
 	rule, err := p.state.DB.GetRuleByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
@@ -92,6 +99,7 @@ func (p *Processor) RuleUpdate(ctx context.Context, id string, form *apimodel.In
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	before := p.converter.InstanceRuleToAdminAPIRule(rule)
 	rule.Text = form.Text
 
 	updatedRule, err := p.state.DB.UpdateRule(ctx, rule)
@@ -99,11 +107,18 @@ func (p *Processor) RuleUpdate(ctx context.Context, id string, form *apimodel.In
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	return p.converter.InstanceRuleToAdminAPIRule(updatedRule), nil
+	apiRule := p.converter.InstanceRuleToAdminAPIRule(updatedRule)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetRule, rule.ID,
+		before, apiRule,
+	)
+
+	return apiRule, nil
 }
 
 // RuleDelete deletes an existing rule.
-func (p *Processor) RuleDelete(ctx context.Context, id string) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
+func (p *Processor) RuleDelete(ctx context.Context, adminAcct *gtsmodel.Account, id string) (*apimodel.AdminInstanceRule, gtserror.WithCode) {
 	rule, err := p.state.DB.GetRuleByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
@@ -120,5 +135,12 @@ func (p *Processor) RuleDelete(ctx context.Context, id string) (*apimodel.AdminI
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	return p.converter.InstanceRuleToAdminAPIRule(deletedRule), nil
+	apiRule := p.converter.InstanceRuleToAdminAPIRule(deletedRule)
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetRule, rule.ID,
+		apiRule, nil,
+	)
+
+	return apiRule, nil
 }
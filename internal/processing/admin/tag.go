@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Number of days of usage
+// history shown for tags.
+const tagHistoryDays = 7
+
+// TagsGet returns a page of tags known
+// to this instance, newest first, along
+// with their usage over the last week.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tags, err := p.state.DB.GetAllTags(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(tags)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = tags[count-1].ID
+		hi = tags[0].ID
+
+		// Prepare a slice of tag API models.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, tag := range tags {
+		apiTag, errWithCode := p.apiTag(ctx, tag)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, apiTag)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/tags",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// TagGet returns the tag with the given ID,
+// along with its usage over the last week.
+func (p *Processor) TagGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiTag(ctx, tag)
+}
+
+// TagUpdate updates the usable, listable and trendable
+// flags of the tag with the given ID, using the given form.
+func (p *Processor) TagUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.AdminTagUpdateRequest,
+) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, errWithCode := p.apiTag(ctx, tag)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var columns []string
+
+	if form.Usable != nil {
+		tag.Useable = util.Ptr(*form.Usable)
+		columns = append(columns, "useable")
+	}
+
+	if form.Listable != nil {
+		tag.Listable = util.Ptr(*form.Listable)
+		columns = append(columns, "listable")
+	}
+
+	if form.Trendable != nil {
+		tag.Trendable = util.Ptr(*form.Trendable)
+		columns = append(columns, "trendable")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return before, nil
+	}
+
+	if err := p.state.DB.UpdateTag(ctx, tag, columns...); err != nil {
+		err := gtserror.Newf("db error updating tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Flags don't change usage,
+	// so reuse history from before.
+	after := new(apimodel.AdminTag)
+	*after = *before
+	after.Usable = *tag.Useable
+	after.Listable = *tag.Listable
+	after.Trendable = *tag.Trendable
+
+	p.AuditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetTag, tag.ID,
+		before, after,
+	)
+
+	return after, nil
+}
+
+func (p *Processor) getTag(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTag(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return tag, nil
+}
+
+// apiTag converts the given tag to its admin API
+// model, with usage history for the last week.
+func (p *Processor) apiTag(
+	ctx context.Context,
+	tag *gtsmodel.Tag,
+) (*apimodel.AdminTag, gtserror.WithCode) {
+	apiTag, err := p.converter.TagToAdminAPITag(ctx, tag)
+	if err != nil {
+		err := gtserror.Newf("error converting tag to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// History runs up to
+	// and including today.
+	var (
+		end   = time.Now().UTC().Truncate(day).Add(day)
+		start = end.Add(-tagHistoryDays * day)
+	)
+
+	points, err := p.state.DB.GetTagPoints(ctx, tag.ID, start, end)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag points: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	uses, _ := dailyValues(points, aggregateValue, start, end)
+	accounts, _ := dailyValues(points, aggregateAccounts, start, end)
+
+	// Newest day first.
+	for i := len(uses) - 1; i >= 0; i-- {
+		date := start.Add(time.Duration(i) * day)
+		apiTag.History = append(apiTag.History, apimodel.TagHistory{
+			Day:      strconv.FormatInt(date.Unix(), 10),
+			Uses:     strconv.FormatInt(uses[i], 10),
+			Accounts: strconv.FormatInt(accounts[i], 10),
+		})
+	}
+
+	return apiTag, nil
+}
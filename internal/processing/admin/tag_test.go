@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type TagTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *TagTestSuite) TestTagsGet() {
+	resp, errWithCode := suite.adminProcessor.TagsGet(context.Background(), &paging.Page{Limit: 20})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(resp.Items, 2) {
+		suite.FailNow("")
+	}
+
+	// Newest first.
+	apiTag := resp.Items[0].(*apimodel.AdminTag)
+	suite.Equal(suite.testTags["Hashtag"].ID, apiTag.ID)
+	suite.Equal("hashtag", apiTag.Name)
+	suite.Equal("http://localhost:8080/tags/hashtag", apiTag.URL)
+	suite.Len(apiTag.History, 7)
+	suite.True(apiTag.Usable)
+	suite.True(apiTag.Listable)
+	suite.True(apiTag.Trendable)
+}
+
+func (suite *TagTestSuite) TestTagUpdate() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		tag       = suite.testTags["welcome"]
+	)
+
+	apiTag, errWithCode := suite.adminProcessor.TagUpdate(ctx, adminAcct, tag.ID, &apimodel.AdminTagUpdateRequest{
+		Listable:  util.Ptr(false),
+		Trendable: util.Ptr(false),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.True(apiTag.Usable)
+	suite.False(apiTag.Listable)
+	suite.False(apiTag.Trendable)
+
+	// Changes should be stored.
+	dbTag, err := suite.state.DB.GetTag(ctx, tag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbTag.Useable)
+	suite.False(*dbTag.Listable)
+	suite.False(*dbTag.Trendable)
+
+	// Tag should no longer be searchable.
+	tags, err := suite.state.DB.SearchForTags(ctx, "welcome", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *TagTestSuite) TestTagGetNotFound() {
+	_, errWithCode := suite.adminProcessor.TagGet(context.Background(), "01HXZ6YBCXNC2NSY2NB9J0GHRH")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
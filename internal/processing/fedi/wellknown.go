@@ -39,6 +39,7 @@ const (
 	webfingerSelf                   = "self"
 	webFingerSelfContentType        = "application/activity+json"
 	webfingerAccount                = "acct"
+	oauthServiceDocumentation       = "https://docs.gotosocial.org/en/latest/api/authentication/"
 )
 
 var (
@@ -46,6 +47,13 @@ var (
 	nodeInfoInbound   = []string{}
 	nodeInfoOutbound  = []string{}
 	nodeInfoMetadata  = make(map[string]interface{})
+
+	oauthScopes               = []string{"read", "write", "follow", "push", "admin"}
+	oauthResponseTypes        = []string{"code"}
+	oauthGrantTypes           = []string{"authorization_code", "client_credentials"}
+	oauthAuthMethods          = []string{"client_secret_post", "client_secret_basic"}
+	oauthTokenAuthMethods     = []string{"client_secret_post"}
+	oauthCodeChallengeMethods = []string{"S256"}
 )
 
 // NodeInfoRelGet returns a well known response giving the path to node info.
@@ -115,6 +123,26 @@ func (p *Processor) HostMetaGet() *apimodel.HostMeta {
 	}
 }
 
+// OAuthServerMetadataGet returns OAuth 2.0 authorization
+// server metadata for this instance, as per RFC 8414.
+func (p *Processor) OAuthServerMetadataGet() *apimodel.OAuthServerMetadata {
+	base := config.GetProtocol() + "://" + config.GetHost()
+	return &apimodel.OAuthServerMetadata{
+		Issuer:                                 base + "/",
+		AuthorizationEndpoint:                  base + "/oauth/authorize",
+		TokenEndpoint:                          base + "/oauth/token",
+		RevocationEndpoint:                     base + "/oauth/revoke",
+		AppRegistrationEndpoint:                base + "/api/v1/apps",
+		ScopesSupported:                        oauthScopes,
+		ResponseTypesSupported:                 oauthResponseTypes,
+		GrantTypesSupported:                    oauthGrantTypes,
+		TokenEndpointAuthMethodsSupported:      oauthTokenAuthMethods,
+		RevocationEndpointAuthMethodsSupported: oauthAuthMethods,
+		CodeChallengeMethodsSupported:          oauthCodeChallengeMethods,
+		ServiceDocumentation:                   oauthServiceDocumentation,
+	}
+}
+
 // WebfingerGet handles the GET for a webfinger resource. Most commonly, it will be used for returning account lookups.
 func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode) {
 	// Get the local account the request is referring to.
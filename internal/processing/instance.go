@@ -136,7 +136,10 @@ func (p *Processor) InstanceGetRules(ctx context.Context) ([]apimodel.InstanceRu
 	return p.converter.InstanceRulesToAPIRules(i.Rules), nil
 }
 
-func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
+// InstancePatch updates the settings of this instance with the
+// given form, recording the change in the admin audit log as
+// performed by the given admin account.
+func (p *Processor) InstancePatch(ctx context.Context, adminAcct *gtsmodel.Account, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
 	// Fetch this instance from the db for processing.
 	instance, err := p.getThisInstance(ctx)
 	if err != nil {
@@ -151,6 +154,10 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Summarize settings before
+	// update, for the audit log.
+	before := newInstanceAuditSummary(instance, instanceAcc)
+
 	// Columns to update
 	// in the database.
 	var columns []string
@@ -242,7 +249,10 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		columns = append(columns, []string{"terms", "terms_text"}...)
 	}
 
-	var updateInstanceAccount bool
+	var (
+		updateInstanceAccount bool
+		updateAvatarDesc      bool
+	)
 
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		// Process instance avatar image + description.
@@ -260,6 +270,7 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 			err = fmt.Errorf("db error updating instance avatar description: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
+		updateAvatarDesc = true
 	}
 
 	if form.Header != nil && form.Header.Size != 0 {
@@ -289,9 +300,50 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		}
 	}
 
+	if len(columns) != 0 || updateInstanceAccount || updateAvatarDesc {
+		p.admin.AuditLog(ctx, adminAcct,
+			gtsmodel.AuditLogActionUpdate,
+			gtsmodel.AuditLogTargetInstance, instance.ID,
+			before, newInstanceAuditSummary(instance, instanceAcc),
+		)
+	}
+
 	return p.InstanceGetV1(ctx)
 }
 
+// instanceAuditSummary summarizes the admin
+// settings of this instance for the audit log.
+type instanceAuditSummary struct {
+	Title             string `json:"title"`
+	ContactAccountID  string `json:"contact_account_id,omitempty"`
+	ContactEmail      string `json:"contact_email,omitempty"`
+	ShortDescription  string `json:"short_description,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Terms             string `json:"terms,omitempty"`
+	AvatarID          string `json:"avatar_id,omitempty"`
+	AvatarDescription string `json:"avatar_description,omitempty"`
+	HeaderID          string `json:"header_id,omitempty"`
+}
+
+func newInstanceAuditSummary(instance *gtsmodel.Instance, instanceAcc *gtsmodel.Account) *instanceAuditSummary {
+	summary := &instanceAuditSummary{
+		Title:            instance.Title,
+		ContactAccountID: instance.ContactAccountID,
+		ContactEmail:     instance.ContactEmail,
+		ShortDescription: instance.ShortDescriptionText,
+		Description:      instance.DescriptionText,
+		Terms:            instance.TermsText,
+		AvatarID:         instanceAcc.AvatarMediaAttachmentID,
+		HeaderID:         instanceAcc.HeaderMediaAttachmentID,
+	}
+
+	if instanceAcc.AvatarMediaAttachment != nil {
+		summary.AvatarDescription = instanceAcc.AvatarMediaAttachment.Description
+	}
+
+	return summary
+}
+
 func (p *Processor) getThisInstance(ctx context.Context) (*gtsmodel.Instance, error) {
 	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
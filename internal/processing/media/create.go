@@ -19,43 +19,108 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
 // Create creates a new media attachment belonging to the given account, using the request form.
+// The media is either the file in the form, or the file sent as a complete resumable upload.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
-	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
-		f, err := form.File.Open()
-		return f, form.File.Size, err
-	}
-
 	focusX, focusY, err := parseFocus(form.Focus)
 	if err != nil {
 		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
+	var (
+		data   media.DataFunc
+		size   int64
+		upload *gtsmodel.MediaUpload
+	)
+
+	if form.UploadID != "" {
+		// Lock on the upload so it
+		// can't be used more than once.
+		unlock := p.state.ProcessingLocks.Lock(form.UploadID)
+		defer unlock()
+
+		var errWithCode gtserror.WithCode
+		upload, errWithCode = p.getUpload(ctx, account, form.UploadID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if !upload.Complete() {
+			text := fmt.Sprintf("upload isn't complete: received %d of %d bytes", upload.Offset, upload.Length)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		// Stream the staged chunks
+		// of the upload as one file.
+		data = media.UploadData(p.state, upload)
+		size = upload.Length
+	} else {
+		data = func(innerCtx context.Context) (io.ReadCloser, int64, error) {
+			f, err := form.File.Open()
+			return f, form.File.Size, err
+		}
+		size = form.File.Size
+	}
+
+	// Reserve room for the upload in the account's quota,
+	// rejecting it early if it would take them over.
+	if errWithCode := p.reserveStorageQuota(ctx, account, size); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Bytes actually stored for
+	// the upload, once processed.
+	var stored int64
+
+	defer func() {
+		// Correct the reservation to the bytes
+		// actually stored, giving it all back
+		// if processing didn't get that far.
+		if err := p.updateStorageUsed(ctx, account, stored-size); err != nil {
+			log.Errorf(ctx, "error updating media storage used: %v", err)
+		}
+	}()
+
+	if upload != nil {
+		// Once processed, whether successfully
+		// or not, the upload is done with.
+		defer p.releaseUpload(ctx, upload)
+	}
+
 	// process the media attachment and load it immediately
-	media := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
+	attachment, err := processing.LoadAttachment(ctx)
+	if errors.Is(err, media.ErrMediaHashBlocked) {
+		const help = "this media matches media blocked on this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, help)
+	} else if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
 		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	// Count the stored attachment
+	// toward the account's quota.
+	stored = media.StorageUsed(attachment)
+
 	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
@@ -25,7 +25,9 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Delete deletes the media attachment with the given ID, including all files pertaining to that attachment.
@@ -42,23 +44,51 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	errs := []string{}
 
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
+	for _, path := range append([]string{
+		attachment.Thumbnail.Path,
+		attachment.File.Path,
+	}, media.DerivativePaths(attachment)...) {
+		if path == "" {
+			continue
+		}
+
+		if path == attachment.File.Path && util.PtrValueOr(attachment.Proxied, false) {
+			// original file of proxied
+			// remote media isn't stored
+			continue
+		}
+
+		if *attachment.Cached {
+			// release our reference to the (possibly shared) file
+			if _, err := media.ReleaseFile(ctx, p.state, path); err != nil {
+				errs = append(errs, fmt.Sprintf("release file at path %s: %s", path, err))
+			}
+			continue
+		}
+
+		// not cached, so this file shouldn't be here
+		// at all, but leave it alone if it's shared
+		if shared, err := media.IsSharedFile(ctx, p.state, path); err != nil {
+			errs = append(errs, fmt.Sprintf("check file at path %s: %s", path, err))
+			continue
+		} else if shared {
+			continue
 		}
-	}
 
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+		if err := p.state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", path, err))
 		}
 	}
 
 	// delete the attachment
 	if err := p.state.DB.DeleteAttachment(ctx, mediaAttachmentID); err != nil && !errors.Is(err, db.ErrNoEntries) {
 		errs = append(errs, fmt.Sprintf("remove attachment: %s", err))
+	} else {
+		// give the space back to the owning
+		// account's quota, if it was local
+		if err := media.ReleaseStorageUsed(ctx, p.state, attachment); err != nil {
+			errs = append(errs, fmt.Sprintf("release storage used: %s", err))
+		}
 	}
 
 	if len(errs) != 0 {
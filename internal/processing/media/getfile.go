@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // GetFile retrieves a file from storage and streams it back
@@ -85,7 +86,7 @@ func (p *Processor) GetFile(
 	case media.TypeEmoji:
 		return p.getEmojiContent(ctx, wantedMediaID, owningAccountID, mediaSize)
 	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
-		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize)
+		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize, form)
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
 	}
@@ -121,7 +122,7 @@ func parseSize(s string) (media.Size, error) {
 	return "", fmt.Errorf("%s not a recognized media.Size", s)
 }
 
-func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size) (*apimodel.Content, gtserror.WithCode) {
+func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size, form *apimodel.GetContentRequestForm) (*apimodel.Content, gtserror.WithCode) {
 	// retrieve attachment from the database and do basic checks on it
 	a, err := p.state.DB.GetAttachmentByID(ctx, wantedMediaID)
 	if err != nil {
@@ -134,6 +135,13 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	if util.PtrValueOr(a.Blocked, false) {
+		// Dropped for matching a media hash
+		// block, don't serve or recache it.
+		err = gtserror.Newf("attachment %s is blocked", wantedMediaID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
 	// If this is an "Unknown" file type, ie., one we
 	// tried to process and couldn't, or one we refused
 	// to process because it wasn't supported, then we
@@ -203,6 +211,13 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		}
 	}
 
+	if mediaSize == media.SizeOriginal && util.PtrValueOr(a.Proxied, false) {
+		// We don't store the original file
+		// of this remote media, so stream it
+		// from the remote server instead.
+		return p.getProxiedContent(ctx, a)
+	}
+
 	var (
 		storagePath       string
 		attachmentContent = &apimodel.Content{
@@ -217,6 +232,15 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		attachmentContent.ContentLength = int64(a.File.FileSize)
 		storagePath = a.File.Path
 	case media.SizeSmall:
+		if derivative := bestDerivative(a, form.Width); derivative != nil {
+			// Serve a better-sized
+			// copy than the thumbnail.
+			attachmentContent.ContentType = derivative.ContentType
+			attachmentContent.ContentLength = int64(derivative.FileSize)
+			storagePath = derivative.Path
+			break
+		}
+
 		attachmentContent.ContentType = a.Thumbnail.ContentType
 		attachmentContent.ContentLength = int64(a.Thumbnail.FileSize)
 		storagePath = a.Thumbnail.Path
@@ -228,6 +252,73 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 	return p.retrieveFromStorage(ctx, storagePath, attachmentContent)
 }
 
+// bestDerivative returns the derivative of the attachment best suited
+// to display at the given width, or nil if the thumbnail is best (or
+// as good).
+//
+// Best is the narrowest image at least the given width, else the
+// widest image available, so that we never serve more than needed.
+func bestDerivative(a *gtsmodel.MediaAttachment, width int) *gtsmodel.Derivative {
+	if width <= 0 {
+		// Thumbnail asked
+		// for as-is.
+		return nil
+	}
+
+	var (
+		best      *gtsmodel.Derivative
+		bestWidth = a.FileMeta.Small.Width
+	)
+
+	for _, derivative := range a.Derivatives {
+		switch {
+		case bestWidth < width && derivative.Width > bestWidth:
+			// Best isn't wide enough,
+			// anything wider is better.
+		case derivative.Width >= width && derivative.Width < bestWidth:
+			// Narrower, but
+			// still enough.
+		default:
+			continue
+		}
+
+		best = derivative
+		bestWidth = derivative.Width
+	}
+
+	return best
+}
+
+func (p *Processor) getProxiedContent(ctx context.Context, a *gtsmodel.MediaAttachment) (*apimodel.Content, gtserror.WithCode) {
+	remoteMediaIRI, err := url.Parse(a.RemoteURL)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error parsing remote media iri %s: %w", a.RemoteURL, err))
+	}
+
+	dataFn := func(ctx context.Context) (io.ReadCloser, int64, error) {
+		// Always use the instance account, so that we make the
+		// same signed request whoever is asking, and anything
+		// cached along the way is fine to serve to anyone.
+		t, err := p.transportController.NewTransportForUsername(ctx, "")
+		if err != nil {
+			return nil, 0, err
+		}
+		return t.DereferenceMedia(gtscontext.SetFastFail(ctx), remoteMediaIRI)
+	}
+
+	rc, sz, err := p.mediaManager.ProxyMedia(ctx, a, dataFn)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error proxying remote media: %w", err))
+	}
+
+	return &apimodel.Content{
+		ContentType:    a.File.ContentType,
+		ContentLength:  sz,
+		ContentUpdated: a.UpdatedAt,
+		Content:        rc,
+	}, nil
+}
+
 func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owningAccountID string, emojiSize media.Size) (*apimodel.Content, gtserror.WithCode) {
 	emojiContent := &apimodel.Content{}
 	var storagePath string
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+// checkStorageQuota returns an error with code if storing
+// another size bytes of media would take the given local
+// account over its media storage quota.
+func (p *Processor) checkStorageQuota(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	size int64,
+) gtserror.WithCode {
+	// Always (re)load stats, so we
+	// check against the latest usage.
+	if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+		err := gtserror.Newf("db error getting account stats: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting user for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	quota := media.StorageQuota(user, account.Stats)
+	if quota == 0 {
+		// Unlimited.
+		return nil
+	}
+
+	used := *account.Stats.MediaStorageUsed
+	if used+size <= quota {
+		// Still room.
+		return nil
+	}
+
+	const help = "media storage quota exceeded: %s of %s used; " +
+		"delete some existing media before uploading more"
+	text := fmt.Sprintf(help, bytesize.Size(used), bytesize.Size(quota))
+	return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+}
+
+// reserveStorageQuota checks that storing another size bytes of
+// media wouldn't take the given local account over its quota, and
+// if so counts those bytes as used straight away. Both happen under
+// the account lock, so concurrent uploads can't all pass the check.
+//
+// Callers should afterwards correct the reservation to the bytes
+// actually stored (or give it all back) with updateStorageUsed.
+func (p *Processor) reserveStorageQuota(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	size int64,
+) gtserror.WithCode {
+	// Lock on this account since we're changing stats.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	if errWithCode := p.checkStorageQuota(ctx, account, size); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := media.UpdateStorageUsed(ctx, p.state, account, size); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// updateStorageUsed adds delta (which may be negative)
+// bytes to the media storage used by the given account.
+func (p *Processor) updateStorageUsed(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	delta int64,
+) error {
+	// Lock on this account since we're changing stats.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	return media.UpdateStorageUsed(ctx, p.state, account, delta)
+}
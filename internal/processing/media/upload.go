@@ -0,0 +1,210 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+// maxOpenUploads is the max number of unfinished
+// resumable uploads an account may have at once.
+const maxOpenUploads = 10
+
+// UploadCreate starts a new resumable upload of a media
+// file of the given length by the given account.
+func (p *Processor) UploadCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	length int64,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	if length <= 0 {
+		const text = "upload length must be greater than 0"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if max := media.UploadMaxSize(); length > max {
+		text := fmt.Sprintf("upload length %d exceeds max size of %s", length, bytesize.Size(max))
+		return nil, gtserror.NewErrorRequestEntityTooLarge(errors.New(text), text)
+	}
+
+	// Reject the upload before anything's sent
+	// if it would take the account over its quota.
+	if errWithCode := p.checkStorageQuota(ctx, account, length); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	open, err := p.state.DB.CountAccountMediaUploads(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error counting media uploads: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if open >= maxOpenUploads {
+		const text = "too many unfinished uploads; finish or delete some before starting another"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	upload := &gtsmodel.MediaUpload{
+		ID:        id.NewULID(),
+		ExpiresAt: time.Now().Add(config.GetMediaUploadExpiry()),
+		AccountID: account.ID,
+		Length:    length,
+	}
+
+	if err := p.state.DB.PutMediaUpload(ctx, upload); err != nil {
+		err := gtserror.Newf("db error putting media upload: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.MediaUploadToAPIMediaUpload(upload), nil
+}
+
+// UploadGet gets the resumable upload with the
+// given ID, if it belongs to the given account.
+func (p *Processor) UploadGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.MediaUploadToAPIMediaUpload(upload), nil
+}
+
+// UploadAppend adds the data read from r to the resumable upload with
+// the given ID, belonging to the given account. Offset must match the
+// number of bytes of the upload received so far.
+func (p *Processor) UploadAppend(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+	offset int64,
+	r io.Reader,
+) (*apimodel.MediaUpload, gtserror.WithCode) {
+	// Lock on this upload so chunks
+	// can't arrive at the same time.
+	unlock := p.state.ProcessingLocks.Lock(uploadID)
+	defer unlock()
+
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if offset != upload.Offset {
+		text := fmt.Sprintf("offset %d doesn't match upload offset %d", offset, upload.Offset)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	if err := media.PutUploadChunk(ctx, p.state, upload, r); err != nil {
+		if errors.Is(err, media.ErrUploadTooLarge) {
+			return nil, gtserror.NewErrorRequestEntityTooLarge(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Keep the upload
+	// going for longer.
+	upload.ExpiresAt = time.Now().Add(config.GetMediaUploadExpiry())
+
+	if err := p.state.DB.UpdateMediaUpload(ctx, upload,
+		"offset",
+		"chunks",
+		"expires_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating media upload: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.MediaUploadToAPIMediaUpload(upload), nil
+}
+
+// UploadDelete removes the resumable upload with the given
+// ID, belonging to the given account, and anything sent so far.
+func (p *Processor) UploadDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) gtserror.WithCode {
+	unlock := p.state.ProcessingLocks.Lock(uploadID)
+	defer unlock()
+
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := media.DeleteUpload(ctx, p.state, upload); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getUpload gets the unexpired resumable upload with
+// the given ID, if it belongs to the given account.
+func (p *Processor) getUpload(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	uploadID string,
+) (*gtsmodel.MediaUpload, gtserror.WithCode) {
+	upload, err := p.state.DB.GetMediaUploadByID(ctx, uploadID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media upload %s: %w", uploadID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if upload == nil || upload.AccountID != account.ID {
+		err := gtserror.Newf("media upload %s not found", uploadID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if time.Now().After(upload.ExpiresAt) {
+		const text = "upload expired"
+		return nil, gtserror.NewErrorGone(errors.New(text), text)
+	}
+
+	return upload, nil
+}
+
+// releaseUpload removes the given resumable
+// upload once its file has been processed.
+func (p *Processor) releaseUpload(ctx context.Context, upload *gtsmodel.MediaUpload) {
+	if err := media.DeleteUpload(ctx, p.state, upload); err != nil {
+		// Not fatal, the cleaner will
+		// get it once it's expired.
+		log.Errorf(ctx, "error deleting media upload: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type UploadTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *UploadTestSuite) TestUploadInChunks() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	b, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, int64(len(b)))
+	suite.NoError(errWithCode)
+	suite.Zero(upload.Offset)
+
+	// Send the first half.
+	half := len(b) / 2
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader(b[:half]))
+	suite.NoError(errWithCode)
+	suite.EqualValues(half, upload.Offset)
+
+	// Using the incomplete upload should fail.
+	_, errWithCode = suite.mediaProcessor.Create(ctx, testAccount, &apimodel.AttachmentRequest{UploadID: upload.ID})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Resending the first half at
+	// the wrong offset should fail.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader(b[:half]))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Send the second half.
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, int64(half), bytes.NewReader(b[half:]))
+	suite.NoError(errWithCode)
+	suite.EqualValues(len(b), upload.Offset)
+
+	// Create an attachment from the upload.
+	attachment, errWithCode := suite.mediaProcessor.Create(ctx, testAccount, &apimodel.AttachmentRequest{
+		UploadID:    upload.ID,
+		Description: "a chunky test image",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("image", attachment.Type)
+	suite.Equal(1920, attachment.Meta.Original.Width)
+
+	// The upload should be gone.
+	_, err = suite.db.GetMediaUploadByID(ctx, upload.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *UploadTestSuite) TestUploadTooLarge() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, 4)
+	suite.NoError(errWithCode)
+
+	// Sending more than
+	// the length should fail.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, bytes.NewReader([]byte("hello")))
+	suite.Equal(http.StatusRequestEntityTooLarge, errWithCode.Code())
+
+	// Nothing should have been kept.
+	dbUpload, err := suite.db.GetMediaUploadByID(ctx, upload.ID)
+	suite.NoError(err)
+	suite.Zero(dbUpload.Offset)
+	suite.Empty(dbUpload.ChunkIDs)
+
+	// Starting an upload bigger
+	// than videos may be should fail.
+	_, errWithCode = suite.mediaProcessor.UploadCreate(ctx, testAccount, 1<<40)
+	suite.Equal(http.StatusRequestEntityTooLarge, errWithCode.Code())
+}
+
+func (suite *UploadTestSuite) TestUploadOtherAccount() {
+	ctx := context.Background()
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, suite.testAccounts["local_account_1"], 4)
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, suite.testAccounts["local_account_2"], upload.ID, 0, bytes.NewReader([]byte("oops")))
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, &UploadTestSuite{})
+}
@@ -18,9 +18,14 @@
 package processing
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -38,3 +43,52 @@ func (p *Processor) OAuthValidateBearerToken(r *http.Request) (oauth2.TokenInfo,
 	// todo: some kind of metrics stuff here
 	return p.oauthServer.ValidationBearerToken(r)
 }
+
+// OAuthRevokeAccessToken revokes the given access token on behalf of
+// the client with the given credentials, as described in RFC 7009.
+//
+// Unknown tokens are not treated as an error, since the outcome
+// for the caller (the token is not usable) is the same.
+func (p *Processor) OAuthRevokeAccessToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	access string,
+) gtserror.WithCode {
+	client, err := p.state.DB.GetClientByID(ctx, clientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting client %s: %w", clientID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if client == nil || subtle.ConstantTimeCompare(
+		[]byte(client.Secret),
+		[]byte(clientSecret),
+	) != 1 {
+		const help = "client authentication failed"
+		return gtserror.NewErrorUnauthorized(oauth.ErrInvalidClient, help)
+	}
+
+	token, err := p.state.DB.GetTokenByAccess(ctx, access)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil {
+		// Nothing to revoke.
+		return nil
+	}
+
+	if token.ClientID != client.ID {
+		const help = "token was not issued to this client"
+		return gtserror.NewErrorForbidden(oauth.ErrUnauthorizedClient, help)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
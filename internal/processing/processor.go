@@ -21,6 +21,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	mm "github.com/superseriousbusiness/gotosocial/internal/media"
@@ -155,6 +156,7 @@ func NewProcessor(
 	mediaManager *mm.Manager,
 	state *state.State,
 	emailSender email.Sender,
+	policyFilter *policy.Filter,
 ) *Processor {
 	var (
 		parseMentionFunc = GetParseMentionFunc(state, federator)
@@ -181,7 +183,7 @@ func NewProcessor(
 	// Instantiate the rest of the sub
 	// processors + pin them to this struct.
 	processor.account = account.New(&common, state, converter, mediaManager, oauthServer, federator, filter, parseMentionFunc)
-	processor.admin = admin.New(state, cleaner, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.admin = admin.New(state, cleaner, converter, mediaManager, federator.TransportController(), emailSender, policyFilter)
 	processor.fedi = fedi.New(state, &common, converter, federator, filter)
 	processor.filtersv1 = filtersv1.New(state, converter)
 	processor.list = list.New(state, converter)
@@ -191,7 +193,7 @@ func NewProcessor(
 	processor.timeline = timeline.New(state, converter, filter)
 	processor.search = search.New(state, federator, converter, filter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, filter, parseMentionFunc)
-	processor.user = user.New(state, emailSender)
+	processor.user = user.New(state, converter, emailSender)
 
 	// Workers processor handles asynchronous
 	// worker jobs; instantiate it separately
@@ -201,6 +203,7 @@ func NewProcessor(
 		federator,
 		converter,
 		filter,
+		policyFilter,
 		emailSender,
 		&processor.account,
 		&processor.media,
@@ -71,6 +71,11 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	category, errWithCode := reportCategory(form.Category, form.RuleIDs)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	reportID := id.NewULID()
 	report := &gtsmodel.Report{
 		ID:              reportID,
@@ -79,6 +84,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		Account:         account,
 		TargetAccountID: form.AccountID,
 		TargetAccount:   targetAccount,
+		Category:        category,
 		Comment:         form.Comment,
 		StatusIDs:       form.StatusIDs,
 		Statuses:        statuses,
@@ -107,3 +113,38 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 
 	return apiReport, nil
 }
+
+// reportCategory parses and validates the given report
+// category string against the given rule IDs. If no
+// category is given, the category is inferred from
+// whether or not any rules were given.
+func reportCategory(category string, ruleIDs []string) (gtsmodel.ReportCategory, gtserror.WithCode) {
+	switch c := gtsmodel.ReportCategory(category); c {
+
+	case "":
+		if len(ruleIDs) != 0 {
+			return gtsmodel.ReportCategoryViolation, nil
+		}
+		return gtsmodel.ReportCategoryOther, nil
+
+	case gtsmodel.ReportCategoryViolation:
+		if len(ruleIDs) == 0 {
+			const text = "category violation requires at least one rule_id"
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		return c, nil
+
+	case gtsmodel.ReportCategorySpam,
+		gtsmodel.ReportCategoryLegal,
+		gtsmodel.ReportCategoryOther:
+		if len(ruleIDs) != 0 {
+			text := fmt.Sprintf("rule_ids can only be set for category %s", gtsmodel.ReportCategoryViolation)
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		return c, nil
+
+	default:
+		text := fmt.Sprintf("category %s not recognized, valid categories are: spam, legal, violation, other", category)
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+}
@@ -153,6 +153,13 @@ func (p *Processor) notifVisible(
 			return true, nil
 		}
 
+		// Moderation warnings originate from the
+		// instance account, and should always be
+		// shown even if the target blocked it.
+		if n.NotificationType == gtsmodel.NotificationWarning {
+			return true, nil
+		}
+
 		visible, err := p.filter.AccountVisible(ctx, acct, n.OriginAccount)
 		if err != nil {
 			return false, err
@@ -35,7 +35,9 @@ import (
 // TagTimelineGet gets a pageable timeline for the given
 // tagName and given paging parameters. It will ensure
 // that each status in the timeline is actually visible
-// to requestingAcct before returning it.
+// to requestingAcct before returning it. If the tag is
+// not listable on this instance, only statuses authored
+// by requestingAcct will be returned.
 func (p *Processor) TagTimelineGet(
 	ctx context.Context,
 	requestingAcct *gtsmodel.Account,
@@ -50,13 +52,20 @@ func (p *Processor) TagTimelineGet(
 		return nil, errWithCode
 	}
 
-	if tag == nil || !*tag.Useable || !*tag.Listable {
+	if tag == nil || !*tag.Useable {
 		// Obey mastodon API by returning 404 for this.
-		err := fmt.Errorf("tag was not found, or not useable/listable on this instance")
+		err := fmt.Errorf("tag was not found, or not useable on this instance")
 		return nil, gtserror.NewErrorNotFound(err, err.Error())
 	}
 
-	statuses, err := p.state.DB.GetTagTimeline(ctx, tag.ID, maxID, sinceID, minID, limit)
+	var accountID string
+	if !*tag.Listable {
+		// Only the author may see
+		// statuses with non-listable tags.
+		accountID = requestingAcct.ID
+	}
+
+	statuses, err := p.state.DB.GetTagTimeline(ctx, tag.ID, accountID, maxID, sinceID, minID, limit)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = gtserror.Newf("db error getting statuses: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -21,11 +21,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // EmailGetUserForConfirmToken retrieves the user (with account) from
@@ -85,6 +93,13 @@ func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.U
 		return nil, gtserror.NewErrorForbidden(err, err.Error())
 	}
 
+	// The address was checked when it was requested,
+	// but it may since have been confirmed by another
+	// account, or had its domain blocked.
+	if errWithCode := p.checkEmailConfirmable(ctx, user); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Mark the user's email address as confirmed,
 	// and remove the unconfirmed address and the token.
 	user.Email = user.UnconfirmedEmail
@@ -100,8 +115,224 @@ func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.U
 		"confirmed_at",
 		"confirmation_token",
 	); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Another account confirmed
+			// this address in the meantime.
+			const help = "email address already in use"
+			return nil, gtserror.NewErrorConflict(err, help)
+		}
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	return user, nil
 }
+
+// checkEmailConfirmable checks that the given user's unconfirmed
+// email address isn't already used by another account, and that
+// its domain, or any mail hosts it resolves to, aren't blocked.
+func (p *Processor) checkEmailConfirmable(ctx context.Context, user *gtsmodel.User) gtserror.WithCode {
+	hosts, err := email.MailHosts(ctx, p.state.MXResolver, user.UnconfirmedEmail)
+	if err != nil {
+		err := gtserror.Newf("error parsing email address: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	emailBlocked, err := p.state.DB.IsEmailDomainBlocked(ctx, hosts...)
+	if err != nil {
+		err := gtserror.Newf("db error checking email domain blocks: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if emailBlocked {
+		const help = "email address is not permitted on this instance"
+		err := gtserror.Newf("%s: %s", help, user.UnconfirmedEmail)
+		return gtserror.NewErrorBadRequest(err, help)
+	}
+
+	// Other accounts may also have this address pending,
+	// so only an account that has it confirmed counts.
+	other, err := p.state.DB.GetUserByEmailAddress(ctx, user.UnconfirmedEmail)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking email address: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if other != nil && other.ID != user.ID {
+		const help = "email address already in use"
+		err := gtserror.Newf("%s: %s", help, user.UnconfirmedEmail)
+		return gtserror.NewErrorConflict(err, help)
+	}
+
+	return nil
+}
+
+// EmailChange processes an email address change request for the
+// given user. The new address is stored as the user's unconfirmed
+// email, and a confirmation link is sent to it; the user's email
+// address only actually changes once that link is used. A notice
+// is also sent to the current address, in case the change was not
+// requested by the owner of the account.
+func (p *Processor) EmailChange(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+	newEmail string,
+) (*apimodel.User, gtserror.WithCode) {
+	// Ensure provided password is correct.
+	if err := bcrypt.CompareHashAndPassword(
+		[]byte(user.EncryptedPassword),
+		[]byte(password),
+	); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	newEmail = strings.TrimSpace(newEmail)
+	if err := validate.Email(newEmail); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if newEmail == user.Email {
+		const help = "new email address cannot be the same as current email address"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorBadRequest(err, help)
+	}
+
+	if newEmail == user.UnconfirmedEmail {
+		const help = "new email address is already awaiting confirmation"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorBadRequest(err, help)
+	}
+
+	// Ensure the new address's domain, and any mail
+	// hosts it resolves to, aren't blocked. This is
+	// the same check that's performed for sign-ups.
+	hosts, err := email.MailHosts(ctx, p.state.MXResolver, newEmail)
+	if err != nil {
+		err := gtserror.Newf("error parsing email address: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	emailBlocked, err := p.state.DB.IsEmailDomainBlocked(ctx, hosts...)
+	if err != nil {
+		err := gtserror.Newf("db error checking email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if emailBlocked {
+		const help = "email address is not permitted on this instance"
+		err := gtserror.Newf("%s: %s", help, newEmail)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	// Ensure the new address isn't in use.
+	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, newEmail)
+	if err != nil {
+		// Error here can mean a
+		// blocked domain, so is
+		// safe to show the caller.
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if !emailAvailable {
+		const help = "email address already in use"
+		err := gtserror.Newf("%s: %s", help, newEmail)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	if user.Account == nil {
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Store new address as unconfirmed. This
+	// replaces any previous pending change.
+	user.UnconfirmedEmail = newEmail
+	if err := p.state.DB.UpdateUser(ctx, user, "unconfirmed_email"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Send confirmation link to the new address.
+	if err := p.emailChangeConfirm(ctx, user, instance); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Let the old address know about the change
+	// (there may not be one if the user never
+	// got around to confirming their sign-up).
+	if user.Email != "" {
+		if err := p.emailSender.SendEmailChangeEmail(
+			user.Email,
+			email.EmailChangeData{
+				Username:     user.Account.Username,
+				InstanceURL:  instance.URI,
+				InstanceName: instance.Title,
+				NewEmail:     newEmail,
+			},
+		); err != nil {
+			err := gtserror.Newf("error emailing current address: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.converter.UserToAPIUser(ctx, user), nil
+}
+
+// emailChangeConfirm emails the given user's new (unconfirmed)
+// email address, asking them to confirm it, and updates the
+// user with the confirmation token that was sent.
+func (p *Processor) emailChangeConfirm(
+	ctx context.Context,
+	user *gtsmodel.User,
+	instance *gtsmodel.Instance,
+) error {
+	// We use a uuid as our token
+	// since it's secure enough
+	// for this purpose.
+	var (
+		confirmToken = uuid.NewString()
+		confirmLink  = uris.GenerateURIForEmailConfirm(confirmToken)
+	)
+
+	if err := p.emailSender.SendConfirmEmail(
+		user.UnconfirmedEmail,
+		email.ConfirmData{
+			Username:     user.Account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ConfirmLink:  confirmLink,
+			EmailChange:  true,
+		},
+	); err != nil {
+		return gtserror.Newf("error sending confirmation email: %w", err)
+	}
+
+	// Email sent, update the user entry
+	// with the new confirmation token.
+	now := time.Now()
+	user.ConfirmationToken = confirmToken
+	user.ConfirmationSentAt = now
+	user.LastEmailedAt = now
+
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"confirmation_token",
+		"confirmation_sent_at",
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("error updating user entry after email sent: %w", err)
+	}
+
+	return nil
+}
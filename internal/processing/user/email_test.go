@@ -79,6 +79,79 @@ func (suite *EmailConfirmTestSuite) TestConfirmEmailOldToken() {
 	suite.EqualError(errWithCode, "confirmation token expired (older than one week)")
 }
 
+func (suite *EmailConfirmTestSuite) TestConfirmEmailInUse() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	otherUser := suite.testUsers["local_account_2"]
+
+	// set the other user's address as zork's pending one, as
+	// though the other user confirmed it after zork requested it
+	updatingColumns := []string{"unconfirmed_email", "confirmation_sent_at", "confirmation_token"}
+	user.UnconfirmedEmail = otherUser.Email
+	user.ConfirmationSentAt = time.Now().Add(-5 * time.Minute)
+	user.ConfirmationToken = "1d1aa44b-afa4-49c8-ac4b-eceb61715cc6"
+
+	err := suite.db.UpdateByID(ctx, user, user.ID, updatingColumns...)
+	suite.NoError(err)
+
+	updatedUser, errWithCode := suite.user.EmailConfirm(ctx, "1d1aa44b-afa4-49c8-ac4b-eceb61715cc6")
+	suite.Nil(updatedUser)
+	suite.Equal("Conflict: email address already in use", errWithCode.Safe())
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChange() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	oldEmail := user.Email
+
+	apiUser, errWithCode := suite.user.EmailChange(ctx, user, "password", "new.email@example.org")
+	suite.NoError(errWithCode)
+
+	// email shouldn't change until confirmed
+	suite.Equal(oldEmail, apiUser.Email)
+	suite.Equal("new.email@example.org", apiUser.UnconfirmedEmail)
+
+	// confirmation sent to new address, notice sent to old
+	suite.Len(suite.sentEmails, 2)
+	suite.Contains(suite.sentEmails, "new.email@example.org")
+	suite.Contains(suite.sentEmails, oldEmail)
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.NotEmpty(dbUser.ConfirmationToken)
+
+	// confirm the change
+	updatedUser, errWithCode := suite.user.EmailConfirm(ctx, dbUser.ConfirmationToken)
+	suite.NoError(errWithCode)
+	suite.Equal("new.email@example.org", updatedUser.Email)
+	suite.Empty(updatedUser.UnconfirmedEmail)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeWrongPassword() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	apiUser, errWithCode := suite.user.EmailChange(ctx, user, "not the password", "new.email@example.org")
+	suite.Nil(apiUser)
+	suite.Equal("Unauthorized: password was incorrect", errWithCode.Safe())
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeInUse() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	otherUser := suite.testUsers["local_account_2"]
+
+	apiUser, errWithCode := suite.user.EmailChange(ctx, user, "password", otherUser.Email)
+	suite.Nil(apiUser)
+	suite.Equal("Conflict: email address already in use", errWithCode.Safe())
+	suite.Empty(suite.sentEmails)
+}
+
 func TestEmailConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &EmailConfirmTestSuite{})
 }
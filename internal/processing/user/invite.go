@@ -0,0 +1,197 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxInviteExpiry is the longest time
+// in the future an invite may expire.
+const maxInviteExpiry = 365 * 24 * time.Hour
+
+// InvitesGet returns a page of invites created by the given user.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	maxID string,
+	sinceID string,
+	minID string,
+	limit int,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(ctx, user.AccountID, maxID, sinceID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(invites)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	items := make([]interface{}, 0, count)
+	for _, invite := range invites {
+		apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+		if err != nil {
+			err := gtserror.Newf("error converting invite to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiInvite)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:          items,
+		Path:           "/api/v1/invites",
+		NextMaxIDValue: invites[count-1].ID,
+		PrevMinIDValue: invites[0].ID,
+		Limit:          limit,
+	})
+}
+
+// InviteCreate creates a new invite on behalf of the given user.
+//
+// Admins and moderators can always create invites; other users
+// can only do so if user invites are enabled in the config.
+// Only admins and moderators may create auto-approve invites.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	staff := *user.Admin || *user.Moderator
+
+	if !staff && !config.GetAccountsAllowUserInvites() {
+		const help = "creating invites is not permitted on this instance"
+		err := gtserror.Newf("user %s not permitted to create invites", user.ID)
+		return nil, gtserror.NewErrorForbidden(err, help)
+	}
+
+	if !staff && form.AutoApprove {
+		const help = "only admins and moderators may create auto-approve invites"
+		err := gtserror.Newf("user %s not permitted to create auto-approve invites", user.ID)
+		return nil, gtserror.NewErrorForbidden(err, help)
+	}
+
+	if form.MaxUses < 0 {
+		const help = "max_uses must be 0 or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	expiresIn := time.Duration(form.ExpiresIn) * time.Second
+	if expiresIn < 0 || expiresIn > maxInviteExpiry {
+		const help = "expires_in must be between 0 and 31536000 seconds (one year)"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		err := gtserror.Newf("error generating invite code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:          id.NewULID(),
+		Code:        code,
+		AccountID:   user.AccountID,
+		Account:     user.Account,
+		MaxUses:     form.MaxUses,
+		AutoApprove: &form.AutoApprove,
+	}
+
+	if expiresIn != 0 {
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// InviteDelete revokes the invite with the given
+// id, if it was created by the given user.
+func (p *Processor) InviteDelete(
+	ctx context.Context,
+	user *gtsmodel.User,
+	inviteID string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, inviteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || invite.AccountID != user.AccountID {
+		// Don't leak existence of other users' invites.
+		err := gtserror.Newf("invite %s not found for user %s", inviteID, user.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if invite.Uses == 0 {
+		// Unused, just delete it.
+		err = p.state.DB.DeleteInviteByID(ctx, invite.ID)
+	} else if !invite.Expired() {
+		// Used invites are kept so we know who
+		// invited whom; just expire it instead.
+		invite.ExpiresAt = time.Now()
+		err = p.state.DB.UpdateInvite(ctx, invite, "expires_at")
+	}
+
+	if err != nil {
+		err := gtserror.Newf("db error revoking invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// newInviteCode returns a new
+// random, url-safe invite code.
+func newInviteCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type InviteTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestInviteCreateAdmin() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	apiInvite, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{
+		MaxUses:     5,
+		ExpiresIn:   3600,
+		AutoApprove: true,
+	})
+	suite.NoError(errWithCode)
+	suite.NotEmpty(apiInvite.Code)
+	suite.Equal("http://localhost:8080/signup?invite_code="+apiInvite.Code, apiInvite.URL)
+	suite.Equal(5, *apiInvite.MaxUses)
+	suite.NotNil(apiInvite.ExpiresAt)
+	suite.True(apiInvite.AutoApprove)
+	suite.True(apiInvite.Usable)
+
+	dbInvite, err := suite.db.GetInviteByCode(ctx, apiInvite.Code)
+	suite.NoError(err)
+	suite.Equal(user.AccountID, dbInvite.AccountID)
+}
+
+func (suite *InviteTestSuite) TestInviteCreateUserInvitesDisabled() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	config.SetAccountsAllowUserInvites(false)
+
+	apiInvite, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	suite.Nil(apiInvite)
+	suite.Equal("Forbidden: creating invites is not permitted on this instance", errWithCode.Safe())
+}
+
+func (suite *InviteTestSuite) TestInviteCreateUserAutoApprove() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	config.SetAccountsAllowUserInvites(true)
+
+	apiInvite, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{
+		AutoApprove: true,
+	})
+	suite.Nil(apiInvite)
+	suite.Equal("Forbidden: only admins and moderators may create auto-approve invites", errWithCode.Safe())
+
+	apiInvite, errWithCode = suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	suite.NoError(errWithCode)
+	suite.Nil(apiInvite.MaxUses)
+	suite.Nil(apiInvite.ExpiresAt)
+	suite.False(apiInvite.AutoApprove)
+}
+
+func (suite *InviteTestSuite) TestInviteDelete() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	// Unused invite should be deleted.
+	apiInvite, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.user.InviteDelete(ctx, user, apiInvite.ID)
+	suite.NoError(errWithCode)
+
+	_, err := suite.db.GetInviteByID(ctx, apiInvite.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Used invite should be expired instead.
+	apiInvite, errWithCode = suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	suite.NoError(errWithCode)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, apiInvite.ID)
+	suite.NoError(err)
+	dbInvite.Uses = 1
+	suite.NoError(suite.db.UpdateInvite(ctx, dbInvite, "uses"))
+
+	apiInvite, errWithCode = suite.user.InviteDelete(ctx, user, apiInvite.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiInvite.Usable)
+
+	dbInvite, err = suite.db.GetInviteByID(ctx, apiInvite.ID)
+	suite.NoError(err)
+	suite.True(dbInvite.Expired())
+}
+
+func (suite *InviteTestSuite) TestInviteDeleteOtherUser() {
+	ctx := context.Background()
+
+	apiInvite, errWithCode := suite.user.InviteCreate(ctx, suite.testUsers["admin_account"], &apimodel.InviteCreateRequest{})
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.user.InviteDelete(ctx, suite.testUsers["local_account_1"], apiInvite.ID)
+	suite.Equal("Not Found", errWithCode.Safe())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
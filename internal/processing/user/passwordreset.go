@@ -0,0 +1,303 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// resetTokenValidity is how long a
+	// password reset link remains usable.
+	resetTokenValidity = time.Hour
+
+	// resetLimitWindow is how long password reset
+	// requests are counted for after the first
+	// request in a window for an email address / IP.
+	resetLimitWindow = time.Hour
+
+	// resetLimitPerEmail is the max number of
+	// reset requests per email address per window.
+	resetLimitPerEmail = 3
+
+	// resetLimitPerIP is the max number of
+	// reset requests per IP address per window.
+	resetLimitPerIP = 10
+)
+
+// PasswordResetRequest processes a "forgot password" request for
+// the given email address, coming from the given IP address.
+//
+// To prevent the flow being used to enumerate accounts, the
+// caller is given no indication of whether a user with the given
+// address exists; the email is sent (or not) asynchronously, and
+// rate limits apply to the requested address whether or not it's
+// in use. Only exceeding the rate limits results in an error.
+func (p *Processor) PasswordResetRequest(ctx context.Context, emailAddr string, ip net.IP) gtserror.WithCode {
+	emailAddr = strings.TrimSpace(emailAddr)
+	if err := validate.Email(emailAddr); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if !p.resetLimits.allow(emailAddr, ip) {
+		const help = "too many password reset requests, please try again later"
+		err := gtserror.Newf("password reset rate limit reached for %s (%s)", emailAddr, ip)
+		return gtserror.NewErrorTooManyRequests(err, help)
+	}
+
+	// Do the rest in the background, so that
+	// response time doesn't tell the caller
+	// whether the address belongs to a user.
+	p.state.Workers.Email.Queue.Push(func(ctx context.Context) {
+		if err := p.passwordResetSend(ctx, emailAddr); err != nil {
+			log.Errorf(ctx, "error sending password reset: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// passwordResetSend generates a new password reset token
+// for the user with the given email address (if any),
+// and emails them a link with which to reset their password.
+func (p *Processor) passwordResetSend(ctx context.Context, emailAddr string) error {
+	user, err := p.state.DB.GetUserByEmailAddress(ctx, emailAddr)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No user with
+			// this address.
+			return nil
+		}
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() ||
+		*user.Disabled ||
+		user.ExternalID != "" ||
+		!user.Account.SuspendedAt.IsZero() {
+		// Only reset passwords for users who:
+		// - are confirmed
+		// - are not disabled
+		// - don't sign in via OIDC
+		// - are not suspended
+		log.Infof(ctx, "not sending password reset email to ineligible user %s", user.ID)
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	// Generate a new token, replacing any
+	// existing one so that only the most
+	// recently sent link remains usable.
+	now := time.Now()
+	user.ResetPasswordToken = uuid.NewString()
+	user.ResetPasswordSentAt = now
+	user.LastEmailedAt = now
+
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"reset_password_token",
+		"reset_password_sent_at",
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return p.emailSender.SendResetEmail(
+		user.Email,
+		email.ResetData{
+			Username:     user.Account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ResetLink:    uris.GenerateURIForPasswordReset(user.ResetPasswordToken),
+		},
+	)
+}
+
+// PasswordResetGetUserForToken retrieves the user (with account)
+// from the database for the given password reset token string,
+// checking that the token has not yet expired.
+func (p *Processor) PasswordResetGetUserForToken(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		err := errors.New("no token provided")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	user, err := p.state.DB.GetUserByResetPasswordToken(ctx, token)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// No user found for this token.
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if user.ResetPasswordSentAt.Before(time.Now().Add(-resetTokenValidity)) {
+		const help = "password reset link has expired, please request a new one"
+		err := errors.New("reset password token expired")
+		return nil, gtserror.NewErrorForbidden(err, help)
+	}
+
+	if *user.Disabled || !user.Account.SuspendedAt.IsZero() {
+		err := gtserror.Newf("user %s is disabled or suspended", user.ID)
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
+	return user, nil
+}
+
+// PasswordReset sets a new password for the user with the given
+// reset token. The token is consumed, and all OAuth tokens issued
+// to the user are revoked, signing them out of every client. Web
+// sign-in sessions are tied to the password, so those end too.
+func (p *Processor) PasswordReset(ctx context.Context, token string, newPassword string) (*gtsmodel.User, gtserror.WithCode) {
+	user, errWithCode := p.PasswordResetGetUserForToken(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure new password is strong enough.
+	if err := validate.Password(newPassword); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Hash the new password.
+	encryptedPassword, err := bcrypt.GenerateFromPassword(
+		[]byte(newPassword),
+		bcrypt.DefaultCost,
+	)
+	if err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Set new password on user,
+	// and clear the reset token.
+	user.EncryptedPassword = string(encryptedPassword)
+	user.ResetPasswordToken = ""
+	user.ResetPasswordSentAt = time.Time{}
+
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"encrypted_password",
+		"reset_password_token",
+		"reset_password_sent_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Revoke existing tokens so anyone
+	// signed in with the old password
+	// has to sign in again.
+	if err := p.state.DB.DeleteTokensByUserID(ctx, user.ID); err != nil {
+		err := gtserror.Newf("db error deleting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// resetLimiter counts password reset
+// requests per email address and per IP.
+type resetLimiter struct {
+	windows map[string]*resetWindow
+	mu      sync.Mutex
+}
+
+// resetWindow is a fixed limit window
+// for one email address or IP, counting
+// requests made since it started.
+type resetWindow struct {
+	start time.Time
+	count int
+}
+
+func newResetLimiter() *resetLimiter {
+	return &resetLimiter{windows: make(map[string]*resetWindow)}
+}
+
+// allow returns whether a reset request for the
+// given email address from the given IP is allowed,
+// counting the request towards both limits if so.
+func (l *resetLimiter) allow(emailAddr string, ip net.IP) bool {
+	emailKey := "email:" + strings.ToLower(emailAddr)
+	ipKey := "ip:" + ip.String()
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop windows that have ended. Nothing else
+	// evicts them, so counts can't be reset early
+	// by flooding the limiter with other keys.
+	// Expired windows are swept on each check
+	// rather than on a timer, so the limiter
+	// needs no goroutine.
+	for key, window := range l.windows {
+		if now.Sub(window.start) >= resetLimitWindow {
+			delete(l.windows, key)
+		}
+	}
+
+	emailWindow := l.windows[emailKey]
+	ipWindow := l.windows[ipKey]
+
+	// Denied requests don't count, and
+	// don't extend an existing window.
+	if (emailWindow != nil && emailWindow.count >= resetLimitPerEmail) ||
+		(ipWindow != nil && ipWindow.count >= resetLimitPerIP) {
+		return false
+	}
+
+	l.count(emailKey, emailWindow, now)
+	l.count(ipKey, ipWindow, now)
+	return true
+}
+
+// count adds a request to the given window,
+// starting a new one at now if there's none.
+func (l *resetLimiter) count(key string, window *resetWindow, now time.Time) {
+	if window == nil {
+		window = &resetWindow{start: now}
+		l.windows[key] = window
+	}
+	window.count++
+}
@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordResetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *PasswordResetTestSuite) setResetToken(user *gtsmodel.User, sentAt time.Time) string {
+	const token = "a4a1ee2b-5a3e-4c5b-9c1c-0f8f6c3b1d7e"
+
+	user.ResetPasswordToken = token
+	user.ResetPasswordSentAt = sentAt
+	if err := suite.db.UpdateUser(
+		context.Background(),
+		user,
+		"reset_password_token",
+		"reset_password_sent_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+func (suite *PasswordResetTestSuite) TestPasswordResetOK() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.setResetToken(user, time.Now())
+
+	_, errWithCode := suite.user.PasswordReset(ctx, token, "verygoodnewpassword")
+	suite.NoError(errWithCode)
+
+	// Get user from the db again.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+
+	// Check the password has changed
+	// and the token has been used up.
+	err = bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword"))
+	suite.NoError(err)
+	suite.Empty(dbUser.ResetPasswordToken)
+	suite.Zero(dbUser.ResetPasswordSentAt)
+
+	// User's tokens should all be revoked.
+	tokens := []*gtsmodel.Token{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &tokens)
+	suite.NoError(err)
+	suite.Empty(tokens)
+
+	// Token can't be used twice.
+	_, errWithCode = suite.user.PasswordReset(ctx, token, "anotherverygoodnewpassword")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *PasswordResetTestSuite) TestPasswordResetExpired() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.setResetToken(user, time.Now().Add(-2*time.Hour))
+
+	_, errWithCode := suite.user.PasswordReset(ctx, token, "verygoodnewpassword")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Password should not have changed.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	err = bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("password"))
+	suite.NoError(err)
+}
+
+func (suite *PasswordResetTestSuite) TestPasswordResetWeakPassword() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := suite.setResetToken(user, time.Now())
+
+	_, errWithCode := suite.user.PasswordReset(ctx, token, "1234")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Token should still be usable.
+	_, errWithCode = suite.user.PasswordResetGetUserForToken(ctx, token)
+	suite.NoError(errWithCode)
+}
+
+func (suite *PasswordResetTestSuite) TestPasswordResetRequestRateLimit() {
+	ctx := context.Background()
+	ip := net.ParseIP("198.51.100.7")
+
+	// Requests for an address that's not in use
+	// are limited the same as any other address.
+	for i := 0; i < 3; i++ {
+		errWithCode := suite.user.PasswordResetRequest(ctx, "nobody@example.org", ip)
+		suite.NoError(errWithCode)
+	}
+
+	errWithCode := suite.user.PasswordResetRequest(ctx, "nobody@example.org", ip)
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Different address from the same IP is still OK.
+	errWithCode = suite.user.PasswordResetRequest(ctx, "somebody@example.org", ip)
+	suite.NoError(errWithCode)
+}
+
+func (suite *PasswordResetTestSuite) TestPasswordResetRequestQueuesEmail() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.user.PasswordResetRequest(ctx, user.Email, net.ParseIP("198.51.100.8"))
+	suite.NoError(errWithCode)
+
+	// Nothing is sent until
+	// the email worker runs.
+	suite.NotContains(suite.sentEmails, user.Email)
+
+	for {
+		fn, ok := suite.state.Workers.Email.Queue.Pop()
+		if !ok {
+			break
+		}
+		fn(ctx)
+	}
+
+	suite.Contains(suite.sentEmails, user.Email)
+
+	// User should have a reset token now.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.NotEmpty(dbUser.ResetPasswordToken)
+}
+
+func TestPasswordResetTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordResetTestSuite{})
+}
@@ -18,19 +18,37 @@
 package user
 
 import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 type Processor struct {
 	state       *state.State
+	converter   *typeutils.Converter
 	emailSender email.Sender
+
+	// resetLimits tracks password reset
+	// requests per email address and IP.
+	resetLimits *resetLimiter
 }
 
 // New returns a new user processor
-func New(state *state.State, emailSender email.Sender) Processor {
+func New(state *state.State, converter *typeutils.Converter, emailSender email.Sender) Processor {
 	return Processor{
 		state:       state,
+		converter:   converter,
 		emailSender: emailSender,
+		resetLimits: newResetLimiter(),
 	}
 }
+
+// Get returns the API model of the given user.
+func (p *Processor) Get(ctx context.Context, user *gtsmodel.User) (*apimodel.User, gtserror.WithCode) {
+	return p.converter.UserToAPIUser(ctx, user), nil
+}
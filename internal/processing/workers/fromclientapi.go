@@ -101,6 +101,11 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// CREATE BLOCK
 		case ap.ActivityBlock:
 			return p.clientAPI.CreateBlock(ctx, cMsg)
+
+		// CREATE FLAG (moderation warning issued
+		// by an admin to a local account)
+		case ap.ActivityFlag:
+			return p.clientAPI.CreateAccountWarning(ctx, cMsg)
 		}
 
 	// UPDATE SOMETHING
@@ -131,6 +136,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// ACCEPT PROFILE/ACCOUNT (sign-up)
 		case ap.ObjectProfile, ap.ActorPerson:
 			return p.clientAPI.AcceptAccount(ctx, cMsg)
+
+		// ACCEPT NOTE/STATUS (release from quarantine)
+		case ap.ObjectNote:
+			return p.clientAPI.ReleaseStatus(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
@@ -189,6 +198,15 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 			return p.clientAPI.ReportAccount(ctx, cMsg)
 		}
 
+	// FORWARD SOMETHING
+	case messages.ActivityForward:
+		switch cMsg.APObjectType { //nolint:gocritic
+
+		// FORWARD AN EXISTING FLAG/REPORT
+		case ap.ActivityFlag:
+			return p.clientAPI.ForwardReport(ctx, cMsg)
+		}
+
 	// MOVE SOMETHING
 	case ap.ActivityMove:
 		switch cMsg.APObjectType { //nolint:gocritic
@@ -479,6 +497,34 @@ func (p *clientAPI) UpdateReport(ctx context.Context, cMsg *messages.FromClientA
 	return nil
 }
 
+func (p *clientAPI) CreateAccountWarning(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	warning, ok := cMsg.GTSModel.(*gtsmodel.AccountWarning)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.AccountWarning", cMsg.GTSModel)
+	}
+
+	if err := p.state.DB.PopulateAccountWarning(ctx, warning); err != nil {
+		return gtserror.Newf("error populating account warning: %w", err)
+	}
+
+	if warning.Account.IsRemote() {
+		// Warnings are only issued
+		// to local accounts, but
+		// check just in case.
+		return nil
+	}
+
+	if err := p.surface.notifyAccountWarning(ctx, warning); err != nil {
+		log.Errorf(ctx, "error notifying account warning: %v", err)
+	}
+
+	if err := p.surface.emailUserAccountWarning(ctx, warning); err != nil {
+		log.Errorf(ctx, "error emailing account warning: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) AcceptFollow(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	follow, ok := cMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
@@ -738,6 +784,19 @@ func (p *clientAPI) ReportAccount(ctx context.Context, cMsg *messages.FromClient
 	return nil
 }
 
+func (p *clientAPI) ForwardReport(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	report, ok := cMsg.GTSModel.(*gtsmodel.Report)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Report", cMsg.GTSModel)
+	}
+
+	if err := p.federate.Flag(ctx, report); err != nil {
+		return gtserror.Newf("error federating flag: %w", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) MoveAccount(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	// Redirect each local follower of
 	// OriginAccount to follow move target.
@@ -791,6 +850,22 @@ func (p *clientAPI) AcceptAccount(ctx context.Context, cMsg *messages.FromClient
 	return nil
 }
 
+func (p *clientAPI) ReleaseStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", cMsg.GTSModel)
+	}
+
+	// Status was held back from timelines and
+	// notifications when it was created, so
+	// do that now it's out of quarantine.
+	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error timelining and notifying status: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) RejectAccount(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	deniedUser, ok := cMsg.GTSModel.(*gtsmodel.DeniedUser)
 	if !ok {
@@ -26,9 +26,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
-
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
@@ -45,6 +47,7 @@ type fediAPI struct {
 	federate *federate
 	account  *account.Processor
 	utils    *utils
+	policy   *policy.Filter
 }
 
 func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromFediAPI) error {
@@ -162,52 +165,55 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		err        error
 	)
 
-	var ok bool
-
 	switch {
 	case fMsg.APObject != nil:
 		// A model was provided, extract this from message.
+		// Inbound policies were already applied to it by
+		// the federating db, before it was queued for us.
+		var ok bool
 		statusable, ok = fMsg.APObject.(ap.Statusable)
 		if !ok {
 			return gtserror.Newf("cannot cast %T -> ap.Statusable", fMsg.APObject)
 		}
 
-		// Create bare-bones model to pass
-		// into RefreshStatus(), which it will
-		// further populate and insert as new.
-		bareStatus := new(gtsmodel.Status)
-		bareStatus.Local = util.Ptr(false)
-		bareStatus.URI = ap.GetJSONLDId(statusable).String()
-
-		// Call RefreshStatus() to parse and process the provided
-		// statusable model, which it will use to further flesh out
-		// the bare bones model and insert it into the database.
-		status, statusable, err = p.federate.RefreshStatus(ctx,
-			fMsg.Receiving.Username,
-			bareStatus,
-			statusable,
-			// Force refresh within 5min window.
-			dereferencing.Fresh,
-		)
-		if err != nil {
-			return gtserror.Newf("error processing new status %s: %w", bareStatus.URI, err)
-		}
-
 	case fMsg.APIRI != nil:
 		// Model was not set, deref with IRI (this is a forward).
-		// This will also cause the status to be inserted into the db.
-		status, statusable, err = p.federate.GetStatusByURI(ctx,
-			fMsg.Receiving.Username,
-			fMsg.APIRI,
-		)
+		statusable, err = p.getForwardedStatusable(ctx, fMsg)
 		if err != nil {
-			return gtserror.Newf("error dereferencing forwarded status %s: %w", fMsg.APIRI, err)
+			return err
+		}
+
+		if statusable == nil {
+			// Already stored,
+			// or rejected.
+			return nil
 		}
 
 	default:
 		return gtserror.New("neither APObjectModel nor APIri set")
 	}
 
+	// Create bare-bones model to pass
+	// into RefreshStatus(), which it will
+	// further populate and insert as new.
+	bareStatus := new(gtsmodel.Status)
+	bareStatus.Local = util.Ptr(false)
+	bareStatus.URI = ap.GetJSONLDId(statusable).String()
+
+	// Call RefreshStatus() to parse and process the provided
+	// statusable model, which it will use to further flesh out
+	// the bare bones model and insert it into the database.
+	status, statusable, err = p.federate.RefreshStatus(ctx,
+		fMsg.Receiving.Username,
+		bareStatus,
+		statusable,
+		// Force refresh within 5min window.
+		dereferencing.Fresh,
+	)
+	if err != nil {
+		return gtserror.Newf("error processing new status %s: %w", bareStatus.URI, err)
+	}
+
 	if statusable == nil {
 		// Another thread beat us to
 		// creating this status! Return
@@ -228,6 +234,21 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		p.surface.invalidateStatusFromTimelines(ctx, status.InReplyToID)
 	}
 
+	if quarantine := fMsg.Quarantine; quarantine != nil {
+		// Keep the status, but don't surface
+		// it anywhere until an admin releases it.
+		quarantine.ID = id.NewULID()
+		quarantine.StatusID = status.ID
+		quarantine.Status = status
+		quarantine.AccountID = status.AccountID
+		quarantine.Account = status.Account
+
+		if err := p.state.DB.PutQuarantinedStatus(ctx, quarantine); err != nil {
+			log.Errorf(ctx, "db error quarantining status: %v", err)
+		}
+		return nil
+	}
+
 	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
@@ -235,6 +256,66 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	return nil
 }
 
+// getForwardedStatusable fetches the authentic version of the
+// status forwarded to us in fMsg from its origin, and runs it
+// through inbound policies. This happens BEFORE anything is
+// stored, so returns nil if the status is rejected (or if we
+// already have it stored, as there's nothing to do then).
+func (p *fediAPI) getForwardedStatusable(ctx context.Context, fMsg *messages.FromFediAPI) (ap.Statusable, error) {
+	// Check whether we already have this status.
+	status, err := p.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		fMsg.APIRI.String(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting forwarded status %s: %w", fMsg.APIRI, err)
+	}
+
+	if status != nil {
+		// Nothing
+		// to do.
+		return nil, nil
+	}
+
+	// Don't trust whoever forwarded this status to us, but
+	// fetch the authentic article from where it originated.
+	statusable, err := p.federate.FetchStatusable(ctx,
+		fMsg.Receiving.Username,
+		fMsg.APIRI,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error dereferencing forwarded status %s: %w", fMsg.APIRI, err)
+	}
+
+	// Run the status through inbound policies
+	// now we have it. This may rewrite it in place.
+	verdict, err := p.policy.Apply(ctx,
+		fMsg.Requesting,
+		ap.ActivityCreate,
+		statusable,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error applying inbound policies: %w", err)
+	}
+
+	if verdict.Reject {
+		log.Infof(ctx,
+			"forwarded status %s rejected by inbound policy; dropping it",
+			fMsg.APIRI,
+		)
+		return nil, nil
+	}
+
+	if verdict.Quarantine {
+		if fMsg.Quarantine == nil {
+			fMsg.Quarantine = new(gtsmodel.QuarantinedStatus)
+		}
+		fMsg.Quarantine.PolicyID = verdict.QuarantinePolicyID
+	}
+
+	return statusable, nil
+}
+
 func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// Cast poll vote type from the worker message.
 	vote, ok := fMsg.GTSModel.(*gtsmodel.PollVote)
@@ -290,8 +371,16 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
-	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
+	// Follow requests from limited
+	// accounts always need approval.
+	limited, err := p.surface.Filter.AccountLimited(ctx, followRequest.Account)
+	if err != nil {
+		return gtserror.Newf("error checking follow requester limit: %w", err)
+	}
+
+	if *followRequest.TargetAccount.Locked || limited {
+		// Local account is locked, or requester
+		// is limited: just notify the follow request.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
 			log.Errorf(ctx, "error notifying follow request: %v", err)
 		}
@@ -575,6 +664,21 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	// Status representation was refetched, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Check whether the status is quarantined.
+	quarantined, err := p.state.DB.GetQuarantinedStatusByStatusID(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error checking status quarantine: %v", err)
+	}
+
+	if quarantined != nil {
+		// Status was never surfaced,
+		// so don't surface the edit.
+		return nil
+	}
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
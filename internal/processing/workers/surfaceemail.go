@@ -72,6 +72,55 @@ func (s *Surface) emailUserReportClosed(ctx context.Context, report *gtsmodel.Re
 	return s.EmailSender.SendReportClosedEmail(user.Email, reportClosedData)
 }
 
+// emailUserAccountWarning emails the owner of the given
+// warned account, to inform them of the warning and why.
+func (s *Surface) emailUserAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error {
+	user, err := s.State.DB.GetUserByAccountID(ctx, warning.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() ||
+		!*user.Approved ||
+		user.Email == "" {
+		// Only email users who:
+		// - are confirmed
+		// - are approved
+		// - have an email address
+		//
+		// Disabled users are still
+		// emailed, so they know why.
+		return nil
+	}
+
+	instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if err := s.State.DB.PopulateAccountWarning(ctx, warning); err != nil {
+		return gtserror.Newf("error populating account warning: %w", err)
+	}
+
+	accountWarningData := email.AccountWarningData{
+		Username:     warning.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Text:         warning.Text,
+		Rules:        make([]string, 0, len(warning.Rules)),
+	}
+
+	if warning.Action != gtsmodel.AdminActionWarn {
+		accountWarningData.Action = warning.Action.String()
+	}
+
+	for _, rule := range warning.Rules {
+		accountWarningData.Rules = append(accountWarningData.Rules, rule.Text)
+	}
+
+	return s.EmailSender.SendAccountWarningEmail(user.Email, accountWarningData)
+}
+
 // emailUserPleaseConfirm emails the given user
 // to ask them to confirm their email address.
 func (s *Surface) emailUserPleaseConfirm(ctx context.Context, user *gtsmodel.User) error {
@@ -374,6 +374,50 @@ func (s *Surface) notifySignup(ctx context.Context, newUser *gtsmodel.User) erro
 	return errs.Combine()
 }
 
+// notifyAccountWarning notifies the warned
+// account that they've received a warning.
+//
+// Unlike other notifications, this doesn't
+// check for an existing notification with
+// the same parameters, as an account may be
+// warned any number of times. The instance
+// account is used as origin of the warning.
+func (s *Surface) notifyAccountWarning(ctx context.Context, warning *gtsmodel.AccountWarning) error {
+	if warning.Account.IsRemote() {
+		// nothing to do.
+		return nil
+	}
+
+	instanceAcct, err := s.State.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationWarning,
+		TargetAccountID:  warning.AccountID,
+		TargetAccount:    warning.Account,
+		OriginAccountID:  instanceAcct.ID,
+		OriginAccount:    instanceAcct,
+		AccountWarningID: warning.ID,
+		AccountWarning:   warning,
+	}
+
+	if err := s.State.DB.PutNotification(ctx, notif); err != nil {
+		return gtserror.Newf("error putting notification in database: %w", err)
+	}
+
+	// Stream notification to the user.
+	apiNotif, err := s.Converter.NotificationToAPINotification(ctx, notif, nil)
+	if err != nil {
+		return gtserror.Newf("error converting notification to api representation: %w", err)
+	}
+	s.Stream.Notify(ctx, warning.Account, apiNotif)
+
+	return nil
+}
+
 func getNotifyLockURI(
 	notificationType gtsmodel.NotificationType,
 	targetAccount *gtsmodel.Account,
@@ -20,6 +20,7 @@ package workers
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
@@ -40,6 +41,7 @@ func New(
 	federator *federation.Federator,
 	converter *typeutils.Converter,
 	filter *visibility.Filter,
+	policyFilter *policy.Filter,
 	emailSender email.Sender,
 	account *account.Processor,
 	media *media.Processor,
@@ -87,6 +89,7 @@ func New(
 			federate: federate,
 			account:  account,
 			utils:    utils,
+			policy:   policyFilter,
 		},
 	}
 }
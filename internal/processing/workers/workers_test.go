@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -159,7 +160,7 @@ func (suite *WorkersTestSuite) SetupTestStructs() *TestStructs {
 	oauthServer := testrig.NewTestOauthServer(db)
 	emailSender := testrig.NewEmailSender("../../../web/template/", nil)
 
-	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender)
+	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender, policy.NewFilter(&state))
 	testrig.StartWorkers(&state, processor.Workers())
 
 	testrig.StandardDBSetup(db, suite.testAccounts)
@@ -73,7 +73,7 @@ const (
 	statusesPath      = userPathPrefix + `/` + statuses + `/(` + ulid + `)$`
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
-	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
+	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z0-9]+)/(` + ulid + `)\.([a-z0-9]+)$`
 )
 
 var (
@@ -162,6 +162,7 @@ var (
 	// eg 01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH8RMYQ6MSNY3JM2XT1CQ5.jpeg
 	// It captures the account id, media type, media size, file name, and file extension, eg
 	// `01F8MH1H7YV1Z7D2C8K2730QBF`, `attachment`, `small`, `01F8MH8RMYQ6MSNY3JM2XT1CQ5`, `jpeg`.
+	// Media size may include digits, for image derivatives at a given width (eg., `w640`).
 	FilePath = regexp.MustCompile(filePath)
 )
 
@@ -21,6 +21,7 @@ import (
 	"codeberg.org/gruf/go-mutexes"
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
@@ -64,6 +65,12 @@ type State struct {
 	// Workers provides access to this state's collection of worker pools.
 	Workers workers.Workers
 
+	// MXResolver is used to look up the mail exchange hosts
+	// of email domains, when checking sign-ups and email
+	// changes against blocked email domains. If nil, only
+	// the email domain itself is checked.
+	MXResolver email.MXResolver
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -0,0 +1,330 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"codeberg.org/gruf/go-iotools"
+	"codeberg.org/gruf/go-mutexes"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// cacheTmpPrefix prefixes the names of
+// partially written cache files on disk.
+const cacheTmpPrefix = ".tmp-"
+
+// Cache is a size-bounded, least-recently-used cache of
+// storage files on local disk, for use in front of slower
+// (eg., S3) storage. Cached files are stored under the hex
+// SHA-256 of their storage key, in a single directory.
+type Cache struct {
+	path    string
+	maxSize int64
+
+	// LRU list of cache entries,
+	// most recently used at front.
+	lru     list.List
+	entries map[string]*list.Element
+	size    int64
+	mutex   sync.Mutex
+
+	// Per-file locks, held while filling the
+	// cache from storage and while writing to
+	// storage, so a fill can't cache a file
+	// that's being replaced under it.
+	locks mutexes.MutexMap
+
+	// Read counters.
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheEntry is a file in the cache.
+type cacheEntry struct {
+	name string
+	size int64
+}
+
+// NewCache returns a new on-disk cache at given directory path, with
+// total size of all cached files bounded by maxSize. Files left in the
+// directory from a previous run are picked up again in the cache.
+func NewCache(path string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(path, 0o750); err != nil {
+		return nil, err
+	}
+
+	dirents, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		cacheEntry
+		modTime int64
+	}
+
+	// Gather existing cache files.
+	files := make([]file, 0, len(dirents))
+	for _, dirent := range dirents {
+		name := dirent.Name()
+
+		if strings.HasPrefix(name, cacheTmpPrefix) {
+			// Leftover partial write, drop it.
+			_ = os.Remove(filepath.Join(path, name))
+			continue
+		}
+
+		info, err := dirent.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		files = append(files, file{
+			cacheEntry: cacheEntry{name: name, size: info.Size()},
+			modTime:    info.ModTime().UnixNano(),
+		})
+	}
+
+	// Sort oldest first so newest end up at front.
+	slices.SortFunc(files, func(a, b file) int {
+		return cmp.Compare(a.modTime, b.modTime)
+	})
+
+	c := &Cache{
+		path:    path,
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element, len(files)),
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, f := range files {
+		c.entries[f.name] = c.lru.PushFront(&f.cacheEntry)
+		c.size += f.size
+	}
+
+	c.evict()
+
+	return c, nil
+}
+
+// Stats returns the number of reads
+// that were cache hits, and misses.
+func (c *Cache) Stats() (hits int64, misses int64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// ReadStream returns a stream of the file at key, from the cache if
+// possible, otherwise reading it from given source into the cache.
+// Cached files are returned as *os.File, so they support seeking.
+func (c *Cache) ReadStream(
+	ctx context.Context,
+	key string,
+	src func(context.Context, string) (io.ReadCloser, error),
+) (io.ReadCloser, error) {
+	name := cacheName(key)
+
+	if f := c.open(name); f != nil {
+		c.hits.Add(1)
+		return f, nil
+	}
+
+	// Lock out writes, then check again
+	// in case it was filled concurrently.
+	unlock := c.locks.Lock(name)
+	defer unlock()
+
+	if f := c.open(name); f != nil {
+		c.hits.Add(1)
+		return f, nil
+	}
+
+	c.misses.Add(1)
+
+	rc, err := src(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(c.path, cacheTmpPrefix+"*")
+	if err != nil {
+		log.Warnf(ctx, "error creating cache file: %v", err)
+		return rc, nil
+	}
+
+	// Copy source into cache file, up to max cache size.
+	n, err := io.CopyN(tmp, rc, c.maxSize+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = rc.Close()
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, err
+	}
+
+	if n > c.maxSize {
+		// Too large to cache, return what
+		// we've read followed by the rest.
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			_ = rc.Close()
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+			return nil, err
+		}
+
+		r := io.MultiReader(tmp, rc)
+		return iotools.ReadCloser(r, iotools.CloserFunc(func() error {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+			return rc.Close()
+		})), nil
+	}
+
+	// Done with source.
+	_ = rc.Close()
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return nil, err
+	}
+
+	return c.add(name, tmp.Name(), n)
+}
+
+// Lock removes the file at key from the cache, if present, and stops
+// it being filled again until the returned func is called. It is to
+// be held while writing to or removing the file at key in storage.
+func (c *Cache) Lock(key string) func() {
+	unlock := c.locks.Lock(cacheName(key))
+	c.Invalidate(key)
+	return unlock
+}
+
+// Invalidate removes the file at key from the cache, if present.
+func (c *Cache) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.entries[cacheName(key)]; ok {
+		c.remove(elem)
+	}
+}
+
+// open returns the cached file
+// with name, or nil if not cached.
+func (c *Cache) open(name string) *os.File {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[name]
+	if !ok {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Join(c.path, name))
+	if err != nil {
+		// Gone from under us,
+		// drop it from cache.
+		c.remove(elem)
+		return nil
+	}
+
+	// Mark as most recently used.
+	c.lru.MoveToFront(elem)
+
+	return f
+}
+
+// add moves the written temporary file of size into
+// the cache under name, returning it opened for reading.
+func (c *Cache) add(name string, tmpPath string, size int64) (*os.File, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	path := filepath.Join(c.path, name)
+
+	if elem, ok := c.entries[name]; ok {
+		// Cached concurrently, replace it.
+		c.remove(elem)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+
+	elem := c.lru.PushFront(&cacheEntry{name: name, size: size})
+	c.entries[name] = elem
+	c.size += size
+
+	// Open before eviction, which
+	// may remove this very file.
+	f, err := os.Open(path)
+	if err != nil {
+		c.remove(elem)
+		return nil, err
+	}
+
+	c.evict()
+
+	return f, nil
+}
+
+// evict removes least recently used
+// files until within max cache size.
+func (c *Cache) evict() {
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		c.remove(elem)
+	}
+}
+
+// remove removes the cache entry
+// at elem, and its file on disk.
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.name)
+	c.size -= entry.size
+
+	err := os.Remove(filepath.Join(c.path, entry.name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warnf(nil, "error removing cache file: %v", err)
+	}
+}
+
+// cacheName returns the file name in the cache for key.
+func cacheName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
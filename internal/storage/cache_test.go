@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	files := map[string]string{
+		"a": strings.Repeat("a", 40),
+		"b": strings.Repeat("b", 40),
+		"c": strings.Repeat("c", 40),
+		"d": strings.Repeat("d", 200),
+	}
+
+	var reads int
+	src := func(_ context.Context, key string) (io.ReadCloser, error) {
+		reads++
+		return io.NopCloser(strings.NewReader(files[key])), nil
+	}
+
+	cache, err := storage.NewCache(t.TempDir(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(key string) {
+		rc, err := cache.ReadStream(ctx, key, src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+
+		b, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(b, []byte(files[key])) {
+			t.Fatalf("unexpected contents for %s: %q", key, b)
+		}
+	}
+
+	// First reads miss, then hit.
+	read("a")
+	read("b")
+	read("a")
+	if reads != 2 {
+		t.Fatalf("expected 2 source reads, got %d", reads)
+	}
+
+	// Cached files should be seekable.
+	rc, err := cache.ReadStream(ctx, "a", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rc.(*os.File); !ok {
+		t.Fatalf("expected cached file, got %T", rc)
+	}
+	rc.Close()
+
+	// Reading c pushes out least recently used b.
+	read("c")
+	read("a")
+	read("b")
+	if reads != 4 {
+		t.Fatalf("expected 4 source reads, got %d", reads)
+	}
+
+	// Too large to cache, but still readable.
+	read("d")
+	read("d")
+	if reads != 6 {
+		t.Fatalf("expected 6 source reads, got %d", reads)
+	}
+
+	// Invalidated files are read from source again.
+	cache.Invalidate("b")
+	read("b")
+	if reads != 7 {
+		t.Fatalf("expected 7 source reads, got %d", reads)
+	}
+
+	hits, misses := cache.Stats()
+	if hits != 3 || misses != 7 {
+		t.Fatalf("expected 3 hits and 7 misses, got %d and %d", hits, misses)
+	}
+}
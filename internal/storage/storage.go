@@ -73,30 +73,60 @@ type Driver struct {
 	Proxy          bool
 	Bucket         string
 	PresignedCache *ttl.Cache[string, PresignedURL]
+
+	// Optional on-disk cache of
+	// reads from underlying storage.
+	Cache *Cache
 }
 
 // Get returns the byte value for key in storage.
 func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
-	return d.Storage.ReadBytes(ctx, key)
+	if d.Cache == nil {
+		return d.Storage.ReadBytes(ctx, key)
+	}
+
+	// Read through the cache.
+	rc, err := d.GetStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
-	return d.Storage.ReadStream(ctx, key)
+	if d.Cache == nil {
+		return d.Storage.ReadStream(ctx, key)
+	}
+	return d.Cache.ReadStream(ctx, key, d.Storage.ReadStream)
 }
 
 // Put writes the supplied value bytes at key in the storage
 func (d *Driver) Put(ctx context.Context, key string, value []byte) (int, error) {
+	if d.Cache != nil {
+		unlock := d.Cache.Lock(key)
+		defer unlock()
+	}
 	return d.Storage.WriteBytes(ctx, key, value)
 }
 
 // PutStream writes the bytes from supplied reader at key in the storage
 func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader) (int64, error) {
+	if d.Cache != nil {
+		unlock := d.Cache.Lock(key)
+		defer unlock()
+	}
 	return d.Storage.WriteStream(ctx, key, r)
 }
 
 // Remove attempts to remove the supplied key (and corresponding value) from storage.
 func (d *Driver) Delete(ctx context.Context, key string) error {
+	if d.Cache != nil {
+		unlock := d.Cache.Lock(key)
+		defer unlock()
+	}
 	return d.Storage.Remove(ctx, key)
 }
 
@@ -265,6 +295,16 @@ func NewS3Storage() (*Driver, error) {
 		return nil, fmt.Errorf("error opening s3 storage: %w", err)
 	}
 
+	// Open the optional on-disk cache
+	var cache *Cache
+	if cachePath := config.GetStorageS3CacheBasePath(); cachePath != "" {
+		maxSize := config.GetStorageS3CacheMaxSize()
+		cache, err = NewCache(cachePath, int64(maxSize))
+		if err != nil {
+			return nil, fmt.Errorf("error opening s3 cache: %w", err)
+		}
+	}
+
 	// ttl should be lower than the expiry used by S3 to avoid serving invalid URLs
 	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
 	presignedCache.Start(urlCacheExpiryFrequency)
@@ -274,5 +314,6 @@ func NewS3Storage() (*Driver, error) {
 		Bucket:         config.GetStorageS3BucketName(),
 		Storage:        s3,
 		PresignedCache: presignedCache,
+		Cache:          cache,
 	}, nil
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/parser"
@@ -249,11 +250,13 @@ func (cr *customRenderer) renderHashtag(
 //
 //   - Normalize + validate the hashtag.
 //   - Get or create hashtag in the db.
+//   - Check hashtag is useable on this instance.
 //   - Add hashtag to cr.results.Tags slice.
 //   - Return hashtag rendered as nice HTML.
 //
-// If the hashtag is invalid or cannot be retrieved,
-// the unaltered input text will be returned instead.
+// If the hashtag is invalid, cannot be retrieved, or
+// is not useable, the unaltered input text will be
+// returned instead.
 func (cr *customRenderer) handleHashtag(text string) string {
 	normalized, ok := NormalizeHashtag(text)
 	if !ok {
@@ -281,8 +284,11 @@ func (cr *customRenderer) handleHashtag(text string) string {
 		// We didn't have a tag with
 		// this name, create one.
 		tag = &gtsmodel.Tag{
-			ID:   id.NewULID(),
-			Name: name,
+			ID:        id.NewULID(),
+			Name:      name,
+			Useable:   util.Ptr(true),
+			Listable:  util.Ptr(true),
+			Trendable: util.Ptr(true),
 		}
 
 		if err = cr.db.PutTag(cr.ctx, tag); err != nil {
@@ -298,6 +304,12 @@ func (cr *customRenderer) handleHashtag(text string) string {
 		return text
 	}
 
+	if !*tag.Useable {
+		// Admin has disabled this hashtag,
+		// leave it as plain unlinked text.
+		return text
+	}
+
 	// Append tag to result if not done already.
 	//
 	// This prevents multiple uses of a tag in
@@ -18,9 +18,12 @@
 package text_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 const (
@@ -154,6 +157,21 @@ func (suite *PlainTestSuite) TestDeriveMultiple() {
 	suite.Len(f.Emojis, 0)
 }
 
+func (suite *PlainTestSuite) TestParseWithTagNotUseable() {
+	tag := new(gtsmodel.Tag)
+	*tag = *suite.testTags["welcome"]
+	tag.Useable = util.Ptr(false)
+
+	if err := suite.db.UpdateTag(context.Background(), tag, "useable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tag should be left as plain text.
+	formatted := suite.FromPlain(withTag)
+	suite.Equal("<p>here's a simple status that uses hashtag #welcome!</p>", formatted.HTML)
+	suite.Empty(formatted.Tags)
+}
+
 func (suite *PlainTestSuite) TestZalgoHashtag() {
 	statusText := `yo who else loves #praying to #z̸͉̅a̸͚͋l̵͈̊g̸̫͌ỏ̷̪?`
 	f := suite.FromPlain(statusText)
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -30,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -100,6 +102,22 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
 	}
 
+	// Show local users how much of
+	// their media quota they've used.
+	if a.IsLocal() && !a.IsInstance() {
+		user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting user for account %s: %w", a.ID, err)
+		}
+
+		if user != nil {
+			apiAccount.Source.MediaStorage, err = c.mediaStorageToAPIMediaStorage(ctx, a, user)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return apiAccount, nil
 }
 
@@ -152,18 +170,22 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	var (
 		aviURL          string
 		aviURLStatic    string
+		aviSrcSet       string
 		headerURL       string
 		headerURLStatic string
+		headerSrcSet    string
 	)
 
 	if a.AvatarMediaAttachment != nil {
 		aviURL = a.AvatarMediaAttachment.URL
 		aviURLStatic = a.AvatarMediaAttachment.Thumbnail.URL
+		aviSrcSet = profileSrcSet(a.AvatarMediaAttachment)
 	}
 
 	if a.HeaderMediaAttachment != nil {
 		headerURL = a.HeaderMediaAttachment.URL
 		headerURLStatic = a.HeaderMediaAttachment.Thumbnail.URL
+		headerSrcSet = profileSrcSet(a.HeaderMediaAttachment)
 	}
 
 	// convert account gts model fields to front api model fields
@@ -290,6 +312,8 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		HideCollections: hideCollections,
 		Role:            role,
 		Moved:           moved,
+		AvatarSrcSet:    aviSrcSet,
+		HeaderSrcSet:    headerSrcSet,
 	}
 
 	// Bodge default avatar + header in,
@@ -393,6 +417,8 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
+		mediaStorage           *apimodel.MediaStorage
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -441,6 +467,25 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			invite, err := c.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s: %w", user.InviteID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
+
+		mediaStorage, err = c.mediaStorageToAPIMediaStorage(ctx, a, user)
+		if err != nil {
+			return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting media storage for account id %s: %w", a.ID, err)
+		}
+
+		// Admins can see where the quota came from.
+		mediaStorage.QuotaOverride = util.Ptr(a.Stats.MediaStorageQuota != nil)
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -466,10 +511,91 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
+		MediaStorage:           mediaStorage,
+	}, nil
+}
+
+// mediaStorageToAPIMediaStorage returns the media storage used
+// by the given local account and user, along with its quota.
+func (c *Converter) mediaStorageToAPIMediaStorage(
+	ctx context.Context,
+	a *gtsmodel.Account,
+	user *gtsmodel.User,
+) (*apimodel.MediaStorage, error) {
+	// Ensure account stats populated.
+	if a.Stats == nil {
+		if err := c.state.DB.PopulateAccountStats(ctx, a); err != nil {
+			return nil, gtserror.Newf("error getting stats for account %s: %w", a.ID, err)
+		}
+	}
+
+	return &apimodel.MediaStorage{
+		Used:  *a.Stats.MediaStorageUsed,
+		Quota: media.StorageQuota(user, a.Stats),
 	}, nil
 }
 
+// UserToAPIUser converts a user into its API model,
+// for serving to the user themself.
+func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimodel.User {
+	var confirmedAt string
+	if !u.ConfirmedAt.IsZero() {
+		confirmedAt = util.FormatISO8601(u.ConfirmedAt)
+	}
+
+	return &apimodel.User{
+		ID:               u.ID,
+		CreatedAt:        util.FormatISO8601(u.CreatedAt),
+		Email:            u.Email,
+		UnconfirmedEmail: u.UnconfirmedEmail,
+		ConfirmedAt:      confirmedAt,
+		Locale:           u.Locale,
+		AccountID:        u.AccountID,
+		Moderator:        *u.Moderator,
+		Admin:            *u.Admin,
+		Approved:         *u.Approved,
+		Disabled:         *u.Disabled,
+	}
+}
+
+// InviteToAPIInvite converts an invite into its API model.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, i *gtsmodel.Invite) (*apimodel.Invite, error) {
+	if err := c.state.DB.PopulateInvite(ctx, i); err != nil {
+		return nil, gtserror.Newf("error populating invite: %w", err)
+	}
+
+	apiInvite := &apimodel.Invite{
+		ID:          i.ID,
+		Code:        i.Code,
+		URL:         uris.GenerateURIForInvite(i.Code),
+		CreatedAt:   util.FormatISO8601(i.CreatedAt),
+		Uses:        i.Uses,
+		AutoApprove: *i.AutoApprove,
+		Usable:      i.Usable(),
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(i.ExpiresAt)
+		apiInvite.ExpiresAt = &expiresAt
+	}
+
+	if i.MaxUses != 0 {
+		maxUses := i.MaxUses
+		apiInvite.MaxUses = &maxUses
+	}
+
+	if i.Account != nil {
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, i.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting invite account: %w", err)
+		}
+		apiInvite.Account = apiAccount
+	}
+
+	return apiInvite, nil
+}
+
 func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Application) (*apimodel.Application, error) {
 	return &apimodel.Application{
 		ID:           a.ID,
@@ -526,6 +652,7 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 
 	if i := a.Thumbnail.URL; i != "" {
 		apiAttachment.PreviewURL = &i
+		apiAttachment.SrcSet = srcSet(a)
 	}
 
 	if i := a.RemoteURL; i != "" {
@@ -567,6 +694,29 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
+
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+
+		if i := a.FileMeta.Original.Channels; i != nil {
+			// The masto api describes
+			// channels in words, where
+			// it can, eg., `stereo`.
+			switch *i {
+			case 1:
+				apiAttachment.Meta.AudioChannels = "mono"
+			case 2:
+				apiAttachment.Meta.AudioChannels = "stereo"
+			default:
+				apiAttachment.Meta.AudioChannels = strconv.Itoa(*i) + " channels"
+			}
+		}
 	}
 
 	return apiAttachment, nil
@@ -683,6 +833,20 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	}, nil
 }
 
+// TagToAdminAPITag converts a gts model tag into its admin api (frontend) representation for serialization on the API.
+// The 'history' field of the tag will be an empty slice, callers should populate it with usage stats as appropriate.
+func (c *Converter) TagToAdminAPITag(ctx context.Context, t *gtsmodel.Tag) (*apimodel.AdminTag, error) {
+	return &apimodel.AdminTag{
+		ID:        t.ID,
+		Name:      strings.ToLower(t.Name),
+		URL:       uris.URIForTag(t.Name),
+		History:   make([]apimodel.TagHistory, 0),
+		Usable:    *t.Useable,
+		Listable:  *t.Listable,
+		Trendable: *t.Trendable,
+	}, nil
+}
+
 // StatusToAPIStatus converts a gts model status into its api
 // (frontend) representation for serialization on the API.
 //
@@ -1038,6 +1202,12 @@ func (c *Converter) statusToFrontend(
 		Text:               s.Text,
 	}
 
+	// Apply any moderation
+	// limit on the author.
+	if err := c.applyStatusLimit(ctx, s.Account, apiStatus); err != nil {
+		return nil, gtserror.Newf("error applying author limit: %w", err)
+	}
+
 	// Nullable fields.
 	if s.InReplyToID != "" {
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
@@ -1174,6 +1344,91 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// EmailDomainBlockToAPIEmailDomainBlock converts a gts model email domain block into its api equivalent.
+func (c *Converter) EmailDomainBlockToAPIEmailDomainBlock(b *gtsmodel.EmailDomainBlock) *apimodel.EmailDomainBlock {
+	return &apimodel.EmailDomainBlock{
+		ID:        b.ID,
+		Domain:    b.Domain,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		CreatedBy: b.CreatedByAccountID,
+	}
+}
+
+// IPBlockToAPIIPBlock converts a gts model IP block into its api equivalent.
+func (c *Converter) IPBlockToAPIIPBlock(b *gtsmodel.IPBlock) *apimodel.IPBlock {
+	apiBlock := &apimodel.IPBlock{
+		ID:        b.ID,
+		IP:        b.IP,
+		Severity:  b.Severity.String(),
+		Comment:   b.Comment,
+		CreatedBy: b.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+	}
+
+	if !b.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(b.ExpiresAt)
+		apiBlock.ExpiresAt = &expiresAt
+	}
+
+	return apiBlock
+}
+
+// InboundPolicyToAdminAPIInboundPolicy converts a gts model inbound policy into its
+// admin api equivalent. Stats are not stored in the database, so are left for the caller.
+func (c *Converter) InboundPolicyToAdminAPIInboundPolicy(p *gtsmodel.InboundPolicy) *apimodel.AdminInboundPolicy {
+	apiPolicy := &apimodel.AdminInboundPolicy{
+		ID:              p.ID,
+		Title:           p.Title,
+		Priority:        p.Priority,
+		Enabled:         util.PtrValueOr(p.Enabled, true),
+		DryRun:          util.PtrValueOr(p.DryRun, false),
+		Action:          string(p.Action),
+		Domains:         p.Domains,
+		Keywords:        p.Keywords,
+		Regex:           p.Regex,
+		MaxActorAge:     int64(p.MaxActorAge / time.Second),
+		MinMentions:     p.MinMentions,
+		HashtagRewrites: p.HashtagRewrites,
+		CreatedBy:       p.CreatedByAccountID,
+		CreatedAt:       util.FormatISO8601(p.CreatedAt),
+		UpdatedAt:       util.FormatISO8601(p.UpdatedAt),
+	}
+
+	// Always serialize as empty
+	// list / object, never null.
+	if apiPolicy.Domains == nil {
+		apiPolicy.Domains = []string{}
+	}
+	if apiPolicy.Keywords == nil {
+		apiPolicy.Keywords = []string{}
+	}
+	if apiPolicy.HashtagRewrites == nil {
+		apiPolicy.HashtagRewrites = map[string]string{}
+	}
+
+	return apiPolicy
+}
+
+// DomainLimitToAPIDomainLimit converts a gts model domain limit into its api equivalent.
+func (c *Converter) DomainLimitToAPIDomainLimit(l *gtsmodel.DomainLimit) (*apimodel.DomainLimit, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	domain, err := util.DePunify(l.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error de-punifying domain %s: %w", l.Domain, err)
+	}
+
+	return &apimodel.DomainLimit{
+		ID:             l.ID,
+		Domain:         domain,
+		ContentWarning: l.ContentWarning,
+		PrivateComment: l.PrivateComment,
+		PublicComment:  l.PublicComment,
+		CreatedBy:      l.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(l.CreatedAt),
+	}, nil
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -1188,8 +1443,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,  // approval always required
-		InvitesEnabled:       false, // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsAllowUserInvites(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1454,12 +1709,30 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 		apiStatus = apiStatus.Reblog.Status
 	}
 
+	var apiWarning *apimodel.AccountWarning
+	if n.AccountWarningID != "" {
+		if n.AccountWarning == nil {
+			warning, err := c.state.DB.GetAccountWarningByID(ctx, n.AccountWarningID)
+			if err != nil {
+				return nil, fmt.Errorf("NotificationToapi: error getting account warning with id %s from the db: %s", n.AccountWarningID, err)
+			}
+			n.AccountWarning = warning
+		}
+
+		var err error
+		apiWarning, err = c.AccountWarningToAPIAccountWarning(ctx, n.AccountWarning)
+		if err != nil {
+			return nil, fmt.Errorf("NotificationToapi: error converting account warning to api: %s", err)
+		}
+	}
+
 	return &apimodel.Notification{
-		ID:        n.ID,
-		Type:      string(n.NotificationType),
-		CreatedAt: util.FormatISO8601(n.CreatedAt),
-		Account:   apiAccount,
-		Status:    apiStatus,
+		ID:                n.ID,
+		Type:              string(n.NotificationType),
+		CreatedAt:         util.FormatISO8601(n.CreatedAt),
+		Account:           apiAccount,
+		Status:            apiStatus,
+		ModerationWarning: apiWarning,
 	}, nil
 }
 
@@ -1505,7 +1778,7 @@ func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (
 		ID:          r.ID,
 		CreatedAt:   util.FormatISO8601(r.CreatedAt),
 		ActionTaken: !r.ActionTakenAt.IsZero(),
-		Category:    "other", // todo: only support default 'other' category right now
+		Category:    reportCategory(r),
 		Comment:     r.Comment,
 		Forwarded:   *r.Forwarded,
 		StatusIDs:   r.StatusIDs,
@@ -1538,6 +1811,61 @@ func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (
 	return report, nil
 }
 
+// AccountWarningToAPIAccountWarning converts a gts model account warning into
+// its api equivalent, for serving at /api/v1/account_warnings and admin equivalent.
+func (c *Converter) AccountWarningToAPIAccountWarning(ctx context.Context, w *gtsmodel.AccountWarning) (*apimodel.AccountWarning, error) {
+	if err := c.state.DB.PopulateAccountWarning(ctx, w); err != nil {
+		return nil, gtserror.Newf("error populating account warning: %w", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, w.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting target account to api: %w", err)
+	}
+
+	warning := &apimodel.AccountWarning{
+		ID:            w.ID,
+		CreatedAt:     util.FormatISO8601(w.CreatedAt),
+		Action:        "none",
+		Text:          w.Text,
+		StatusIDs:     w.StatusIDs,
+		Rules:         make([]apimodel.InstanceRule, 0, len(w.Rules)),
+		TargetAccount: apiAccount,
+	}
+
+	if w.Action != gtsmodel.AdminActionWarn {
+		warning.Action = w.Action.String()
+	}
+
+	if warning.StatusIDs == nil {
+		warning.StatusIDs = make([]string, 0)
+	}
+
+	for _, rule := range w.Rules {
+		warning.Rules = append(warning.Rules, c.InstanceRuleToAPIRule(*rule))
+	}
+
+	if w.ReportID != "" {
+		reportID := w.ReportID
+		warning.ReportID = &reportID
+	}
+
+	if w.IsAppealed() {
+		warning.Appeal = &apimodel.AccountWarningAppeal{
+			Text:      w.AppealText,
+			State:     w.AppealState.String(),
+			CreatedAt: util.FormatISO8601(w.AppealedAt),
+		}
+
+		if !w.AppealResolvedAt.IsZero() {
+			resolvedAt := util.FormatISO8601(w.AppealResolvedAt)
+			warning.Appeal.ResolvedAt = &resolvedAt
+		}
+	}
+
+	return warning, nil
+}
+
 // ReportToAdminAPIReport converts a gts model report into an admin view report, for serving at /api/v1/admin/reports
 func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error) {
 	var (
@@ -1545,6 +1873,7 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenAt        *string
 		actionTakenComment   *string
 		actionTakenByAccount *apimodel.AdminAccountInfo
+		assignedAccount      *apimodel.AdminAccountInfo
 	)
 
 	if !r.ActionTakenAt.IsZero() {
@@ -1588,6 +1917,20 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 
+	if r.AssignedAccountID != "" {
+		if r.AssignedAccount == nil {
+			r.AssignedAccount, err = c.state.DB.GetAccountByID(ctx, r.AssignedAccountID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting assigned account with id %s from the db: %w", r.AssignedAccountID, err)
+			}
+		}
+
+		assignedAccount, err = c.AccountToAdminAPIAccount(ctx, r.AssignedAccount)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting assigned account with id %s to adminAPIAccount: %w", r.AssignedAccountID, err)
+		}
+	}
+
 	statuses := make([]*apimodel.Status, 0, len(r.StatusIDs))
 	if len(r.StatusIDs) != 0 && len(r.Statuses) == 0 {
 		r.Statuses, err = c.state.DB.GetStatusesByIDs(ctx, r.StatusIDs)
@@ -1625,14 +1968,14 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		ID:                   r.ID,
 		ActionTaken:          !r.ActionTakenAt.IsZero(),
 		ActionTakenAt:        actionTakenAt,
-		Category:             "other", // todo: only support default 'other' category right now
+		Category:             reportCategory(r),
 		Comment:              r.Comment,
 		Forwarded:            *r.Forwarded,
 		CreatedAt:            util.FormatISO8601(r.CreatedAt),
 		UpdatedAt:            util.FormatISO8601(r.UpdatedAt),
 		Account:              account,
 		TargetAccount:        targetAccount,
-		AssignedAccount:      actionTakenByAccount,
+		AssignedAccount:      assignedAccount,
 		ActionTakenByAccount: actionTakenByAccount,
 		ActionTakenComment:   actionTakenComment,
 		Statuses:             statuses,
@@ -1640,6 +1983,162 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 	}, nil
 }
 
+// ReportNoteToAdminAPIReportNote converts a gts model report note into an admin view report note.
+func (c *Converter) ReportNoteToAdminAPIReportNote(ctx context.Context, n *gtsmodel.ReportNote) (*apimodel.AdminReportNote, error) {
+	var err error
+
+	if n.Account == nil {
+		n.Account, err = c.state.DB.GetAccountByID(ctx, n.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportNoteToAdminAPIReportNote: error getting account with id %s from the db: %w", n.AccountID, err)
+		}
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, n.Account)
+	if err != nil {
+		return nil, fmt.Errorf("ReportNoteToAdminAPIReportNote: error converting account with id %s to adminAPIAccount: %w", n.AccountID, err)
+	}
+
+	return &apimodel.AdminReportNote{
+		ID:        n.ID,
+		CreatedAt: util.FormatISO8601(n.CreatedAt),
+		Content:   n.Content,
+		Account:   account,
+	}, nil
+}
+
+// QuarantinedStatusToAdminAPIQuarantinedStatus converts a gts model quarantined status into its admin view.
+func (c *Converter) QuarantinedStatusToAdminAPIQuarantinedStatus(
+	ctx context.Context,
+	q *gtsmodel.QuarantinedStatus,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.AdminQuarantinedStatus, error) {
+	if err := c.state.DB.PopulateQuarantinedStatus(ctx, q); err != nil {
+		return nil, gtserror.Newf("error populating quarantined status: %w", err)
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, q.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account with id %s to adminAPIAccount: %w", q.AccountID, err)
+	}
+
+	status, err := c.StatusToAPIStatus(ctx, q.Status, requestingAccount, statusfilter.FilterContextNone, nil)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status with id %s to api status: %w", q.StatusID, err)
+	}
+
+	apiQuarantined := &apimodel.AdminQuarantinedStatus{
+		ID:        q.ID,
+		CreatedAt: util.FormatISO8601(q.CreatedAt),
+		Score:     q.Score,
+		Signals:   q.Signals,
+		Account:   account,
+		Status:    status,
+	}
+
+	if apiQuarantined.Signals == nil {
+		apiQuarantined.Signals = []string{}
+	}
+
+	if q.PolicyID != "" {
+		policyID := q.PolicyID
+		apiQuarantined.PolicyID = &policyID
+	}
+
+	return apiQuarantined, nil
+}
+
+// MediaUploadToAPIMediaUpload converts a gts model media upload into its api equivalent.
+func (c *Converter) MediaUploadToAPIMediaUpload(u *gtsmodel.MediaUpload) *apimodel.MediaUpload {
+	return &apimodel.MediaUpload{
+		ID:        u.ID,
+		Length:    u.Length,
+		Offset:    u.Offset,
+		ExpiresAt: util.FormatISO8601(u.ExpiresAt),
+	}
+}
+
+// MediaHashBlockToAPIMediaHashBlock converts a gts model media hash block into its api equivalent.
+func (c *Converter) MediaHashBlockToAPIMediaHashBlock(b *gtsmodel.MediaHashBlock) *apimodel.MediaHashBlock {
+	return &apimodel.MediaHashBlock{
+		ID:        b.ID,
+		Hash:      b.Hash,
+		Comment:   b.Comment,
+		CreatedBy: b.CreatedByAccountID,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+	}
+}
+
+// MediaHashMatchToAdminAPIMediaHashMatch converts a gts model media hash match into its admin view.
+func (c *Converter) MediaHashMatchToAdminAPIMediaHashMatch(
+	ctx context.Context,
+	m *gtsmodel.MediaHashMatch,
+) (*apimodel.AdminMediaHashMatch, error) {
+	if err := c.state.DB.PopulateMediaHashMatch(ctx, m); err != nil {
+		return nil, gtserror.Newf("error populating media hash match: %w", err)
+	}
+
+	apiMatch := &apimodel.AdminMediaHashMatch{
+		ID:             m.ID,
+		CreatedAt:      util.FormatISO8601(m.CreatedAt),
+		Hash:           m.Hash,
+		Distance:       m.Distance,
+		RemoteURL:      m.RemoteURL,
+		MediaHashBlock: c.MediaHashBlockToAPIMediaHashBlock(m.MediaHashBlock),
+	}
+
+	if m.Attachment != nil && m.Attachment.StatusID != "" {
+		statusID := m.Attachment.StatusID
+		apiMatch.StatusID = &statusID
+	}
+
+	if m.Account != nil {
+		account, err := c.AccountToAdminAPIAccount(ctx, m.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account with id %s to adminAPIAccount: %w", m.AccountID, err)
+		}
+		apiMatch.Account = account
+	}
+
+	return apiMatch, nil
+}
+
+// AuditLogEntryToAdminAPIAuditLogEntry converts a gts model audit log entry into its admin view.
+func (c *Converter) AuditLogEntryToAdminAPIAuditLogEntry(ctx context.Context, e *gtsmodel.AuditLogEntry) (*apimodel.AdminAuditLogEntry, error) {
+	var err error
+
+	if e.Account == nil {
+		e.Account, err = c.state.DB.GetAccountByID(ctx, e.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting account %s: %w", e.AccountID, err)
+		}
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, e.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account %s: %w", e.AccountID, err)
+	}
+
+	entry := &apimodel.AdminAuditLogEntry{
+		ID:         e.ID,
+		CreatedAt:  util.FormatISO8601(e.CreatedAt),
+		Account:    account,
+		Action:     e.Action,
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+	}
+
+	if e.Before != "" {
+		entry.Before = json.RawMessage(e.Before)
+	}
+
+	if e.After != "" {
+		entry.After = json.RawMessage(e.After)
+	}
+
+	return entry, nil
+}
+
 // ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error) {
 	return &apimodel.List{
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -124,7 +125,11 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "follow_requests_count": 0,
     "also_known_as_uris": [
       "http://localhost:8080/users/1happyturtle"
-    ]
+    ],
+    "media_storage": {
+      "used": 4463269,
+      "quota": 0
+    }
   },
   "enable_rss": true,
   "role": {
@@ -304,7 +309,11 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "follow_requests_count": 0,
+    "media_storage": {
+      "used": 4463269,
+      "quota": 0
+    }
   },
   "enable_rss": true,
   "role": {
@@ -715,6 +724,51 @@ func (suite *InternalToFrontendTestSuite) TestHideFilteredStatusToFrontend() {
 	suite.ErrorIs(err, statusfilter.ErrHideStatus)
 }
 
+func (suite *InternalToFrontendTestSuite) TestLimitedDomainStatusToFrontend() {
+	ctx := context.Background()
+
+	if err := suite.db.PutDomainLimit(ctx, &gtsmodel.DomainLimit{
+		ID:                 "01HXD6Q3R5S7T9V1W3X5Y7Z9A1",
+		Domain:             "fossbros-anonymous.io",
+		ContentWarning:     "from a limited instance",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status has media but no content warning.
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	requestingAccount := suite.testAccounts["admin_account"]
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+	suite.True(apiStatus.Sensitive)
+	suite.Equal("from a limited instance", apiStatus.SpoilerText)
+}
+
+func (suite *InternalToFrontendTestSuite) TestSilencedAccountStatusToFrontend() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	account.SilencedAt = time.Now()
+	account.SilencedContentWarning = "from a silenced account"
+	if err := suite.db.UpdateAccount(ctx, account, "silenced_at", "silenced_content_warning"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status already has a content warning.
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil)
+	suite.NoError(err)
+	suite.True(apiStatus.Sensitive)
+	suite.Equal("from a silenced account; introduction post", apiStatus.SpoilerText)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments() {
 	testStatus := suite.testStatuses["remote_account_2_status_1"]
 	requestingAccount := suite.testAccounts["admin_account"]
@@ -1613,7 +1667,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
         "name": "user"
       }
     },
-    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "media_storage": {
+      "used": 0,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "assigned_account": {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1659,7 +1718,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
         "name": "admin"
       }
     },
-    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+    "media_storage": {
+      "used": 69401,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "action_taken_by_account": {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1705,7 +1769,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
         "name": "admin"
       }
     },
-    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+    "media_storage": {
+      "used": 69401,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "statuses": [],
   "rules": [],
@@ -1785,7 +1854,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
         "name": "user"
       }
     },
-    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
+    "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+    "media_storage": {
+      "used": 0,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "target_account": {
     "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -2050,6 +2124,11 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "role": {
         "name": "user"
       }
+    },
+    "media_storage": {
+      "used": 0,
+      "quota": 0,
+      "quota_override": false
     }
   },
   "assigned_account": {
@@ -2096,7 +2175,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
         "name": "admin"
       }
     },
-    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+    "media_storage": {
+      "used": 69401,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "action_taken_by_account": {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -2142,7 +2226,12 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
         "name": "admin"
       }
     },
-    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F"
+    "created_by_application_id": "01F8MGXQRHYF5QPMTMXP78QC2F",
+    "media_storage": {
+      "used": 69401,
+      "quota": 0,
+      "quota_override": false
+    }
   },
   "statuses": [],
   "rules": [],
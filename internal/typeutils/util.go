@@ -264,3 +264,115 @@ func ContentToContentLanguage(
 
 	return contentStr, langTagStr
 }
+
+// applyStatusLimit applies any moderation limit on the given
+// status author to the given frontend status, by forcing the
+// status to be marked sensitive if it has media attached, and
+// prepending the limit content warning to the status if set.
+func (c *Converter) applyStatusLimit(
+	ctx context.Context,
+	author *gtsmodel.Account,
+	apiStatus *apimodel.Status,
+) error {
+	limited := author.IsSilenced()
+	cw := author.SilencedContentWarning
+
+	if author.IsRemote() {
+		limit, err := c.state.DB.MatchDomainLimit(ctx, author.Domain)
+		if err != nil {
+			return err
+		}
+
+		if limit != nil {
+			limited = true
+
+			// Account content warning takes
+			// precedence over domain one.
+			if cw == "" {
+				cw = limit.ContentWarning
+			}
+		}
+	}
+
+	if !limited {
+		// Nothing to do.
+		return nil
+	}
+
+	if len(apiStatus.MediaAttachments) != 0 {
+		apiStatus.Sensitive = true
+	}
+
+	if cw == "" {
+		// No content warning to add.
+		return nil
+	}
+
+	apiStatus.Sensitive = true
+	switch {
+	case apiStatus.SpoilerText == "":
+		apiStatus.SpoilerText = cw
+	case !strings.HasPrefix(apiStatus.SpoilerText, cw):
+		apiStatus.SpoilerText = cw + "; " + apiStatus.SpoilerText
+	}
+
+	return nil
+}
+
+// reportCategory returns the API category string for
+// the given report, falling back to "other" for reports
+// created before categories were stored.
+func reportCategory(r *gtsmodel.Report) string {
+	if r.Category == "" {
+		return string(gtsmodel.ReportCategoryOther)
+	}
+	return string(r.Category)
+}
+
+// srcSet returns an HTML image srcset for the given attachment's
+// thumbnail at each of its derivative widths, letting browsers
+// pick the best-sized image to fetch for display. Returns an
+// empty string if the attachment has no derivatives.
+func srcSet(a *gtsmodel.MediaAttachment) string {
+	if len(a.Derivatives) == 0 || a.Thumbnail.URL == "" {
+		return ""
+	}
+
+	// Widths on offer, the thumbnail
+	// itself included, smallest first.
+	widths := make([]int, 0, len(a.Derivatives)+1)
+	widths = append(widths, a.FileMeta.Small.Width)
+	for _, derivative := range a.Derivatives {
+		widths = append(widths, derivative.Width)
+	}
+	slices.Sort(widths)
+	widths = slices.Compact(widths)
+
+	candidates := make([]string, 0, len(widths))
+	for _, width := range widths {
+		w := strconv.Itoa(width)
+		candidates = append(candidates, a.Thumbnail.URL+"?width="+w+" "+w+"w")
+	}
+
+	return strings.Join(candidates, ", ")
+}
+
+// profileSrcSet is like srcSet, but for avatars and headers, which
+// are shown full-size on the web, so the original is on offer too.
+// Animated GIFs are left out, as their derivatives are static.
+func profileSrcSet(a *gtsmodel.MediaAttachment) string {
+	if a.File.ContentType == "image/gif" {
+		return ""
+	}
+
+	set := srcSet(a)
+	if set == "" || a.URL == "" ||
+		a.FileMeta.Original.Width <= a.FileMeta.Small.Width {
+		// Original is no
+		// bigger than thumb.
+		return set
+	}
+
+	w := strconv.Itoa(a.FileMeta.Original.Width)
+	return set + ", " + a.URL + " " + w + "w"
+}
@@ -27,25 +27,27 @@ import (
 )
 
 const (
-	UsersPath        = "users"         // UsersPath is for serving users info
-	StatusesPath     = "statuses"      // StatusesPath is for serving statuses
-	InboxPath        = "inbox"         // InboxPath represents the activitypub inbox location
-	OutboxPath       = "outbox"        // OutboxPath represents the activitypub outbox location
-	FollowersPath    = "followers"     // FollowersPath represents the activitypub followers location
-	FollowingPath    = "following"     // FollowingPath represents the activitypub following location
-	LikedPath        = "liked"         // LikedPath represents the activitypub liked location
-	CollectionsPath  = "collections"   // CollectionsPath represents the activitypub collections location
-	FeaturedPath     = "featured"      // FeaturedPath represents the activitypub featured location
-	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
-	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
-	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
-	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
-	MovesPath        = "moves"         // MovesPath is used to generate the URI for a move
-	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
-	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
-	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
-	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
-	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+	UsersPath         = "users"          // UsersPath is for serving users info
+	StatusesPath      = "statuses"       // StatusesPath is for serving statuses
+	InboxPath         = "inbox"          // InboxPath represents the activitypub inbox location
+	OutboxPath        = "outbox"         // OutboxPath represents the activitypub outbox location
+	FollowersPath     = "followers"      // FollowersPath represents the activitypub followers location
+	FollowingPath     = "following"      // FollowingPath represents the activitypub following location
+	LikedPath         = "liked"          // LikedPath represents the activitypub liked location
+	CollectionsPath   = "collections"    // CollectionsPath represents the activitypub collections location
+	FeaturedPath      = "featured"       // FeaturedPath represents the activitypub featured location
+	PublicKeyPath     = "main-key"       // PublicKeyPath is for serving an account's public key
+	FollowPath        = "follow"         // FollowPath used to generate the URI for an individual follow or follow request
+	UpdatePath        = "updates"        // UpdatePath is used to generate the URI for an account update
+	BlocksPath        = "blocks"         // BlocksPath is used to generate the URI for a block
+	MovesPath         = "moves"          // MovesPath is used to generate the URI for a move
+	ReportsPath       = "reports"        // ReportsPath is used to generate the URI for a report/flag
+	ConfirmEmailPath  = "confirm_email"  // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	ResetPasswordPath = "reset_password" // ResetPasswordPath is used to generate the URI for a password reset link
+	SignupPath        = "signup"         // SignupPath is used to generate the URI for an invite link
+	FileserverPath    = "fileserver"     // FileserverPath is a path component for serving attachments + media
+	EmojiPath         = "emoji"          // EmojiPath represents the activitypub emoji location
+	TagsPath          = "tags"           // TagsPath represents the activitypub tags location
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -136,6 +138,21 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForPasswordReset returns a link for a user to click on to reset their password.
+func GenerateURIForPasswordReset(token string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ResetPasswordPath, token)
+}
+
+// GenerateURIForInvite returns a link for signing up using an invite -- something like:
+// https://example.org/signup?invite_code=Z7mhrNAv6w
+func GenerateURIForInvite(code string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?invite_code=%s", protocol, host, SignupPath, code)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
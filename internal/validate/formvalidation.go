@@ -45,6 +45,8 @@ const (
 	maximumProfileFields          = 6
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
+	maximumAppealTextLength       = 1000
+	maximumReportNoteLength       = 500
 )
 
 // Password returns a helpful error if the given password
@@ -321,6 +323,32 @@ func FilterKeyword(keyword string) error {
 	return nil
 }
 
+// AppealText validates the text of an appeal against an account warning.
+func AppealText(text string) error {
+	if text == "" {
+		return fmt.Errorf("appeal text must be provided, and must be no more than %d chars", maximumAppealTextLength)
+	}
+
+	if length := len([]rune(text)); length > maximumAppealTextLength {
+		return fmt.Errorf("appeal text length must be no more than %d chars, provided text was %d chars", maximumAppealTextLength, length)
+	}
+
+	return nil
+}
+
+// ReportNote validates the content of a moderator note on a report.
+func ReportNote(content string) error {
+	if content == "" {
+		return fmt.Errorf("note content must be provided, and must be no more than %d chars", maximumReportNoteLength)
+	}
+
+	if length := len([]rune(content)); length > maximumReportNoteLength {
+		return fmt.Errorf("note content length must be no more than %d chars, provided content was %d chars", maximumReportNoteLength, length)
+	}
+
+	return nil
+}
+
 // FilterContexts validates the context of a new or updated filter.
 func FilterContexts(contexts []apimodel.FilterContext) error {
 	if len(contexts) == 0 {
@@ -359,7 +387,10 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// Sign-ups using an invite are permitted
+	// even when registration is otherwise closed;
+	// the invite itself is checked by the caller.
+	if !config.GetAccountsRegistrationOpen() && form.InviteCode == "" {
 		return errors.New("registration is not open for this server")
 	}
 
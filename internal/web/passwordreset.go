@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package web
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+func (m *Module) forgotPasswordGETHandler(c *gin.Context) {
+	instance, _, ok := m.passwordResetPrepare(c)
+	if !ok {
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "forgot_password.tmpl",
+		Instance: instance,
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) forgotPasswordPOSTHandler(c *gin.Context) {
+	instance, instanceGet, ok := m.passwordResetPrepare(c)
+	if !ok {
+		return
+	}
+
+	emailAddr := c.PostForm("email")
+
+	clientIP := c.ClientIP()
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		err := errors.New("ip address could not be parsed from request")
+		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
+		return
+	}
+
+	if errWithCode := m.processor.User().PasswordResetRequest(
+		c.Request.Context(),
+		emailAddr,
+		ip,
+	); errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Serve the same page whether or
+	// not the address is in use, so
+	// as not to leak who's signed up.
+	page := apiutil.WebPage{
+		Template: "forgot_password_sent.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"email": emailAddr,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) resetPasswordGETHandler(c *gin.Context) {
+	instance, instanceGet, ok := m.passwordResetPrepare(c)
+	if !ok {
+		return
+	}
+
+	// If there's no token in the query,
+	// just serve the 404 web handler.
+	token := c.Query("token")
+	if token == "" {
+		errWithCode := gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound)))
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Check token validity but don't consume it yet.
+	user, errWithCode := m.processor.User().PasswordResetGetUserForToken(c.Request.Context(), token)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Serve page where user can enter a
+	// new password and POST it back here.
+	page := apiutil.WebPage{
+		Template: "reset_password.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"username": user.Account.Username,
+			"token":    token,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) resetPasswordPOSTHandler(c *gin.Context) {
+	instance, instanceGet, ok := m.passwordResetPrepare(c)
+	if !ok {
+		return
+	}
+
+	// If there's no token in the query,
+	// just serve the 404 web handler.
+	token := c.Query("token")
+	if token == "" {
+		errWithCode := gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound)))
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	user, errWithCode := m.processor.User().PasswordReset(
+		c.Request.Context(),
+		token,
+		c.PostForm("password"),
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "reset_password_done.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"username": user.Account.Username,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// passwordResetPrepare does the common setup for password reset
+// handlers: fetching the instance, negotiating text/html, and
+// ensuring password resets are possible on this instance. It
+// returns false if the caller should return without doing more.
+func (m *Module) passwordResetPrepare(c *gin.Context) (
+	*apimodel.InstanceV1,
+	func(context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+	bool,
+) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return nil, nil, false
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return nil, nil, false
+	}
+
+	// Passwords are managed elsewhere when
+	// sign-in goes through an OIDC provider.
+	if config.GetOIDCEnabled() {
+		errWithCode := gtserror.NewErrorNotFound(errors.New("password reset not available with OIDC enabled"))
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return nil, nil, false
+	}
+
+	return instance, instanceGet, true
+}
@@ -25,11 +25,17 @@ import (
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+// inviteCodeKey is the query key used
+// to pass an invite code to the sign-up page.
+const inviteCodeKey = "invite_code"
+
 func (m *Module) signupGETHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -53,12 +59,47 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If an invite code was given, make sure it's
+	// usable before the visitor fills in the form.
+	var invite *gtsmodel.Invite
+	if code := c.Query(inviteCodeKey); code != "" {
+		invite, errWithCode = m.processor.Account().InviteGetUsable(ctx, code)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+	}
+
+	// If a sign-up challenge is configured,
+	// issue a new one to render in the form.
+	var (
+		signupChallenge *challenge.Challenge
+		javascript      []string
+	)
+	if m.signupChallenge != nil {
+		var err error
+		signupChallenge, err = m.signupChallenge.Issue(ctx)
+		if err != nil {
+			err := gtserror.Newf("error issuing sign-up challenge: %w", err)
+			apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+			return
+		}
+
+		javascript = append(javascript, jsSignup)
+		if signupChallenge.Script != "" {
+			javascript = append(javascript, signupChallenge.Script)
+		}
+	}
+
 	page := apiutil.WebPage{
-		Template: "sign-up.tmpl",
-		Instance: instance,
-		OGMeta:   apiutil.OGBase(instance),
+		Template:   "sign-up.tmpl",
+		Instance:   instance,
+		OGMeta:     apiutil.OGBase(instance),
+		Javascript: javascript,
 		Extra: map[string]any{
 			"reasonRequired": config.GetAccountsReasonRequired(),
+			"invite":         invite,
+			"challenge":      signupChallenge,
 		},
 	}
 
@@ -108,6 +149,15 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 	}
 	form.IP = signUpIP
 
+	// Make sure the sign-up challenge was completed,
+	// before we do anything that touches the db.
+	if m.signupChallenge != nil {
+		if errWithCode := m.verifySignupChallenge(c, signUpIP); errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+	}
+
 	// We have all the info we need, call account create
 	// (this will also trigger side effects like sending emails etc).
 	user, errWithCode := m.processor.Account().Create(
@@ -131,8 +181,30 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"email":    user.UnconfirmedEmail,
 			"username": user.Account.Username,
+			"approved": *user.Approved,
 		},
 	}
 
 	apiutil.TemplateWebPage(c, page)
 }
+
+// verifySignupChallenge checks the sign-up challenge
+// response included in the submitted sign-up form,
+// recording any failure in metrics.
+func (m *Module) verifySignupChallenge(c *gin.Context, ip net.IP) gtserror.WithCode {
+	ctx := c.Request.Context()
+
+	// Form was already parsed by ShouldBind.
+	err := challenge.Check(ctx, m.signupChallenge, c.Request.PostForm, ip)
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := challenge.IsFailure(err); !ok {
+		err := gtserror.Newf("error verifying sign-up challenge: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	const help = "sign-up check failed; please go back, reload the sign-up page, and try again"
+	return gtserror.NewErrorBadRequest(err, help)
+}
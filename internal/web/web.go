@@ -26,6 +26,7 @@ import (
 	"codeberg.org/gruf/go-cache/v3"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/challenge"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -37,6 +38,8 @@ import (
 
 const (
 	confirmEmailPath   = "/" + uris.ConfirmEmailPath
+	resetPasswordPath  = "/" + uris.ResetPasswordPath
+	forgotPasswordPath = "/forgot_password"
 	profileGroupPath   = "/@:username"
 	statusPath         = "/statuses/:" + apiutil.WebStatusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
 	tagsPath           = "/tags/:" + apiutil.TagNameKey
@@ -69,19 +72,29 @@ const (
 
 	jsFrontend = distPathPrefix + "/frontend.js" // Progressive enhancement frontend JS.
 	jsSettings = distPathPrefix + "/settings.js" // Settings panel React application.
+	jsSignup   = distPathPrefix + "/signup.js"   // Sign-up challenge solver.
 )
 
 type Module struct {
-	processor    *processing.Processor
-	eTagCache    cache.Cache[string, eTagCacheEntry]
-	isURIBlocked func(context.Context, *url.URL) (bool, error)
+	processor       *processing.Processor
+	eTagCache       cache.Cache[string, eTagCacheEntry]
+	isURIBlocked    func(context.Context, *url.URL) (bool, error)
+	signupChallenge challenge.Challenger
 }
 
-func New(db db.DB, processor *processing.Processor) *Module {
+// New returns a new web module. If signupChallenge
+// is not nil, visitors must complete its challenge
+// in order to submit the sign-up form.
+func New(
+	db db.DB,
+	processor *processing.Processor,
+	signupChallenge challenge.Challenger,
+) *Module {
 	return &Module{
-		processor:    processor,
-		eTagCache:    newETagCache(),
-		isURIBlocked: db.IsURIBlocked,
+		processor:       processor,
+		eTagCache:       newETagCache(),
+		isURIBlocked:    db.IsURIBlocked,
+		signupChallenge: signupChallenge,
 	}
 }
 
@@ -117,6 +130,10 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	r.AttachHandler(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
+	r.AttachHandler(http.MethodGet, forgotPasswordPath, m.forgotPasswordGETHandler)
+	r.AttachHandler(http.MethodPost, forgotPasswordPath, m.forgotPasswordPOSTHandler)
+	r.AttachHandler(http.MethodGet, resetPasswordPath, m.resetPasswordGETHandler)
+	r.AttachHandler(http.MethodPost, resetPasswordPath, m.resetPasswordPOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
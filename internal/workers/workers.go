@@ -52,6 +52,12 @@ type Workers struct {
 	// asynchronous media processing jobs.
 	Media FnWorkerPool
 
+	// Email provides a worker pool for
+	// sending emails in the background,
+	// outside of the request that caused
+	// them to be sent.
+	Email FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -69,6 +75,7 @@ func (w *Workers) Start() {
 	w.Federator.Start(4 * maxprocs)
 	w.Dereference.Start(4 * maxprocs)
 	w.Media.Start(8 * maxprocs)
+	w.Email.Start(maxprocs)
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -79,6 +86,7 @@ func (w *Workers) Stop() {
 	w.Federator.Stop()
 	w.Dereference.Stop()
 	w.Media.Stop()
+	w.Email.Stop()
 }
 
 // nocopy when embedded will signal linter to
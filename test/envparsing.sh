@@ -6,9 +6,12 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-allow-user-invites": false,
     "accounts-custom-css-length": 5000,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
+    "accounts-signup-challenge": "pow",
+    "accounts-signup-pow-difficulty": 20,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
     "advanced-header-filter-mode": "",
@@ -94,7 +97,15 @@ EXPECT=$(cat << "EOF"
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
     "instance-federation-mode": "allowlist",
+    "instance-federation-spam-burst-weight": 7,
+    "instance-federation-spam-duplicate-weight": 6,
+    "instance-federation-spam-errant-link-weight": 12,
     "instance-federation-spam-filter": true,
+    "instance-federation-spam-media-weight": 11,
+    "instance-federation-spam-new-account-age": 3600000000000,
+    "instance-federation-spam-new-account-weight": 5,
+    "instance-federation-spam-threshold": 20,
+    "instance-federation-spam-unknown-mention-weight": 4,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
@@ -110,14 +121,31 @@ EXPECT=$(cat << "EOF"
     "log-db-queries": true,
     "log-level": "info",
     "log-timestamp-format": "banana",
+    "media-audio-max-size": 420,
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
+    "media-derivative-widths": [
+        400,
+        800
+    ],
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
+    "media-hash-block-distance": 10,
     "media-image-max-size": 420,
+    "media-quota-admin": 0,
+    "media-quota-moderator": 4200,
+    "media-quota-user": 420,
     "media-remote-cache-days": 30,
+    "media-remote-proxy": true,
+    "media-remote-proxy-cache-max-size": 420,
+    "media-remote-proxy-cache-ttl": 60000000000,
+    "media-remote-proxy-domains": [
+        "example.org",
+        "example.com"
+    ],
+    "media-upload-expiry": 3600000000000,
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
@@ -140,6 +168,7 @@ EXPECT=$(cat << "EOF"
         "write"
     ],
     "oidc-skip-verification": true,
+    "parallelism": 8,
     "password": "",
     "path": "",
     "port": 6969,
@@ -161,6 +190,8 @@ EXPECT=$(cat << "EOF"
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
+    "storage-s3-cache-base-path": "/root/cache",
+    "storage-s3-cache-max-size": 69,
     "storage-s3-endpoint": "localhost:9000",
     "storage-s3-proxy": true,
     "storage-s3-secret-key": "miniostorage",
@@ -170,6 +201,7 @@ EXPECT=$(cat << "EOF"
     "syslog-protocol": "udp",
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
+    "to": "",
     "tracing-enabled": false,
     "tracing-endpoint": "localhost:4317",
     "tracing-insecure-transport": true,
@@ -220,6 +252,14 @@ GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
+GTS_INSTANCE_FEDERATION_SPAM_THRESHOLD=20 \
+GTS_INSTANCE_FEDERATION_SPAM_UNKNOWN_MENTION_WEIGHT=4 \
+GTS_INSTANCE_FEDERATION_SPAM_MEDIA_WEIGHT=11 \
+GTS_INSTANCE_FEDERATION_SPAM_ERRANT_LINK_WEIGHT=12 \
+GTS_INSTANCE_FEDERATION_SPAM_NEW_ACCOUNT_WEIGHT=5 \
+GTS_INSTANCE_FEDERATION_SPAM_NEW_ACCOUNT_AGE='1h' \
+GTS_INSTANCE_FEDERATION_SPAM_DUPLICATE_WEIGHT=6 \
+GTS_INSTANCE_FEDERATION_SPAM_BURST_WEIGHT=7 \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
@@ -227,13 +267,25 @@ GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
+GTS_ACCOUNTS_SIGNUP_CHALLENGE=pow \
+GTS_ACCOUNTS_SIGNUP_POW_DIFFICULTY=20 \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
+GTS_MEDIA_AUDIO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_QUOTA_USER=420 \
+GTS_MEDIA_QUOTA_MODERATOR=4200 \
+GTS_MEDIA_REMOTE_PROXY=true \
+GTS_MEDIA_REMOTE_PROXY_DOMAINS='example.org,example.com' \
+GTS_MEDIA_REMOTE_PROXY_CACHE_TTL='1m' \
+GTS_MEDIA_REMOTE_PROXY_CACHE_MAX_SIZE=420 \
+GTS_MEDIA_HASH_BLOCK_DISTANCE=10 \
+GTS_MEDIA_UPLOAD_EXPIRY='1h' \
+GTS_MEDIA_DERIVATIVE_WIDTHS='400,800' \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -244,6 +296,8 @@ GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
 GTS_STORAGE_S3_BUCKET='gts' \
+GTS_STORAGE_S3_CACHE_BASE_PATH='/root/cache' \
+GTS_STORAGE_S3_CACHE_MAX_SIZE=69 \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
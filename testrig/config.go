@@ -66,12 +66,20 @@ func testDefaults() config.Configuration {
 		WebTemplateBaseDir: "./web/template/",
 		WebAssetBaseDir:    "./web/assets/",
 
-		InstanceFederationMode:         config.InstanceFederationModeDefault,
-		InstanceFederationSpamFilter:   true,
-		InstanceExposePeers:            true,
-		InstanceExposeSuspended:        true,
-		InstanceExposeSuspendedWeb:     true,
-		InstanceDeliverToSharedInboxes: true,
+		InstanceFederationMode:                     config.InstanceFederationModeDefault,
+		InstanceFederationSpamFilter:               true,
+		InstanceFederationSpamThreshold:            10,
+		InstanceFederationSpamUnknownMentionWeight: 3,
+		InstanceFederationSpamMediaWeight:          10,
+		InstanceFederationSpamErrantLinkWeight:     10,
+		InstanceFederationSpamNewAccountWeight:     4,
+		InstanceFederationSpamNewAccountAge:        24 * time.Hour,
+		InstanceFederationSpamDuplicateWeight:      5,
+		InstanceFederationSpamBurstWeight:          5,
+		InstanceExposePeers:                        true,
+		InstanceExposeSuspended:                    true,
+		InstanceExposeSuspendedWeb:                 true,
+		InstanceDeliverToSharedInboxes:             true,
 		InstanceLanguages: language.Languages{
 			{
 				TagStr: "nl",
@@ -81,13 +89,17 @@ func testDefaults() config.Configuration {
 			},
 		},
 
-		AccountsRegistrationOpen: true,
-		AccountsReasonRequired:   true,
-		AccountsAllowCustomCSS:   true,
-		AccountsCustomCSSLength:  10000,
+		AccountsRegistrationOpen:    true,
+		AccountsReasonRequired:      true,
+		AccountsAllowUserInvites:    false,
+		AccountsSignupChallenge:     "",
+		AccountsSignupPowDifficulty: 4,
+		AccountsAllowCustomCSS:      true,
+		AccountsCustomCSSLength:     10000,
 
 		MediaImageMaxSize:        10485760, // 10MiB
 		MediaVideoMaxSize:        41943040, // 40MiB
+		MediaAudioMaxSize:        41943040, // 40MiB
 		MediaDescriptionMinChars: 0,
 		MediaDescriptionMaxChars: 500,
 		MediaRemoteCacheDays:     7,
@@ -95,6 +107,20 @@ func testDefaults() config.Configuration {
 		MediaEmojiRemoteMaxSize:  102400,         // 100KiB
 		MediaCleanupFrom:         "00:00",        // midnight.
 		MediaCleanupEvery:        24 * time.Hour, // 1/day.
+		MediaQuotaUser:           0,              // unlimited.
+		MediaQuotaModerator:      0,              // unlimited.
+		MediaQuotaAdmin:          0,              // unlimited.
+
+		MediaRemoteProxy:             false,
+		MediaRemoteProxyDomains:      []string{},
+		MediaRemoteProxyCacheTTL:     5 * time.Minute,
+		MediaRemoteProxyCacheMaxSize: 104857600, // 100MiB
+
+		MediaHashBlockDistance: 6,
+
+		MediaUploadExpiry: 24 * time.Hour,
+
+		MediaDerivativeWidths: []int{320, 640, 1280},
 
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
@@ -65,9 +65,21 @@ var testModels = []interface{}{
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
+	&gtsmodel.ReportNote{},
 	&gtsmodel.Rule{},
+	&gtsmodel.Invite{},
+	&gtsmodel.IPBlock{},
+	&gtsmodel.DomainLimit{},
+	&gtsmodel.AccountWarning{},
+	&gtsmodel.AuditLogEntry{},
+	&gtsmodel.InboundPolicy{},
+	&gtsmodel.QuarantinedStatus{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
+	&gtsmodel.MediaBlob{},
+	&gtsmodel.MediaUpload{},
+	&gtsmodel.MediaHashBlock{},
+	&gtsmodel.MediaHashMatch{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -19,6 +19,7 @@ package testrig
 
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/policy"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -32,5 +33,6 @@ func NewTestFederatingDB(state *state.State) federatingdb.DB {
 		typeutils.NewConverter(state),
 		visibility.NewFilter(state),
 		spam.NewFilter(state),
+		policy.NewFilter(state),
 	)
 }
@@ -2139,6 +2139,7 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 			UpdatedAt: TimeMustParse("2022-05-14T13:21:09+02:00"),
 			Useable:   util.Ptr(true),
 			Listable:  util.Ptr(true),
+			Trendable: util.Ptr(true),
 		},
 		"Hashtag": {
 			ID:        "01FCT9SGYA71487N8D0S1M638G",
@@ -2147,6 +2148,7 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 			UpdatedAt: TimeMustParse("2022-05-14T13:21:09+02:00"),
 			Useable:   util.Ptr(true),
 			Listable:  util.Ptr(true),
+			Trendable: util.Ptr(true),
 		},
 	}
 }
@@ -2563,6 +2565,7 @@ func NewTestReports() map[string]*gtsmodel.Report {
 			ActionTaken:            "user was warned not to be a turtle anymore",
 			ActionTakenAt:          TimeMustParse("2022-05-15T17:01:56+02:00"),
 			ActionTakenByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			AssignedAccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
 		},
 	}
 }
@@ -56,6 +56,7 @@ func StartNoopWorkers(state *state.State) {
 	// _ = state.Workers.Federator.Start(1)
 	// _ = state.Workers.Dereference.Start(1)
 	// _ = state.Workers.Media.Start(1)
+	// _ = state.Workers.Email.Start(1)
 	//
 	// (except for the scheduler, that's fine)
 	_ = state.Workers.Scheduler.Start()
@@ -83,6 +84,7 @@ func StartWorkers(state *state.State, processor *workers.Processor) {
 	state.Workers.Federator.Start(1)
 	state.Workers.Dereference.Start(1)
 	state.Workers.Media.Start(1)
+	state.Workers.Email.Start(1)
 }
 
 func StopWorkers(state *state.State) {
@@ -91,6 +93,7 @@ func StopWorkers(state *state.State) {
 	state.Workers.Federator.Stop()
 	state.Workers.Dereference.Stop()
 	state.Workers.Media.Stop()
+	state.Workers.Email.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {
@@ -64,6 +64,15 @@ skulk({
 				}]
 			],
 		},
+		signup: {
+			entryFile: "signup",
+			outputFile: "signup.js",
+			preset: ["js"],
+			prodCfg: prodCfg,
+			transform: [
+				["babelify", { global: true }]
+			],
+		},
 		settings: {
 			entryFile: "settings",
 			outputFile: "settings.js",
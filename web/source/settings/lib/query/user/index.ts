@@ -24,6 +24,7 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
+import type { Invite, InviteCreateFormData } from "../../types/invite";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -44,6 +45,19 @@ const extended = gtsApi.injectEndpoints({
 				body: data
 			})
 		}),
+		user: build.query<any, void>({
+			query: () => ({
+				url: `/api/v1/user`
+			})
+		}),
+		emailChange: build.mutation({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/email_change`,
+				body: data
+			}),
+			...replaceCacheOnMutation("user")
+		}),
 		aliasAccount: build.mutation<any, UpdateAliasesFormData>({
 			async queryFn(formData, _api, _extraOpts, fetchWithBQ) {
 				// Pull entries out from the hooked form.
@@ -68,6 +82,33 @@ const extended = gtsApi.injectEndpoints({
 				body: data
 			})
 		}),
+		invites: build.query<Invite[], void>({
+			query: () => ({
+				url: `/api/v1/invites`
+			}),
+			providesTags: [{ type: "Invites", id: "LIST" }]
+		}),
+		createInvite: build.mutation<Invite, InviteCreateFormData>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/invites`,
+				body: {
+					// Form values are strings,
+					// API expects numbers.
+					max_uses: Number(data.max_uses),
+					expires_in: Number(data.expires_in),
+					auto_approve: data.auto_approve,
+				}
+			}),
+			invalidatesTags: [{ type: "Invites", id: "LIST" }]
+		}),
+		revokeInvite: build.mutation<Invite, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/invites/${id}`
+			}),
+			invalidatesTags: [{ type: "Invites", id: "LIST" }]
+		}),
 		accountThemes: build.query<Theme[], void>({
 			query: () => ({
 				url: `/api/v1/accounts/themes`
@@ -79,7 +120,12 @@ const extended = gtsApi.injectEndpoints({
 export const {
 	useUpdateCredentialsMutation,
 	usePasswordChangeMutation,
+	useUserQuery,
+	useEmailChangeMutation,
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
+	useInvitesQuery,
+	useCreateInviteMutation,
+	useRevokeInviteMutation,
 } = extended;
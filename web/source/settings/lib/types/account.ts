@@ -37,6 +37,7 @@ export interface AdminAccount {
 	silenced: boolean,
 	suspended: boolean,
 	created_by_application_id: string,
+	invited_by_account_id?: string,
 	account: Account,
 }
 
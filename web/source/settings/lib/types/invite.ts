@@ -0,0 +1,38 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import { Account } from "./account";
+
+export interface Invite {
+	id: string;
+	code: string;
+	url: string;
+	created_at: string;
+	expires_at: string | null;
+	max_uses: number | null;
+	uses: number;
+	auto_approve: boolean;
+	usable: boolean;
+	account?: Account;
+}
+
+export interface InviteCreateFormData {
+	max_uses: string;
+	expires_in: string;
+	auto_approve: boolean;
+}
@@ -24,7 +24,7 @@ import FormWithData from "../../../../lib/form/form-with-data";
 import FakeProfile from "../../../../components/fake-profile";
 import { AdminAccount } from "../../../../lib/types/account";
 import { AccountActions } from "./actions";
-import { useParams } from "wouter";
+import { Link, useParams } from "wouter";
 import { useBaseUrl } from "../../../../lib/navigation/util";
 import BackButton from "../../../../components/back-button";
 import { UseOurInstanceAccount, yesOrNo } from "./util";
@@ -191,6 +191,15 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 					<dt>Sign-Up Reason</dt>
 					<dd>{adminAcct.invite_request ?? <i>none provided</i>}</dd>
 				</div>
+				{ adminAcct.invited_by_account_id &&
+					<div className="info-list-entry">
+						<dt>Invited By</dt>
+						<dd>
+							<Link to={`~/settings/moderation/accounts/${adminAcct.invited_by_account_id}`}>
+								View inviting account
+							</Link>
+						</dd>
+					</div> }
 				{ (adminAcct.ip && adminAcct.ip !== "0.0.0.0") &&
 					<div className="info-list-entry">
 						<dt>Sign-Up IP</dt>
@@ -0,0 +1,148 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+import React from "react";
+import { useBoolInput, useTextInput } from "../../lib/form";
+import useFormSubmit from "../../lib/form/submit";
+import { Checkbox, Select } from "../../components/form/inputs";
+import FormWithData from "../../lib/form/form-with-data";
+import MutationButton from "../../components/form/mutation-button";
+import { useHasPermission } from "../../lib/navigation/util";
+import {
+	useCreateInviteMutation,
+	useInvitesQuery,
+	useRevokeInviteMutation,
+} from "../../lib/query/user";
+import type { Invite } from "../../lib/types/invite";
+
+export default function UserInvites() {
+	return (
+		<div className="user-invites">
+			<h1>Invites</h1>
+			<p>
+				Anyone with one of your invite links can sign up to this instance,
+				even when registration is closed. The admins of this instance will
+				be able to see which accounts signed up using your invites.
+			</p>
+			<InviteCreateForm />
+			<FormWithData
+				dataQuery={useInvitesQuery}
+				DataForm={InvitesList}
+			/>
+		</div>
+	);
+}
+
+function InviteCreateForm() {
+	const staff = useHasPermission(["moderator"]);
+
+	const form = {
+		maxUses: useTextInput("max_uses", { defaultValue: "1" }),
+		expiresIn: useTextInput("expires_in", { defaultValue: "604800" }),
+		autoApprove: useBoolInput("auto_approve", { defaultValue: false }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useCreateInviteMutation(), {
+		changedOnly: false,
+	});
+
+	return (
+		<form className="invite-create" onSubmit={submitForm}>
+			<h2>Create invite</h2>
+			<Select field={form.maxUses} label="Max uses" options={
+				<>
+					<option value="1">1 use</option>
+					<option value="5">5 uses</option>
+					<option value="10">10 uses</option>
+					<option value="25">25 uses</option>
+					<option value="0">No limit</option>
+				</>
+			}>
+			</Select>
+			<Select field={form.expiresIn} label="Expires after" options={
+				<>
+					<option value="3600">1 hour</option>
+					<option value="86400">1 day</option>
+					<option value="604800">1 week</option>
+					<option value="2592000">30 days</option>
+					<option value="0">Never</option>
+				</>
+			}>
+			</Select>
+			{ staff &&
+				<Checkbox
+					field={form.autoApprove}
+					label="Approve sign-ups using this invite automatically"
+				/>
+			}
+			<MutationButton
+				disabled={false}
+				label="Create invite"
+				result={result}
+			/>
+		</form>
+	);
+}
+
+function InvitesList({ data: invites }: { data: Invite[] }) {
+	if (invites.length === 0) {
+		return <b>You haven't created any invites yet.</b>;
+	}
+
+	return (
+		<div className="list invites">
+			{invites.map((invite) => <InviteEntry key={invite.id} invite={invite} />)}
+		</div>
+	);
+}
+
+function InviteEntry({ invite }: { invite: Invite }) {
+	const [revoke, result] = useRevokeInviteMutation();
+
+	const uses = invite.max_uses === null
+		? `${invite.uses} uses`
+		: `${invite.uses} / ${invite.max_uses} uses`;
+
+	const expires = invite.expires_at === null
+		? "never expires"
+		: `expires ${new Date(invite.expires_at).toLocaleString()}`;
+
+	return (
+		<div className="entry invite">
+			<div className="invite-info">
+				{invite.usable
+					? <input type="text" readOnly value={invite.url} aria-label="Invite link" />
+					: <s>{invite.url}</s>
+				}
+				<span>
+					{uses}, {expires}
+					{invite.auto_approve && ", approved automatically"}
+				</span>
+			</div>
+			{invite.usable &&
+				<button
+					className="danger"
+					disabled={result.isLoading}
+					onClick={() => revoke(invite.id)}
+				>
+					Revoke
+				</button>
+			}
+		</div>
+	);
+}
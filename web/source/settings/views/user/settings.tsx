@@ -25,7 +25,12 @@ import FormWithData from "../../lib/form/form-with-data";
 import Languages from "../../components/languages";
 import MutationButton from "../../components/form/mutation-button";
 import { useVerifyCredentialsQuery } from "../../lib/query/oauth";
-import { usePasswordChangeMutation, useUpdateCredentialsMutation } from "../../lib/query/user";
+import {
+	useEmailChangeMutation,
+	usePasswordChangeMutation,
+	useUpdateCredentialsMutation,
+	useUserQuery,
+} from "../../lib/query/user";
 
 export default function UserSettings() {
 	return (
@@ -98,6 +103,10 @@ function UserSettingsForm({ data }) {
 				/>
 			</form>
 			<PasswordChange />
+			<FormWithData
+				dataQuery={useUserQuery}
+				DataForm={EmailChange}
+			/>
 		</>
 	);
 }
@@ -168,3 +177,61 @@ function PasswordChange() {
 		</form>
 	);
 }
+
+function EmailChange({ data: user }) {
+	const form = {
+		password: useTextInput("password"),
+		newEmail: useTextInput("new_email", {
+			validator(val) {
+				if (val != "" && val == user.email) {
+					return "New email address same as current email address";
+				}
+				return "";
+			}
+		})
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useEmailChangeMutation());
+
+	return (
+		<form className="change-email" onSubmit={submitForm}>
+			<div className="form-section-docs">
+				<h3>Change Email</h3>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#email-change"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			<p>Your current email address is <b>{user.email}</b>.</p>
+			{user.unconfirmed_email && user.unconfirmed_email != user.email &&
+				<p>
+					A confirmation link has been sent to <b>{user.unconfirmed_email}</b>.
+					Your email address will change once you click the link in that email.
+				</p>
+			}
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+			<TextInput
+				type="email"
+				name="newEmail"
+				field={form.newEmail}
+				label="New email address"
+				autoComplete="email"
+			/>
+			<MutationButton
+				disabled={false}
+				label="Change email address"
+				result={result}
+			/>
+		</form>
+	);
+}
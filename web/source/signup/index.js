@@ -0,0 +1,94 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+	Solves the proof-of-work sign-up challenge in the
+	background, so that it's (hopefully) done by the
+	time the visitor has finished filling in the form.
+
+	The challenge is to find a nonce such that the
+	SHA-256 hash of "token:nonce" has at least
+	`difficulty` leading zero bits.
+*/
+
+const challenge = document.getElementById("signup-challenge");
+if (challenge && challenge.dataset.kind == "pow") {
+	solvePoW(challenge);
+}
+
+function solvePoW(challenge) {
+	const form = challenge.closest("form");
+	const submit = form.querySelector("button[type=submit]");
+	const status = challenge.querySelector(".signup-challenge-status");
+
+	const token = form.elements["challenge_token"].value;
+	const difficulty = parseInt(form.elements["challenge_difficulty"].value);
+	const nonceInput = form.elements["challenge_nonce"];
+
+	const setStatus = (text) => {
+		if (status) {
+			status.textContent = text;
+		}
+	};
+
+	if (!window.crypto || !window.crypto.subtle) {
+		setStatus("Your browser doesn't support the automated check required to sign up. Please try a different browser.");
+		submit.disabled = true;
+		return;
+	}
+
+	submit.disabled = true;
+	setStatus("Your browser is completing a quick automated check, please wait...");
+
+	const encoder = new TextEncoder();
+	const prefix = token + ":";
+
+	(async () => {
+		for (let nonce = 0; ; nonce++) {
+			const hash = await crypto.subtle.digest(
+				"SHA-256",
+				encoder.encode(prefix + nonce),
+			);
+
+			if (leadingZeroBits(new Uint8Array(hash)) >= difficulty) {
+				nonceInput.value = nonce.toString();
+				break;
+			}
+		}
+
+		submit.disabled = false;
+		setStatus("Automated check complete, you can now submit the form.");
+	})().catch((e) => {
+		console.error(e);
+		submit.disabled = false;
+		setStatus("The automated check failed; submitting the form will probably not work. Please reload the page and try again.");
+	});
+}
+
+function leadingZeroBits(bytes) {
+	let bits = 0;
+	for (const b of bytes) {
+		if (b == 0) {
+			bits += 8;
+			continue;
+		}
+		return bits + Math.clz32(b) - 24;
+	}
+	return bits;
+}